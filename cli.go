@@ -4,18 +4,293 @@ import (
 	"fmt"
 	"gcredstash"
 	"gcredstash/command"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/mitchellh/cli"
 	"os"
+	"strconv"
 )
 
 func Run(args []string) int {
 	// Meta-option for executables.
 	// It defines output color and its stdout/stderr stream.
 
-	awsSession := session.New()
+	// Global options are only read before the command name: after it, they
+	// are options of the command, or of the program exec and rotate-run
+	// start (k8s-secret has its own --namespace, for example).
+	globalArgs, commandArgs := command.SplitGlobalArgs(args, "--env", "--store", "--namespace")
+	globalArgs, verbose := command.HasOption(globalArgs, "--verbose")
+	globalArgs, debug := command.HasOption(globalArgs, "--debug")
+	globalArgs, readOnly := command.HasOption(globalArgs, "--read-only")
+	globalArgs, dryRun := command.HasOption(globalArgs, "--dry-run")
+	globalArgs, offline := command.HasOption(globalArgs, "--offline")
+	globalArgs, timed := command.HasOption(globalArgs, "--timing")
+	globalArgs, eventuallyConsistent := command.HasOption(globalArgs, "--eventually-consistent")
+
+	if os.Getenv("GCREDSTASH_READ_ONLY") == "1" {
+		readOnly = true
+	}
+
+	if os.Getenv("GCREDSTASH_OFFLINE") == "1" {
+		offline = true
+	}
+
+	globalArgs, env, err := command.ParseOptionWithValue(globalArgs, "--env")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return 1
+	}
+
+	if env == "" {
+		env = os.Getenv("GCREDSTASH_ENV")
+	}
+
+	globalArgs, storeName, err := command.ParseOptionWithValue(globalArgs, "--store")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return 1
+	}
+
+	if storeName == "" {
+		storeName = os.Getenv("GCREDSTASH_STORE")
+	}
+
+	globalArgs, namespace, err := command.ParseOptionWithValue(globalArgs, "--namespace")
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return 1
+	}
+
+	args = append(globalArgs, commandArgs...)
+
+	if namespace == "" {
+		namespace = os.Getenv("GCREDSTASH_NAMESPACE")
+	}
+
+	configFile, err := gcredstash.LoadConfigFile(gcredstash.ConfigFilePath())
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return 1
+	}
+
+	config, err := configFile.Resolve(env, storeName)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return 1
+	}
+
+	// Environment variables take precedence over the config file.
+	if table := os.Getenv("GCREDSTASH_TABLE"); table != "" {
+		config.Table = table
+	}
+
+	if kmsKey := os.Getenv("GCREDSTASH_KMS_KEY"); kmsKey != "" {
+		config.KmsKey = kmsKey
+	}
+
+	if readKmsKey := os.Getenv("GCREDSTASH_READ_KMS_KEY"); readKmsKey != "" {
+		config.ReadKmsKey = readKmsKey
+	}
+
+	if recoveryKmsKey := os.Getenv("GCREDSTASH_RECOVERY_KMS_KEY"); recoveryKmsKey != "" {
+		config.RecoveryKmsKey = recoveryKmsKey
+	}
+
+	if rateLimit := os.Getenv("GCREDSTASH_RATE_LIMIT"); rateLimit != "" {
+		config.RateLimit = rateLimit
+	}
+
+	if timeout := os.Getenv("GCREDSTASH_TIMEOUT"); timeout != "" {
+		config.Timeout = timeout
+	}
+
+	if imds := os.Getenv("GCREDSTASH_IMDS"); imds != "" {
+		config.Imds = imds
+	}
+
+	if sharedConfig := os.Getenv("GCREDSTASH_SHARED_CONFIG"); sharedConfig != "" {
+		config.SharedConfig = sharedConfig
+	}
+
+	if awsSdk := os.Getenv("GCREDSTASH_AWS_SDK"); awsSdk != "" {
+		config.AwsSdk = awsSdk
+	}
+
+	if softDelete := os.Getenv("GCREDSTASH_SOFT_DELETE"); softDelete != "" {
+		config.SoftDelete = softDelete
+	}
+
+	if chunkedStorage := os.Getenv("GCREDSTASH_CHUNKED_STORAGE"); chunkedStorage != "" {
+		config.ChunkedStorage = chunkedStorage
+	}
+
+	if s3Bucket := os.Getenv("GCREDSTASH_S3_BUCKET"); s3Bucket != "" {
+		config.S3Bucket = s3Bucket
+	}
+
+	if s3Threshold := os.Getenv("GCREDSTASH_S3_THRESHOLD"); s3Threshold != "" {
+		config.S3Threshold = s3Threshold
+	}
+
+	if offlineCache := os.Getenv("GCREDSTASH_OFFLINE_CACHE"); offlineCache != "" {
+		config.OfflineCache = offlineCache
+	}
+
+	if keychainCacheTTL := os.Getenv("GCREDSTASH_KEYCHAIN_CACHE_TTL"); keychainCacheTTL != "" {
+		config.KeychainCacheTTL = keychainCacheTTL
+	}
+
+	if daxEndpoint := os.Getenv("GCREDSTASH_DAX_ENDPOINT"); daxEndpoint != "" {
+		config.DaxEndpoint = daxEndpoint
+	}
+
+	if readConsistency := os.Getenv("GCREDSTASH_READ_CONSISTENCY"); readConsistency != "" {
+		config.ReadConsistency = readConsistency
+	}
+
+	if refuseTerminalOutput := os.Getenv("GCREDSTASH_REFUSE_TERMINAL_OUTPUT"); refuseTerminalOutput != "" {
+		config.RefuseTerminalOutput = refuseTerminalOutput
+	}
+
+	if namespace != "" {
+		config.Namespace = namespace
+	}
+
+	if os.Getenv("AWS_REGION") != "" {
+		config.Region = ""
+	}
+
+	if os.Getenv("AWS_PROFILE") != "" {
+		config.Profile = ""
+	}
+
+	logLevel := gcredstash.LOG_LEVEL_INFO
+
+	if debug {
+		logLevel = gcredstash.LOG_LEVEL_DEBUG
+	} else if verbose {
+		logLevel = gcredstash.LOG_LEVEL_VERBOSE
+	}
+
+	logger := gcredstash.NewStdLogger(logLevel)
+	author := os.Getenv("GCREDSTASH_CREATED_BY")
+
+	if author == "" {
+		author = os.Getenv("USER")
+	}
+
+	accessLog := os.Getenv("GCREDSTASH_ACCESS_LOG")
+	onExpired := os.Getenv("GCREDSTASH_ON_EXPIRED")
+
+	if err := gcredstash.ValidateExpiredPolicy(onExpired); err != nil {
+		fmt.Fprintf(os.Stderr, "error: GCREDSTASH_ON_EXPIRED: %s\n", err.Error())
+		return 1
+	}
+
+	refuseTerminalOutput := false
+
+	if config.RefuseTerminalOutput != "" {
+		refuseTerminalOutput, err = strconv.ParseBool(config.RefuseTerminalOutput)
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: invalid refuse_terminal_output: %s (must be true or false)\n", config.RefuseTerminalOutput)
+			return 1
+		}
+	}
+
+	var timing *gcredstash.Timing
+
+	if timed {
+		timing = gcredstash.NewTiming()
+	}
+
+	var openStore func(profile *gcredstash.ConfigProfile) (*gcredstash.Store, error)
+
+	openStore = func(profile *gcredstash.ConfigProfile) (*gcredstash.Store, error) {
+		store, err := gcredstash.NewStore(profile, logger)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if timing != nil {
+			timing.Instrument(store.Driver)
+		}
+
+		store.Driver.ReadOnly = readOnly
+		store.Driver.DryRun = dryRun
+		store.Driver.Author = author
+		store.Driver.OnExpired = onExpired
+		store.Driver.Passphrase = gcredstash.EnvPassphrase()
+		store.Driver.Offline = offline
+
+		if eventuallyConsistent {
+			store.Driver.ReadConsistency = gcredstash.READ_CONSISTENCY_EVENTUAL
+		}
+
+		if offline && store.Driver.DataKeyCache == nil {
+			store.Driver.DataKeyCache = gcredstash.NewDataKeyCache(gcredstash.DefaultDataKeyCachePath(), gcredstash.NewOSKeychain())
+		}
+
+		if accessLog != "" {
+			awsSession, err := gcredstash.NewSession(profile)
+
+			if err != nil {
+				return nil, err
+			}
+
+			store.Driver.AccessLog, err = gcredstash.NewAccessLogger(accessLog, cloudwatchlogs.New(awsSession))
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, name := range profile.FallbackStoreNames() {
+			fallbackProfile, err := configFile.Resolve("", name)
+
+			if err != nil {
+				return nil, fmt.Errorf("fallback_stores: %s", err.Error())
+			}
+
+			fallbackProfile.Env = profile.Env
+			// Fallback stores do not fall back further.
+			fallbackProfile.FallbackStores = ""
+			fallback, err := openStore(fallbackProfile)
+
+			if err != nil {
+				return nil, err
+			}
+
+			store.Driver.Fallbacks = append(store.Driver.Fallbacks, &gcredstash.FallbackStore{Name: name, Driver: fallback.Driver, Table: fallback.Table})
+		}
+
+		return store, nil
+	}
+
+	store, err := openStore(config)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return 1
+	}
+
+	if path := os.Getenv("GCREDSTASH_FILE"); path != "" {
+		localKms, err := gcredstash.NewLocalKms(os.Getenv("GCREDSTASH_MASTER_KEY"))
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: GCREDSTASH_MASTER_KEY: %s\n", err.Error())
+			return 1
+		}
+
+		store.Driver.Backend = &gcredstash.FileBackend{Path: path}
+		store.Driver.Kms = localKms
+		store.Driver.Sts = nil
+	}
 
 	meta := &command.Meta{
 		Ui: &cli.ColoredUi{
@@ -27,23 +302,34 @@ func Run(args []string) int {
 				Reader:      os.Stdin,
 			},
 		},
-		Table:  os.Getenv("GCREDSTASH_TABLE"),
-		KmsKey: os.Getenv("GCREDSTASH_KMS_KEY"),
-		Driver: &gcredstash.Driver{
-			Ddb: dynamodb.New(awsSession),
-			Kms: kms.New(awsSession),
+		Table:    store.Table,
+		KmsKey:   store.KmsKey,
+		Version:  Version,
+		Driver:   store.Driver,
+		Terminal: gcredstash.IsTerminal(os.Stdout),
+
+		RefuseTerminalOutput: refuseTerminalOutput,
+		OpenStore: func(name string) (*gcredstash.Store, error) {
+			profile, err := configFile.Resolve("", name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			// {env} in the named store refers to the current environment.
+			profile.Env = config.Env
+
+			return openStore(profile)
 		},
 	}
 
-	if meta.Table == "" {
-		meta.Table = "credential-store"
-	}
+	exitCode := RunCustom(args, Commands(meta))
 
-	if meta.KmsKey == "" {
-		meta.KmsKey = "alias/credstash"
+	if timing != nil {
+		timing.Print(os.Stderr)
 	}
 
-	return RunCustom(args, Commands(meta))
+	return exitCode
 }
 
 func RunCustom(args []string, commands map[string]cli.CommandFactory) int {
@@ -6,41 +6,307 @@ import (
 )
 
 func Commands(meta *command.Meta) map[string]cli.CommandFactory {
-	return map[string]cli.CommandFactory{
+	var commands map[string]cli.CommandFactory
+
+	commands = map[string]cli.CommandFactory{
+		"alias": func() (cli.Command, error) {
+			return &command.AliasCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"audit": func() (cli.Command, error) {
+			return &command.AuditCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"cert check": func() (cli.Command, error) {
+			return &command.CertCheckCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"cert info": func() (cli.Command, error) {
+			return &command.CertInfoCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"cert put": func() (cli.Command, error) {
+			return &command.CertPutCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"chamber env": func() (cli.Command, error) {
+			return &command.ChamberEnvCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"chamber exec": func() (cli.Command, error) {
+			return &command.ChamberExecCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"chamber read": func() (cli.Command, error) {
+			return &command.ChamberReadCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"chamber write": func() (cli.Command, error) {
+			return &command.ChamberWriteCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"completion": func() (cli.Command, error) {
+			names := []string{}
+
+			for name := range commands {
+				names = append(names, name)
+			}
+
+			return &command.CompletionCommand{
+				Meta:     *meta,
+				Commands: names,
+			}, nil
+		},
+		"copy": func() (cli.Command, error) {
+			return &command.CopyCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"credential-process": func() (cli.Command, error) {
+			return &command.CredentialProcessCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"delete": func() (cli.Command, error) {
 			return &command.DeleteCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"diff": func() (cli.Command, error) {
+			return &command.DiffCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"docker-env": func() (cli.Command, error) {
+			return &command.DockerEnvCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"dotenv": func() (cli.Command, error) {
+			return &command.DotenvCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"edit": func() (cli.Command, error) {
+			return &command.EditCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"exists": func() (cli.Command, error) {
+			return &command.ExistsCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"expiring": func() (cli.Command, error) {
+			return &command.ExpiringCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"get": func() (cli.Command, error) {
 			return &command.GetCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"get-sshkey": func() (cli.Command, error) {
+			return &command.GetSSHKeyCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"getall": func() (cli.Command, error) {
 			return &command.GetallCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"grant": func() (cli.Command, error) {
+			return &command.GrantCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"k8s-secret": func() (cli.Command, error) {
+			return &command.K8sSecretCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"keys": func() (cli.Command, error) {
+			return &command.KeysCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"list": func() (cli.Command, error) {
 			return &command.ListCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"lock": func() (cli.Command, error) {
+			return &command.LockCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"migrate from-secretsmanager": func() (cli.Command, error) {
+			return &command.MigrateFromSecretsManagerCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"migrate from-ssm": func() (cli.Command, error) {
+			return &command.MigrateFromSsmCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"migrate from-vault": func() (cli.Command, error) {
+			return &command.MigrateFromVaultCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"migrate to-secretsmanager": func() (cli.Command, error) {
+			return &command.MigrateToSecretsManagerCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"migrate to-ssm": func() (cli.Command, error) {
+			return &command.MigrateToSsmCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"migrate to-vault": func() (cli.Command, error) {
+			return &command.MigrateToVaultCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"mount": func() (cli.Command, error) {
+			return &command.MountCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"needs-rotation": func() (cli.Command, error) {
+			return &command.NeedsRotationCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"node-attrs": func() (cli.Command, error) {
+			return &command.NodeAttrsCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"policy": func() (cli.Command, error) {
+			return &command.PolicyCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"prune": func() (cli.Command, error) {
+			return &command.PruneCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"purge": func() (cli.Command, error) {
+			return &command.PurgeCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"put": func() (cli.Command, error) {
 			return &command.PutCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"put-sshkey": func() (cli.Command, error) {
+			return &command.PutSSHKeyCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"putall": func() (cli.Command, error) {
+			return &command.PutallCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"rename": func() (cli.Command, error) {
+			return &command.RenameCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"restore": func() (cli.Command, error) {
+			return &command.RestoreCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"restore-snapshot": func() (cli.Command, error) {
+			return &command.RestoreSnapshotCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"rollback": func() (cli.Command, error) {
+			return &command.RollbackCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"rotate-run": func() (cli.Command, error) {
+			return &command.RotateRunCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"search": func() (cli.Command, error) {
+			return &command.SearchCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"serve": func() (cli.Command, error) {
+			return &command.ServeCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"serve-grpc": func() (cli.Command, error) {
+			return &command.ServeGrpcCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"setup": func() (cli.Command, error) {
 			return &command.SetupCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"snapshot": func() (cli.Command, error) {
+			return &command.SnapshotCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"status": func() (cli.Command, error) {
+			return &command.StatusCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"template": func() (cli.Command, error) {
 			return &command.TemplateCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"tf-data": func() (cli.Command, error) {
+			return &command.TfDataCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"totp": func() (cli.Command, error) {
+			return &command.TotpCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"watch": func() (cli.Command, error) {
+			return &command.WatchCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"write-files": func() (cli.Command, error) {
+			return &command.WriteFilesCommand{
+				Meta: *meta,
+			}, nil
+		},
 	}
+
+	return commands
 }
@@ -0,0 +1,117 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// AccessDeniedError is returned when AWS refuses an action for lack of
+// permission. Action is the IAM action, e.g. dynamodb:Query, and Resource
+// the ARN of what it was denied on, or the table or key name when AWS did
+// not report the ARN. The message says what to grant instead of repeating
+// the SDK's; Err keeps the original error. IsAccessDenied reports it.
+type AccessDeniedError struct {
+	Action   string
+	Resource string
+	Err      error
+}
+
+func (e *AccessDeniedError) Error() string {
+	msg := e.Action + " was denied"
+
+	if e.Resource != "" {
+		msg += " on " + e.Resource
+	}
+
+	return msg + ": the caller's IAM policy must allow it (`gcredstash policy` prints one)"
+}
+
+func (e *AccessDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// Hint says what to grant, for output that shows it apart from the error.
+func (e *AccessDeniedError) Hint() string {
+	resource := e.Resource
+
+	if resource == "" {
+		resource = "the resource"
+	}
+
+	return fmt.Sprintf("allow %s on %s for the caller", e.Action, resource)
+}
+
+// AWS reports the denied action and resource in the message, e.g. "User:
+// arn:aws:iam::123456789012:user/app is not authorized to perform:
+// dynamodb:Query on resource: arn:aws:dynamodb:...:table/credential-store
+// because ...".
+var accessDeniedRegexp = regexp.MustCompile(`not authorized to perform:? ([A-Za-z0-9-]+:[A-Za-z0-9*]+)(?: on resource:? (arn:\S+))?`)
+
+// NewAccessDeniedError turns an access denial of action on resource into an
+// *AccessDeniedError, preferring the action and resource ARN AWS reports.
+// Other errors, and errors that already are one, are returned as they are.
+func NewAccessDeniedError(action string, resource string, err error) error {
+	if !IsAccessDenied(err) {
+		return err
+	}
+
+	var denied *AccessDeniedError
+
+	if errors.As(err, &denied) {
+		return err
+	}
+
+	if match := accessDeniedRegexp.FindStringSubmatch(err.Error()); match != nil {
+		action = match[1]
+
+		if match[2] != "" {
+			resource = strings.TrimRight(match[2], ".,;")
+		}
+	}
+
+	return &AccessDeniedError{Action: action, Resource: resource, Err: err}
+}
+
+// AddAccessDeniedHandlers makes AWS requests made with handlers fail with an
+// *AccessDeniedError when they are denied.
+func AddAccessDeniedHandlers(handlers *request.Handlers) {
+	// AfterRetry runs after each failed attempt, and clears the error of
+	// one that is retried; what is left is the error Send returns.
+	handlers.AfterRetry.PushBack(func(r *request.Request) {
+		if r.Error == nil {
+			return
+		}
+
+		service := r.ClientInfo.SigningName
+
+		if service == "" {
+			service = strings.ToLower(r.ClientInfo.ServiceName)
+		}
+
+		r.Error = NewAccessDeniedError(service+":"+r.Operation.Name, requestResource(r.Params), r.Error)
+	})
+}
+
+// requestResource returns the table, key, parameter or secret a request is
+// made on, or "" when it has none.
+func requestResource(params interface{}) string {
+	value := reflect.Indirect(reflect.ValueOf(params))
+
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for _, name := range []string{"TableName", "KeyId", "Name", "SecretId"} {
+		field := value.FieldByName(name)
+
+		if field.IsValid() && field.Kind() == reflect.Ptr && !field.IsNil() && field.Elem().Kind() == reflect.String {
+			return field.Elem().String()
+		}
+	}
+
+	return ""
+}
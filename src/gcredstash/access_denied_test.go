@@ -0,0 +1,85 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"testing"
+)
+
+func TestNewAccessDeniedError(t *testing.T) {
+	raw := awserr.New("AccessDeniedException", "User: arn:aws:iam::123456789012:user/app is not authorized to perform: "+
+		"dynamodb:Query on resource: arn:aws:dynamodb:us-east-1:123456789012:table/credential-store "+
+		"because no identity-based policy allows the dynamodb:Query action", nil)
+
+	err := NewAccessDeniedError("dynamodb:Query", "credential-store", raw)
+	denied := &AccessDeniedError{}
+
+	if !errors.As(err, &denied) || !IsAccessDenied(err) || !errors.Is(err, raw) {
+		t.Fatalf("\nexpected: %v\ngot: %v\n", "*AccessDeniedError", err)
+	}
+
+	expected := "dynamodb:Query was denied on arn:aws:dynamodb:us-east-1:123456789012:table/credential-store: " +
+		"the caller's IAM policy must allow it (`gcredstash policy` prints one)"
+
+	if err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+
+	expected = "allow dynamodb:Query on arn:aws:dynamodb:us-east-1:123456789012:table/credential-store for the caller"
+
+	if denied.Hint() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, denied.Hint())
+	}
+
+	err = NewAccessDeniedError("dynamodb:GetItem", "credential-store", awserr.New("AccessDeniedException", "not authorized", nil))
+	expected = "dynamodb:GetItem was denied on credential-store: the caller's IAM policy must allow it (`gcredstash policy` prints one)"
+
+	if err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+
+	if again := NewAccessDeniedError("dynamodb:Scan", "other", err); again != err {
+		t.Errorf("\nexpected: %v\ngot: %v\n", err, again)
+	}
+
+	other := awserr.New("ResourceNotFoundException", "no table", nil)
+
+	if got := NewAccessDeniedError("dynamodb:Query", "credential-store", other); got != other {
+		t.Errorf("\nexpected: %v\ngot: %v\n", other, got)
+	}
+
+	if got := NewAccessDeniedError("dynamodb:Query", "credential-store", nil); got != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, got)
+	}
+}
+
+func TestAddAccessDeniedHandlers(t *testing.T) {
+	handlers := &request.Handlers{}
+	AddAccessDeniedHandlers(handlers)
+
+	r := &request.Request{
+		ClientInfo: metadata.ClientInfo{ServiceName: "dynamodb", SigningName: "dynamodb"},
+		Operation:  &request.Operation{Name: "PutItem"},
+		Params:     &dynamodb.PutItemInput{TableName: aws.String("credential-store")},
+		Error:      awserr.New("AccessDeniedException", "not authorized", nil),
+	}
+
+	handlers.AfterRetry.Run(r)
+	expected := "dynamodb:PutItem was denied on credential-store: the caller's IAM policy must allow it (`gcredstash policy` prints one)"
+
+	if r.Error == nil || r.Error.Error() != expected || !IsAccessDenied(r.Error) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, r.Error)
+	}
+
+	r.Error = awserr.New("ConditionalCheckFailedException", "exists", nil)
+	handlers.AfterRetry.Run(r)
+
+	if !IsConditionalCheckFailed(r.Error) || r.Error.Error() != "ConditionalCheckFailedException: exists" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "ConditionalCheckFailedException: exists", r.Error)
+	}
+}
@@ -0,0 +1,241 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ACCESS_RESULT_OK        = "ok"
+	ACCESS_RESULT_NOT_FOUND = "not_found"
+	ACCESS_RESULT_ERROR     = "error"
+)
+
+// AccessEvent records a single get, put, delete, restore or purge. It
+// never contains the credential value.
+type AccessEvent struct {
+	Time    string `json:"time"`
+	Caller  string `json:"caller"`
+	Action  string `json:"action"`
+	Table   string `json:"table"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Result  string `json:"result"`
+	Error   string `json:"error,omitempty"`
+}
+
+// AccessLogger is a sink for access events. When Driver.AccessLog is set,
+// an operation whose event cannot be written fails.
+type AccessLogger interface {
+	LogAccess(event *AccessEvent) error
+}
+
+// FileAccessLogger appends events to a file as JSON lines.
+type FileAccessLogger struct {
+	Path  string
+	mutex sync.Mutex
+}
+
+func (logger *FileAccessLogger) LogAccess(event *AccessEvent) error {
+	line, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	fp, err := os.OpenFile(logger.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fp.Write(append(line, '\n'))
+
+	if err != nil {
+		fp.Close()
+		return err
+	}
+
+	return fp.Close()
+}
+
+// CloudWatchAccessLogger sends events to a CloudWatch Logs stream, creating
+// the stream on first use. The log group must already exist.
+type CloudWatchAccessLogger struct {
+	Logs   cloudwatchlogsiface.CloudWatchLogsAPI
+	Group  string
+	Stream string
+	mutex  sync.Mutex
+	ready  bool
+}
+
+func (logger *CloudWatchAccessLogger) LogAccess(event *AccessEvent) error {
+	message, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	if !logger.ready {
+		_, err := logger.Logs.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(logger.Group),
+			LogStreamName: aws.String(logger.Stream),
+		})
+
+		var exists *cloudwatchlogs.ResourceAlreadyExistsException
+
+		if err != nil && !errors.As(err, &exists) && !strings.Contains(err.Error(), cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+			return err
+		}
+
+		logger.ready = true
+	}
+
+	_, err = logger.Logs.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(logger.Group),
+		LogStreamName: aws.String(logger.Stream),
+		LogEvents: []*cloudwatchlogs.InputLogEvent{
+			{
+				Message:   aws.String(string(message)),
+				Timestamp: aws.Int64(time.Now().UnixNano() / int64(time.Millisecond)),
+			},
+		},
+	})
+
+	return err
+}
+
+func accessResult(err error) string {
+	switch {
+	case err == nil:
+		return ACCESS_RESULT_OK
+	case errors.Is(err, ErrSecretNotFound), errors.Is(err, ErrVersionNotFound):
+		return ACCESS_RESULT_NOT_FOUND
+	}
+
+	return ACCESS_RESULT_ERROR
+}
+
+// callerIdentity is the caller ARN of a driver, shared with the copies
+// WithContext makes of it.
+type callerIdentity struct {
+	once sync.Once
+	arn  string
+}
+
+var callerIdentityMu sync.Mutex
+
+func (driver *Driver) callerIdentity() *callerIdentity {
+	callerIdentityMu.Lock()
+	defer callerIdentityMu.Unlock()
+
+	if driver.identity == nil {
+		driver.identity = &callerIdentity{}
+	}
+
+	return driver.identity
+}
+
+// caller returns the ARN of the AWS identity in use, falling back to Author
+// when STS is not available.
+func (driver *Driver) caller() string {
+	identity := driver.callerIdentity()
+
+	identity.once.Do(func() {
+		identity.arn = driver.Author
+
+		if driver.Sts == nil {
+			return
+		}
+
+		resp, err := driver.Sts.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+
+		if err != nil {
+			driver.logger().Verbosef("could not get caller identity: %s", err.Error())
+			return
+		}
+
+		identity.arn = aws.StringValue(resp.Arn)
+	})
+
+	return identity.arn
+}
+
+// logAccess writes an event for an operation that finished with err and
+// returns err, or the logging error if the operation succeeded. Puts and
+// deletes of a dry run are not logged.
+func (driver *Driver) logAccess(action string, name string, version string, table string, err error) error {
+	if driver.AccessLog == nil || (driver.DryRun && action != "get") {
+		return err
+	}
+
+	now := time.Now
+
+	if driver.Now != nil {
+		now = driver.Now
+	}
+
+	event := &AccessEvent{
+		Time:    now().UTC().Format(time.RFC3339),
+		Caller:  driver.caller(),
+		Action:  action,
+		Table:   table,
+		Name:    name,
+		Version: version,
+		Result:  accessResult(err),
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	logErr := driver.AccessLog.LogAccess(event)
+
+	if err == nil && logErr != nil {
+		return fmt.Errorf("could not write access log: %s", logErr.Error())
+	}
+
+	return err
+}
+
+// NewAccessLogger returns a logger for GCREDSTASH_ACCESS_LOG style
+// destinations: "cloudwatch:GROUP[:STREAM]" or a file path. logs is only
+// used for CloudWatch.
+func NewAccessLogger(dest string, logs cloudwatchlogsiface.CloudWatchLogsAPI) (AccessLogger, error) {
+	if !strings.HasPrefix(dest, "cloudwatch:") {
+		if dest == "" {
+			return nil, fmt.Errorf("empty access log destination")
+		}
+
+		return &FileAccessLogger{Path: dest}, nil
+	}
+
+	groupAndStream := strings.SplitN(strings.TrimPrefix(dest, "cloudwatch:"), ":", 2)
+
+	if groupAndStream[0] == "" {
+		return nil, fmt.Errorf("invalid access log destination: %s", dest)
+	}
+
+	stream := "gcredstash"
+
+	if len(groupAndStream) > 1 && groupAndStream[1] != "" {
+		stream = groupAndStream[1]
+	}
+
+	return &CloudWatchAccessLogger{Logs: logs, Group: groupAndStream[0], Stream: stream}, nil
+}
@@ -0,0 +1,95 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAccessLog(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		testutils.TempFile("", func(logFile *os.File) {
+			driver.Author = "alice"
+			driver.AccessLog = &FileAccessLogger{Path: logFile.Name()}
+
+			table := "credential-store"
+
+			driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+			driver.GetSecret("foo.bar", "", table, nil)
+			driver.GetSecret("foo.baz", "", table, nil)
+			driver.DeleteSecrets("foo.bar", "", table)
+
+			content, _ := ioutil.ReadFile(logFile.Name())
+
+			if strings.Contains(string(content), "100") {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "no credential value", string(content))
+			}
+
+			events := []AccessEvent{}
+
+			for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+				event := AccessEvent{}
+				json.Unmarshal([]byte(line), &event)
+				event.Time = ""
+				event.Error = ""
+				events = append(events, event)
+			}
+
+			expected := []AccessEvent{
+				{Caller: "alice", Action: "put", Table: table, Name: "foo.bar", Version: "0000000000000000001", Result: "ok"},
+				{Caller: "alice", Action: "get", Table: table, Name: "foo.bar", Version: "0000000000000000001", Result: "ok"},
+				{Caller: "alice", Action: "get", Table: table, Name: "foo.baz", Result: "not_found"},
+				{Caller: "alice", Action: "delete", Table: table, Name: "foo.bar", Result: "ok"},
+			}
+
+			if !reflect.DeepEqual(events, expected) {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expected, events)
+			}
+		})
+	})
+}
+
+func TestAccessLogWriteFailure(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.AccessLog = &FileAccessLogger{Path: f.Name() + ".missing/access.log"}
+
+		err := driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", "credential-store", nil)
+
+		if err == nil || !strings.HasPrefix(err.Error(), "could not write access log: ") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "could not write access log", err)
+		}
+	})
+}
+
+func TestNewAccessLogger(t *testing.T) {
+	logger, err := NewAccessLogger("cloudwatch:/gcredstash/access", nil)
+	expected := &CloudWatchAccessLogger{Group: "/gcredstash/access", Stream: "gcredstash"}
+
+	if !reflect.DeepEqual(logger, expected) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, logger, err)
+	}
+
+	logger, err = NewAccessLogger("cloudwatch:/gcredstash/access:web", nil)
+	expected = &CloudWatchAccessLogger{Group: "/gcredstash/access", Stream: "web"}
+
+	if !reflect.DeepEqual(logger, expected) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, logger, err)
+	}
+
+	logger, err = NewAccessLogger("/var/log/gcredstash.log", nil)
+
+	if !reflect.DeepEqual(logger, &FileAccessLogger{Path: "/var/log/gcredstash.log"}) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "/var/log/gcredstash.log", logger, err)
+	}
+
+	_, err = NewAccessLogger("cloudwatch:", nil)
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
@@ -0,0 +1,55 @@
+package gcredstash
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NestAttributes turns the credentials under prefix into nested maps, with
+// the prefix removed and each dot in the rest of the name starting a new
+// level: app.db.password becomes {"db": {"password": ...}} for prefix app.
+// A name that is both a value and a parent of other names is an error.
+func NestAttributes(creds map[string]string, prefix string) (map[string]interface{}, error) {
+	attrs := map[string]interface{}{}
+
+	for _, name := range sortedEnvKeys(creds) {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		path := strings.Split(strings.TrimPrefix(name, prefix), ".")
+		node := attrs
+
+		for i, key := range path {
+			if key == "" {
+				return nil, fmt.Errorf("%s: empty attribute name", name)
+			}
+
+			if i == len(path)-1 {
+				if _, ok := node[key]; ok {
+					return nil, fmt.Errorf("%s: conflicts with %s.*", name, name)
+				}
+
+				node[key] = creds[name]
+				break
+			}
+
+			child, ok := node[key]
+
+			if !ok {
+				child = map[string]interface{}{}
+				node[key] = child
+			}
+
+			childMap, ok := child.(map[string]interface{})
+
+			if !ok {
+				return nil, fmt.Errorf("%s: conflicts with %s", name, prefix+strings.Join(path[:i+1], "."))
+			}
+
+			node = childMap
+		}
+	}
+
+	return attrs, nil
+}
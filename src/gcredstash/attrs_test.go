@@ -0,0 +1,56 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"reflect"
+	"testing"
+)
+
+func TestNestAttributes(t *testing.T) {
+	creds := map[string]string{
+		"app.db.user":     "app",
+		"app.db.password": "s3cr3t",
+		"app.secret_key":  "key",
+		"other.token":     "token",
+	}
+
+	attrs, err := NestAttributes(creds, "app.")
+
+	expected := map[string]interface{}{
+		"db": map[string]interface{}{
+			"user":     "app",
+			"password": "s3cr3t",
+		},
+		"secret_key": "key",
+	}
+
+	if !reflect.DeepEqual(attrs, expected) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, attrs, err)
+	}
+
+	json := ToJson(attrs)
+	expectedJson := `{
+  "db": {
+    "password": "s3cr3t",
+    "user": "app"
+  },
+  "secret_key": "key"
+}`
+
+	if json != expectedJson {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedJson, json)
+	}
+}
+
+func TestNestAttributesConflict(t *testing.T) {
+	for _, creds := range []map[string]string{
+		{"app.db": "x", "app.db.password": "s3cr3t"},
+		{"app.db..password": "s3cr3t"},
+	} {
+		_, err := NestAttributes(creds, "app.")
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	}
+}
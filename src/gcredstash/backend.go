@@ -0,0 +1,259 @@
+package gcredstash
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"strings"
+)
+
+type ScanOptions struct {
+	Attributes []string
+	Prefix     string
+	// IncludeDeleted also returns soft-deleted versions; see
+	// TombstoneBackend.
+	IncludeDeleted bool
+}
+
+type QueryOptions struct {
+	Limit          int64
+	Descending     bool
+	Attributes     []string
+	IncludeDeleted bool
+	// EventuallyConsistent reads at half the capacity units of a
+	// consistent read, but may miss the latest writes.
+	EventuallyConsistent bool
+	// Version, if set, only matches that version, so that a single item
+	// can be read with a projection of Attributes.
+	Version string
+}
+
+// Backend stores credential items keyed by name and version.
+// GetItem returns a nil item when the version does not exist, and PutItem
+// must fail when the name and version are already stored.
+type Backend interface {
+	GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error)
+	PutItem(table string, item map[string]*dynamodb.AttributeValue) error
+	Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error)
+	Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error)
+	Delete(table string, name string, version string) error
+}
+
+type DynamoDBBackend struct {
+	Ddb dynamodbiface.DynamoDBAPI
+	// EventuallyConsistent makes Query eventually consistent, which DAX
+	// needs to serve it from its cache.
+	EventuallyConsistent bool
+}
+
+func (driver *Driver) baseBackend() Backend {
+	if driver.Backend == nil {
+		return &DynamoDBBackend{Ddb: driver.Ddb}
+	}
+
+	return driver.Backend
+}
+
+// readBackend is the backend credentials are read with: the Dax cluster
+// when it is set, and backend() otherwise.
+func (driver *Driver) readBackend() Backend {
+	if driver.Dax == nil || driver.Backend != nil {
+		return driver.backend()
+	}
+
+	return driver.wrapBackend(&DynamoDBBackend{Ddb: driver.Dax, EventuallyConsistent: true})
+}
+
+func (driver *Driver) backend() Backend {
+	return driver.wrapBackend(driver.baseBackend())
+}
+
+func (driver *Driver) wrapBackend(backend Backend) Backend {
+	backend = &ChunkingBackend{Backend: backend, Split: driver.ChunkedStorage}
+
+	if driver.S3 != nil {
+		threshold := driver.S3Threshold
+
+		if threshold == 0 {
+			threshold = DEFAULT_S3_THRESHOLD
+		}
+
+		backend = &S3OverflowBackend{Backend: backend, S3: driver.S3, Bucket: driver.S3Bucket, Threshold: threshold}
+	}
+
+	if driver.KeychainCache != nil {
+		backend = &keychainCacheBackend{Backend: backend, Cache: driver.KeychainCache, Logger: driver.logger()}
+	}
+
+	if driver.DryRun {
+		backend = &DryRunBackend{Backend: backend, Logger: driver.logger()}
+	}
+
+	backend = &TombstoneBackend{Backend: backend}
+
+	if driver.Namespace != "" {
+		backend = &NamespaceBackend{Backend: backend, Namespace: driver.Namespace}
+	}
+
+	return backend
+}
+
+func projectionExpression(attrs []string) *string {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	names := []string{}
+
+	for _, attr := range attrs {
+		if attr == "name" {
+			attr = "#name"
+		}
+
+		names = append(names, attr)
+	}
+
+	return aws.String(strings.Join(names, ","))
+}
+
+func (backend *DynamoDBBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	params := &dynamodb.GetItemInput{
+		TableName: aws.String(table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"name":    {S: aws.String(name)},
+			"version": {S: aws.String(version)},
+		},
+	}
+
+	resp, err := backend.Ddb.GetItem(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Item, nil
+}
+
+func (backend *DynamoDBBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	params := &dynamodb.PutItemInput{
+		TableName:                aws.String(table),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_not_exists(#name)"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	}
+
+	_, err := backend.Ddb.PutItem(params)
+
+	return err
+}
+
+func (backend *DynamoDBBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	if opts == nil {
+		opts = &QueryOptions{}
+	}
+
+	params := &dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		ConsistentRead:           aws.Bool(!backend.EventuallyConsistent && !opts.EventuallyConsistent),
+		KeyConditionExpression:   aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name": {S: aws.String(name)},
+		},
+		ProjectionExpression: projectionExpression(opts.Attributes),
+	}
+
+	if opts.Version != "" {
+		params.KeyConditionExpression = aws.String("#name = :name AND version = :version")
+		params.ExpressionAttributeValues[":version"] = &dynamodb.AttributeValue{S: aws.String(opts.Version)}
+	}
+
+	if opts.Limit > 0 {
+		params.Limit = aws.Int64(opts.Limit)
+	}
+
+	if opts.Descending {
+		params.ScanIndexForward = aws.Bool(false)
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for {
+		resp, err := backend.Ddb.Query(params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, resp.Items...)
+
+		if opts.Limit > 0 && int64(len(items)) >= opts.Limit {
+			return items[:opts.Limit], nil
+		}
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		params.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+
+	return items, nil
+}
+
+func (backend *DynamoDBBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+
+	params := &dynamodb.ScanInput{
+		TableName: aws.String(table),
+	}
+
+	if len(opts.Attributes) > 0 {
+		params.ProjectionExpression = projectionExpression(opts.Attributes)
+		params.ExpressionAttributeNames = map[string]*string{"#name": aws.String("name")}
+	}
+
+	if opts.Prefix != "" {
+		params.FilterExpression = aws.String("begins_with(#name, :prefix)")
+		params.ExpressionAttributeNames = map[string]*string{"#name": aws.String("name")}
+		params.ExpressionAttributeValues = map[string]*dynamodb.AttributeValue{
+			":prefix": {S: aws.String(opts.Prefix)},
+		}
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for {
+		resp, err := backend.Ddb.Scan(params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, resp.Items...)
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		params.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+
+	return items, nil
+}
+
+func (backend *DynamoDBBackend) Delete(table string, name string, version string) error {
+	params := &dynamodb.DeleteItemInput{
+		TableName: aws.String(table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"name":    {S: aws.String(name)},
+			"version": {S: aws.String(version)},
+		},
+	}
+
+	_, err := backend.Ddb.DeleteItem(params)
+
+	return err
+}
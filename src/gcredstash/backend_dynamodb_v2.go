@@ -0,0 +1,328 @@
+package gcredstash
+
+import (
+	"context"
+	"fmt"
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	configv2 "github.com/aws/aws-sdk-go-v2/config"
+	dynamodbv2 "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"time"
+)
+
+const (
+	AWS_SDK_V1 = "v1"
+	AWS_SDK_V2 = "v2"
+)
+
+// ValidateAwsSdk checks the aws_sdk setting of a config profile.
+func ValidateAwsSdk(sdk string) error {
+	switch sdk {
+	case "", AWS_SDK_V1, AWS_SDK_V2:
+		return nil
+	}
+
+	return fmt.Errorf("invalid aws_sdk: %s (must be v1 or v2)", sdk)
+}
+
+// DynamoDBV2API is the part of the aws-sdk-go-v2 DynamoDB client used by
+// DynamoDBV2Backend.
+type DynamoDBV2API interface {
+	GetItem(ctx context.Context, params *dynamodbv2.GetItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.GetItemOutput, error)
+	PutItem(ctx context.Context, params *dynamodbv2.PutItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.PutItemOutput, error)
+	Query(ctx context.Context, params *dynamodbv2.QueryInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodbv2.ScanInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.ScanOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodbv2.DeleteItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.DeleteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodbv2.TransactWriteItemsInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.TransactWriteItemsOutput, error)
+}
+
+// DynamoDBV2Backend is a Backend on aws-sdk-go-v2. Its requests are made
+// with Context, or context.Background() when it is nil, and each fails
+// with a *TimeoutError after Timeout unless it is 0. Other errors keep the
+// "Code: message" form of the v2 SDK, so IsConditionalCheckFailed and
+// IsThrottle work as with DynamoDBBackend.
+type DynamoDBV2Backend struct {
+	Ddb     DynamoDBV2API
+	Context context.Context
+	Timeout time.Duration
+	// Region is only used in error messages.
+	Region string
+}
+
+// LoadAwsV2Config loads the aws-sdk-go-v2 configuration for the region and
+// AWS profile of a config profile, with the adaptive retry mode.
+func LoadAwsV2Config(ctx context.Context, profile *ConfigProfile) (awsv2.Config, error) {
+	opts := []func(*configv2.LoadOptions) error{
+		configv2.WithRetryMode(awsv2.RetryModeAdaptive),
+	}
+
+	if profile.Region != "" {
+		opts = append(opts, configv2.WithRegion(profile.Region))
+	}
+
+	if profile.Profile != "" {
+		opts = append(opts, configv2.WithSharedConfigProfile(profile.Profile))
+	}
+
+	return configv2.LoadDefaultConfig(ctx, opts...)
+}
+
+// NewDynamoDBV2Backend returns a DynamoDBV2Backend for an aws-sdk-go-v2
+// configuration.
+func NewDynamoDBV2Backend(cfg awsv2.Config) *DynamoDBV2Backend {
+	return &DynamoDBV2Backend{Ddb: dynamodbv2.NewFromConfig(cfg), Region: cfg.Region}
+}
+
+func (backend *DynamoDBV2Backend) context() (context.Context, context.CancelFunc) {
+	ctx := backend.Context
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if backend.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, backend.Timeout)
+}
+
+// requestError turns the failure of operation on table into a *TimeoutError
+// when ctx timed out, or an *AccessDeniedError when it was denied.
+func (backend *DynamoDBV2Backend) requestError(ctx context.Context, operation string, table string, err error) error {
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return &TimeoutError{Service: "DynamoDB", Region: backend.Region, Operation: operation, Timeout: backend.Timeout, Err: err}
+	}
+
+	return NewAccessDeniedError("dynamodb:"+operation, table, err)
+}
+
+func attributeToV2(value *dynamodb.AttributeValue) types.AttributeValue {
+	switch {
+	case value.S != nil:
+		return &types.AttributeValueMemberS{Value: *value.S}
+	case value.N != nil:
+		return &types.AttributeValueMemberN{Value: *value.N}
+	case value.B != nil:
+		return &types.AttributeValueMemberB{Value: value.B}
+	case value.BOOL != nil:
+		return &types.AttributeValueMemberBOOL{Value: *value.BOOL}
+	case value.M != nil:
+		return &types.AttributeValueMemberM{Value: itemToV2(value.M)}
+	}
+
+	return &types.AttributeValueMemberNULL{Value: true}
+}
+
+func attributeFromV2(value types.AttributeValue) *dynamodb.AttributeValue {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return &dynamodb.AttributeValue{S: aws.String(v.Value)}
+	case *types.AttributeValueMemberN:
+		return &dynamodb.AttributeValue{N: aws.String(v.Value)}
+	case *types.AttributeValueMemberB:
+		return &dynamodb.AttributeValue{B: v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return &dynamodb.AttributeValue{BOOL: aws.Bool(v.Value)}
+	case *types.AttributeValueMemberM:
+		return &dynamodb.AttributeValue{M: itemFromV2(v.Value)}
+	}
+
+	return &dynamodb.AttributeValue{NULL: aws.Bool(true)}
+}
+
+func itemToV2(item map[string]*dynamodb.AttributeValue) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+
+	v2 := map[string]types.AttributeValue{}
+
+	for key, value := range item {
+		if value != nil {
+			v2[key] = attributeToV2(value)
+		}
+	}
+
+	return v2
+}
+
+func itemFromV2(v2 map[string]types.AttributeValue) map[string]*dynamodb.AttributeValue {
+	if v2 == nil {
+		return nil
+	}
+
+	item := map[string]*dynamodb.AttributeValue{}
+
+	for key, value := range v2 {
+		item[key] = attributeFromV2(value)
+	}
+
+	return item
+}
+
+func itemsFromV2(v2 []map[string]types.AttributeValue) []map[string]*dynamodb.AttributeValue {
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range v2 {
+		items = append(items, itemFromV2(item))
+	}
+
+	return items
+}
+
+func keyToV2(name string, version string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"name":    &types.AttributeValueMemberS{Value: name},
+		"version": &types.AttributeValueMemberS{Value: version},
+	}
+}
+
+func (backend *DynamoDBV2Backend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	resp, err := backend.Ddb.GetItem(ctx, &dynamodbv2.GetItemInput{
+		TableName: awsv2.String(table),
+		Key:       keyToV2(name, version),
+	})
+
+	if err != nil {
+		return nil, backend.requestError(ctx, "GetItem", table, err)
+	}
+
+	if len(resp.Item) == 0 {
+		return nil, nil
+	}
+
+	return itemFromV2(resp.Item), nil
+}
+
+func (backend *DynamoDBV2Backend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	_, err := backend.Ddb.PutItem(ctx, &dynamodbv2.PutItemInput{
+		TableName:                awsv2.String(table),
+		Item:                     itemToV2(item),
+		ConditionExpression:      awsv2.String("attribute_not_exists(#name)"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+	})
+
+	return backend.requestError(ctx, "PutItem", table, err)
+}
+
+func (backend *DynamoDBV2Backend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	if opts == nil {
+		opts = &QueryOptions{}
+	}
+
+	params := &dynamodbv2.QueryInput{
+		TableName:                awsv2.String(table),
+		ConsistentRead:           awsv2.Bool(!opts.EventuallyConsistent),
+		KeyConditionExpression:   awsv2.String("#name = :name"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name": &types.AttributeValueMemberS{Value: name},
+		},
+		ProjectionExpression: projectionExpression(opts.Attributes),
+	}
+
+	if opts.Version != "" {
+		params.KeyConditionExpression = awsv2.String("#name = :name AND version = :version")
+		params.ExpressionAttributeValues[":version"] = &types.AttributeValueMemberS{Value: opts.Version}
+	}
+
+	if opts.Limit > 0 {
+		params.Limit = awsv2.Int32(int32(opts.Limit))
+	}
+
+	if opts.Descending {
+		params.ScanIndexForward = awsv2.Bool(false)
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for {
+		ctx, cancel := backend.context()
+		resp, err := backend.Ddb.Query(ctx, params)
+		cancel()
+
+		if err != nil {
+			return nil, backend.requestError(ctx, "Query", table, err)
+		}
+
+		items = append(items, itemsFromV2(resp.Items)...)
+
+		if opts.Limit > 0 && int64(len(items)) >= opts.Limit {
+			return items[:opts.Limit], nil
+		}
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		params.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+
+	return items, nil
+}
+
+func (backend *DynamoDBV2Backend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+
+	params := &dynamodbv2.ScanInput{
+		TableName: awsv2.String(table),
+	}
+
+	if len(opts.Attributes) > 0 {
+		params.ProjectionExpression = projectionExpression(opts.Attributes)
+		params.ExpressionAttributeNames = map[string]string{"#name": "name"}
+	}
+
+	if opts.Prefix != "" {
+		params.FilterExpression = awsv2.String("begins_with(#name, :prefix)")
+		params.ExpressionAttributeNames = map[string]string{"#name": "name"}
+		params.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":prefix": &types.AttributeValueMemberS{Value: opts.Prefix},
+		}
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for {
+		ctx, cancel := backend.context()
+		resp, err := backend.Ddb.Scan(ctx, params)
+		cancel()
+
+		if err != nil {
+			return nil, backend.requestError(ctx, "Scan", table, err)
+		}
+
+		items = append(items, itemsFromV2(resp.Items)...)
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			break
+		}
+
+		params.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+
+	return items, nil
+}
+
+func (backend *DynamoDBV2Backend) Delete(table string, name string, version string) error {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	_, err := backend.Ddb.DeleteItem(ctx, &dynamodbv2.DeleteItemInput{
+		TableName: awsv2.String(table),
+		Key:       keyToV2(name, version),
+	})
+
+	return backend.requestError(ctx, "DeleteItem", table, err)
+}
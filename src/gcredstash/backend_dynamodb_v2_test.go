@@ -0,0 +1,229 @@
+package gcredstash
+
+import (
+	"context"
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	dynamodbv2 "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type memoryDynamoDBV2 struct {
+	items map[string]map[string]types.AttributeValue
+	// pageSize, if set, is how many items a Query returns at most.
+	pageSize int
+}
+
+func v2String(value types.AttributeValue) string {
+	return value.(*types.AttributeValueMemberS).Value
+}
+
+func (ddb *memoryDynamoDBV2) GetItem(ctx context.Context, params *dynamodbv2.GetItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.GetItemOutput, error) {
+	key := v2String(params.Key["name"]) + "/" + v2String(params.Key["version"])
+
+	return &dynamodbv2.GetItemOutput{Item: ddb.items[key]}, nil
+}
+
+func (ddb *memoryDynamoDBV2) PutItem(ctx context.Context, params *dynamodbv2.PutItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.PutItemOutput, error) {
+	key := v2String(params.Item["name"]) + "/" + v2String(params.Item["version"])
+
+	if _, ok := ddb.items[key]; ok {
+		return nil, errors.New("api error ConditionalCheckFailedException: The conditional request failed")
+	}
+
+	ddb.items[key] = params.Item
+
+	return &dynamodbv2.PutItemOutput{}, nil
+}
+
+func (ddb *memoryDynamoDBV2) Query(ctx context.Context, params *dynamodbv2.QueryInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.QueryOutput, error) {
+	name := v2String(params.ExpressionAttributeValues[":name"])
+	items := []map[string]types.AttributeValue{}
+
+	for _, item := range ddb.items {
+		if v2String(item["name"]) == name {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return v2String(items[i]["version"]) > v2String(items[j]["version"])
+	})
+
+	if params.ExclusiveStartKey != nil {
+		for i, item := range items {
+			if v2String(item["version"]) == v2String(params.ExclusiveStartKey["version"]) {
+				items = items[i+1:]
+				break
+			}
+		}
+	}
+
+	pageSize := len(items)
+
+	if ddb.pageSize > 0 && ddb.pageSize < pageSize {
+		pageSize = ddb.pageSize
+	}
+
+	if params.Limit != nil && int(*params.Limit) < pageSize {
+		pageSize = int(*params.Limit)
+	}
+
+	resp := &dynamodbv2.QueryOutput{Items: items[:pageSize]}
+
+	if pageSize < len(items) {
+		resp.LastEvaluatedKey = map[string]types.AttributeValue{"name": items[pageSize-1]["name"], "version": items[pageSize-1]["version"]}
+	}
+
+	return resp, nil
+}
+
+func (ddb *memoryDynamoDBV2) Scan(ctx context.Context, params *dynamodbv2.ScanInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.ScanOutput, error) {
+	items := []map[string]types.AttributeValue{}
+
+	for _, item := range ddb.items {
+		items = append(items, item)
+	}
+
+	return &dynamodbv2.ScanOutput{Items: items}, nil
+}
+
+func (ddb *memoryDynamoDBV2) DeleteItem(ctx context.Context, params *dynamodbv2.DeleteItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.DeleteItemOutput, error) {
+	delete(ddb.items, v2String(params.Key["name"])+"/"+v2String(params.Key["version"]))
+
+	return &dynamodbv2.DeleteItemOutput{}, nil
+}
+
+func (ddb *memoryDynamoDBV2) TransactWriteItems(ctx context.Context, params *dynamodbv2.TransactWriteItemsInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.TransactWriteItemsOutput, error) {
+	for _, action := range params.TransactItems {
+		if action.Put != nil {
+			key := v2String(action.Put.Item["name"]) + "/" + v2String(action.Put.Item["version"])
+
+			if _, ok := ddb.items[key]; ok {
+				return nil, errors.New("api error TransactionCanceledException: Transaction cancelled, please refer cancellation reasons for specific reasons [ConditionalCheckFailed]")
+			}
+		}
+	}
+
+	for _, action := range params.TransactItems {
+		if action.Put != nil {
+			ddb.items[v2String(action.Put.Item["name"])+"/"+v2String(action.Put.Item["version"])] = action.Put.Item
+		} else {
+			delete(ddb.items, v2String(action.Delete.Key["name"])+"/"+v2String(action.Delete.Key["version"]))
+		}
+	}
+
+	return &dynamodbv2.TransactWriteItemsOutput{}, nil
+}
+
+func TestDriverWithDynamoDBV2Backend(t *testing.T) {
+	backend := &DynamoDBV2Backend{Ddb: &memoryDynamoDBV2{items: map[string]map[string]types.AttributeValue{}}}
+	driver := &Driver{Backend: backend, Logger: &NopLogger{}}
+	table := "credential-store"
+
+	for _, version := range []string{"0000000000000000001", "0000000000000000002"} {
+		err := driver.PutItem("test.key", version, []byte("key"), []byte("contents"), []byte("hmac"), table)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+	}
+
+	version, err := driver.GetHighestVersion("test.key", table)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if version != 2 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", 2, version)
+	}
+
+	err = driver.PutItem("test.key", "0000000000000000002", []byte("key"), []byte("contents"), []byte("hmac"), table)
+
+	if !IsConditionalCheckFailed(err) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "ConditionalCheckFailedException", err)
+	}
+
+	item, err := backend.GetItem(table, "test.key", "0000000000000000003")
+
+	if item != nil || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", nil, item, err)
+	}
+
+	err = driver.DeleteSecrets("test.key", "0000000000000000001", table)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := map[string][]string{"test.key": {"0000000000000000002"}}
+
+	if !reflect.DeepEqual(GroupVersions(items), expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+	}
+}
+
+func TestValidateAwsSdk(t *testing.T) {
+	for _, sdk := range []string{"", "v1", "v2"} {
+		if err := ValidateAwsSdk(sdk); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+	}
+
+	if err := ValidateAwsSdk("v3"); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestDynamoDBV2BackendTransactions(t *testing.T) {
+	ddb := &memoryDynamoDBV2{items: map[string]map[string]types.AttributeValue{}, pageSize: 1}
+	backend := &DynamoDBV2Backend{Ddb: ddb}
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	driver := &Driver{Backend: backend, Kms: localKms, Logger: &NopLogger{}}
+	table := "credential-store"
+
+	for i := 1; i <= 3; i++ {
+		_, err := driver.PutSecrets(map[string]string{"db.user": "app", "db.pass": "100"}, "", "alias/credstash", table, nil, nil)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+	}
+
+	if items, err := backend.Query(table, "db.pass", nil); len(items) != 3 || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", 3, len(items), err)
+	}
+
+	if items, err := backend.Query(table, "db.pass", &QueryOptions{Limit: 2}); len(items) != 2 || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", 2, len(items), err)
+	}
+
+	_, err := driver.PutSecrets(map[string]string{"db.user": "app"}, VersionNumToStr(3), "alias/credstash", table, nil, nil)
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "an existing version", err)
+	}
+
+	if _, err := driver.RenameSecret("db.pass", "db.password", table, true); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if items, _ := backend.Query(table, "db.pass", nil); len(items) != 0 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", 0, len(items))
+	}
+
+	if value, err := driver.GetSecret("db.password", "", table, nil); value != "100" || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+	}
+}
@@ -0,0 +1,261 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileBackend keeps items of every table in a single JSON file. It is meant
+// for local development and has no locking across processes.
+type FileBackend struct {
+	Path  string
+	mutex sync.Mutex
+}
+
+func (backend *FileBackend) load() (map[string][]map[string]string, error) {
+	tables := map[string][]map[string]string{}
+	content, err := ioutil.ReadFile(backend.Path)
+
+	if os.IsNotExist(err) {
+		return tables, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) == 0 {
+		return tables, nil
+	}
+
+	err = json.Unmarshal(content, &tables)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", backend.Path, err.Error())
+	}
+
+	return tables, nil
+}
+
+func (backend *FileBackend) save(tables map[string][]map[string]string) error {
+	content, err := json.MarshalIndent(tables, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	tmpfile, err := ioutil.TempFile(filepath.Dir(backend.Path), ".gcredstash")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.Write(content)
+
+	if err != nil {
+		tmpfile.Close()
+		return err
+	}
+
+	err = tmpfile.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpfile.Name(), 0600)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpfile.Name(), backend.Path)
+}
+
+// fileItemToItem turns a file item back into an item. Keys such as
+// tags.team are the entries of a map attribute.
+func fileItemToItem(m map[string]string) map[string]*dynamodb.AttributeValue {
+	item := map[string]*dynamodb.AttributeValue{}
+
+	for key, value := range m {
+		if kv := strings.SplitN(key, ".", 2); len(kv) == 2 {
+			if item[kv[0]] == nil {
+				item[kv[0]] = &dynamodb.AttributeValue{M: map[string]*dynamodb.AttributeValue{}}
+			}
+
+			item[kv[0]].M[kv[1]] = &dynamodb.AttributeValue{S: aws.String(value)}
+			continue
+		}
+
+		item[key] = &dynamodb.AttributeValue{S: aws.String(value)}
+	}
+
+	return item
+}
+
+// itemToFileItem keeps the string attributes of item, and the string
+// entries of its map attributes as attr.key.
+func itemToFileItem(item map[string]*dynamodb.AttributeValue) map[string]string {
+	m := map[string]string{}
+
+	for key, value := range item {
+		if value != nil && value.S != nil {
+			m[key] = *value.S
+		}
+
+		if value != nil && value.M != nil {
+			for k, v := range value.M {
+				if v != nil && v.S != nil {
+					m[key+"."+k] = *v.S
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+func (backend *FileBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range tables[table] {
+		if m["name"] == name && m["version"] == version {
+			return fileItemToItem(m), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (backend *FileBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return err
+	}
+
+	m := itemToFileItem(item)
+
+	for _, stored := range tables[table] {
+		if stored["name"] == m["name"] && stored["version"] == m["version"] {
+			return fmt.Errorf("ConditionalCheckFailedException: %s version %s already exists in %s", m["name"], m["version"], backend.Path)
+		}
+	}
+
+	tables[table] = append(tables[table], m)
+
+	return backend.save(tables)
+}
+
+func (backend *FileBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	if opts == nil {
+		opts = &QueryOptions{}
+	}
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []map[string]string{}
+
+	for _, m := range tables[table] {
+		if m["name"] == name && (opts.Version == "" || m["version"] == opts.Version) {
+			matched = append(matched, m)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if opts.Descending {
+			return matched[i]["version"] > matched[j]["version"]
+		}
+
+		return matched[i]["version"] < matched[j]["version"]
+	})
+
+	if opts.Limit > 0 && int64(len(matched)) > opts.Limit {
+		matched = matched[:opts.Limit]
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for _, m := range matched {
+		items = append(items, fileItemToItem(m))
+	}
+
+	return items, nil
+}
+
+func (backend *FileBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for _, m := range tables[table] {
+		if strings.HasPrefix(m["name"], opts.Prefix) {
+			items = append(items, fileItemToItem(m))
+		}
+	}
+
+	return items, nil
+}
+
+func (backend *FileBackend) Delete(table string, name string, version string) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return err
+	}
+
+	kept := []map[string]string{}
+
+	for _, m := range tables[table] {
+		if m["name"] == name && m["version"] == version {
+			continue
+		}
+
+		kept = append(kept, m)
+	}
+
+	tables[table] = kept
+
+	return backend.save(tables)
+}
@@ -0,0 +1,84 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileBackendWithLocalKms(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+		context := map[string]string{"app": "test"}
+
+		err := driver.PutSecret("test.key", "test.value", "0000000000000000001", "alias/credstash", table, context)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		err = driver.PutSecret("test.key", "test.value", "0000000000000000001", "alias/credstash", table, context)
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+
+		reopened := &Driver{Backend: &FileBackend{Path: f.Name()}, Kms: driver.Kms}
+		value, err := reopened.GetSecret("test.key", "", table, context)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if value != "test.value" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "test.value", value)
+		}
+
+		_, err = reopened.GetSecret("test.key", "", table, map[string]string{"app": "other"})
+
+		if err == nil || !strings.Contains(err.Error(), "encryption context provided may not match") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "context mismatch error", err)
+		}
+
+		items, err := reopened.ListSecrets(table)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		expected := map[string][]string{"test.key": {"0000000000000000001"}}
+
+		if !reflect.DeepEqual(GroupVersions(items), expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+		}
+	})
+}
+
+func TestNewLocalKmsWithInvalidKey(t *testing.T) {
+	_, err := NewLocalKms("c2hvcnQ=")
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestLocalKmsKeyCalls(t *testing.T) {
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	driver := &Driver{Kms: localKms}
+
+	_, err := driver.InspectKey("alias/credstash", nil)
+	expected := "Could not resolve KMS key(alias/credstash): kms:DescribeKey is not supported with the file backend's local master key"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+
+	_, err = driver.CreateGrant("arn:aws:iam::123456789012:role/app", []string{"Decrypt"}, "alias/credstash")
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
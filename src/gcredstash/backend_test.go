@@ -0,0 +1,212 @@
+package gcredstash
+
+import (
+	"fmt"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type memoryBackend struct {
+	items map[string]map[string]*dynamodb.AttributeValue
+}
+
+func (backend *memoryBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	return backend.items[name+"/"+version], nil
+}
+
+func (backend *memoryBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	key := *item["name"].S + "/" + *item["version"].S
+
+	if _, ok := backend.items[key]; ok {
+		return fmt.Errorf("%s already exists", key)
+	}
+
+	backend.items[key] = item
+
+	return nil
+}
+
+func (backend *memoryBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range backend.items {
+		if *item["name"].S == name && (opts == nil || opts.Version == "" || *item["version"].S == opts.Version) {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return *items[i]["version"].S > *items[j]["version"].S
+	})
+
+	if opts != nil && opts.Limit > 0 && int64(len(items)) > opts.Limit {
+		items = items[:opts.Limit]
+	}
+
+	return items, nil
+}
+
+func (backend *memoryBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range backend.items {
+		if opts == nil || strings.HasPrefix(*item["name"].S, opts.Prefix) {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+func (backend *memoryBackend) Delete(table string, name string, version string) error {
+	delete(backend.items, name+"/"+version)
+	return nil
+}
+
+func TestDriverWithBackend(t *testing.T) {
+	backend := &memoryBackend{items: map[string]map[string]*dynamodb.AttributeValue{}}
+	driver := &Driver{Backend: backend, Logger: &NopLogger{}}
+	table := "credential-store"
+
+	for _, version := range []string{"0000000000000000001", "0000000000000000002"} {
+		err := driver.PutItem("test.key", version, []byte("key"), []byte("contents"), []byte("hmac"), table)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+	}
+
+	version, err := driver.GetHighestVersion("test.key", table)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if version != 2 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", 2, version)
+	}
+
+	err = driver.PutItem("test.key", "0000000000000000002", []byte("key"), []byte("contents"), []byte("hmac"), table)
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+
+	err = driver.DeleteSecrets("test.key", "0000000000000000001", table)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := map[string][]string{"test.key": {"0000000000000000002"}}
+
+	if !reflect.DeepEqual(GroupVersions(items), expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+	}
+}
+
+func TestDynamoDBBackendQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	table := "credential-store"
+	name := "test.key"
+
+	item := map[string]string{
+		"name":    name,
+		"version": "0000000000000000001",
+	}
+
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		Limit:                    aws.Int64(1),
+		ConsistentRead:           aws.Bool(true),
+		ScanIndexForward:         aws.Bool(false),
+		KeyConditionExpression:   aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name": {S: aws.String(name)},
+		},
+		ProjectionExpression: aws.String("#name,version"),
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(1),
+		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
+	}, nil)
+
+	backend := &DynamoDBBackend{Ddb: mddb}
+
+	items, err := backend.Query(table, name, &QueryOptions{
+		Limit:      1,
+		Descending: true,
+		Attributes: []string{"name", "version"},
+	})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)}
+
+	if !reflect.DeepEqual(items, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, items)
+	}
+}
+
+func TestDynamoDBBackendQueryPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	table := "credential-store"
+	name := "test.key"
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for i := 1; i <= 3; i++ {
+		items = append(items, testutils.MapToItem(map[string]string{"name": name, "version": VersionNumToStr(i)}))
+	}
+
+	input := func(startKey map[string]*dynamodb.AttributeValue) *dynamodb.QueryInput {
+		return &dynamodb.QueryInput{
+			TableName:                aws.String(table),
+			ConsistentRead:           aws.Bool(true),
+			KeyConditionExpression:   aws.String("#name = :name"),
+			ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":name": {S: aws.String(name)},
+			},
+			ExclusiveStartKey: startKey,
+		}
+	}
+
+	mddb.EXPECT().Query(input(nil)).Return(&dynamodb.QueryOutput{
+		Items:            items[:2],
+		LastEvaluatedKey: items[1],
+	}, nil)
+
+	mddb.EXPECT().Query(input(items[1])).Return(&dynamodb.QueryOutput{
+		Items: items[2:],
+	}, nil)
+
+	backend := &DynamoDBBackend{Ddb: mddb}
+	got, err := backend.Query(table, name, nil)
+
+	if !reflect.DeepEqual(got, items) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", items, got, err)
+	}
+}
@@ -0,0 +1,122 @@
+package gcredstash
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	value   *SecureBytes
+	version string
+	expires time.Time
+}
+
+// SecretCache decrypts the latest version of a credential on first use and
+// keeps the plaintext for TTL, so that long-running readers do not call KMS
+// on every read. It is safe for concurrent use.
+type SecretCache struct {
+	Driver  *Driver
+	Table   string
+	Context map[string]string
+	TTL     time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]*cacheEntry
+	hits    uint64
+	misses  uint64
+}
+
+func NewSecretCache(driver *Driver, table string, context map[string]string, ttl time.Duration) *SecretCache {
+	return &SecretCache{
+		Driver:  driver,
+		Table:   table,
+		Context: context,
+		TTL:     ttl,
+		entries: map[string]*cacheEntry{},
+	}
+}
+
+func (cache *SecretCache) now() time.Time {
+	if cache.Driver.Now != nil {
+		return cache.Driver.Now()
+	}
+
+	return time.Now()
+}
+
+// Get returns a copy of the plaintext, which the caller should clear with
+// Wipe once it is no longer needed.
+func (cache *SecretCache) Get(name string) ([]byte, error) {
+	value, _, err := cache.GetWithVersion(name)
+	return value, err
+}
+
+// GetWithVersion is like Get but also returns the cached version.
+func (cache *SecretCache) GetWithVersion(name string) ([]byte, string, error) {
+	cache.mutex.Lock()
+	entry, ok := cache.entries[name]
+
+	if ok && cache.now().Before(entry.expires) {
+		cache.hits++
+		value := append([]byte{}, entry.value.Bytes()...)
+		cache.mutex.Unlock()
+		return value, entry.version, nil
+	}
+
+	cache.misses++
+	cache.mutex.Unlock()
+
+	// Decrypt without holding the lock, so one slow KMS call does not block
+	// reads of other credentials.
+	value, version, err := cache.Driver.GetSecretBytesWithVersion(name, "", cache.Table, cache.Context)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if old, ok := cache.entries[name]; ok {
+		old.value.Wipe()
+	}
+
+	cache.entries[name] = &cacheEntry{
+		value:   NewSecureBytes(append([]byte{}, value...)),
+		version: version,
+		expires: cache.now().Add(cache.TTL),
+	}
+
+	return value, version, nil
+}
+
+// Invalidate drops a cached credential, e.g. after a change was observed.
+func (cache *SecretCache) Invalidate(name string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if entry, ok := cache.entries[name]; ok {
+		entry.value.Wipe()
+		delete(cache.entries, name)
+	}
+}
+
+// Purge wipes and drops every cached credential.
+func (cache *SecretCache) Purge() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for name, entry := range cache.entries {
+		entry.value.Wipe()
+		delete(cache.entries, name)
+	}
+}
+
+// Stats returns the number of reads served from the cache and the number
+// that had to be decrypted.
+func (cache *SecretCache) Stats() (uint64, uint64) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.hits, cache.misses
+}
@@ -0,0 +1,59 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSecretCache(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		now := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+		driver.Now = func() time.Time { return now }
+		table := "credential-store"
+
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+
+		cache := NewSecretCache(driver, table, nil, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			value, err := cache.Get("foo.bar")
+
+			if string(value) != "100" || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", string(value), err)
+			}
+
+			// Callers may wipe the returned value without affecting the cache.
+			Wipe(value)
+		}
+
+		driver.PutSecret("foo.bar", "200", "0000000000000000002", "alias/credstash", table, nil)
+		value, _ := cache.Get("foo.bar")
+
+		if string(value) != "100" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "100", string(value))
+		}
+
+		now = now.Add(2 * time.Minute)
+		value, _ = cache.Get("foo.bar")
+
+		if string(value) != "200" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "200", string(value))
+		}
+
+		hits, misses := cache.Stats()
+
+		if hits != 2 || misses != 2 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "2 hits, 2 misses", []uint64{hits, misses})
+		}
+
+		_, err := cache.Get("foo.baz")
+
+		if !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "not found", err)
+		}
+	})
+}
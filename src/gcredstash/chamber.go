@@ -0,0 +1,62 @@
+package gcredstash
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChamberName maps a chamber-style "service/key" path to a credential
+// name, "service.key". Like chamber, names are lower-cased, and services
+// can be nested ("org/service/key" is "org.service.key").
+func ChamberName(path string) (string, error) {
+	path = strings.ToLower(strings.Trim(path, "/"))
+
+	if !strings.Contains(path, "/") {
+		return "", fmt.Errorf("%s: expected service/key", path)
+	}
+
+	return strings.Replace(path, "/", ".", -1), nil
+}
+
+// ChamberService maps a chamber service to the credential prefix of its keys.
+func ChamberService(service string) string {
+	return strings.Replace(strings.ToLower(strings.Trim(service, "/")), "/", ".", -1) + "."
+}
+
+// ChamberEnv returns the environment variables chamber exec sets for the
+// keys of services: each key upper-cased, with dashes and dots replaced by
+// underscores. When services share a key, the later service wins.
+func ChamberEnv(creds map[string]string, services []string) map[string]string {
+	env := map[string]string{}
+
+	for _, service := range services {
+		prefix := ChamberService(service)
+
+		for _, name := range sortedEnvKeys(creds) {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+
+			key := strings.TrimPrefix(name, prefix)
+
+			if strings.Contains(key, ".") {
+				continue
+			}
+
+			env[EnvName(key, nil)] = creds[name]
+		}
+	}
+
+	return env
+}
+
+// FormatChamberEnv formats env as the export lines of chamber env.
+func FormatChamberEnv(env map[string]string) string {
+	out := ""
+
+	for _, key := range sortedEnvKeys(env) {
+		out += fmt.Sprintf("export %s=%s\n", key, ShellQuote(env[key]))
+	}
+
+	return out
+}
@@ -0,0 +1,53 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"reflect"
+	"testing"
+)
+
+func TestChamberName(t *testing.T) {
+	for path, expected := range map[string]string{
+		"web/db-password":     "web.db-password",
+		"Web/DB_PASSWORD":     "web.db_password",
+		"/org/web/api-token/": "org.web.api-token",
+	} {
+		name, err := ChamberName(path)
+
+		if name != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, name, err)
+		}
+	}
+
+	if _, err := ChamberName("web"); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestChamberEnv(t *testing.T) {
+	creds := map[string]string{
+		"global.log-level":  "info",
+		"global.db-host":    "db.example.com",
+		"web.db-host":       "web-db.example.com",
+		"web.api.token":     "nested",
+		"worker.queue-name": "jobs",
+	}
+
+	env := ChamberEnv(creds, []string{"global", "web"})
+
+	expected := map[string]string{
+		"LOG_LEVEL": "info",
+		"DB_HOST":   "web-db.example.com",
+	}
+
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, env)
+	}
+
+	out := FormatChamberEnv(env)
+	expectedOut := "export DB_HOST='web-db.example.com'\nexport LOG_LEVEL='info'\n"
+
+	if out != expectedOut {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedOut, out)
+	}
+}
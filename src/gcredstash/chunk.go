@@ -0,0 +1,385 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"strconv"
+	"strings"
+)
+
+// MAX_ITEM_SIZE is the largest item DynamoDB stores.
+const MAX_ITEM_SIZE = 400 * 1024
+
+// CHUNK_SIZE is how much of the base64 contents each item of a chunked
+// version holds, leaving room for its other attributes.
+const CHUNK_SIZE = 350 * 1024
+
+// ItemSize returns the size DynamoDB counts for item: the lengths of its
+// attribute names and values.
+func ItemSize(item map[string]*dynamodb.AttributeValue) int {
+	size := 0
+
+	for attr, value := range item {
+		size += len(attr)
+
+		switch {
+		case value == nil:
+		case value.S != nil:
+			size += len(*value.S)
+		case value.N != nil:
+			size += len(*value.N)
+		case value.B != nil:
+			size += len(value.B)
+		case value.M != nil:
+			size += 3 + ItemSize(value.M)
+		default:
+			size++
+		}
+	}
+
+	return size
+}
+
+// ChunkingBackend checks the size of the items put through it. When Split
+// is set, a version over MAX_ITEM_SIZE is stored as several items: the
+// first keeps the name, the attributes and the number of items in
+// chunk_count, and the others, named name + "\x00" + their index, hold the
+// rest of the contents. They are written in one transaction when the
+// backend supports it and they fit, and the written ones are deleted again
+// when a later one fails otherwise. Without Split it fails with an
+// ItemTooLargeError.
+// Reads put chunked versions back together, so the backends above only see
+// whole items. It is always in place, so chunked versions stay readable
+// and deletable when Split is turned off.
+type ChunkingBackend struct {
+	Backend Backend
+	Split   bool
+}
+
+func chunkName(name string, index int) string {
+	return fmt.Sprintf("%s\x00%d", name, index)
+}
+
+func isChunk(item map[string]*dynamodb.AttributeValue) bool {
+	return strings.Contains(stringAttr(item, "name"), "\x00")
+}
+
+func chunkCount(item map[string]*dynamodb.AttributeValue) int {
+	count, err := strconv.Atoi(stringAttr(item, "chunk_count"))
+
+	if err != nil {
+		return 1
+	}
+
+	return count
+}
+
+// chunkKeys returns the keys of the items item is stored as, itself last.
+func chunkKeys(item map[string]*dynamodb.AttributeValue) []map[string]*dynamodb.AttributeValue {
+	keys := []map[string]*dynamodb.AttributeValue{}
+
+	for i := 1; i < chunkCount(item); i++ {
+		keys = append(keys, map[string]*dynamodb.AttributeValue{
+			"name":    {S: aws.String(chunkName(stringAttr(item, "name"), i))},
+			"version": item["version"],
+		})
+	}
+
+	return append(keys, item)
+}
+
+// split returns the items to store item as, the one that keeps its name
+// last, so that a version is never visible before all of it is stored.
+func (backend *ChunkingBackend) split(item map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, error) {
+	whole := map[string]*dynamodb.AttributeValue{}
+
+	for attr, value := range item {
+		whole[attr] = value
+	}
+
+	delete(whole, "chunk_count")
+	name := stringAttr(whole, "name")
+	size := ItemSize(whole)
+
+	if size <= MAX_ITEM_SIZE {
+		return []map[string]*dynamodb.AttributeValue{whole}, nil
+	}
+
+	if !backend.Split {
+		return nil, &ItemTooLargeError{Name: name, Size: size, CanChunk: true}
+	}
+
+	contents := stringAttr(whole, "contents")
+	parts := []string{}
+
+	for len(contents) > CHUNK_SIZE {
+		parts = append(parts, contents[:CHUNK_SIZE])
+		contents = contents[CHUNK_SIZE:]
+	}
+
+	parts = append(parts, contents)
+	whole["contents"] = &dynamodb.AttributeValue{S: aws.String(parts[0])}
+	whole["chunk_count"] = &dynamodb.AttributeValue{S: aws.String(strconv.Itoa(len(parts)))}
+
+	// The other attributes alone can be too large.
+	if size := ItemSize(whole); size > MAX_ITEM_SIZE {
+		return nil, &ItemTooLargeError{Name: name, Size: size}
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for i, part := range parts[1:] {
+		items = append(items, map[string]*dynamodb.AttributeValue{
+			"name":     {S: aws.String(chunkName(name, i+1))},
+			"version":  whole["version"],
+			"contents": {S: aws.String(part)},
+		})
+	}
+
+	return append(items, whole), nil
+}
+
+// join puts the contents of a chunked item back together. Its other
+// items are taken from chunks, keyed by name, when there, and read
+// otherwise.
+func (backend *ChunkingBackend) join(table string, item map[string]*dynamodb.AttributeValue, chunks map[string]map[string]*dynamodb.AttributeValue) (map[string]*dynamodb.AttributeValue, error) {
+	count := chunkCount(item)
+
+	if count < 2 || item["contents"] == nil {
+		return item, nil
+	}
+
+	name := stringAttr(item, "name")
+	version := stringAttr(item, "version")
+	contents := []string{stringAttr(item, "contents")}
+
+	for i := 1; i < count; i++ {
+		chunk, ok := chunks[chunkName(name, i)]
+
+		if !ok || stringAttr(chunk, "version") != version {
+			var err error
+			chunk, err = backend.Backend.GetItem(table, chunkName(name, i), version)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if chunk == nil {
+			return nil, fmt.Errorf("%s version %d is missing part %d of %d", name, Atoi(version), i+1, count)
+		}
+
+		contents = append(contents, stringAttr(chunk, "contents"))
+	}
+
+	whole := map[string]*dynamodb.AttributeValue{}
+
+	for attr, value := range item {
+		whole[attr] = value
+	}
+
+	whole["contents"] = &dynamodb.AttributeValue{S: aws.String(strings.Join(contents, ""))}
+
+	return whole, nil
+}
+
+func (backend *ChunkingBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	item, err := backend.Backend.GetItem(table, name, version)
+
+	if err != nil || item == nil {
+		return item, err
+	}
+
+	return backend.join(table, item, nil)
+}
+
+func (backend *ChunkingBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	items, err := backend.split(item)
+
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 1 {
+		return backend.Backend.PutItem(table, items[0])
+	}
+
+	transactional, ok := backend.Backend.(TransactionalBackend)
+
+	if ok && len(items) <= MAX_TRANSACT_ITEMS && itemsSize(items) <= MAX_TRANSACT_SIZE {
+		return transactional.PutItems(table, items)
+	}
+
+	for i, item := range items {
+		if err := backend.Backend.PutItem(table, item); err != nil {
+			// The parts already written are new, as puts never overwrite.
+			for _, written := range items[:i] {
+				backend.Backend.Delete(table, stringAttr(written, "name"), stringAttr(written, "version"))
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func itemsSize(items []map[string]*dynamodb.AttributeValue) int {
+	size := 0
+
+	for _, item := range items {
+		size += ItemSize(item)
+	}
+
+	return size
+}
+
+func (backend *ChunkingBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, err := backend.Backend.Query(table, name, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		items[i], err = backend.join(table, item, nil)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func (backend *ChunkingBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, err := backend.Backend.Scan(table, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := map[string]map[string]*dynamodb.AttributeValue{}
+	whole := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		if isChunk(item) {
+			chunks[stringAttr(item, "name")] = item
+		}
+	}
+
+	for _, item := range items {
+		if isChunk(item) {
+			continue
+		}
+
+		item, err := backend.join(table, item, chunks)
+
+		if err != nil {
+			return nil, err
+		}
+
+		whole = append(whole, item)
+	}
+
+	return whole, nil
+}
+
+// Delete also deletes the other items of a chunked version, whether Split
+// is set or not.
+func (backend *ChunkingBackend) Delete(table string, name string, version string) error {
+	items, err := backend.Backend.Query(table, name, &QueryOptions{Version: version, Attributes: []string{"chunk_count"}})
+
+	if err != nil {
+		return err
+	}
+
+	if err := backend.Backend.Delete(table, name, version); err != nil || len(items) == 0 {
+		return err
+	}
+
+	for i := 1; i < chunkCount(items[0]); i++ {
+		if err := backend.Backend.Delete(table, chunkName(name, i), version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (backend *ChunkingBackend) splitAll(items []map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, error) {
+	all := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		split, err := backend.split(item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, split...)
+	}
+
+	return all, nil
+}
+
+func (backend *ChunkingBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	transactional, ok := backend.Backend.(TransactionalBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	all, err := backend.splitAll(items)
+
+	if err != nil {
+		return err
+	}
+
+	return transactional.PutItems(table, all)
+}
+
+func (backend *ChunkingBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	replacing, ok := backend.Backend.(ReplacingBackend)
+
+	if !ok {
+		return errSoftDeleteUnsupported
+	}
+
+	items, err := backend.split(item)
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := replacing.ReplaceItem(table, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (backend *ChunkingBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	moving, ok := backend.Backend.(MovingBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	all, err := backend.splitAll(items)
+
+	if err != nil {
+		return err
+	}
+
+	keys := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range from {
+		keys = append(keys, chunkKeys(item)...)
+	}
+
+	return moving.MoveItems(table, all, keys)
+}
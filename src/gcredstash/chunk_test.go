@@ -0,0 +1,159 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestItemSize(t *testing.T) {
+	item := map[string]*dynamodb.AttributeValue{
+		"name": {S: aws.String("db.pass")},
+		"ttl":  {N: aws.String("1700000000")},
+		"tags": {M: map[string]*dynamodb.AttributeValue{"team": {S: aws.String("payments")}}},
+	}
+
+	if size := ItemSize(item); size != 4+7+3+10+4+3+4+8 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", 4+7+3+10+4+3+4+8, size)
+	}
+}
+
+func TestDriverItemTooLarge(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		err := driver.PutSecret("big.blob", strings.Repeat("x", 400*1024), VersionNumToStr(1), "alias/credstash", table, nil)
+
+		if !errors.Is(err, ErrItemTooLarge) || !strings.Contains(err.Error(), "big.blob is too large to store: ") || !strings.Contains(err.Error(), "chunked_storage: true") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrItemTooLarge, err)
+		}
+
+		if _, err := driver.GetSecret("big.blob", "", table, nil); !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+	})
+}
+
+func TestDriverChunkedStorage(t *testing.T) {
+	table := "credential-store"
+	value := strings.Repeat("0123456789abcdef", 40*1024)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Events = &NopEventHandler{}
+		driver.ChunkedStorage = true
+
+		if err := driver.PutSecret("big.blob", value, VersionNumToStr(1), "alias/credstash", table, nil); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		driver.PutSecret("small.key", "abc", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		if got, err := driver.GetSecret("big.blob", "", table, nil); got != value || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", len(value), len(got), err)
+		}
+
+		items, _ := driver.ListSecrets(table)
+
+		if len(items) != 2 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 2, len(items))
+		}
+
+		archive, meta, err := driver.Snapshot(table, "alias/credstash")
+
+		if meta == nil || meta.ItemCount != 2 || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", 2, meta, err)
+		}
+
+		testutils.TempDriver(func(restoreDriver *Driver, g *os.File) {
+			restoreDriver.ChunkedStorage = true
+
+			if _, restored, err := restoreDriver.RestoreSnapshot(archive, table); restored != 2 || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", 2, restored, err)
+			}
+
+			// Reads do not need chunked storage on.
+			restoreDriver.ChunkedStorage = false
+
+			if got, err := restoreDriver.GetSecret("big.blob", "", table, nil); got != value || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", len(value), len(got), err)
+			}
+		})
+
+		if err := driver.DeleteSecrets("big.blob", "", table); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		content, _ := ioutil.ReadFile(f.Name())
+
+		if strings.Contains(string(content), "big.blob") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "no items of big.blob", string(content)[:200])
+		}
+	})
+}
+
+func TestDriverChunkedDeleteWithoutSplit(t *testing.T) {
+	table := "credential-store"
+	value := strings.Repeat("0123456789abcdef", 40*1024)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Events = &NopEventHandler{}
+		driver.ChunkedStorage = true
+		driver.PutSecret("big.blob", value, VersionNumToStr(1), "alias/credstash", table, nil)
+
+		driver.ChunkedStorage = false
+
+		if err := driver.DeleteSecrets("big.blob", "", table); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		content, _ := ioutil.ReadFile(f.Name())
+
+		if strings.Contains(string(content), "big.blob") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "no items of big.blob", string(content)[:200])
+		}
+	})
+}
+
+func TestChunkingBackendPutFailure(t *testing.T) {
+	table := "credential-store"
+	version := VersionNumToStr(1)
+	item := map[string]*dynamodb.AttributeValue{
+		"name":     {S: aws.String("big.blob")},
+		"version":  {S: aws.String(version)},
+		"contents": {S: aws.String(strings.Repeat("x", 800*1024))},
+	}
+	existing := map[string]*dynamodb.AttributeValue{
+		"name":     {S: aws.String("big.blob")},
+		"version":  {S: aws.String(version)},
+		"contents": {S: aws.String("small")},
+	}
+
+	memory := &memoryBackend{items: map[string]map[string]*dynamodb.AttributeValue{"big.blob/" + version: existing}}
+	backend := &ChunkingBackend{Backend: memory, Split: true}
+
+	if err := backend.PutItem(table, item); err == nil || len(memory.items) != 1 {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "only the existing item", err, len(memory.items))
+	}
+
+	testutils.TempFile("", func(f *os.File) {
+		file := &FileBackend{Path: f.Name()}
+		file.PutItem(table, existing)
+		backend := &ChunkingBackend{Backend: file, Split: true}
+
+		if err := backend.PutItem(table, item); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "an error", err)
+		}
+
+		items, _ := file.Scan(table, nil)
+
+		if len(items) != 1 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 1, len(items))
+		}
+	})
+}
@@ -0,0 +1,510 @@
+package gcredstash
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"io"
+	"time"
+)
+
+const (
+	DEFAULT_TABLE   = "credential-store"
+	DEFAULT_KMS_KEY = "alias/credstash"
+)
+
+// Config configures a Client. Empty fields fall back to the CLI defaults.
+// When Session is nil, a session is created from the standard AWS
+// environment and shared config, with Region applied if set.
+type Config struct {
+	Table    string
+	KmsKey   string
+	Region   string
+	Session  *session.Session
+	Logger   Logger
+	ReadOnly bool
+	// AccessLog receives an event for every Get, Put and Delete.
+	AccessLog AccessLogger
+	// RateLimit limits the requests per second to each AWS service.
+	RateLimit float64
+	// Timeout limits each AWS call, retries included. It is
+	// DEFAULT_TIMEOUT when 0; a negative Timeout disables it.
+	Timeout time.Duration
+	// Events receives progress events instead of Logger.
+	Events EventHandler
+	// Credentials controls how the session sources AWS credentials.
+	// It is ignored when Session is set.
+	Credentials CredentialOptions
+	// Naming rejects puts of names that break the rules. Names are not
+	// normalized; call Naming.Normalize before Put to do so.
+	Naming *NamingRules
+	// Namespace is prefixed to every credential name, e.g. "team1/".
+	Namespace string
+	// NamespaceKeys maps name prefixes to the KMS key used by Put, over
+	// KmsKey and WithKmsKey.
+	NamespaceKeys map[string]string
+	// SoftDelete makes Delete only mark versions as deleted; see
+	// Driver.RestoreSecrets and Driver.PurgeSecrets.
+	SoftDelete bool
+	// ReadKmsKey, if set, is the only KMS key Get decrypts with. KmsKey
+	// stays the key Put uses, e.g. a key of another account.
+	ReadKmsKey string
+	// RecoveryKmsKey, if set, wraps the data key of every Put a second
+	// time, so Get still works when the primary key is disabled; see
+	// Driver.RecoveryKmsKey.
+	RecoveryKmsKey string
+	// Passphrase returns the passphrase of credentials put with
+	// WithPassphrase; see Driver.Passphrase.
+	Passphrase func(name string) ([]byte, error)
+	// DataKeyCache keeps the data keys Get decrypts, and Offline makes Get
+	// decrypt with it instead of KMS; see Driver.Offline.
+	DataKeyCache *DataKeyCache
+	Offline      bool
+	// KeychainCache keeps the credentials Get reads in the OS keychain;
+	// see Driver.KeychainCache.
+	KeychainCache *KeychainCache
+	// DaxEndpoint, if set, is a DAX cluster Get reads through; see
+	// Driver.Dax.
+	DaxEndpoint string
+	// ReadConsistency "eventual" makes Get read the latest version with
+	// eventually consistent reads; see Driver.ReadConsistency.
+	ReadConsistency string
+	// Fallbacks are read in order when a credential is not in the table;
+	// see Driver.Fallbacks.
+	Fallbacks []*FallbackStore
+	// ChunkedStorage stores values too large for a DynamoDB item as
+	// several items; see ChunkingBackend.
+	ChunkedStorage bool
+	// S3Bucket, if set, is where Put stores values over S3Threshold bytes;
+	// see Driver.S3Bucket.
+	S3Bucket    string
+	S3Threshold int
+}
+
+// Client reads and writes credentials in one credential store.
+// It is safe for concurrent use.
+type Client struct {
+	Driver *Driver
+	Table  string
+	KmsKey string
+}
+
+// CallOption changes a single Client call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	version string
+	context map[string]string
+	kmsKey  string
+	put     PutOptions
+
+	requireContext bool
+	ifVersion      int
+	checkVersion   bool
+	idempotent     bool
+}
+
+// WithVersion selects a credential version instead of the latest one.
+func WithVersion(version int) CallOption {
+	return func(opts *callOptions) {
+		opts.version = VersionNumToStr(version)
+	}
+}
+
+// WithIfVersion makes Put store the next version only if version is the
+// latest one (0 for a new credential), and fail with a
+// *VersionConflictError otherwise.
+func WithIfVersion(version int) CallOption {
+	return func(opts *callOptions) {
+		opts.ifVersion = version
+		opts.checkVersion = true
+	}
+}
+
+// WithIdempotent makes Put store the value as the next version only if the
+// latest version does not already hold it with the same encryption
+// context; otherwise Put returns the latest version. See
+// Driver.PutSecretBytesIdempotent.
+func WithIdempotent() CallOption {
+	return func(opts *callOptions) {
+		opts.idempotent = true
+	}
+}
+
+// WithEncryptionContext sets the KMS encryption context.
+func WithEncryptionContext(context map[string]string) CallOption {
+	return func(opts *callOptions) {
+		opts.context = context
+	}
+}
+
+// WithPolicyContext sets the encryption context and makes Put refuse to
+// store without one.
+func WithPolicyContext(context PolicyContext) CallOption {
+	return func(opts *callOptions) {
+		opts.context = context
+		opts.requireContext = true
+	}
+}
+
+// WithKmsKey overrides the client's KMS key for Put.
+func WithKmsKey(kmsKey string) CallOption {
+	return func(opts *callOptions) {
+		opts.kmsKey = kmsKey
+	}
+}
+
+// WithPutOptions sets the encryption scheme and digest for Put.
+func WithPutOptions(put PutOptions) CallOption {
+	return func(opts *callOptions) {
+		opts.put = put
+	}
+}
+
+// WithPassphrase makes Put encrypt the value with a key derived from
+// passphrase as well; see PutOptions.Passphrase. Get needs
+// Config.Passphrase to read it.
+func WithPassphrase(passphrase []byte) CallOption {
+	return func(opts *callOptions) {
+		opts.put.Passphrase = passphrase
+	}
+}
+
+// NewDriver returns a Driver whose AWS clients share the given session.
+func NewDriver(awsSession *session.Session, logger Logger) *Driver {
+	driver := &Driver{
+		Ddb:            dynamodb.New(awsSession),
+		Streams:        dynamodbstreams.New(awsSession),
+		Kms:            kms.New(awsSession),
+		SecretsManager: secretsmanager.New(awsSession),
+		Ssm:            ssm.New(awsSession),
+		Sts:            sts.New(awsSession),
+		S3:             s3.New(awsSession),
+		Logger:         logger,
+		Now:            time.Now,
+	}
+
+	driver.DriverForRegion = func(region string) *Driver {
+		regional := NewDriver(awsSession.Copy(&aws.Config{Region: aws.String(region)}), logger)
+		regional.ReadOnly = driver.ReadOnly
+		regional.Author = driver.Author
+		regional.Context = driver.Context
+		regional.AccessLog = driver.AccessLog
+		regional.Events = driver.Events
+		regional.Naming = driver.Naming
+		regional.Namespace = driver.Namespace
+		regional.NamespaceKeys = driver.NamespaceKeys
+		regional.SoftDelete = driver.SoftDelete
+		regional.ReadKmsKey = driver.ReadKmsKey
+		regional.RecoveryKmsKey = driver.RecoveryKmsKey
+		regional.Passphrase = driver.Passphrase
+		regional.DataKeyCache = driver.DataKeyCache
+		regional.Offline = driver.Offline
+		regional.KeychainCache = driver.KeychainCache
+		regional.ReadConsistency = driver.ReadConsistency
+		regional.ChunkedStorage = driver.ChunkedStorage
+		return regional
+	}
+
+	return driver
+}
+
+// New returns a Client for the configured credential store.
+func New(cfg *Config) (*Client, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	for _, key := range []string{cfg.KmsKey, cfg.ReadKmsKey, cfg.RecoveryKmsKey} {
+		if err := ValidateKmsKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ValidateReadConsistency(cfg.ReadConsistency); err != nil {
+		return nil, err
+	}
+
+	awsSession := cfg.Session
+
+	if awsSession == nil {
+		awsConfig := &aws.Config{}
+
+		if cfg.Region != "" {
+			awsConfig.Region = aws.String(cfg.Region)
+		}
+
+		sess, err := newSessionWithCredentials(session.Options{
+			Config:            *awsConfig,
+			SharedConfigState: session.SharedConfigEnable,
+		}, cfg.Credentials)
+
+		if err != nil {
+			return nil, err
+		}
+
+		awsSession = sess
+	}
+
+	// The session may be the caller's, so handlers go on a copy.
+	awsSession = awsSession.Copy()
+	AddAccessDeniedHandlers(&awsSession.Handlers)
+
+	if cfg.RateLimit > 0 {
+		AddRateLimitHandlers(&awsSession.Handlers, cfg.RateLimit)
+	}
+
+	timeout := cfg.Timeout
+
+	if timeout == 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+
+	if timeout > 0 {
+		AddTimeoutHandlers(&awsSession.Handlers, timeout)
+	}
+
+	logger := cfg.Logger
+
+	if logger == nil {
+		logger = &NopLogger{}
+	}
+
+	client := &Client{
+		Driver: NewDriver(awsSession, logger),
+		Table:  cfg.Table,
+		KmsKey: cfg.KmsKey,
+	}
+
+	client.Driver.ReadOnly = cfg.ReadOnly
+	client.Driver.AccessLog = cfg.AccessLog
+	client.Driver.Events = cfg.Events
+	client.Driver.Naming = cfg.Naming
+	client.Driver.Namespace = cfg.Namespace
+	client.Driver.NamespaceKeys = cfg.NamespaceKeys
+	client.Driver.SoftDelete = cfg.SoftDelete
+	client.Driver.ReadKmsKey = cfg.ReadKmsKey
+	client.Driver.RecoveryKmsKey = cfg.RecoveryKmsKey
+	client.Driver.Passphrase = cfg.Passphrase
+	client.Driver.DataKeyCache = cfg.DataKeyCache
+	client.Driver.Offline = cfg.Offline
+	client.Driver.KeychainCache = cfg.KeychainCache
+	client.Driver.ReadConsistency = cfg.ReadConsistency
+	client.Driver.Fallbacks = cfg.Fallbacks
+	client.Driver.ChunkedStorage = cfg.ChunkedStorage
+	client.Driver.S3Bucket = cfg.S3Bucket
+	client.Driver.S3Threshold = cfg.S3Threshold
+
+	if cfg.DaxEndpoint != "" {
+		dax, err := NewDaxClient(awsSession, cfg.DaxEndpoint)
+
+		if err != nil {
+			return nil, err
+		}
+
+		client.Driver.Dax = dax
+	}
+
+	if client.Table == "" {
+		client.Table = DEFAULT_TABLE
+	}
+
+	if client.KmsKey == "" {
+		client.KmsKey = DEFAULT_KMS_KEY
+	}
+
+	return client, nil
+}
+
+func (client *Client) callOptions(opts []CallOption) *callOptions {
+	options := &callOptions{kmsKey: client.KmsKey}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// Get returns the latest version of a credential, or the version given
+// with WithVersion. The AWS requests of every call are made with its
+// context; see Driver.WithContext.
+func (client *Client) Get(ctx context.Context, name string, opts ...CallOption) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecret(name, options.version, client.Table, options.context)
+}
+
+// GetOrDefault is like Get, but returns defaultValue when the credential is
+// not stored.
+func (client *Client) GetOrDefault(ctx context.Context, name string, defaultValue string, opts ...CallOption) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecretOrDefault(name, options.version, client.Table, options.context, defaultValue)
+}
+
+// GetBytes is like Get but returns the plaintext as a byte slice, which the
+// caller should clear with Wipe once it is no longer needed.
+func (client *Client) GetBytes(ctx context.Context, name string, opts ...CallOption) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecretBytes(name, options.version, client.Table, options.context)
+}
+
+// GetBytesWithVersion is like GetBytes but also returns the version that
+// was read.
+func (client *Client) GetBytesWithVersion(ctx context.Context, name string, opts ...CallOption) ([]byte, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecretBytesWithVersion(name, options.version, client.Table, options.context)
+}
+
+// GetSecure is like GetBytes but wraps the plaintext in SecureBytes.
+func (client *Client) GetSecure(ctx context.Context, name string, opts ...CallOption) (*SecureBytes, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecureSecret(name, options.version, client.Table, options.context)
+}
+
+// GetTo writes the plaintext to w without converting it to a string.
+func (client *Client) GetTo(ctx context.Context, w io.Writer, name string, opts ...CallOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecretTo(w, name, options.version, client.Table, options.context)
+}
+
+// GetAll returns the latest version of every credential matching a glob pattern.
+func (client *Client) GetAll(ctx context.Context, pattern string, opts ...CallOption) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetSecretsByPattern(pattern, client.Table, options.context)
+}
+
+// Exists reports whether a credential, or the version given with
+// WithVersion, is stored, without reading its contents or calling KMS.
+func (client *Client) Exists(ctx context.Context, name string, opts ...CallOption) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).Exists(name, options.version, client.Table)
+}
+
+// GetMetadata returns the metadata of the latest version of a credential,
+// or of the version given with WithVersion, without reading its contents.
+func (client *Client) GetMetadata(ctx context.Context, name string, opts ...CallOption) (*SecretInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).GetMetadata(name, options.version, client.Table)
+}
+
+// Put stores a credential and returns the version it was stored as. Without
+// WithVersion the next version after the highest stored one is used.
+func (client *Client) Put(ctx context.Context, name string, value string, opts ...CallOption) (int, error) {
+	return client.PutBytes(ctx, name, []byte(value), opts...)
+}
+
+// PutBytes is like Put but takes the plaintext as a byte slice.
+func (client *Client) PutBytes(ctx context.Context, name string, value []byte, opts ...CallOption) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	options := client.callOptions(opts)
+	version := options.version
+
+	if options.requireContext {
+		if err := PolicyContext(options.context).Validate(); err != nil {
+			return 0, err
+		}
+
+		options.put.RequireContext = true
+	}
+
+	driver := client.Driver.WithContext(ctx)
+	var err error
+
+	if options.idempotent {
+		version, _, err = driver.PutSecretBytesIdempotent(name, value, options.kmsKey, client.Table, options.context, &options.put)
+	} else if options.checkVersion {
+		version, err = driver.PutSecretBytesIfVersion(name, value, options.ifVersion, options.kmsKey, client.Table, options.context, &options.put)
+	} else if version == "" {
+		version, err = driver.PutSecretBytesNextVersion(name, value, options.kmsKey, client.Table, options.context, &options.put)
+	} else {
+		err = driver.PutSecretBytesWithOptions(name, value, version, options.kmsKey, client.Table, options.context, &options.put)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return Atoi(version), nil
+}
+
+// Delete removes every version of a credential, or only the version given
+// with WithVersion.
+func (client *Client) Delete(ctx context.Context, name string, opts ...CallOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	options := client.callOptions(opts)
+
+	return client.Driver.WithContext(ctx).DeleteSecrets(name, options.version, client.Table)
+}
+
+// List returns the stored versions of every credential, sorted ascending.
+func (client *Client) List(ctx context.Context) (map[string][]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	items, err := client.Driver.WithContext(ctx).ListSecrets(client.Table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return GroupVersions(items), nil
+}
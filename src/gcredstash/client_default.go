@@ -0,0 +1,84 @@
+package gcredstash
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+var defaultClient struct {
+	sync.Mutex
+	client *Client
+}
+
+// Configure sets up the shared Client used by Default, Get and Put. In AWS
+// Lambda, call it from init with the function's session, so that the
+// session and AWS clients are created once per container and reused by
+// every invocation.
+func Configure(cfg *Config) error {
+	client, err := New(cfg)
+
+	if err != nil {
+		return err
+	}
+
+	SetDefault(client)
+
+	return nil
+}
+
+// SetDefault replaces the shared Client.
+func SetDefault(client *Client) {
+	defaultClient.Lock()
+	defer defaultClient.Unlock()
+
+	defaultClient.client = client
+}
+
+// Default returns the shared Client. Without Configure, it is created on
+// first use from the AWS environment, GCREDSTASH_TABLE and
+// GCREDSTASH_KMS_KEY. It is safe for concurrent use; a failed creation is
+// retried on the next call.
+func Default() (*Client, error) {
+	defaultClient.Lock()
+	defer defaultClient.Unlock()
+
+	if defaultClient.client != nil {
+		return defaultClient.client, nil
+	}
+
+	client, err := New(&Config{
+		Table:  os.Getenv("GCREDSTASH_TABLE"),
+		KmsKey: os.Getenv("GCREDSTASH_KMS_KEY"),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defaultClient.client = client
+
+	return client, nil
+}
+
+// Get reads a credential with the shared Client.
+func Get(ctx context.Context, name string, opts ...CallOption) (string, error) {
+	client, err := Default()
+
+	if err != nil {
+		return "", err
+	}
+
+	return client.Get(ctx, name, opts...)
+}
+
+// Put stores a credential with the shared Client.
+func Put(ctx context.Context, name string, value string, opts ...CallOption) (int, error) {
+	client, err := Default()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return client.Put(ctx, name, value, opts...)
+}
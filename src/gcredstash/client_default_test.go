@@ -0,0 +1,54 @@
+package gcredstash
+
+import (
+	"context"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestDefaultClient(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		client := &Client{
+			Driver: driver,
+			Table:  DEFAULT_TABLE,
+			KmsKey: DEFAULT_KMS_KEY,
+		}
+
+		SetDefault(client)
+		defer SetDefault(nil)
+
+		ctx := context.Background()
+		version, err := Put(ctx, "test.key", "100")
+
+		if version != 1 || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", 1, version, err)
+		}
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				shared, err := Default()
+
+				if shared != client || err != nil {
+					t.Errorf("\nexpected: %v\ngot: %v %v\n", client, shared, err)
+				}
+
+				value, err := Get(ctx, "test.key")
+
+				if value != "100" || err != nil {
+					t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+}
@@ -0,0 +1,185 @@
+package gcredstash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("us-east-1")}))
+	client, err := New(&Config{Session: sess})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if client.Table != DEFAULT_TABLE || client.KmsKey != DEFAULT_KMS_KEY {
+		t.Errorf("\nexpected: %v\ngot: %v\n", []string{DEFAULT_TABLE, DEFAULT_KMS_KEY}, []string{client.Table, client.KmsKey})
+	}
+}
+
+func TestClient(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		client := &Client{
+			Driver: driver,
+			Table:  DEFAULT_TABLE,
+			KmsKey: DEFAULT_KMS_KEY,
+		}
+
+		ctx := context.Background()
+		encCtx := WithEncryptionContext(map[string]string{"app": "test"})
+
+		for _, value := range []string{"v1", "v2"} {
+			_, err := client.Put(ctx, "test.key", value, encCtx)
+
+			if err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		value, err := client.Get(ctx, "test.key", encCtx)
+
+		if value != "v2" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "v2", value, err)
+		}
+
+		value, err = client.Get(ctx, "test.key", encCtx, WithVersion(1))
+
+		if value != "v1" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "v1", value, err)
+		}
+
+		_, err = client.PutBytes(ctx, "test.cert", []byte("-----BEGIN CERTIFICATE-----"), encCtx)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		buf := &bytes.Buffer{}
+		err = client.GetTo(ctx, buf, "test.cert", encCtx)
+
+		if buf.String() != "-----BEGIN CERTIFICATE-----" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "-----BEGIN CERTIFICATE-----", buf.String(), err)
+		}
+
+		err = client.Delete(ctx, "test.cert")
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		err = client.Delete(ctx, "test.key", WithVersion(1))
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		versions, err := client.List(ctx)
+		expected := map[string][]string{"test.key": {"0000000000000000002"}}
+
+		if !reflect.DeepEqual(versions, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, versions)
+		}
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err = client.Get(canceled, "test.key", encCtx)
+
+		if err != context.Canceled {
+			t.Errorf("\nexpected: %v\ngot: %v\n", context.Canceled, err)
+		}
+	})
+}
+
+func TestClientGetOrDefault(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		client := &Client{
+			Driver: driver,
+			Table:  DEFAULT_TABLE,
+			KmsKey: DEFAULT_KMS_KEY,
+		}
+
+		ctx := context.Background()
+		client.Put(ctx, "feature.flags", "on")
+
+		if value, err := client.GetOrDefault(ctx, "feature.flags", "off"); value != "on" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "on", value, err)
+		}
+
+		if value, err := client.GetOrDefault(ctx, "feature.limits", ""); value != "" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "", value, err)
+		}
+
+		if _, err := client.GetOrDefault(ctx, "feature.flags", "off", WithVersion(2)); !errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionNotFound, err)
+		}
+	})
+}
+
+func TestClientWithPolicyContext(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		client := &Client{
+			Driver: driver,
+			Table:  DEFAULT_TABLE,
+			KmsKey: DEFAULT_KMS_KEY,
+		}
+
+		ctx := context.Background()
+		_, err := client.Put(ctx, "test.key", "value", WithPolicyContext(RoleContext("webserver")))
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		value, err := client.Get(ctx, "test.key", WithEncryptionContext(map[string]string{"role": "webserver"}))
+
+		if value != "value" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "value", value, err)
+		}
+
+		_, err = client.Put(ctx, "test.key", "value", WithPolicyContext(PolicyContext{}))
+
+		if err != ErrContextRequired {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrContextRequired, err)
+		}
+
+		_, err = client.Put(ctx, "test.key", "value", WithPolicyContext(RoleContext("")))
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
+
+func TestClientPutWithIfVersion(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		client := &Client{
+			Driver: driver,
+			Table:  DEFAULT_TABLE,
+			KmsKey: DEFAULT_KMS_KEY,
+		}
+
+		ctx := context.Background()
+		version, err := client.Put(ctx, "test.key", `{"a":1}`, WithIfVersion(0))
+
+		if version != 1 || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", 1, version, err)
+		}
+
+		_, err = client.Put(ctx, "test.key", `{"a":2}`, WithIfVersion(0))
+		var conflict *VersionConflictError
+
+		if !errors.As(err, &conflict) || conflict.Latest != 1 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionConflict, err)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package gcredstash
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DEFAULT_CLIP_TIMEOUT is how long get --clip leaves a credential on the
+// clipboard.
+const DEFAULT_CLIP_TIMEOUT = 45 * time.Second
+
+// Clipboard is the system clipboard. Copy with an empty value clears it.
+type Clipboard interface {
+	Copy(value []byte) error
+	Paste() ([]byte, error)
+}
+
+// CommandClipboard is a Clipboard on the clipboard commands of the OS:
+// pbcopy(1) and pbpaste(1) on macOS, clip and PowerShell's Get-Clipboard on
+// Windows, and wl-copy(1) and wl-paste(1) under Wayland or xclip(1)
+// elsewhere.
+type CommandClipboard struct {
+	// GOOS selects the commands; runtime.GOOS when empty.
+	GOOS string
+	// Getenv reads WAYLAND_DISPLAY; os.Getenv when nil.
+	Getenv func(key string) string
+	// Command builds the commands run; exec.Command when nil.
+	Command func(name string, args ...string) *exec.Cmd
+}
+
+func NewOSClipboard() Clipboard {
+	return &CommandClipboard{}
+}
+
+func (clipboard *CommandClipboard) goos() string {
+	if clipboard.GOOS == "" {
+		return runtime.GOOS
+	}
+
+	return clipboard.GOOS
+}
+
+func (clipboard *CommandClipboard) wayland() bool {
+	getenv := os.Getenv
+
+	if clipboard.Getenv != nil {
+		getenv = clipboard.Getenv
+	}
+
+	return getenv("WAYLAND_DISPLAY") != ""
+}
+
+func (clipboard *CommandClipboard) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	command := exec.Command
+
+	if clipboard.Command != nil {
+		command = clipboard.Command
+	}
+
+	cmd := command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("%s: %s", name, strings.TrimSpace(stderr.String()))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s (is a clipboard available?)", name, err.Error())
+	}
+
+	return out, nil
+}
+
+func (clipboard *CommandClipboard) Copy(value []byte) error {
+	var err error
+
+	switch {
+	case clipboard.goos() == "darwin":
+		_, err = clipboard.run(value, "pbcopy")
+	case clipboard.goos() == "windows":
+		_, err = clipboard.run(value, "clip")
+	case clipboard.wayland() && len(value) == 0:
+		_, err = clipboard.run(nil, "wl-copy", "--clear")
+	case clipboard.wayland():
+		_, err = clipboard.run(value, "wl-copy")
+	default:
+		_, err = clipboard.run(value, "xclip", "-selection", "clipboard", "-in")
+	}
+
+	return err
+}
+
+func (clipboard *CommandClipboard) Paste() ([]byte, error) {
+	switch {
+	case clipboard.goos() == "darwin":
+		return clipboard.run(nil, "pbpaste")
+	case clipboard.goos() == "windows":
+		return clipboard.run(nil, "powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw")
+	case clipboard.wayland():
+		return clipboard.run(nil, "wl-paste", "--no-newline")
+	default:
+		return clipboard.run(nil, "xclip", "-selection", "clipboard", "-out")
+	}
+}
+
+// ClearClipboard clears clipboard if it still holds value, and leaves
+// whatever the user copied since alone.
+func ClearClipboard(clipboard Clipboard, value []byte) error {
+	current, err := clipboard.Paste()
+	defer Wipe(current)
+
+	// Clear anyway when the clipboard cannot be read.
+	if err == nil && !bytes.Equal(bytes.TrimRight(current, "\r\n"), bytes.TrimRight(value, "\r\n")) {
+		return nil
+	}
+
+	return clipboard.Copy(nil)
+}
@@ -0,0 +1,77 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// scriptClipboard is a CommandClipboard that runs script instead of the
+// clipboard commands, and records the commands.
+func scriptClipboard(goos string, wayland string, script string, commands *[]string) *CommandClipboard {
+	return &CommandClipboard{
+		GOOS:   goos,
+		Getenv: func(key string) string { return wayland },
+		Command: func(name string, args ...string) *exec.Cmd {
+			*commands = append(*commands, strings.TrimSpace(name+" "+strings.Join(args, " ")))
+			return exec.Command("sh", "-c", script)
+		},
+	}
+}
+
+func TestCommandClipboard(t *testing.T) {
+	for _, c := range []struct {
+		goos     string
+		wayland  string
+		expected []string
+	}{
+		{"darwin", "", []string{"pbcopy", "pbpaste"}},
+		{"windows", "", []string{"clip", "powershell -NoProfile -Command Get-Clipboard -Raw"}},
+		{"linux", "wayland-0", []string{"wl-copy", "wl-paste --no-newline"}},
+		{"linux", "", []string{"xclip -selection clipboard -in", "xclip -selection clipboard -out"}},
+	} {
+		commands := []string{}
+		clipboard := scriptClipboard(c.goos, c.wayland, `test "$(cat)" = s3cr3t && echo s3cr3t`, &commands)
+
+		if err := clipboard.Copy([]byte("s3cr3t")); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		clipboard.Command = scriptClipboard(c.goos, c.wayland, "echo s3cr3t", &commands).Command
+
+		if value, err := clipboard.Paste(); string(value) != "s3cr3t\n" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "s3cr3t", string(value), err)
+		}
+
+		if strings.Join(commands, "\n") != strings.Join(c.expected, "\n") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", c.expected, commands)
+		}
+	}
+}
+
+func TestClearClipboard(t *testing.T) {
+	commands := []string{}
+	ClearClipboard(scriptClipboard("darwin", "", "echo s3cr3t", &commands), []byte("s3cr3t"))
+	expected := []string{"pbpaste", "pbcopy"}
+
+	if strings.Join(commands, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, commands)
+	}
+
+	commands = []string{}
+	ClearClipboard(scriptClipboard("darwin", "", "echo something else", &commands), []byte("s3cr3t"))
+	expected = []string{"pbpaste"}
+
+	if strings.Join(commands, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, commands)
+	}
+
+	commands = []string{}
+	ClearClipboard(scriptClipboard("linux", "wayland-0", "exit 1", &commands), []byte("s3cr3t"))
+	expected = []string{"wl-paste --no-newline", "wl-copy --clear"}
+
+	if strings.Join(commands, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, commands)
+	}
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type AliasCommand struct {
+	Meta
+}
+
+func (c *AliasCommand) parseArgs(args []string) (string, string, string, error) {
+	if len(args) < 2 {
+		return "", "", "", fmt.Errorf("too few arguments")
+	}
+
+	if len(args) > 2 {
+		return "", "", "", fmt.Errorf("too many arguments")
+	}
+
+	target, version, err := gcredstash.ParseAliasTarget(args[1])
+
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return c.Driver.Naming.Normalize(args[0]), target, version, nil
+}
+
+func (c *AliasCommand) RunImpl(args []string) (string, error) {
+	alias, target, targetVersion, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	version, err := c.Driver.PutAlias(alias, target, targetVersion, c.Table)
+
+	if err != nil {
+		return "", err
+	}
+
+	if targetVersion != "" {
+		target = fmt.Sprintf("%s@v%d", target, gcredstash.Atoi(targetVersion))
+	}
+
+	return fmt.Sprintf("%s -- version: %d is an alias of %s\n", alias, gcredstash.Atoi(version), target), nil
+}
+
+func (c *AliasCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *AliasCommand) Synopsis() string {
+	return "Point a credential at another credential"
+}
+
+func (c *AliasCommand) Help() string {
+	helpText := `
+usage: gcredstash alias alias target[@vVERSION]
+`
+	return strings.TrimSpace(helpText)
+}
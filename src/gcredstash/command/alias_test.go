@@ -0,0 +1,49 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestAliasCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("db.pass", "100", gcredstash.VersionNumToStr(1), "alias/credstash", "credential-store", nil)
+		driver.PutSecret("db.pass", "200", gcredstash.VersionNumToStr(2), "alias/credstash", "credential-store", nil)
+
+		cmd := &AliasCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"db.pass.current", "db.pass@v1"}, "db.pass.current -- version: 1 is an alias of db.pass@v1\n"},
+			{[]string{"db.pass.current", "db.pass"}, "db.pass.current -- version: 2 is an alias of db.pass\n"},
+		} {
+			out, err := cmd.RunImpl(tc.args)
+
+			if out != tc.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", tc.expected, out, err)
+			}
+		}
+
+		value, _ := driver.GetSecret("db.pass.current", "", "credential-store", nil)
+
+		if value != "200" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "200", value)
+		}
+
+		_, err := cmd.RunImpl([]string{"db.pass.current"})
+
+		if err == nil || err.Error() != "too few arguments" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "too few arguments", err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type AuditCommand struct {
+	Meta
+}
+
+func (c *AuditCommand) parseArgs(args []string) (string, map[string]string, error) {
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return prefix, context, nil
+}
+
+func (c *AuditCommand) RunImpl(args []string) (string, error) {
+	prefix, context, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	results, err := c.Driver.AuditSecrets(c.Table, prefix, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{}
+	failed := 0
+	tampered := 0
+
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+
+		failed++
+
+		if errors.Is(result.Err, gcredstash.ErrIntegrity) {
+			tampered++
+		}
+
+		lines = append(lines, fmt.Sprintf("%s -- version: %d: %s", result.Name, gcredstash.Atoi(result.Version), result.Err.Error()))
+	}
+
+	lines = append(lines, fmt.Sprintf("%d version(s) checked, %d failed", len(results), failed))
+	out := strings.Join(lines, "\n") + "\n"
+
+	if tampered > 0 {
+		message := fmt.Sprintf("%d credential version(s) failed HMAC verification", tampered)
+		return out, &gcredstash.IntegrityError{Message: message}
+	}
+
+	if failed > 0 {
+		return out, fmt.Errorf("%d credential version(s) could not be verified", failed)
+	}
+
+	return out, nil
+}
+
+func (c *AuditCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *AuditCommand) Synopsis() string {
+	return "Verify the HMAC of every stored credential version"
+}
+
+func (c *AuditCommand) Help() string {
+	helpText := `
+usage: gcredstash audit [--prefix PREFIX] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
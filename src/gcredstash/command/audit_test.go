@@ -0,0 +1,62 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestAuditCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+
+		driver.PutSecret("db.user", "app", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("db.password", "s3cr3t", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &AuditCommand{
+			Meta: Meta{Table: table, Driver: driver},
+		}
+
+		out, err := cmd.RunImpl([]string{})
+		expected := "2 version(s) checked, 0 failed\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		tables := map[string][]map[string]string{}
+		content, _ := ioutil.ReadFile(f.Name())
+		json.Unmarshal(content, &tables)
+
+		for _, item := range tables[table] {
+			if item["name"] == "db.password" {
+				flipped := "0"
+
+				if item["hmac"][0] == '0' {
+					flipped = "1"
+				}
+
+				item["hmac"] = flipped + item["hmac"][1:]
+			}
+		}
+
+		content, _ = json.Marshal(tables)
+		ioutil.WriteFile(f.Name(), content, 0600)
+
+		out, err = cmd.RunImpl([]string{})
+		expected = "db.password -- version: 1: Computed HMAC on db.password does not match stored HMAC\n2 version(s) checked, 1 failed\n"
+
+		if out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+		}
+
+		if !errors.Is(err, gcredstash.ErrIntegrity) || ExitCode(err) != EXIT_INTEGRITY {
+			t.Errorf("\nexpected: %v\ngot: %v\n", gcredstash.ErrIntegrity, err)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DEFAULT_CERT_WARN_DAYS = 30
+
+type CertPutCommand struct {
+	Meta
+}
+
+type CertInfoCommand struct {
+	Meta
+}
+
+type CertCheckCommand struct {
+	Meta
+}
+
+func (c *CertPutCommand) RunImpl(args []string) (string, error) {
+	newArgs, version, err := ParseVersion(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(newArgs) < 3 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	name := newArgs[0]
+	files := newArgs[1:3]
+	rest := newArgs[3:]
+
+	if len(rest) > 0 && !strings.Contains(rest[0], "=") {
+		files = append(files, rest[0])
+		rest = rest[1:]
+	}
+
+	context, err := gcredstash.ParseContext(rest)
+
+	if err != nil {
+		return "", err
+	}
+
+	contents := []string{}
+
+	for _, file := range files {
+		content, err := gcredstash.ReadFile(file)
+
+		if err != nil {
+			return "", err
+		}
+
+		contents = append(contents, content)
+	}
+
+	bundle := &gcredstash.CertBundle{Cert: contents[0], Key: contents[1]}
+
+	if len(contents) > 2 {
+		bundle.Chain = contents[2]
+	}
+
+	version, err = c.Driver.PutCertBundle(name, bundle, version, c.KmsKey, c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s has been stored as version %d\n", name, gcredstash.Atoi(version)), nil
+}
+
+func (c *CertPutCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *CertPutCommand) Synopsis() string {
+	return "Store a certificate, its key and chain in one transaction"
+}
+
+func (c *CertPutCommand) Help() string {
+	helpText := `
+usage: gcredstash cert put [-v VERSION] name cert_file key_file [chain_file] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func FormatCertInfo(info *gcredstash.CertInfo, now time.Time) string {
+	lines := []string{
+		fmt.Sprintf("name:      %s", info.Name),
+		fmt.Sprintf("version:   %d", gcredstash.Atoi(info.Version)),
+		fmt.Sprintf("subject:   %s", info.Subject),
+		fmt.Sprintf("issuer:    %s", info.Issuer),
+		fmt.Sprintf("dns names: %s", strings.Join(info.DNSNames, ", ")),
+		fmt.Sprintf("not after: %s (%d days left)", info.NotAfter.UTC().Format(time.RFC3339), info.DaysLeft(now)),
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (c *CertInfoCommand) RunImpl(args []string) (string, error) {
+	newArgs, version, err := ParseVersion(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(newArgs) < 1 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	context, err := gcredstash.ParseContext(newArgs[1:])
+
+	if err != nil {
+		return "", err
+	}
+
+	info, err := c.Driver.GetCertInfo(newArgs[0], version, c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return FormatCertInfo(info, time.Now()), nil
+}
+
+func (c *CertInfoCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *CertInfoCommand) Synopsis() string {
+	return "Show the subject and expiry of a stored certificate"
+}
+
+func (c *CertInfoCommand) Help() string {
+	helpText := `
+usage: gcredstash cert info [-v VERSION] name [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *CertCheckCommand) RunImpl(args []string) (string, error) {
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return "", err
+	}
+
+	newArgs, daysStr, err := ParseOptionWithValue(newArgs, "--days")
+
+	if err != nil {
+		return "", err
+	}
+
+	days := DEFAULT_CERT_WARN_DAYS
+
+	if daysStr != "" {
+		days, err = strconv.Atoi(daysStr)
+
+		if err != nil || days < 0 {
+			return "", fmt.Errorf("invalid days: %s", daysStr)
+		}
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	if err != nil {
+		return "", err
+	}
+
+	infos, err := c.Driver.ListCertInfo(prefix, c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	out := ""
+	expiring := 0
+
+	for _, info := range infos {
+		daysLeft := info.DaysLeft(now)
+
+		if daysLeft >= days {
+			continue
+		}
+
+		expiring++
+
+		if daysLeft < 0 {
+			out += fmt.Sprintf("%s -- version: %d expired on %s\n", info.Name, gcredstash.Atoi(info.Version), info.NotAfter.UTC().Format(time.RFC3339))
+		} else {
+			out += fmt.Sprintf("%s -- version: %d expires on %s (%d days left)\n", info.Name, gcredstash.Atoi(info.Version), info.NotAfter.UTC().Format(time.RFC3339), daysLeft)
+		}
+	}
+
+	out += fmt.Sprintf("%d certificate(s) checked, %d expiring within %d days\n", len(infos), expiring, days)
+
+	if expiring > 0 {
+		return out, fmt.Errorf("%d certificate(s) expire within %d days", expiring, days)
+	}
+
+	return out, nil
+}
+
+func (c *CertCheckCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *CertCheckCommand) Synopsis() string {
+	return "Fail if a stored certificate expires within N days"
+}
+
+func (c *CertCheckCommand) Help() string {
+	helpText := `
+usage: gcredstash cert check [--days N] [--prefix PREFIX] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertCommands(t *testing.T) {
+	notAfter := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Second)
+	cert, key := testutils.SelfSignedCert("www.example.com", notAfter)
+
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		testutils.TempFile(cert, func(certFile *os.File) {
+			testutils.TempFile(key, func(keyFile *os.File) {
+				out, err := (&CertPutCommand{Meta: meta}).RunImpl([]string{"www", certFile.Name(), keyFile.Name(), "env=prod"})
+				expected := "www has been stored as version 1\n"
+
+				if err != nil || out != expected {
+					t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+				}
+			})
+		})
+
+		out, err := (&CertInfoCommand{Meta: meta}).RunImpl([]string{"www", "env=prod"})
+
+		if err != nil || !strings.Contains(out, "subject:   CN=www.example.com\n") || !strings.Contains(out, "(9 days left)") {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "CN=www.example.com, 9 days left", out, err)
+		}
+
+		out, err = (&CertCheckCommand{Meta: meta}).RunImpl([]string{"--days", "5", "env=prod"})
+		expected := "1 certificate(s) checked, 0 expiring within 5 days\n"
+
+		if err != nil || out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, err = (&CertCheckCommand{Meta: meta}).RunImpl([]string{"env=prod"})
+		expectedErr := "1 certificate(s) expire within 30 days"
+
+		if err == nil || err.Error() != expectedErr || !strings.HasPrefix(out, "www -- version: 1 expires on ") {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expectedErr, out, err)
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"gcredstash"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type ChamberEnvCommand struct {
+	Meta
+}
+
+type ChamberExecCommand struct {
+	Meta
+}
+
+type ChamberReadCommand struct {
+	Meta
+}
+
+type ChamberWriteCommand struct {
+	Meta
+}
+
+// parseChamberServices splits args into services and the context, which
+// starts at the first key=value.
+func parseChamberServices(args []string) ([]string, map[string]string, error) {
+	services := []string{}
+
+	for len(args) > 0 && !strings.Contains(args[0], "=") {
+		services = append(services, args[0])
+		args = args[1:]
+	}
+
+	if len(services) == 0 {
+		return nil, nil, fmt.Errorf("too few arguments")
+	}
+
+	context, err := gcredstash.ParseContext(args)
+
+	return services, context, err
+}
+
+func chamberEnv(meta *Meta, services []string, context map[string]string) (map[string]string, error) {
+	creds := map[string]string{}
+
+	for _, service := range services {
+		serviceCreds, err := meta.Driver.GetSecretsByPattern(gcredstash.ChamberService(service)+"*", meta.Table, context)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range serviceCreds {
+			creds[name] = value
+		}
+	}
+
+	return gcredstash.ChamberEnv(creds, services), nil
+}
+
+func (c *ChamberEnvCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	services, context, err := parseChamberServices(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.checkTerminalOutput(force); err != nil {
+		return "", err
+	}
+
+	env, err := chamberEnv(&c.Meta, services, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return gcredstash.FormatChamberEnv(env), nil
+}
+
+func (c *ChamberEnvCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *ChamberEnvCommand) Synopsis() string {
+	return "Print chamber-style export lines for the keys of services"
+}
+
+func (c *ChamberEnvCommand) Help() string {
+	helpText := `
+usage: gcredstash chamber env [--force-stdout] service [service ...] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ChamberExecCommand) RunImpl(args []string) (string, error) {
+	newArgs, pristine := HasOption(args, "--pristine")
+	sep := -1
+
+	for i, arg := range newArgs {
+		if arg == "--" {
+			sep = i
+			break
+		}
+	}
+
+	if sep < 0 || sep == len(newArgs)-1 {
+		return "", fmt.Errorf("usage: chamber exec service [service ...] -- command [args ...]")
+	}
+
+	services, context, err := parseChamberServices(newArgs[:sep])
+
+	if err != nil {
+		return "", err
+	}
+
+	env, err := chamberEnv(&c.Meta, services, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(newArgs[sep+1], newArgs[sep+2:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if !pristine {
+		cmd.Env = os.Environ()
+	}
+
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	return "", cmd.Run()
+}
+
+func (c *ChamberExecCommand) Run(args []string) int {
+	_, err := c.RunImpl(args)
+
+	if err != nil {
+		var exitErr *exec.ExitError
+
+		// The command has already reported its own failure.
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *ChamberExecCommand) Synopsis() string {
+	return "Run a command with the keys of services in its environment"
+}
+
+func (c *ChamberExecCommand) Help() string {
+	helpText := `
+usage: gcredstash chamber exec [--pristine] service [service ...] [context [context ...]] -- command [args ...]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ChamberReadCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	newArgs, version, err := ParseVersion(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.checkTerminalOutput(force); err != nil {
+		return "", err
+	}
+
+	if len(newArgs) < 2 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	name, err := gcredstash.ChamberName(newArgs[0] + "/" + newArgs[1])
+
+	if err != nil {
+		return "", err
+	}
+
+	context, err := gcredstash.ParseContext(newArgs[2:])
+
+	if err != nil {
+		return "", err
+	}
+
+	value, err := c.Driver.GetSecret(name, version, c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return value + "\n", nil
+}
+
+func (c *ChamberReadCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *ChamberReadCommand) Synopsis() string {
+	return "Get the credential of a chamber service key"
+}
+
+func (c *ChamberReadCommand) Help() string {
+	helpText := `
+usage: gcredstash chamber read [-v VERSION] [--force-stdout] service key [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ChamberWriteCommand) RunImpl(args []string) (string, error) {
+	if len(args) < 3 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	name, err := gcredstash.ChamberName(args[0] + "/" + args[1])
+
+	if err != nil {
+		return "", err
+	}
+
+	value := args[2]
+
+	if value == "-" {
+		value = ReadStdin()
+	}
+
+	context, err := gcredstash.ParseContext(args[3:])
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.Driver.PutSecretNextVersion(name, value, c.KmsKey, c.Table, context, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s has been stored\n", name), nil
+}
+
+func (c *ChamberWriteCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *ChamberWriteCommand) Synopsis() string {
+	return "Store a new version of a chamber service key"
+}
+
+func (c *ChamberWriteCommand) Help() string {
+	helpText := `
+usage: gcredstash chamber write service key value|- [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
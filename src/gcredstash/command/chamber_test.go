@@ -0,0 +1,63 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestChamberCommands(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		write := &ChamberWriteCommand{Meta: meta}
+
+		for _, args := range [][]string{
+			{"global", "log-level", "info"},
+			{"Web", "db-host", "old.example.com"},
+			{"web", "db-host", "db.example.com"},
+		} {
+			if _, err := write.RunImpl(args); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		read := &ChamberReadCommand{Meta: meta}
+		out, err := read.RunImpl([]string{"web", "db-host"})
+
+		if out != "db.example.com\n" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "db.example.com", out, err)
+		}
+
+		env := &ChamberEnvCommand{Meta: meta}
+		out, err = env.RunImpl([]string{"global", "web"})
+		expected := "export DB_HOST='db.example.com'\nexport LOG_LEVEL='info'\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		exec := &ChamberExecCommand{Meta: meta}
+		_, err = exec.RunImpl([]string{"global", "web", "--", "sh", "-c", `test "$DB_HOST" = db.example.com`})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if status := exec.Run([]string{"web", "--", "sh", "-c", "exit 3"}); status != 3 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 3, status)
+		}
+
+		_, err = exec.RunImpl([]string{"web", "sh"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+type CompletionCommand struct {
+	Meta
+	Commands []string
+}
+
+const bashCompletion = `
+_gcredstash() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "%s" -- "$cur"))
+    elif [ "$COMP_CWORD" -eq 2 ] && [ -n "$(_gcredstash_subcommands "${COMP_WORDS[1]}")" ]; then
+        COMPREPLY=($(compgen -W "$(_gcredstash_subcommands "${COMP_WORDS[1]}")" -- "$cur"))
+    elif [[ "$cur" != -* ]]; then
+        COMPREPLY=($(compgen -W "$(gcredstash completion names "$cur" 2>/dev/null)" -- "$cur"))
+    fi
+}
+
+_gcredstash_subcommands() {
+    case "$1" in
+%s    esac
+}
+
+complete -F _gcredstash gcredstash
+`
+
+const zshCompletion = `
+#compdef gcredstash
+
+_gcredstash() {
+    if (( CURRENT == 2 )); then
+        compadd -- %s
+        return
+    fi
+
+    if (( CURRENT == 3 )); then
+        case "${words[2]}" in
+%s        esac
+    fi
+
+    if [[ "${words[CURRENT]}" != -* ]]; then
+        compadd -- ${(f)"$(gcredstash completion names "${words[CURRENT]}" 2>/dev/null)"}
+    fi
+}
+
+compdef _gcredstash gcredstash
+`
+
+const fishCompletion = `
+complete -c gcredstash -f
+complete -c gcredstash -n '__fish_use_subcommand' -a '%s'
+%scomplete -c gcredstash -n 'not __fish_use_subcommand; and not __fish_seen_subcommand_from %s' -a '(gcredstash completion names (commandline -ct) 2>/dev/null)'
+`
+
+// commandTree splits the registered commands into top-level words and the
+// subcommands of each nested command (e.g. "migrate from-ssm").
+func (c *CompletionCommand) commandTree() ([]string, map[string][]string) {
+	seen := map[string]bool{}
+	top := []string{}
+	nested := map[string][]string{}
+
+	for _, name := range c.Commands {
+		words := strings.SplitN(name, " ", 2)
+
+		if !seen[words[0]] {
+			seen[words[0]] = true
+			top = append(top, words[0])
+		}
+
+		if len(words) > 1 {
+			nested[words[0]] = append(nested[words[0]], words[1])
+		}
+	}
+
+	sort.Strings(top)
+
+	for _, subs := range nested {
+		sort.Strings(subs)
+	}
+
+	return top, nested
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := []string{}
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (c *CompletionCommand) script(shell string) (string, error) {
+	top, nested := c.commandTree()
+	parents := sortedKeys(nested)
+	cases := ""
+
+	switch shell {
+	case "bash":
+		for _, parent := range parents {
+			cases += fmt.Sprintf("        %s) echo \"%s\" ;;\n", parent, strings.Join(nested[parent], " "))
+		}
+
+		return fmt.Sprintf(bashCompletion, strings.Join(top, " "), cases), nil
+	case "zsh":
+		for _, parent := range parents {
+			cases += fmt.Sprintf("            %s) compadd -- %s; return ;;\n", parent, strings.Join(nested[parent], " "))
+		}
+
+		return fmt.Sprintf(zshCompletion, strings.Join(top, " "), cases), nil
+	case "fish":
+		for _, parent := range parents {
+			cases += fmt.Sprintf("complete -c gcredstash -n '__fish_seen_subcommand_from %s' -a '%s'\n", parent, strings.Join(nested[parent], " "))
+		}
+
+		return fmt.Sprintf(fishCompletion, strings.Join(top, " "), cases, strings.Join(append(parents, "completion"), " ")), nil
+	}
+
+	return "", fmt.Errorf("unsupported shell: %s", shell)
+}
+
+func (c *CompletionCommand) names(prefix string) (string, error) {
+	items, err := c.Driver.ListSecretsWithPrefix(c.Table, prefix)
+
+	if err != nil {
+		return "", err
+	}
+
+	seen := map[string]bool{}
+	names := []string{}
+
+	for name := range items {
+		if !seen[*name] {
+			seen[*name] = true
+			names = append(names, *name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, "\n"), nil
+}
+
+func (c *CompletionCommand) RunImpl(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	if args[0] == "names" {
+		if len(args) > 2 {
+			return "", fmt.Errorf("too many arguments")
+		}
+
+		prefix := ""
+
+		if len(args) == 2 {
+			prefix = args[1]
+		}
+
+		return c.names(prefix)
+	}
+
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments")
+	}
+
+	script, err := c.script(args[0])
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimLeft(script, "\n"), nil
+}
+
+func (c *CompletionCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	if out != "" {
+		fmt.Println(strings.TrimRight(out, "\n"))
+	}
+
+	return 0
+}
+
+func (c *CompletionCommand) Synopsis() string {
+	return "Print a shell completion script"
+}
+
+func (c *CompletionCommand) Help() string {
+	helpText := `
+usage: gcredstash completion bash|zsh|fish
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	cmd := &CompletionCommand{
+		Commands: []string{"get", "list", "migrate to-ssm", "migrate from-ssm"},
+	}
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		out, err := cmd.RunImpl([]string{shell})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		for _, expected := range []string{"get list migrate", "from-ssm to-ssm", "gcredstash completion names"} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+			}
+		}
+	}
+
+	_, err := cmd.RunImpl([]string{"tcsh"})
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestCompletionCommandNames(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+
+		driver.PutSecret("db.user", "app", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("db.password", "s3cr3t", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("db.password", "s3cr3t2", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("api.token", "xyz", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &CompletionCommand{
+			Meta: Meta{Table: table, Driver: driver},
+		}
+
+		out, err := cmd.RunImpl([]string{"names", "db."})
+		expected := "db.password\ndb.user"
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type CopyCommand struct {
+	Meta
+}
+
+type copyArgs struct {
+	from         string
+	to           string
+	toTable      string
+	keepVersions bool
+	pattern      string
+	context      map[string]string
+}
+
+func (c *CopyCommand) parseArgs(args []string) (*copyArgs, error) {
+	parsed := &copyArgs{}
+	newArgs, keepVersions := HasOption(args, "--keep-versions")
+	parsed.keepVersions = keepVersions
+
+	newArgs, from, err := ParseOptionWithValue(newArgs, "--from")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, to, err := ParseOptionWithValue(newArgs, "--to")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, toTable, err := ParseOptionWithValue(newArgs, "--to-table")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if to == "" && toTable == "" {
+		return nil, fmt.Errorf("--to or --to-table is required")
+	}
+
+	if len(newArgs) < 1 {
+		return nil, fmt.Errorf("too few arguments")
+	}
+
+	parsed.from = from
+	parsed.to = to
+	parsed.toTable = toTable
+	parsed.pattern = newArgs[0]
+	parsed.context, err = gcredstash.ParseContext(newArgs[1:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func (c *CopyCommand) RunImpl(args []string) (string, error) {
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	source, err := c.Store(parsed.from)
+
+	if err != nil {
+		return "", err
+	}
+
+	target, err := c.Store(parsed.to)
+
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.toTable != "" {
+		target.Table = parsed.toTable
+	}
+
+	copied, err := source.Driver.CopySecrets(parsed.pattern, source.Table, target.Driver, target.Table, target.KmsKey, parsed.context, parsed.keepVersions)
+	lines := []string{}
+
+	for _, secret := range copied {
+		lines = append(lines, fmt.Sprintf("%s -- version %d has been copied as version %d", secret.Name, gcredstash.Atoi(secret.SourceVersion), gcredstash.Atoi(secret.Version)))
+	}
+
+	out := ""
+
+	if len(lines) > 0 {
+		out = strings.Join(lines, "\n") + "\n"
+	}
+
+	if err != nil {
+		return out, err
+	}
+
+	if len(copied) == 0 {
+		return out, fmt.Errorf("no credentials match %s", parsed.pattern)
+	}
+
+	return out, nil
+}
+
+func (c *CopyCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *CopyCommand) Synopsis() string {
+	return "Copy credentials to another store"
+}
+
+func (c *CopyCommand) Help() string {
+	helpText := `
+usage: gcredstash copy [--from STORE] [--to STORE] [--to-table TABLE] [--keep-versions] pattern [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestCopyCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("foo.bar", "baz", "0000000000000000001", "alias/credstash", "credential-store", nil)
+
+		cmd := &CopyCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+				OpenStore: func(name string) (*gcredstash.Store, error) {
+					if name != "backup" {
+						return nil, fmt.Errorf("unknown store: %s", name)
+					}
+
+					return &gcredstash.Store{Driver: driver, Table: "credential-store-backup", KmsKey: "alias/credstash"}, nil
+				},
+			},
+		}
+
+		out, err := cmd.RunImpl([]string{"--to", "backup", "foo.*"})
+		expected := "foo.bar -- version 1 has been copied as version 1\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		value, _ := driver.GetSecret("foo.bar", "", "credential-store-backup", nil)
+
+		if value != "baz" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "baz", value)
+		}
+
+		_, err = cmd.RunImpl([]string{"--to", "prod", "foo.*"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+
+		_, err = cmd.RunImpl([]string{"foo.*"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
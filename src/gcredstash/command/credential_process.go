@@ -0,0 +1,69 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type CredentialProcessCommand struct {
+	Meta
+}
+
+func (c *CredentialProcessCommand) RunImpl(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	prefix := args[0]
+	context, err := gcredstash.ParseContext(args[1:])
+
+	if err != nil {
+		return "", err
+	}
+
+	creds, err := c.Driver.GetSecretsByPattern(prefix+"*", c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	output, err := gcredstash.NewCredentialProcessOutput(creds, prefix)
+
+	if err != nil {
+		return "", err
+	}
+
+	json, err := output.JSON()
+
+	if err != nil {
+		return "", err
+	}
+
+	return json + "\n", nil
+}
+
+func (c *CredentialProcessCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *CredentialProcessCommand) Synopsis() string {
+	return "Print stored AWS access keys for credential_process"
+}
+
+func (c *CredentialProcessCommand) Help() string {
+	helpText := `
+usage: gcredstash credential-process prefix [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gcredstash"
 	"os"
+	"sort"
 	"strings"
 )
 
@@ -12,7 +13,7 @@ type DeleteCommand struct {
 }
 
 func (c *DeleteCommand) parseArgs(args []string) (string, string, error) {
-	newArgs, version, err := gcredstash.ParseVersion(args)
+	newArgs, version, err := ParseVersion(args)
 
 	if err != nil {
 		return "", "", err
@@ -26,12 +27,67 @@ func (c *DeleteCommand) parseArgs(args []string) (string, string, error) {
 		return "", "", fmt.Errorf("too many arguments")
 	}
 
-	credential := args[0]
+	credential := newArgs[0]
 
 	return credential, version, nil
 }
 
+// deleteTagged deletes every credential under prefix whose tags match.
+func (c *DeleteCommand) deleteTagged(args []string, tags map[string]string) error {
+	if _, hasVersion := HasOption(args, "-v"); hasVersion {
+		return fmt.Errorf("-v cannot be combined with --tag")
+	}
+
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	prefix := ""
+
+	if len(args) == 1 {
+		prefix = args[0]
+	}
+
+	tagged, err := c.Driver.TaggedNames(c.Meta.Table, prefix, tags)
+
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+
+	for name := range tagged {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := c.Driver.DeleteSecrets(name, "", c.Meta.Table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *DeleteCommand) RunImpl(args []string) error {
+	args, tagArgs, err := ParseOptionWithValues(args, "--tag")
+
+	if err != nil {
+		return err
+	}
+
+	tags, err := gcredstash.ParseTags(tagArgs)
+
+	if err != nil {
+		return err
+	}
+
+	if len(tags) > 0 {
+		return c.deleteTagged(args, tags)
+	}
+
 	credential, version, err := c.parseArgs(args)
 
 	if err != nil {
@@ -52,7 +108,7 @@ func (c *DeleteCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	return 0
@@ -65,6 +121,7 @@ func (c *DeleteCommand) Synopsis() string {
 func (c *DeleteCommand) Help() string {
 	helpText := `
 usage: gcredstash delete [-v VERSION] credential
+       gcredstash delete --tag KEY=VALUE ... [prefix]
 `
 	return strings.TrimSpace(helpText)
 }
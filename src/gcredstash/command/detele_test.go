@@ -37,11 +37,27 @@ func TestDeleteCommand(t *testing.T) {
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":name": {S: aws.String(name)},
 		},
+		ProjectionExpression: aws.String("#name,version,deleted_at"),
 	}).Return(&dynamodb.QueryOutput{
 		Count: aws.Int64(1),
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
 	}, nil)
 
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		ConsistentRead:           aws.Bool(true),
+		KeyConditionExpression:   aws.String("#name = :name AND version = :version"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name":    {S: aws.String(name)},
+			":version": {S: aws.String(version)},
+		},
+		ProjectionExpression: aws.String("chunk_count"),
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(1),
+		Items: []map[string]*dynamodb.AttributeValue{{}},
+	}, nil)
+
 	mddb.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
 		TableName: aws.String(table),
 		Key: map[string]*dynamodb.AttributeValue{
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type DiffCommand struct {
+	Meta
+}
+
+func (c *DiffCommand) parseArgs(args []string) (string, string, error) {
+	newArgs, region, err := ParseOptionWithValue(args, "--region")
+
+	if err != nil {
+		return "", "", err
+	}
+
+	newArgs, table, err := ParseOptionWithValue(newArgs, "--table")
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(newArgs) > 0 {
+		return "", "", fmt.Errorf("too many arguments")
+	}
+
+	if region == "" && table == "" {
+		return "", "", fmt.Errorf("--region or --table is required")
+	}
+
+	return region, table, nil
+}
+
+func (c *DiffCommand) format(diffs []gcredstash.SecretDiff) string {
+	maxNameLen := 0
+
+	for _, diff := range diffs {
+		if len(diff.Name) > maxNameLen {
+			maxNameLen = len(diff.Name)
+		}
+	}
+
+	lines := []string{}
+
+	for _, diff := range diffs {
+		versionNum := gcredstash.Atoi(diff.Version)
+		lines = append(lines, fmt.Sprintf("%-*s -- version: %d: %s", maxNameLen, diff.Name, versionNum, diff.Reason))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (c *DiffCommand) RunImpl(args []string) (string, error) {
+	region, table, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	target := c.Driver
+
+	if region != "" {
+		target, err = c.Driver.ForRegion(region)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if table == "" {
+		table = c.Table
+	}
+
+	diffs, err := c.Driver.DiffSecrets(c.Table, target, table)
+
+	if err != nil {
+		return "", err
+	}
+
+	out := c.format(diffs)
+
+	if len(diffs) > 0 {
+		return out, fmt.Errorf("%d credential version(s) differ", len(diffs))
+	}
+
+	return out, nil
+}
+
+func (c *DiffCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *DiffCommand) Synopsis() string {
+	return "Compare credentials with another table or region"
+}
+
+func (c *DiffCommand) Help() string {
+	helpText := `
+usage: gcredstash diff [--region REGION] [--table TABLE]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"testing"
+)
+
+func TestDiffCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	scan := func(table string, items ...map[string]string) {
+		output := &dynamodb.ScanOutput{}
+
+		for _, item := range items {
+			output.Items = append(output.Items, testutils.MapToItem(item))
+		}
+
+		mddb.EXPECT().Scan(&dynamodb.ScanInput{
+			TableName:                aws.String(table),
+			ProjectionExpression:     aws.String("#name,version,hmac,deleted_at"),
+			ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		}).Return(output, nil)
+	}
+
+	scan("credential-store",
+		map[string]string{"name": "foo.bar", "version": "0000000000000000001", "hmac": "aaa"},
+		map[string]string{"name": "foo.bar", "version": "0000000000000000002", "hmac": "bbb"},
+		map[string]string{"name": "foo.baz", "version": "0000000000000000001", "hmac": "ccc"},
+	)
+
+	scan("credential-store-dr",
+		map[string]string{"name": "foo.bar", "version": "0000000000000000001", "hmac": "aaa"},
+		map[string]string{"name": "foo.baz", "version": "0000000000000000001", "hmac": "ddd"},
+		map[string]string{"name": "foo.qux", "version": "0000000000000000003", "hmac": "eee"},
+	)
+
+	cmd := &DiffCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	args := []string{"--table", "credential-store-dr"}
+	out, err := cmd.RunImpl(args)
+
+	expected := `foo.bar -- version: 2: only in source
+foo.baz -- version: 1: hmac differs
+foo.qux -- version: 3: only in target
+`
+
+	if out != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+
+	expectedErr := "3 credential version(s) differ"
+
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedErr, err)
+	}
+}
+
+func TestDiffCommandWithoutTarget(t *testing.T) {
+	cmd := &DiffCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{},
+		},
+	}
+
+	_, err := cmd.RunImpl([]string{})
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
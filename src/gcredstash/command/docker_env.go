@@ -0,0 +1,135 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const DOCKER_SECRETS_TARGET = "/run/secrets"
+
+type DockerEnvCommand struct {
+	Meta
+}
+
+type dockerEnvArgs struct {
+	opts       *gcredstash.EnvNameOptions
+	envFile    string
+	secretsDir string
+	context    map[string]string
+}
+
+func (c *DockerEnvCommand) parseArgs(args []string) (*dockerEnvArgs, error) {
+	newArgs, opts, err := parseEnvNameOptions(args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &dockerEnvArgs{opts: opts}
+	newArgs, parsed.envFile, err = ParseOptionWithValue(newArgs, "--env-file")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, parsed.secretsDir, err = ParseOptionWithValue(newArgs, "--secrets-dir")
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.context, err = gcredstash.ParseContext(newArgs)
+
+	return parsed, err
+}
+
+func (c *DockerEnvCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.secretsDir == "" && parsed.envFile == "" {
+		if err := c.checkTerminalOutput(force); err != nil {
+			return "", err
+		}
+	}
+
+	creds, err := c.Driver.GetSecretsByPattern(parsed.opts.Prefix+"*", c.Table, parsed.context)
+
+	if err != nil {
+		return "", err
+	}
+
+	env, err := gcredstash.EnvNames(creds, parsed.opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.secretsDir != "" {
+		err = gcredstash.WriteSecretFiles(parsed.secretsDir, env)
+
+		if err != nil {
+			return "", err
+		}
+
+		dir, err := filepath.Abs(parsed.secretsDir)
+
+		if err != nil {
+			return "", err
+		}
+
+		mount := fmt.Sprintf("type=bind,source=%s,target=%s,readonly", dir, DOCKER_SECRETS_TARGET)
+
+		return "--mount " + gcredstash.ShellQuote(mount) + "\n", nil
+	}
+
+	if parsed.envFile != "" {
+		content, err := gcredstash.FormatDockerEnvFile(env)
+
+		if err != nil {
+			return "", err
+		}
+
+		err = ioutil.WriteFile(parsed.envFile, []byte(content), 0600)
+
+		if err != nil {
+			return "", err
+		}
+
+		return "--env-file " + gcredstash.ShellQuote(parsed.envFile) + "\n", nil
+	}
+
+	return gcredstash.FormatDockerEnvArgs(env) + "\n", nil
+}
+
+func (c *DockerEnvCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *DockerEnvCommand) Synopsis() string {
+	return "Print docker run arguments that pass credentials"
+}
+
+func (c *DockerEnvCommand) Help() string {
+	helpText := `
+usage: gcredstash docker-env [--prefix PREFIX] [--keep-prefix] [--keep-case] [--keep-dots] [--env-file FILE | --secrets-dir DIR | --force-stdout] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
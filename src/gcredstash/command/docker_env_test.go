@@ -0,0 +1,52 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDockerEnvCommandWithSecretsDir(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("app.secret", "s3cr3t", "0000000000000000001", "alias/credstash", table, nil)
+
+		dir, err := ioutil.TempDir("", "gcredstash")
+
+		if err != nil {
+			panic(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		cmd := &DockerEnvCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		out, err := cmd.RunImpl([]string{"--prefix", "app.", "--secrets-dir", dir})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		expected := "--mount 'type=bind,source=" + dir + ",target=/run/secrets,readonly'\n"
+
+		if out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+		}
+
+		content, _ := ioutil.ReadFile(filepath.Join(dir, "SECRET"))
+
+		if string(content) != "s3cr3t" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "s3cr3t", string(content))
+		}
+	})
+}
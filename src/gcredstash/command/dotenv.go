@@ -0,0 +1,111 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+type DotenvCommand struct {
+	Meta
+}
+
+func parseEnvNameOptions(args []string) ([]string, *gcredstash.EnvNameOptions, error) {
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := &gcredstash.EnvNameOptions{Prefix: prefix}
+	newArgs, opts.KeepPrefix = HasOption(newArgs, "--keep-prefix")
+	newArgs, opts.KeepCase = HasOption(newArgs, "--keep-case")
+	newArgs, opts.KeepDots = HasOption(newArgs, "--keep-dots")
+
+	return newArgs, opts, nil
+}
+
+func (c *DotenvCommand) parseArgs(args []string) (*gcredstash.EnvNameOptions, string, map[string]string, error) {
+	newArgs, opts, err := parseEnvNameOptions(args)
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	newArgs, output, err := ParseOptionWithValue(newArgs, "-o")
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	return opts, output, context, err
+}
+
+func (c *DotenvCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	opts, output, context, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if output == "" {
+		if err := c.checkTerminalOutput(force); err != nil {
+			return "", err
+		}
+	}
+
+	creds, err := c.Driver.GetSecretsByPattern(opts.Prefix+"*", c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	env, err := gcredstash.EnvNames(creds, opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	out := gcredstash.FormatDotenv(env)
+
+	if output == "" {
+		return out, nil
+	}
+
+	err = ioutil.WriteFile(output, []byte(out), 0600)
+
+	if err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (c *DotenvCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *DotenvCommand) Synopsis() string {
+	return "Write credentials as a .env file"
+}
+
+func (c *DotenvCommand) Help() string {
+	helpText := `
+usage: gcredstash dotenv [--prefix PREFIX] [--keep-prefix] [--keep-case] [--keep-dots] [-o FILE | --force-stdout] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestDotenvCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("app.database-url", "postgres://db/app", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("app.secret", "old value", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("app.secret", "new value", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("other.key", "ignored", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &DotenvCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		out, err := cmd.RunImpl([]string{"--prefix", "app."})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		expected := `DATABASE_URL=postgres://db/app
+SECRET="new value"
+`
+
+		if out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+		}
+	})
+}
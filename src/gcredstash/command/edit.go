@@ -0,0 +1,237 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gcredstash"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type EditCommand struct {
+	Meta
+}
+
+type editArgs struct {
+	credential string
+	sets       []string
+	yes        bool
+	context    map[string]string
+}
+
+func (c *EditCommand) parseArgs(args []string) (*editArgs, error) {
+	parsed := &editArgs{}
+	newArgs, sets, err := ParseOptionWithValues(args, "--set")
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.sets = sets
+	newArgs, parsed.yes = HasOption(newArgs, "-y")
+
+	if len(newArgs) < 1 {
+		return nil, fmt.Errorf("too few arguments")
+	}
+
+	parsed.credential = newArgs[0]
+	parsed.context, err = gcredstash.ParseContext(newArgs[1:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// applySets applies key=value mutations to a JSON value. The result is
+// indented when the original value spans several lines.
+func applySets(value string, sets []string) (string, error) {
+	var doc interface{}
+
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return "", fmt.Errorf("--set requires a JSON value: %s", err.Error())
+	}
+
+	for _, set := range sets {
+		kv := strings.SplitN(set, "=", 2)
+
+		if len(kv) != 2 {
+			return "", fmt.Errorf("--set must be PATH=VALUE: %s", set)
+		}
+
+		var err error
+		doc, err = gcredstash.SetJSONPath(doc, kv[0], gcredstash.ParseJSONValue(kv[1]))
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var edited []byte
+	var err error
+
+	if strings.Contains(strings.TrimSpace(value), "\n") {
+		edited, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		edited, err = json.Marshal(doc)
+	}
+
+	return string(edited), err
+}
+
+// runEditor opens value in $VISUAL or $EDITOR (default: vi) and returns
+// the edited text. The temporary file is only readable by the user and is
+// removed afterwards.
+func runEditor(value string) (string, error) {
+	editor := os.Getenv("VISUAL")
+
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := ioutil.TempFile("", "gcredstash-edit-")
+
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(value)
+	f.Close()
+
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", f.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %s", editor, err.Error())
+	}
+
+	edited, err := ioutil.ReadFile(f.Name())
+
+	if err != nil {
+		return "", err
+	}
+
+	ioutil.WriteFile(f.Name(), make([]byte, len(edited)), 0600)
+
+	if !strings.HasSuffix(value, "\n") {
+		edited = bytes.TrimRight(edited, "\r\n")
+	}
+
+	return string(edited), nil
+}
+
+// previewText indents JSON so that the diff is shown per field.
+func previewText(value string) string {
+	indented := &bytes.Buffer{}
+
+	if json.Indent(indented, []byte(value), "", "  ") != nil {
+		return value
+	}
+
+	return indented.String()
+}
+
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
+
+func (c *EditCommand) RunImpl(args []string) error {
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	credential := parsed.credential
+	current, version, err := c.Driver.GetSecretBytesWithVersion(credential, "", c.Table, parsed.context)
+
+	if err != nil {
+		return err
+	}
+
+	value := string(current)
+	gcredstash.Wipe(current)
+
+	var edited string
+
+	if len(parsed.sets) > 0 {
+		edited, err = applySets(value, parsed.sets)
+	} else {
+		edited, err = runEditor(value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if edited == value {
+		fmt.Printf("%s is unchanged\n", credential)
+		return nil
+	}
+
+	if json.Valid([]byte(value)) && !json.Valid([]byte(edited)) {
+		return fmt.Errorf("%s: the edited value is not valid JSON", credential)
+	}
+
+	latestVersion := gcredstash.Atoi(version)
+
+	for _, line := range gcredstash.DiffLines(previewText(value), previewText(edited)) {
+		fmt.Println(line)
+	}
+
+	if !parsed.yes && !confirm(fmt.Sprintf("Store as version %d? [y/N] ", latestVersion+1)) {
+		return fmt.Errorf("%s has not been stored", credential)
+	}
+
+	version, err = c.Driver.PutSecretIfVersion(credential, edited, latestVersion, c.KmsKey, c.Table, parsed.context, nil)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s has been stored -- version %d\n", credential, gcredstash.Atoi(version))
+
+	return nil
+}
+
+func (c *EditCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *EditCommand) Synopsis() string {
+	return "Edit a credential in $EDITOR or set fields of a JSON value"
+}
+
+func (c *EditCommand) Help() string {
+	helpText := `
+usage: gcredstash edit [--set PATH=VALUE ...] [-y] credential [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestEditCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("db.config", `{"password":"old","port":5432}`, "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &EditCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		err := cmd.RunImpl([]string{"-y", "--set", "password=s3cr3t", "--set", "port=6432", "db.config"})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		actual, _ := driver.GetSecret("db.config", "0000000000000000002", table, nil)
+		expected := `{"password":"s3cr3t","port":6432}`
+
+		if actual != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+		}
+
+		os.Setenv("VISUAL", `sh -c 'printf "{\"password\":\"edited\"}\n" > "$0"'`)
+		defer os.Unsetenv("VISUAL")
+
+		err = cmd.RunImpl([]string{"-y", "db.config"})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		actual, _ = driver.GetSecret("db.config", "", table, nil)
+		expected = `{"password":"edited"}`
+
+		if actual != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+		}
+
+		os.Setenv("VISUAL", `sh -c 'printf "{broken" > "$0"'`)
+		err = cmd.RunImpl([]string{"-y", "db.config"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+
+		err = cmd.RunImpl([]string{"-y", "--set", "password.x=1", "db.config"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type ExistsCommand struct {
+	Meta
+}
+
+func (c *ExistsCommand) parseArgs(args []string) (string, string, error) {
+	if len(args) < 1 {
+		return "", "", fmt.Errorf("too few arguments")
+	}
+
+	if len(args) > 2 {
+		return "", "", fmt.Errorf("too many arguments")
+	}
+
+	version := ""
+
+	if len(args) == 2 {
+		ver, err := strconv.Atoi(args[1])
+
+		if err != nil {
+			return "", "", fmt.Errorf("invalid version: %s", args[1])
+		}
+
+		version = gcredstash.VersionNumToStr(ver)
+	}
+
+	return args[0], version, nil
+}
+
+// RunImpl returns a NotFoundError when the credential or version is not
+// stored. Only its keys are read: nothing is decrypted.
+func (c *ExistsCommand) RunImpl(args []string) (string, error) {
+	credential, version, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	exists, err := c.Driver.Exists(credential, version, c.Table)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return "", &gcredstash.NotFoundError{Name: credential, Version: version}
+	}
+
+	return "", nil
+}
+
+func (c *ExistsCommand) Run(args []string) int {
+	_, err := c.RunImpl(args)
+
+	// A missing credential is an answer, not an error.
+	if errors.Is(err, gcredstash.ErrSecretNotFound) || errors.Is(err, gcredstash.ErrVersionNotFound) {
+		return EXIT_NOT_FOUND
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *ExistsCommand) Synopsis() string {
+	return "Check that a credential exists, without decrypting it"
+}
+
+func (c *ExistsCommand) Help() string {
+	helpText := `
+usage: gcredstash exists credential [version]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"errors"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestExistsCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		(&PutCommand{Meta: meta}).RunImpl([]string{"foo.bar", "100"})
+		// Nothing is decrypted, so no KMS is needed.
+		driver.Kms = nil
+		cmd := &ExistsCommand{Meta: meta}
+
+		for _, c := range []struct {
+			args     []string
+			expected error
+		}{
+			{[]string{"foo.bar"}, nil},
+			{[]string{"foo.bar", "1"}, nil},
+			{[]string{"foo.bar", "2"}, gcredstash.ErrVersionNotFound},
+			{[]string{"foo.baz"}, gcredstash.ErrSecretNotFound},
+		} {
+			if _, err := cmd.RunImpl(c.args); !errors.Is(err, c.expected) {
+				t.Errorf("\nexpected: %v\ngot: %v\n", c.expected, err)
+			}
+		}
+
+		if code := cmd.Run([]string{"foo.baz"}); code != EXIT_NOT_FOUND {
+			t.Errorf("\nexpected: %v\ngot: %v\n", EXIT_NOT_FOUND, code)
+		}
+
+		if code := cmd.Run([]string{"foo.bar"}); code != 0 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 0, code)
+		}
+
+		if _, err := cmd.RunImpl([]string{"foo.bar", "latest"}); err == nil || err.Error() != "invalid version: latest" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "invalid version: latest", err)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"errors"
+	"gcredstash"
+)
+
+const (
+	EXIT_ERROR         = 1
+	EXIT_NOT_FOUND     = 2
+	EXIT_ACCESS_DENIED = 3
+	EXIT_INTEGRITY     = 4
+	EXIT_THROTTLED     = 5
+	EXIT_EXPIRED       = 6
+	EXIT_CONFLICT      = 7
+)
+
+// ExitCode returns the exit status for an error returned by RunImpl, so
+// that scripts can tell failure classes apart.
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, gcredstash.ErrSecretNotFound), errors.Is(err, gcredstash.ErrVersionNotFound):
+		return EXIT_NOT_FOUND
+	case gcredstash.IsAccessDenied(err):
+		return EXIT_ACCESS_DENIED
+	case errors.Is(err, gcredstash.ErrIntegrity):
+		return EXIT_INTEGRITY
+	case gcredstash.IsThrottle(err):
+		return EXIT_THROTTLED
+	case errors.Is(err, gcredstash.ErrExpired):
+		return EXIT_EXPIRED
+	case errors.Is(err, gcredstash.ErrVersionConflict):
+		return EXIT_CONFLICT
+	}
+
+	return EXIT_ERROR
+}
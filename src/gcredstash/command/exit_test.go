@@ -0,0 +1,31 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	. "gcredstash/command"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := map[error]int{
+		&gcredstash.NotFoundError{Name: "test.key"}:                                                                   EXIT_NOT_FOUND,
+		&gcredstash.NotFoundError{Name: "test.key", Version: "0000000000000000002"}:                                   EXIT_NOT_FOUND,
+		awserr.New("AccessDeniedException", "not authorized", nil):                                                    EXIT_ACCESS_DENIED,
+		fmt.Errorf("Could not generate key using KMS key(alias/credstash): AccessDeniedException: not authorized"):    EXIT_ACCESS_DENIED,
+		&gcredstash.IntegrityError{Name: "test.key", Message: "Computed HMAC on test.key does not match stored HMAC"}: EXIT_INTEGRITY,
+		awserr.New("ProvisionedThroughputExceededException", "slow down", nil):                                        EXIT_THROTTLED,
+		&gcredstash.ExpiredError{Name: "test.key", Version: "0000000000000000001"}:                                    EXIT_EXPIRED,
+		&gcredstash.VersionConflictError{Name: "test.key", Expected: 1, Latest: 2}:                                    EXIT_CONFLICT,
+		fmt.Errorf("too few arguments"):                                                                               EXIT_ERROR,
+	}
+
+	for err, expected := range tests {
+		actual := ExitCode(err)
+
+		if actual != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+	"time"
+)
+
+const DEFAULT_EXPIRING_WITHIN = 7 * 24 * time.Hour
+
+type ExpiringCommand struct {
+	Meta
+}
+
+func (c *ExpiringCommand) RunImpl(args []string) (string, error) {
+	newArgs, withinStr, err := ParseOptionWithValue(args, "--within")
+
+	if err != nil {
+		return "", err
+	}
+
+	within := DEFAULT_EXPIRING_WITHIN
+
+	if withinStr != "" {
+		within, err = gcredstash.ParseAge(withinStr)
+
+		if err != nil {
+			return "", fmt.Errorf("invalid duration: %s", withinStr)
+		}
+	}
+
+	if len(newArgs) > 1 {
+		return "", fmt.Errorf("too many arguments")
+	}
+
+	prefix := ""
+
+	if len(newArgs) == 1 {
+		prefix = newArgs[0]
+	}
+
+	now := time.Now()
+	infos, err := c.Driver.ExpiringSecrets(c.Table, prefix, now.Add(within))
+
+	if err != nil {
+		return "", err
+	}
+
+	out := ""
+
+	for _, info := range infos {
+		verb := "expires"
+
+		if expiresAt, _ := time.Parse(time.RFC3339, info.ExpiresAt); !now.Before(expiresAt) {
+			verb = "expired"
+		}
+
+		out += fmt.Sprintf("%s -- version: %d %s at %s\n", info.Name, gcredstash.Atoi(info.Version), verb, info.ExpiresAt)
+	}
+
+	return out, nil
+}
+
+func (c *ExpiringCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *ExpiringCommand) Synopsis() string {
+	return "List credentials that expire soon or have expired"
+}
+
+func (c *ExpiringCommand) Help() string {
+	helpText := `
+usage: gcredstash expiring [--within AGE] [prefix]
+`
+	return strings.TrimSpace(helpText)
+}
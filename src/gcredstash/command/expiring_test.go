@@ -0,0 +1,39 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestExpiringCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		cmd := &PutCommand{Meta: meta}
+		cmd.RunImpl([]string{"--ttl", "3d", "foo.bar", "100"})
+		cmd.RunImpl([]string{"--ttl", "30d", "foo.baz", "200"})
+		cmd.RunImpl([]string{"foo.qux", "300"})
+
+		out, err := (&ExpiringCommand{Meta: meta}).RunImpl([]string{"--within", "7d"})
+		re := regexp.MustCompile(`\Afoo.bar -- version: 1 expires at \S+Z\n\z`)
+
+		if err != nil || !re.MatchString(out) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", re, out, err)
+		}
+
+		err = cmd.RunImpl([]string{"--auto-delete", "foo.zoo", "400"})
+		expected := "--auto-delete requires --ttl"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
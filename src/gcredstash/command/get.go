@@ -1,19 +1,50 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"gcredstash"
 	"github.com/ryanuber/go-glob"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 )
 
 type GetCommand struct {
 	Meta
+
+	// Clipboard is where --clip copies to; the OS clipboard when nil.
+	Clipboard gcredstash.Clipboard
+}
+
+type getArgs struct {
+	credentials []string
+	version     string
+	context     map[string]string
+	noNL        bool
+	noErr       bool
+	raw         bool
+	errOut      string
+	locked      bool
+	lockFile    string
+	field       string
+	clip        bool
+	clipTimeout time.Duration
+	mask        bool
+	show        bool
+	force       bool
+	hasDefault  bool
+	defaultVal  string
 }
 
-func (c *GetCommand) parseArgs(args []string) (string, string, map[string]string, bool, bool, string, error) {
-	argsWithoutN, noNL := gcredstash.HasOption(args, "-n")
+func (c *GetCommand) parseArgs(args []string) (*getArgs, error) {
+	parsed := &getArgs{}
+	argsWithoutN, noNL := HasOption(args, "-n")
+	argsWithoutN, noline := HasOption(argsWithoutN, "--noline")
+	noNL = noNL || noline
 
 	if !noNL {
 		trailingNewline := os.Getenv("GCREDSTASH_GET_TRAILING_NEWLINE")
@@ -23,44 +54,159 @@ func (c *GetCommand) parseArgs(args []string) (string, string, map[string]string
 		}
 	}
 
-	argsWithoutNS, noErr := gcredstash.HasOption(argsWithoutN, "-s")
-	argsWithoutNSE, errOut, err := gcredstash.ParseOptionWithValue(argsWithoutNS, "-e")
+	parsed.noNL = noNL
+	argsWithoutNS, noErr := HasOption(argsWithoutN, "-s")
+	parsed.noErr = noErr
+	argsWithoutNS, parsed.raw = HasOption(argsWithoutNS, "--raw")
+	argsWithoutNSE, errOut, err := ParseOptionWithValue(argsWithoutNS, "-e")
 
 	if errOut == "" {
 		errOut = os.Getenv("GCREDSTASH_GET_ERROUT")
 	}
 
+	parsed.errOut = errOut
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, version, err := ParseVersion(argsWithoutNSE)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.version = version
+	newArgs, parsed.locked = HasOption(newArgs, "--locked")
+	newArgs, parsed.lockFile, err = ParseOptionWithValue(newArgs, "--lock-file")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, parsed.field, err = ParseOptionWithValue(newArgs, "--field")
+
 	if err != nil {
-		return "", "", nil, false, false, "", err
+		return nil, err
 	}
 
-	newArgs, version, err := gcredstash.ParseVersion(argsWithoutNSE)
+	newArgs, parsed.mask = HasOption(newArgs, "--mask")
+	newArgs, parsed.show = HasOption(newArgs, "--show")
+
+	if parsed.mask && parsed.show {
+		return nil, fmt.Errorf("--mask and --show are mutually exclusive")
+	}
+
+	newArgs, parsed.force = HasOption(newArgs, "--force-stdout")
+	newArgs, defaults, err := ParseOptionWithValues(newArgs, "--default")
 
 	if err != nil {
-		return "", "", nil, false, false, "", err
+		return nil, err
+	}
+
+	// --default "" is a default too, so it is told apart from no --default.
+	if len(defaults) > 0 {
+		parsed.hasDefault = true
+		parsed.defaultVal = defaults[len(defaults)-1]
+	}
+
+	newArgs, parsed.clip = HasOption(newArgs, "--clip")
+	newArgs, clipTimeout, err := ParseOptionWithValue(newArgs, "--clip-timeout")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if clipTimeout == "" {
+		clipTimeout = os.Getenv("GCREDSTASH_CLIP_TIMEOUT")
+	}
+
+	parsed.clipTimeout = gcredstash.DEFAULT_CLIP_TIMEOUT
+
+	if clipTimeout != "" {
+		parsed.clipTimeout, err = gcredstash.ParseAge(clipTimeout)
+
+		if err != nil || parsed.clipTimeout <= 0 {
+			return nil, fmt.Errorf("invalid clip timeout: %s", clipTimeout)
+		}
+	}
+
+	if parsed.lockFile == "" {
+		parsed.lockFile = gcredstash.DEFAULT_LOCK_FILE
+	} else {
+		parsed.locked = true
+	}
+
+	// Credential names come first; the context starts at the first key=value.
+	for len(newArgs) > 0 && !strings.Contains(newArgs[0], "=") {
+		parsed.credentials = append(parsed.credentials, newArgs[0])
+		newArgs = newArgs[1:]
+	}
+
+	if len(parsed.credentials) < 1 && !parsed.locked {
+		return nil, fmt.Errorf("too few arguments")
 	}
 
-	if len(newArgs) < 1 {
-		return "", "", nil, false, false, "", fmt.Errorf("too few arguments")
+	if parsed.locked {
+		if version != "" {
+			return nil, fmt.Errorf("-v cannot be used with --locked")
+		}
+
+		for _, credential := range parsed.credentials {
+			if strings.Contains(credential, "*") {
+				return nil, fmt.Errorf("wildcards cannot be used with --locked")
+			}
+		}
+	}
+
+	if parsed.field != "" && (len(parsed.credentials) != 1 || strings.Contains(parsed.credentials[0], "*")) {
+		return nil, fmt.Errorf("--field can only be used with a single credential")
+	}
+
+	if parsed.clip && (len(parsed.credentials) != 1 || strings.Contains(parsed.credentials[0], "*")) {
+		return nil, fmt.Errorf("--clip can only be used with a single credential")
+	}
+
+	if parsed.hasDefault && (len(parsed.credentials) != 1 || strings.Contains(parsed.credentials[0], "*")) {
+		return nil, fmt.Errorf("--default can only be used with a single credential")
 	}
 
-	credential := newArgs[0]
-	context, err := gcredstash.ParseContext(newArgs[1:])
+	if len(parsed.credentials) > 1 {
+		if version != "" {
+			return nil, fmt.Errorf("-v cannot be used with more than one credential")
+		}
+
+		for _, credential := range parsed.credentials {
+			if strings.Contains(credential, "*") {
+				return nil, fmt.Errorf("wildcards cannot be used with more than one credential")
+			}
+		}
+	}
 
-	return credential, version, context, noNL, noErr, errOut, err
+	parsed.context, err = gcredstash.ParseContext(newArgs)
+
+	return parsed, err
 }
 
-func (c *GetCommand) getCredential(credential string, version string, context map[string]string) (string, error) {
+func (c *GetCommand) getCredential(credential string, version string, context map[string]string, field string) (string, error) {
 	value, err := c.Driver.GetSecret(credential, version, c.Table, context)
 
 	if err != nil {
 		return "", err
 	}
 
+	if field != "" {
+		value, err = gcredstash.JSONField(value, field)
+
+		if err != nil {
+			return "", fmt.Errorf("%s: %s", credential, err.Error())
+		}
+	}
+
 	return value, nil
 }
 
-func (c *GetCommand) getCredentials(credential string, version string, context map[string]string) (string, error) {
+func (c *GetCommand) getCredentials(credential string, version string, context map[string]string, raw bool, noNL bool, mask bool) (string, error) {
 	names := map[string]bool{}
 	items, err := c.Driver.ListSecrets(c.Table)
 
@@ -88,7 +234,59 @@ func (c *GetCommand) getCredentials(credential string, version string, context m
 		creds[name] = value
 	}
 
-	return gcredstash.MapToJson(creds) + "\n", nil
+	matched := []string{}
+
+	for name, _ := range creds {
+		matched = append(matched, name)
+	}
+
+	sort.Strings(matched)
+
+	if mask {
+		maskCredentials(creds)
+	}
+
+	return formatCredentials(creds, matched, raw, noNL), nil
+}
+
+// maskOutput reports whether values are printed masked: with --mask, or
+// on a terminal without --show.
+func (m *Meta) maskOutput(mask bool, show bool) bool {
+	if mask || (m.Terminal && !show) {
+		if !mask {
+			fmt.Fprintf(os.Stderr, "values are masked on a terminal; use --show to print them\n")
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func maskCredentials(creds map[string]string) {
+	for name, value := range creds {
+		creds[name] = gcredstash.MaskSecret(value)
+	}
+}
+
+// formatCredentials prints creds as a JSON object, or with raw as the bare
+// values of names one per line. noNL drops the final newline.
+func formatCredentials(creds map[string]string, names []string, raw bool, noNL bool) string {
+	out := ""
+
+	if raw {
+		for _, name := range names {
+			out += creds[name] + "\n"
+		}
+	} else {
+		out = gcredstash.MapToJson(creds) + "\n"
+	}
+
+	if noNL {
+		out = strings.TrimSuffix(out, "\n")
+	}
+
+	return out
 }
 
 func (c *GetCommand) write(filename string, message string) {
@@ -107,37 +305,180 @@ func (c *GetCommand) write(filename string, message string) {
 	fp.WriteString(message)
 }
 
+// lockedVersions reads the pinned versions of credentials from the lock
+// file, or of every credential in it when none is given.
+func (c *GetCommand) lockedVersions(parsed *getArgs) (map[string]string, error) {
+	locked, err := gcredstash.ReadLockFile(parsed.lockFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parsed.credentials) == 0 {
+		for name, _ := range locked {
+			parsed.credentials = append(parsed.credentials, name)
+		}
+
+		if len(parsed.credentials) == 0 {
+			return nil, fmt.Errorf("%s has no credentials", parsed.lockFile)
+		}
+
+		sort.Strings(parsed.credentials)
+	}
+
+	versions := map[string]string{}
+
+	for _, credential := range parsed.credentials {
+		version, ok := locked[credential]
+
+		if !ok {
+			return nil, fmt.Errorf("%s is not in %s", credential, parsed.lockFile)
+		}
+
+		versions[credential] = version
+	}
+
+	return versions, nil
+}
+
+func (c *GetCommand) getMultipleCredentials(credentials []string, versions map[string]string, context map[string]string, raw bool, noNL bool, mask bool) (string, error) {
+	var creds map[string]string
+	var err error
+
+	if versions == nil {
+		creds, err = c.Driver.GetSecrets(credentials, c.Table, context)
+	} else {
+		creds, err = c.Driver.GetSecretsWithVersions(versions, c.Table, context)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if mask {
+		maskCredentials(creds)
+	}
+
+	return formatCredentials(creds, credentials, raw, noNL), nil
+}
+
+// clip copies value to the clipboard instead of printing it, and clears it
+// after timeout, or early on an interrupt.
+func (c *GetCommand) clip(credential string, value string, timeout time.Duration) error {
+	clipboard := c.Clipboard
+
+	if clipboard == nil {
+		clipboard = gcredstash.NewOSClipboard()
+	}
+
+	if err := clipboard.Copy([]byte(value)); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%s copied to the clipboard; clearing it in %s\n", credential, timeout)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	select {
+	case <-time.After(timeout):
+	case <-interrupt:
+	}
+
+	if err := gcredstash.ClearClipboard(clipboard, []byte(value)); err != nil {
+		return fmt.Errorf("could not clear the clipboard: %s", err.Error())
+	}
+
+	fmt.Fprintf(os.Stderr, "clipboard cleared\n")
+
+	return nil
+}
+
 func (c *GetCommand) RunImpl(args []string) (string, error) {
-	credential, version, context, noNL, noErr, errOut, err := c.parseArgs(args)
+	parsed, err := c.parseArgs(args)
 
 	if err != nil {
 		return "", err
 	}
 
-	if strings.Contains(credential, "*") {
-		value, err := c.getCredentials(credential, version, context)
+	var versions map[string]string
+
+	if parsed.locked {
+		versions, err = c.lockedVersions(parsed)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	credential, version, context := parsed.credentials[0], parsed.version, parsed.context
+	mask := !parsed.clip && c.maskOutput(parsed.mask, parsed.show)
 
-		if err != nil && errOut != "" {
-			c.write(errOut, fmt.Sprintf("error: gcredstash get %v: %s\n", args, err.Error()))
+	if !parsed.clip && !mask {
+		if err := c.checkTerminalOutput(parsed.force); err != nil {
+			return "", err
+		}
+	}
+
+	if versions != nil {
+		version = versions[credential]
+	}
+
+	if len(parsed.credentials) > 1 {
+		value, err := c.getMultipleCredentials(parsed.credentials, versions, context, parsed.raw, parsed.noNL, mask)
+
+		if err != nil {
+			if parsed.errOut != "" {
+				c.write(parsed.errOut, fmt.Sprintf("error: gcredstash get %v: %s\n", args, err.Error()))
+			}
+
+			if parsed.noErr {
+				return "", nil
+			} else {
+				return "", err
+			}
+		}
+
+		return value, nil
+	} else if strings.Contains(credential, "*") {
+		value, err := c.getCredentials(credential, version, context, parsed.raw, parsed.noNL, mask)
+
+		if err != nil && parsed.errOut != "" {
+			c.write(parsed.errOut, fmt.Sprintf("error: gcredstash get %v: %s\n", args, err.Error()))
 		}
 
 		return value, err
 	} else {
-		value, err := c.getCredential(credential, version, context)
+		value, err := c.getCredential(credential, version, context, parsed.field)
+
+		// The default is printed as given, without --field.
+		if parsed.hasDefault && errors.Is(err, gcredstash.ErrSecretNotFound) {
+			value, err = parsed.defaultVal, nil
+		}
 
 		if err != nil {
-			if errOut != "" {
-				c.write(errOut, fmt.Sprintf("error: gcredstash get %v: %s\n", args, err.Error()))
+			if parsed.errOut != "" {
+				c.write(parsed.errOut, fmt.Sprintf("error: gcredstash get %v: %s\n", args, err.Error()))
 			}
 
-			if noErr {
+			if parsed.noErr {
 				return "", nil
 			} else {
 				return "", err
 			}
 		}
 
-		if noNL {
+		if parsed.clip {
+			return "", c.clip(credential, value, parsed.clipTimeout)
+		}
+
+		if mask {
+			value = gcredstash.MaskSecret(value)
+		}
+
+		// A raw value is printed exactly as it is stored.
+		if parsed.noNL || parsed.raw {
 			return value, nil
 		} else {
 			return value + "\n", nil
@@ -150,7 +491,7 @@ func (c *GetCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	fmt.Print(out)
@@ -164,7 +505,10 @@ func (c *GetCommand) Synopsis() string {
 
 func (c *GetCommand) Help() string {
 	helpText := `
-usage: gcredstash get [-v VERSION] [-n] [-s] [-e ERROUT] credential [context [context ...]]
+usage: gcredstash get [-v VERSION] [--field PATH] [--default VALUE] [--mask|--show] [--force-stdout] [-n|--noline] [--raw] [-s] [-e ERROUT] credential [context [context ...]]
+       gcredstash get [--mask|--show] [--force-stdout] [-n|--noline] [--raw] [-s] [-e ERROUT] credential credential [credential ...] [context [context ...]]
+       gcredstash get --locked [--lock-file FILE] [--mask|--show] [--force-stdout] [-n|--noline] [--raw] [-s] [-e ERROUT] [credential ...] [context [context ...]]
+       gcredstash get --clip [--clip-timeout DURATION] [-v VERSION] [--field PATH] credential [context [context ...]]
 `
 	return strings.TrimSpace(helpText)
 }
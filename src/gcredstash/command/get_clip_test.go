@@ -0,0 +1,53 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+// memoryClipboard is a Clipboard that records what was copied to it.
+type memoryClipboard struct {
+	copied []string
+}
+
+func (clipboard *memoryClipboard) Copy(value []byte) error {
+	clipboard.copied = append(clipboard.copied, string(value))
+	return nil
+}
+
+func (clipboard *memoryClipboard) Paste() ([]byte, error) {
+	return []byte(clipboard.copied[len(clipboard.copied)-1]), nil
+}
+
+func TestGetCommandClip(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("db.password", "s3cr3t", gcredstash.VersionNumToStr(1), "alias/credstash", table, nil)
+		clipboard := &memoryClipboard{}
+		cmd := &GetCommand{Meta: Meta{Table: table, KmsKey: "alias/credstash", Driver: driver}, Clipboard: clipboard}
+
+		out, err := cmd.RunImpl([]string{"--clip", "--clip-timeout", "10ms", "db.password"})
+
+		if out != "" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "nothing printed", out, err)
+		}
+
+		if len(clipboard.copied) != 2 || clipboard.copied[0] != "s3cr3t" || clipboard.copied[1] != "" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", []string{"s3cr3t", ""}, clipboard.copied)
+		}
+
+		for _, args := range [][]string{
+			{"--clip", "db.password", "db.user"},
+			{"--clip", "db.*"},
+			{"--clip", "--clip-timeout", "0", "db.password"},
+		} {
+			if _, err := cmd.RunImpl(args); err == nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"errors"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestGetCommandDefault(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("app.config", `{"port": 8080}`, gcredstash.VersionNumToStr(1), "alias/credstash", table, nil)
+		cmd := &GetCommand{Meta: Meta{Table: table, KmsKey: "alias/credstash", Driver: driver}}
+
+		for _, c := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"--default", "8000", "--field", "port", "app.config"}, "8080\n"},
+			{[]string{"--default", "8000", "--field", "port", "app.missing"}, "8000\n"},
+			{[]string{"app.missing", "--default", ""}, "\n"},
+			{[]string{"-n", "--default", "none", "app.missing", "env=prod"}, "none"},
+		} {
+			if out, err := cmd.RunImpl(c.args); out != c.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", c.expected, out, err)
+			}
+		}
+
+		if _, err := cmd.RunImpl([]string{"--default", "x", "-v", "2", "app.config"}); !errors.Is(err, gcredstash.ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", gcredstash.ErrVersionNotFound, err)
+		}
+
+		if _, err := cmd.RunImpl([]string{"--default", "x", "app.*"}); err == nil || err.Error() != "--default can only be used with a single credential" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "--default can only be used with a single credential", err)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestGetCommandMask(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("db.password", "correct horse battery", gcredstash.VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("db.user", "app", gcredstash.VersionNumToStr(1), "alias/credstash", table, nil)
+		meta := Meta{Table: table, KmsKey: "alias/credstash", Driver: driver}
+
+		for _, c := range []struct {
+			terminal bool
+			args     []string
+			expected string
+		}{
+			{false, []string{"db.password"}, "correct horse battery\n"},
+			{false, []string{"--mask", "db.password"}, "co...ry (21 chars)\n"},
+			{true, []string{"db.password"}, "co...ry (21 chars)\n"},
+			{true, []string{"--show", "db.password"}, "correct horse battery\n"},
+			{true, []string{"db.*"}, "{\n  \"db.password\": \"co...ry (21 chars)\",\n  \"db.user\": \"... (3 chars)\"\n}\n"},
+			{false, []string{"--mask", "db.password", "db.user"}, "{\n  \"db.password\": \"co...ry (21 chars)\",\n  \"db.user\": \"... (3 chars)\"\n}\n"},
+		} {
+			meta.Terminal = c.terminal
+			out, err := (&GetCommand{Meta: meta}).RunImpl(c.args)
+
+			if out != c.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", c.expected, out, err)
+			}
+		}
+
+		meta.Terminal = true
+		out, err := (&GetallCommand{Meta: meta}).RunImpl([]string{"--show"})
+		expected := "{\n  \"db.password\": \"correct horse battery\",\n  \"db.user\": \"app\"\n}\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		if _, err := (&GetCommand{Meta: meta}).RunImpl([]string{"--mask", "--show", "db.password"}); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
+
+func TestRefuseTerminalOutput(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("db.password", "correct horse battery", gcredstash.VersionNumToStr(1), "alias/credstash", table, nil)
+		meta := Meta{Table: table, KmsKey: "alias/credstash", Driver: driver, Terminal: true, RefuseTerminalOutput: true}
+
+		if _, err := (&GetCommand{Meta: meta}).RunImpl([]string{"--show", "db.password"}); err != ErrTerminalOutput {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrTerminalOutput, err)
+		}
+
+		if _, err := (&DotenvCommand{Meta: meta}).RunImpl([]string{}); err != ErrTerminalOutput {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrTerminalOutput, err)
+		}
+
+		for _, args := range [][]string{{"db.password"}, {"--show", "--force-stdout", "db.password"}} {
+			if _, err := (&GetCommand{Meta: meta}).RunImpl(args); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		meta.Terminal = false
+
+		if out, err := (&GetCommand{Meta: meta}).RunImpl([]string{"db.password"}); out != "correct horse battery\n" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "correct horse battery", out, err)
+		}
+	})
+}
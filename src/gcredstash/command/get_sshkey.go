@@ -0,0 +1,143 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"gcredstash"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type GetSSHKeyCommand struct {
+	Meta
+}
+
+type getSSHKeyArgs struct {
+	credential string
+	version    string
+	output     string
+	agent      bool
+	context    map[string]string
+}
+
+func (c *GetSSHKeyCommand) parseArgs(args []string) (*getSSHKeyArgs, error) {
+	parsed := &getSSHKeyArgs{}
+	newArgs, agent := HasOption(args, "--agent")
+	parsed.agent = agent
+
+	newArgs, output, err := ParseOptionWithValue(newArgs, "-o")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output != "" && parsed.agent {
+		return nil, fmt.Errorf("-o and --agent are mutually exclusive")
+	}
+
+	parsed.output = output
+
+	newArgs, parsed.version, err = ParseVersion(newArgs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(newArgs) < 1 {
+		return nil, fmt.Errorf("too few arguments")
+	}
+
+	parsed.credential = newArgs[0]
+	parsed.context, err = gcredstash.ParseContext(newArgs[1:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// addToSSHAgent loads key into the agent at SSH_AUTH_SOCK with ssh-add,
+// so that the key never touches the disk.
+func addToSSHAgent(key []byte) error {
+	if os.Getenv("SSH_AUTH_SOCK") == "" {
+		return fmt.Errorf("SSH_AUTH_SOCK is not set")
+	}
+
+	cmd := exec.Command("ssh-add", "-")
+	cmd.Stdin = bytes.NewReader(key)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+func (c *GetSSHKeyCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !parsed.agent && parsed.output == "" {
+		if err := c.checkTerminalOutput(force); err != nil {
+			return "", err
+		}
+	}
+
+	key, err := c.Driver.GetSecretBytes(parsed.credential, parsed.version, c.Table, parsed.context)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer gcredstash.Wipe(key)
+
+	if parsed.agent {
+		err = addToSSHAgent(key)
+
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s has been added to ssh-agent\n", parsed.credential), nil
+	}
+
+	if parsed.output != "" {
+		err = gcredstash.WriteSSHKeyFile(parsed.output, key)
+
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s has been written to %s\n", parsed.credential, parsed.output), nil
+	}
+
+	return string(key), nil
+}
+
+func (c *GetSSHKeyCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *GetSSHKeyCommand) Synopsis() string {
+	return "Write an SSH private key to a file or ssh-agent"
+}
+
+func (c *GetSSHKeyCommand) Help() string {
+	helpText := `
+usage: gcredstash get-sshkey [-v VERSION] [-o FILE | --agent | --force-stdout] credential [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -95,7 +95,7 @@ func TestGetCommandWithWildcard(t *testing.T) {
 
 	mddb.EXPECT().Scan(&dynamodb.ScanInput{
 		TableName:                aws.String(table),
-		ProjectionExpression:     aws.String("#name,version"),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
 		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
 	}).Return(&dynamodb.ScanOutput{
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
@@ -195,6 +195,7 @@ func TestGetCommandWithTrailingNewline(t *testing.T) {
 
 	args := []string{name}
 	os.Setenv("GCREDSTASH_GET_TRAILING_NEWLINE", "1")
+	defer os.Unsetenv("GCREDSTASH_GET_TRAILING_NEWLINE")
 	out, err := cmd.RunImpl(args)
 	expected := "test.value"
 
@@ -453,6 +454,7 @@ func TestGetCommandWithErrOutEnv(t *testing.T) {
 
 	args := []string{name}
 	os.Setenv("GCREDSTASH_GET_ERROUT", tmpfile.Name())
+	defer os.Unsetenv("GCREDSTASH_GET_ERROUT")
 	_, err := cmd.RunImpl(args)
 	expectedError := "Item {'name': 'test.key'} couldn't be found."
 	expectedErrOut := regexp.MustCompile(`^error: gcredstash get \[test\.key\]: Item {'name': 'test\.key'} couldn't be found\.\n$`)
@@ -471,3 +473,130 @@ func TestGetCommandWithErrOutEnv(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", expectedErrOut, string(errOut))
 	}
 }
+
+func TestGetCommandWithMultipleCredentials(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("db.pass", "p4ss", "0000000000000000001", "alias/credstash", table, map[string]string{"app": "web"})
+		driver.PutSecret("api.key", "k3y", "0000000000000000001", "alias/credstash", table, map[string]string{"app": "web"})
+
+		cmd := &GetCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"db.pass", "api.key", "app=web"}, "{\n  \"api.key\": \"k3y\",\n  \"db.pass\": \"p4ss\"\n}\n"},
+			{[]string{"--raw", "db.pass", "api.key", "app=web"}, "p4ss\nk3y\n"},
+		} {
+			out, err := cmd.RunImpl(tc.args)
+
+			if err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			if out != tc.expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, out)
+			}
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"db.pass", "mail.token", "app=web"}, "Item {'name': 'mail.token'} couldn't be found."},
+			{[]string{"-v", "1", "db.pass", "api.key"}, "-v cannot be used with more than one credential"},
+			{[]string{"db.*", "api.key"}, "wildcards cannot be used with more than one credential"},
+		} {
+			_, err := cmd.RunImpl(tc.args)
+
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+			}
+		}
+	})
+}
+
+func TestGetCommandWithRawAndNoline(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("foo.baz", "200\n", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &GetCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"--noline", "foo.bar"}, "100"},
+			{[]string{"--raw", "foo.bar"}, "100"},
+			{[]string{"--raw", "foo.baz"}, "200\n"},
+			{[]string{"--raw", "foo.*"}, "100\n200\n\n"},
+			{[]string{"--raw", "-n", "foo.bar", "foo.baz"}, "100\n200\n"},
+			{[]string{"-n", "foo.b*r"}, "{\n  \"foo.bar\": \"100\"\n}"},
+		} {
+			out, err := cmd.RunImpl(tc.args)
+
+			if err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			if out != tc.expected {
+				t.Errorf("\n%v\nexpected: %q\ngot: %q\n", tc.args, tc.expected, out)
+			}
+		}
+	})
+}
+
+func TestGetCommandWithField(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("db.config", `{"password":"s3cr3t","port":5432}`, "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("db.url", "postgres://db", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &GetCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"--field", ".password", "db.config"}, "s3cr3t\n"},
+			{[]string{"--field", "port", "-n", "db.config"}, "5432"},
+		} {
+			out, err := cmd.RunImpl(tc.args)
+
+			if out != tc.expected || err != nil {
+				t.Errorf("\n%v\nexpected: %q\ngot: %q %v\n", tc.args, tc.expected, out, err)
+			}
+		}
+
+		for _, args := range [][]string{
+			{"--field", ".user", "db.config"},
+			{"--field", ".password", "db.url"},
+			{"--field", ".password", "db.*"},
+		} {
+			if _, err := cmd.RunImpl(args); err == nil {
+				t.Errorf("\n%v\nexpected: %v\ngot: %v\n", args, "error", err)
+			}
+		}
+	})
+}
@@ -49,6 +49,22 @@ func (c *GetallCommand) getCredentials(names []string, context map[string]string
 }
 
 func (c *GetallCommand) RunImpl(args []string) (string, error) {
+	args, mask := HasOption(args, "--mask")
+	args, show := HasOption(args, "--show")
+	args, force := HasOption(args, "--force-stdout")
+
+	if mask && show {
+		return "", fmt.Errorf("--mask and --show are mutually exclusive")
+	}
+
+	mask = c.maskOutput(mask, show)
+
+	if !mask {
+		if err := c.checkTerminalOutput(force); err != nil {
+			return "", err
+		}
+	}
+
 	context, err := gcredstash.ParseContext(args)
 
 	if err != nil {
@@ -63,6 +79,10 @@ func (c *GetallCommand) RunImpl(args []string) (string, error) {
 
 	creds := c.getCredentials(names, context)
 
+	if mask {
+		maskCredentials(creds)
+	}
+
 	return gcredstash.MapToJson(creds) + "\n", nil
 }
 
@@ -71,7 +91,7 @@ func (c *GetallCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	fmt.Print(out)
@@ -85,7 +105,7 @@ func (c *GetallCommand) Synopsis() string {
 
 func (c *GetallCommand) Help() string {
 	helpText := `
-usage: gcredstash getall [context [context ...]]
+usage: gcredstash getall [--mask|--show] [--force-stdout] [context [context ...]]
 `
 	return strings.TrimSpace(helpText)
 }
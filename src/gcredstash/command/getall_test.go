@@ -32,7 +32,7 @@ func TestGetallCommand(t *testing.T) {
 
 	mddb.EXPECT().Scan(&dynamodb.ScanInput{
 		TableName:                aws.String(table),
-		ProjectionExpression:     aws.String("#name,version"),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
 		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
 	}).Return(&dynamodb.ScanOutput{
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
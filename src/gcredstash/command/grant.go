@@ -0,0 +1,76 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type GrantCommand struct {
+	Meta
+}
+
+func (c *GrantCommand) parseArgs(args []string) (string, bool, bool, error) {
+	argsWithoutR, read := HasOption(args, "--read")
+	newArgs, write := HasOption(argsWithoutR, "--write")
+
+	if len(newArgs) < 1 {
+		return "", false, false, fmt.Errorf("too few arguments")
+	}
+
+	if len(newArgs) > 1 {
+		return "", false, false, fmt.Errorf("too many arguments")
+	}
+
+	if !read && !write {
+		read = true
+	}
+
+	principal := newArgs[0]
+
+	return principal, read, write, nil
+}
+
+func (c *GrantCommand) RunImpl(args []string) (string, error) {
+	principal, read, write, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	operations := gcredstash.GrantOperations(read, write)
+	grantId, err := c.Driver.CreateGrant(principal, operations, c.KmsKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	out := fmt.Sprintf("grant %s has been created for %s (%s)\n", grantId, principal, strings.Join(operations, ", "))
+
+	return out, nil
+}
+
+func (c *GrantCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *GrantCommand) Synopsis() string {
+	return "Grant a principal access to the KMS key"
+}
+
+func (c *GrantCommand) Help() string {
+	helpText := `
+usage: gcredstash grant [--read] [--write] principal_arn
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"testing"
+)
+
+func TestGrantCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	kmsKey := "alias/credstash"
+	keyArn := "arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4"
+	principal := "arn:aws:iam::123456789012:role/app"
+
+	mkms.EXPECT().DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(kmsKey),
+	}).Return(&kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{Arn: aws.String(keyArn)},
+	}, nil)
+
+	mkms.EXPECT().CreateGrant(&kms.CreateGrantInput{
+		KeyId:            aws.String(keyArn),
+		GranteePrincipal: aws.String(principal),
+		Operations:       []*string{aws.String("Decrypt")},
+	}).Return(&kms.CreateGrantOutput{
+		GrantId: aws.String("grant-id"),
+	}, nil)
+
+	cmd := &GrantCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: kmsKey,
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	args := []string{principal, "--read"}
+	out, err := cmd.RunImpl(args)
+	expected := "grant grant-id has been created for arn:aws:iam::123456789012:role/app (Decrypt)\n"
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
+
+func TestGrantCommandWithReadWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	kmsKey := "alias/credstash"
+	keyArn := "arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4"
+	principal := "arn:aws:iam::123456789012:role/deployer"
+
+	mkms.EXPECT().DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(kmsKey),
+	}).Return(&kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{Arn: aws.String(keyArn)},
+	}, nil)
+
+	mkms.EXPECT().CreateGrant(&kms.CreateGrantInput{
+		KeyId:            aws.String(keyArn),
+		GranteePrincipal: aws.String(principal),
+		Operations:       []*string{aws.String("Decrypt"), aws.String("GenerateDataKey")},
+	}).Return(&kms.CreateGrantOutput{
+		GrantId: aws.String("grant-id"),
+	}, nil)
+
+	cmd := &GrantCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: kmsKey,
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	args := []string{"--read", "--write", principal}
+	out, err := cmd.RunImpl(args)
+	expected := "grant grant-id has been created for arn:aws:iam::123456789012:role/deployer (Decrypt, GenerateDataKey)\n"
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type K8sSecretCommand struct {
+	Meta
+}
+
+type k8sSecretArgs struct {
+	name           string
+	namespace      string
+	hashAnnotation bool
+	pattern        string
+	context        map[string]string
+}
+
+func (c *K8sSecretCommand) parseArgs(args []string) (*k8sSecretArgs, error) {
+	parsed := &k8sSecretArgs{}
+	newArgs, name, err := ParseOptionWithValue(args, "--name")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, parsed.namespace, err = ParseOptionWithValue(newArgs, "--namespace")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, parsed.hashAnnotation = HasOption(newArgs, "--hash-annotation")
+
+	if name == "" {
+		return nil, fmt.Errorf("--name is required")
+	}
+
+	if len(newArgs) < 1 {
+		return nil, fmt.Errorf("too few arguments")
+	}
+
+	parsed.name = name
+	parsed.pattern = newArgs[0]
+	parsed.context, err = gcredstash.ParseContext(newArgs[1:])
+
+	return parsed, err
+}
+
+func (c *K8sSecretCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.checkTerminalOutput(force); err != nil {
+		return "", err
+	}
+
+	creds, err := c.Driver.GetSecretsByPattern(parsed.pattern, c.Table, parsed.context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return gcredstash.K8sSecretManifest(parsed.name, parsed.namespace, creds, parsed.hashAnnotation)
+}
+
+func (c *K8sSecretCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *K8sSecretCommand) Synopsis() string {
+	return "Render credentials as a Kubernetes Secret manifest"
+}
+
+func (c *K8sSecretCommand) Help() string {
+	helpText := `
+usage: gcredstash k8s-secret --name NAME [--namespace NAMESPACE] [--hash-annotation] [--force-stdout] pattern [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
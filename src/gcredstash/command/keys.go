@@ -0,0 +1,114 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type KeysCommand struct {
+	Meta
+}
+
+func (c *KeysCommand) format(info *gcredstash.KeyInfo) string {
+	lines := []string{
+		fmt.Sprintf("Key:      %s", info.Alias),
+		fmt.Sprintf("ARN:      %s", *info.Metadata.Arn),
+	}
+
+	if info.Metadata.KeyState != nil {
+		lines = append(lines, fmt.Sprintf("State:    %s", *info.Metadata.KeyState))
+	}
+
+	if info.RotationErr != nil {
+		lines = append(lines, fmt.Sprintf("Rotation: unknown (%s)", info.RotationErr.Error()))
+	} else if info.RotationEnabled {
+		lines = append(lines, "Rotation: enabled")
+	} else {
+		lines = append(lines, "Rotation: disabled")
+	}
+
+	if info.GrantsErr != nil {
+		lines = append(lines, fmt.Sprintf("Grants:   unknown (%s)", info.GrantsErr.Error()))
+	} else if len(info.Grants) == 0 {
+		lines = append(lines, "Grants:   none")
+	} else {
+		lines = append(lines, "Grants:")
+
+		for _, grant := range info.Grants {
+			operations := []string{}
+
+			for _, op := range grant.Operations {
+				operations = append(operations, *op)
+			}
+
+			grantee := ""
+
+			if grant.GranteePrincipal != nil {
+				grantee = *grant.GranteePrincipal
+			}
+
+			lines = append(lines, fmt.Sprintf("  %s %s (%s)", *grant.GrantId, grantee, strings.Join(operations, ", ")))
+		}
+	}
+
+	lines = append(lines, "GenerateDataKey: "+checkResult(info.GenerateErr))
+	lines = append(lines, "Decrypt:         "+checkResult(info.DecryptErr))
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func checkResult(err error) string {
+	if err != nil {
+		return "NG (" + err.Error() + ")"
+	}
+
+	return "OK"
+}
+
+func (c *KeysCommand) RunImpl(args []string) (string, error) {
+	context, err := gcredstash.ParseContext(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	info, err := c.Driver.InspectKey(c.KmsKey, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	out := c.format(info)
+
+	if !info.IsUsable() {
+		return out, fmt.Errorf("KMS key(%s) cannot be used by the current caller", c.KmsKey)
+	}
+
+	return out, nil
+}
+
+func (c *KeysCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *KeysCommand) Synopsis() string {
+	return "Inspect the KMS key and check access to it"
+}
+
+func (c *KeysCommand) Help() string {
+	helpText := `
+usage: gcredstash keys [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"errors"
+	"gcredstash"
+	. "gcredstash/command"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"testing"
+)
+
+func TestKeysCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	kmsKey := "alias/credstash"
+	keyArn := "arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4"
+	plaintext := []byte("1234567890123456789012345678901212345678901234567890123456789012")
+
+	mkms.EXPECT().DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(kmsKey),
+	}).Return(&kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{Arn: aws.String(keyArn), KeyState: aws.String("Enabled")},
+	}, nil)
+
+	mkms.EXPECT().GetKeyRotationStatus(&kms.GetKeyRotationStatusInput{
+		KeyId: aws.String(keyArn),
+	}).Return(&kms.GetKeyRotationStatusOutput{
+		KeyRotationEnabled: aws.Bool(true),
+	}, nil)
+
+	mkms.EXPECT().ListGrantsPages(&kms.ListGrantsInput{
+		KeyId: aws.String(keyArn),
+	}, gomock.Any()).Do(func(params *kms.ListGrantsInput, fn func(*kms.ListGrantsResponse, bool) bool) {
+		fn(&kms.ListGrantsResponse{
+			Grants: []*kms.GrantListEntry{
+				{
+					GrantId:          aws.String("grant-id"),
+					GranteePrincipal: aws.String("arn:aws:iam::123456789012:role/app"),
+					Operations:       []*string{aws.String("Decrypt")},
+				},
+			},
+		}, true)
+	}).Return(nil)
+
+	mkms.EXPECT().GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:         aws.String(kmsKey),
+		NumberOfBytes: aws.Int64(64),
+	}).Return(&kms.GenerateDataKeyOutput{
+		CiphertextBlob: []byte("blobData"),
+		Plaintext:      plaintext,
+	}, nil)
+
+	mkms.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("blobData"),
+	}).Return(nil, errors.New("AccessDeniedException: not authorized"))
+
+	cmd := &KeysCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: kmsKey,
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	out, err := cmd.RunImpl([]string{})
+	expectedOut := `Key:      alias/credstash
+ARN:      arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4
+State:    Enabled
+Rotation: enabled
+Grants:
+  grant-id arn:aws:iam::123456789012:role/app (Decrypt)
+GenerateDataKey: OK
+Decrypt:         NG (kms:Decrypt was denied on KMS key(alias/credstash): the key policy, or the caller's IAM policy where the key policy defers to IAM, must allow it)
+`
+	expectedErr := "KMS key(alias/credstash) cannot be used by the current caller"
+
+	if expectedOut != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedOut, out)
+	}
+
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedErr, err)
+	}
+}
@@ -1,11 +1,13 @@
 package command
 
 import (
+	"bytes"
 	"fmt"
 	"gcredstash"
 	"os"
 	"sort"
 	"strings"
+	"text/tabwriter"
 )
 
 type ListCommand struct {
@@ -24,19 +26,150 @@ func (c *ListCommand) getLines(items map[*string]*string) []string {
 	return lines
 }
 
+func (c *ListCommand) formatTable(infos []*gcredstash.SecretInfo) string {
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tCREATED_AT\tCREATED_BY\tCOMMENT")
+
+	orDash := func(str string) string {
+		if str == "" {
+			return "-"
+		}
+
+		return str
+	}
+
+	for _, info := range infos {
+		versionNum := gcredstash.Atoi(info.Version)
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", info.Name, versionNum, orDash(info.CreatedAt), orDash(info.CreatedBy), orDash(info.Comment))
+	}
+
+	w.Flush()
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
 func (c *ListCommand) RunImpl(args []string) (string, error) {
-	if len(args) > 0 {
+	newArgs, verbose := HasOption(args, "-l")
+	newArgs, deleted := HasOption(newArgs, "--deleted")
+	newArgs, sortKey, err := ParseOptionWithValue(newArgs, "--sort")
+
+	if err != nil {
+		return "", err
+	}
+
+	newArgs, tagArgs, err := ParseOptionWithValues(newArgs, "--tag")
+
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := gcredstash.ParseTags(tagArgs)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(newArgs) > 1 {
 		return "", fmt.Errorf("too many arguments")
 	}
 
-	items, err := c.Driver.ListSecrets(c.Table)
+	prefix := ""
+
+	if len(newArgs) == 1 {
+		prefix = newArgs[0]
+	}
+
+	if deleted && (verbose || sortKey != "" || len(tags) > 0) {
+		return "", fmt.Errorf("--deleted cannot be combined with -l, --sort or --tag")
+	}
+
+	var tagged map[string]bool
+
+	if len(tags) > 0 {
+		tagged, err = c.Driver.TaggedNames(c.Table, prefix, tags)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if deleted {
+		items, err := c.Driver.DeletedSecrets(c.Table, prefix)
+
+		if err != nil {
+			return "", err
+		}
+
+		lines := c.getLines(items)
+		sort.Strings(lines)
+
+		return strings.Join(lines, "\n"), nil
+	}
+
+	if !verbose && sortKey == "" {
+		items, err := c.Driver.ListSecretsWithPrefix(c.Table, prefix)
+
+		if err != nil {
+			return "", err
+		}
+
+		if tagged != nil {
+			for name := range items {
+				if !tagged[*name] {
+					delete(items, name)
+				}
+			}
+		}
+
+		lines := c.getLines(items)
+		sort.Strings(lines)
+
+		return strings.Join(lines, "\n"), nil
+	}
+
+	infos, err := c.Driver.ListSecretsWithMetadata(c.Table, prefix)
+
+	if err != nil {
+		return "", err
+	}
+
+	if tagged != nil {
+		matched := []*gcredstash.SecretInfo{}
+
+		for _, info := range infos {
+			if tagged[info.Name] {
+				matched = append(matched, info)
+			}
+		}
+
+		infos = matched
+	}
+
+	err = gcredstash.SortSecretInfos(infos, sortKey)
 
 	if err != nil {
 		return "", err
 	}
 
-	lines := c.getLines(items)
-	sort.Strings(lines)
+	if verbose {
+		return c.formatTable(infos), nil
+	}
+
+	maxNameLen := 0
+
+	for _, info := range infos {
+		if len(info.Name) > maxNameLen {
+			maxNameLen = len(info.Name)
+		}
+	}
+
+	lines := []string{}
+
+	for _, info := range infos {
+		versionNum := gcredstash.Atoi(info.Version)
+		lines = append(lines, fmt.Sprintf("%-*s -- version: %d", maxNameLen, info.Name, versionNum))
+	}
 
 	return strings.Join(lines, "\n"), nil
 }
@@ -46,7 +179,7 @@ func (c *ListCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	fmt.Println(out)
@@ -60,7 +193,8 @@ func (c *ListCommand) Synopsis() string {
 
 func (c *ListCommand) Help() string {
 	helpText := `
-usage: gcredstash list
+usage: gcredstash list [-l] [--sort name|version|date] [--tag KEY=VALUE ...] [prefix]
+       gcredstash list --deleted [prefix]
 `
 
 	return strings.TrimSpace(helpText)
@@ -33,7 +33,7 @@ func TestListCommand(t *testing.T) {
 
 	mddb.EXPECT().Scan(&dynamodb.ScanInput{
 		TableName:                aws.String(table),
-		ProjectionExpression:     aws.String("#name,version"),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
 		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
 	}).Return(&dynamodb.ScanOutput{
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
@@ -59,3 +59,120 @@ func TestListCommand(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
 	}
 }
+
+func TestListCommandVerbose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	table := "credential-store"
+
+	mddb.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName:                aws.String(table),
+		ProjectionExpression:     aws.String("#name,version,created_at,created_by,comment,expires_at,rotate_every,tags,deleted_at"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	}).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			testutils.MapToItem(map[string]string{
+				"name":       "foo.bar",
+				"version":    "0000000000000000002",
+				"created_at": "2026-10-14T09:12:03Z",
+				"created_by": "alice",
+				"comment":    "rotated",
+			}),
+			testutils.MapToItem(map[string]string{
+				"name":    "foo.baz",
+				"version": "0000000000000000001",
+			}),
+		},
+	}, nil)
+
+	cmd := &ListCommand{
+		Meta: Meta{
+			Table:  table,
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	out, err := cmd.RunImpl([]string{"-l", "--sort", "date"})
+
+	expected := `NAME     VERSION  CREATED_AT            CREATED_BY  COMMENT
+foo.baz  1        -                     -           -
+foo.bar  2        2026-10-14T09:12:03Z  alice       rotated`
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
+
+func TestListCommandWithPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	table := "credential-store"
+
+	mddb.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName:                aws.String(table),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
+		FilterExpression:         aws.String("begins_with(#name, :prefix)"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":prefix": {S: aws.String("app.")},
+		},
+	}).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			testutils.MapToItem(map[string]string{"name": "app.key", "version": "0000000000000000001"}),
+		},
+		LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+			"name":    {S: aws.String("app.key")},
+			"version": {S: aws.String("0000000000000000001")},
+		},
+	}, nil)
+
+	mddb.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName:                aws.String(table),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
+		FilterExpression:         aws.String("begins_with(#name, :prefix)"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":prefix": {S: aws.String("app.")},
+		},
+		ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+			"name":    {S: aws.String("app.key")},
+			"version": {S: aws.String("0000000000000000001")},
+		},
+	}).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			testutils.MapToItem(map[string]string{"name": "app.secret", "version": "0000000000000000003"}),
+		},
+	}, nil)
+
+	cmd := &ListCommand{
+		Meta: Meta{
+			Table:  table,
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	out, err := cmd.RunImpl([]string{"app."})
+
+	expected := `app.key    -- version: 1
+app.secret -- version: 3`
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"sort"
+	"strings"
+)
+
+type LockCommand struct {
+	Meta
+}
+
+func (c *LockCommand) parseArgs(args []string) (string, string, error) {
+	newArgs, lockFile, err := ParseOptionWithValue(args, "-o")
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if lockFile == "" {
+		lockFile = gcredstash.DEFAULT_LOCK_FILE
+	}
+
+	if len(newArgs) > 1 {
+		return "", "", fmt.Errorf("too many arguments")
+	}
+
+	pattern := "*"
+
+	if len(newArgs) == 1 {
+		pattern = newArgs[0]
+	}
+
+	return pattern, lockFile, nil
+}
+
+func (c *LockCommand) RunImpl(args []string) (string, error) {
+	pattern, lockFile, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := c.Driver.LockVersions(pattern, c.Table)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no credentials match %s", pattern)
+	}
+
+	err = gcredstash.WriteLockFile(lockFile, versions)
+
+	if err != nil {
+		return "", err
+	}
+
+	names := []string{}
+
+	for name, _ := range versions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	out := ""
+
+	for _, name := range names {
+		out += fmt.Sprintf("%s -- version: %d has been locked in %s\n", name, gcredstash.Atoi(versions[name]), lockFile)
+	}
+
+	return out, nil
+}
+
+func (c *LockCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *LockCommand) Synopsis() string {
+	return "Pin the latest versions of credentials in a lock file"
+}
+
+func (c *LockCommand) Help() string {
+	helpText := `
+usage: gcredstash lock [-o FILE] [pattern]
+`
+	return strings.TrimSpace(helpText)
+}
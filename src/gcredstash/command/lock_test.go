@@ -0,0 +1,68 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestLockCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("foo.bar", "200", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("foo.baz", "300", "0000000000000000001", "alias/credstash", table, nil)
+
+		meta := Meta{
+			Table:  table,
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		testutils.TempFile("", func(lock *os.File) {
+			out, err := (&LockCommand{Meta: meta}).RunImpl([]string{"-o", lock.Name(), "foo.*"})
+			expected := "foo.bar -- version: 2 has been locked in " + lock.Name() + "\n" +
+				"foo.baz -- version: 1 has been locked in " + lock.Name() + "\n"
+
+			if err != nil || out != expected {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+			}
+
+			driver.PutSecret("foo.bar", "400", "0000000000000000003", "alias/credstash", table, nil)
+			driver.PutSecret("foo.qux", "500", "0000000000000000001", "alias/credstash", table, nil)
+			cmd := &GetCommand{Meta: meta}
+
+			for _, tc := range []struct {
+				args     []string
+				expected string
+			}{
+				{[]string{"--lock-file", lock.Name(), "foo.bar"}, "200\n"},
+				{[]string{"--locked", "--lock-file", lock.Name(), "--raw", "foo.baz", "foo.bar"}, "300\n200\n"},
+				{[]string{"--lock-file", lock.Name()}, "{\n  \"foo.bar\": \"200\",\n  \"foo.baz\": \"300\"\n}\n"},
+			} {
+				out, err := cmd.RunImpl(tc.args)
+
+				if err != nil || out != tc.expected {
+					t.Errorf("\n%v\nexpected: %q\ngot: %q %v\n", tc.args, tc.expected, out, err)
+				}
+			}
+
+			for _, tc := range []struct {
+				args     []string
+				expected string
+			}{
+				{[]string{"--lock-file", lock.Name(), "foo.qux"}, "foo.qux is not in " + lock.Name()},
+				{[]string{"--locked", "-v", "1", "foo.bar"}, "-v cannot be used with --locked"},
+				{[]string{"--locked", "foo.*"}, "wildcards cannot be used with --locked"},
+			} {
+				_, err := cmd.RunImpl(tc.args)
+
+				if err == nil || err.Error() != tc.expected {
+					t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+				}
+			}
+		})
+	})
+}
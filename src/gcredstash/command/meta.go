@@ -1,6 +1,8 @@
 package command
 
 import (
+	"errors"
+	"fmt"
 	"gcredstash"
 	"github.com/mitchellh/cli"
 )
@@ -12,4 +14,39 @@ type Meta struct {
 	KmsKey  string
 	Version string
 	Driver  *gcredstash.Driver
+
+	// Terminal reports that stdout is an interactive terminal, where get
+	// and getall mask values unless --show is given.
+	Terminal bool
+	// RefuseTerminalOutput makes the commands that print plaintext refuse
+	// to when Terminal is set, unless --force-stdout is given.
+	RefuseTerminalOutput bool
+
+	// OpenStore returns a named store from the config file.
+	OpenStore func(name string) (*gcredstash.Store, error)
+}
+
+var ErrTerminalOutput = errors.New("refusing to print plaintext to a terminal (refuse_terminal_output is set): redirect the output, or use --force-stdout")
+
+// checkTerminalOutput returns ErrTerminalOutput when plaintext is about to
+// be printed to a terminal that RefuseTerminalOutput keeps it off.
+func (m *Meta) checkTerminalOutput(force bool) error {
+	if m.Terminal && m.RefuseTerminalOutput && !force {
+		return ErrTerminalOutput
+	}
+
+	return nil
+}
+
+// Store returns the named store, or the current one when name is empty.
+func (m *Meta) Store(name string) (*gcredstash.Store, error) {
+	if name == "" {
+		return &gcredstash.Store{Driver: m.Driver, Table: m.Table, KmsKey: m.KmsKey}, nil
+	}
+
+	if m.OpenStore == nil {
+		return nil, fmt.Errorf("unknown store: %s", name)
+	}
+
+	return m.OpenStore(name)
 }
@@ -0,0 +1,380 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type MigrateToSecretsManagerCommand struct {
+	Meta
+}
+
+type MigrateFromSecretsManagerCommand struct {
+	Meta
+}
+
+func parseMigrateArgs(args []string) (string, map[string]string, error) {
+	newArgs, pattern, err := ParseOptionWithValue(args, "-p")
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	return pattern, context, err
+}
+
+func (c *MigrateToSecretsManagerCommand) RunImpl(args []string) (string, error) {
+	pattern, context, err := parseMigrateArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	migrated, err := c.Driver.MigrateToSecretsManager(pattern, c.Table, context)
+	out := ""
+
+	for _, line := range migrated {
+		out += line + " has been copied to Secrets Manager\n"
+	}
+
+	return out, err
+}
+
+func (c *MigrateToSecretsManagerCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MigrateToSecretsManagerCommand) Synopsis() string {
+	return "Copy credentials to AWS Secrets Manager"
+}
+
+func (c *MigrateToSecretsManagerCommand) Help() string {
+	helpText := `
+usage: gcredstash migrate to-secretsmanager [-p PATTERN] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *MigrateFromSecretsManagerCommand) RunImpl(args []string) (string, error) {
+	pattern, context, err := parseMigrateArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	migrated, err := c.Driver.MigrateFromSecretsManager(pattern, c.KmsKey, c.Table, context)
+	out := ""
+
+	for _, line := range migrated {
+		out += line + " has been copied from Secrets Manager\n"
+	}
+
+	return out, err
+}
+
+func (c *MigrateFromSecretsManagerCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MigrateFromSecretsManagerCommand) Synopsis() string {
+	return "Copy credentials from AWS Secrets Manager"
+}
+
+func (c *MigrateFromSecretsManagerCommand) Help() string {
+	helpText := `
+usage: gcredstash migrate from-secretsmanager [-p PATTERN] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+type MigrateToSsmCommand struct {
+	Meta
+}
+
+type MigrateFromSsmCommand struct {
+	Meta
+}
+
+func parseSsmMigrateArgs(args []string) (string, *gcredstash.SsmMapping, map[string]string, error) {
+	argsWithoutP, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if prefix == "" {
+		prefix = "/"
+	}
+
+	if !strings.HasPrefix(prefix, "/") {
+		return "", nil, nil, fmt.Errorf("prefix must start with '/': %s", prefix)
+	}
+
+	newArgs, nested := HasOption(argsWithoutP, "--nested")
+	pattern, context, err := parseMigrateArgs(newArgs)
+
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	mapping := &gcredstash.SsmMapping{Prefix: prefix, Nested: nested}
+
+	return pattern, mapping, context, nil
+}
+
+func (c *MigrateToSsmCommand) RunImpl(args []string) (string, error) {
+	pattern, mapping, context, err := parseSsmMigrateArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	migrated, err := c.Driver.MigrateToSsm(pattern, mapping, c.Table, context)
+	out := ""
+
+	for _, line := range migrated {
+		out += line + " has been copied to Parameter Store\n"
+	}
+
+	return out, err
+}
+
+func (c *MigrateToSsmCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MigrateToSsmCommand) Synopsis() string {
+	return "Copy credentials to SSM Parameter Store"
+}
+
+func (c *MigrateToSsmCommand) Help() string {
+	helpText := `
+usage: gcredstash migrate to-ssm [--prefix PREFIX] [--nested] [-p PATTERN] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *MigrateFromSsmCommand) RunImpl(args []string) (string, error) {
+	pattern, mapping, context, err := parseSsmMigrateArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	migrated, err := c.Driver.MigrateFromSsm(pattern, mapping, c.KmsKey, c.Table, context)
+	out := ""
+
+	for _, line := range migrated {
+		out += line + " has been copied from Parameter Store\n"
+	}
+
+	return out, err
+}
+
+func (c *MigrateFromSsmCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MigrateFromSsmCommand) Synopsis() string {
+	return "Copy credentials from SSM Parameter Store"
+}
+
+func (c *MigrateFromSsmCommand) Help() string {
+	helpText := `
+usage: gcredstash migrate from-ssm [--prefix PREFIX] [--nested] [-p PATTERN] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+type MigrateToVaultCommand struct {
+	Meta
+}
+
+type MigrateFromVaultCommand struct {
+	Meta
+}
+
+func parseVaultMigrateArgs(args []string) (string, *gcredstash.VaultClient, *gcredstash.VaultMapping, map[string]string, error) {
+	newArgs, address, err := ParseOptionWithValue(args, "--address")
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	newArgs, mount, err := ParseOptionWithValue(newArgs, "--mount")
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	newArgs, kvVersion, err := ParseOptionWithValue(newArgs, "--kv-version")
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	if kvVersion == "" {
+		kvVersion = "2"
+	}
+
+	newArgs, prefix, err := ParseOptionWithValue(newArgs, "--prefix")
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	newArgs, key, err := ParseOptionWithValue(newArgs, "--key")
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	pattern, context, err := parseMigrateArgs(newArgs)
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	version, err := strconv.Atoi(kvVersion)
+
+	if err != nil {
+		return "", nil, nil, nil, fmt.Errorf("--kv-version must be 1 or 2: %s", kvVersion)
+	}
+
+	vault, err := gcredstash.NewVaultClient(address, mount, version)
+
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	mapping := &gcredstash.VaultMapping{Prefix: prefix, Key: key}
+
+	return pattern, vault, mapping, context, nil
+}
+
+func (c *MigrateToVaultCommand) RunImpl(args []string) (string, error) {
+	pattern, vault, mapping, context, err := parseVaultMigrateArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	migrated, err := c.Driver.MigrateToVault(pattern, vault, mapping, c.Table, context)
+	out := ""
+
+	for _, line := range migrated {
+		out += line + " has been copied to Vault\n"
+	}
+
+	return out, err
+}
+
+func (c *MigrateToVaultCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MigrateToVaultCommand) Synopsis() string {
+	return "Copy credentials to a HashiCorp Vault KV mount"
+}
+
+func (c *MigrateToVaultCommand) Help() string {
+	helpText := `
+usage: gcredstash migrate to-vault [--address URL] [--mount MOUNT] [--kv-version 1|2] [--prefix PATH] [--key KEY] [-p PATTERN] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *MigrateFromVaultCommand) RunImpl(args []string) (string, error) {
+	pattern, vault, mapping, context, err := parseVaultMigrateArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	migrated, err := c.Driver.MigrateFromVault(pattern, vault, mapping, c.KmsKey, c.Table, context)
+	out := ""
+
+	for _, line := range migrated {
+		out += line + " has been copied from Vault\n"
+	}
+
+	return out, err
+}
+
+func (c *MigrateFromVaultCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MigrateFromVaultCommand) Synopsis() string {
+	return "Copy credentials from a HashiCorp Vault KV mount"
+}
+
+func (c *MigrateFromVaultCommand) Help() string {
+	helpText := `
+usage: gcredstash migrate from-vault [--address URL] [--mount MOUNT] [--kv-version 1|2] [--prefix PATH] [--key KEY] [-p PATTERN] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
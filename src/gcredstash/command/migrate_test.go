@@ -0,0 +1,257 @@
+package command
+
+import (
+	"errors"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"testing"
+)
+
+func TestMigrateToSecretsManagerCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	msm := mockaws.NewMockSecretsManagerAPI(ctrl)
+
+	name := "test.key"
+	version := "0000000000000000002"
+	table := "credential-store"
+
+	item := map[string]string{
+		"contents": "eBtO1lgLxIe6Yw==",
+		"hmac":     "b23a3efafd4795e50ca87afd7d764f263e9ae456499a8d40eece70a63ed5da27",
+		"key":      "CiDY1vsR456LEdoL3+0p+PrTCleoqi/sutbDfJZNiUSpphLLAQEBAQB42Nb7EeOeixHaC9/tKfj60wpXqKov7LrWw3yWTYlEqaYAAACiMIGfBgkqhkiG9w0BBwaggZEwgY4CAQAwgYgGCSqGSIb3DQEHATAeBglghkgBZQMEAS4wEQQMy/Oc2pOJsR0y9nbhAgEQgFsHECqku7QZiRjLmmeGyhcsgWdWvi7Op3luJu4soi5sP0pqcsjTrBJqOXHLazgyBS9wb6deP8zpXa/41WT0ZpNY9at4gw7+XRtbz8f4Rlh8WnyFnK5RZ7i0mOlD",
+		"name":     name,
+		"version":  version,
+	}
+
+	mddb.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName:                aws.String(table),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	}).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
+	}, nil)
+
+	mddb.EXPECT().GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"name":    {S: aws.String(name)},
+			"version": {S: aws.String(version)},
+		},
+	}).Return(&dynamodb.GetItemOutput{
+		Item: testutils.MapToItem(item),
+	}, nil)
+
+	mkms.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte(gcredstash.B64Decode(item["key"])),
+	}).Return(&kms.DecryptOutput{
+		Plaintext: []byte{188, 163, 172, 238, 203, 68, 210, 84, 58, 152, 145, 235, 42, 23, 204, 164, 62, 139, 115, 220, 63, 85, 98, 228, 48, 229, 82, 62, 72, 86, 255, 162, 53, 75, 177, 91, 204, 232, 206, 127, 200, 23, 43, 148, 246, 221, 240, 247, 94, 72, 147, 211, 60, 139, 50, 150, 18, 100, 28, 24, 240, 2, 199, 121},
+	}, nil)
+
+	msm.EXPECT().PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:           aws.String(name),
+		SecretString:       aws.String("test.value"),
+		ClientRequestToken: aws.String("gcredstash-version-" + version),
+	}).Return(&secretsmanager.PutSecretValueOutput{}, nil)
+
+	cmd := &MigrateToSecretsManagerCommand{
+		Meta: Meta{
+			Table:  table,
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms, SecretsManager: msm},
+		},
+	}
+
+	args := []string{"-p", "test.*"}
+	out, err := cmd.RunImpl(args)
+	expected := "test.key -- version 2 has been copied to Secrets Manager\n"
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
+
+func TestMigrateFromSecretsManagerCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	msm := mockaws.NewMockSecretsManagerAPI(ctrl)
+
+	name := "test.key"
+	table := "credential-store"
+	kmsKey := "alias/credstash"
+
+	msm.EXPECT().ListSecretsPages(
+		&secretsmanager.ListSecretsInput{},
+		gomock.Any(),
+	).Do(func(params *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) {
+		fn(&secretsmanager.ListSecretsOutput{
+			SecretList: []*secretsmanager.SecretListEntry{
+				{Name: aws.String(name)},
+				{Name: aws.String("other.key")},
+			},
+		}, true)
+	}).Return(nil)
+
+	msm.EXPECT().GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(name),
+	}).Return(&secretsmanager.GetSecretValueOutput{
+		SecretString: aws.String("100"),
+		VersionId:    aws.String("EXAMPLE1-90ab-cdef-fedc-ba987SECRET1"),
+	}, nil)
+
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		Limit:                    aws.Int64(1),
+		ConsistentRead:           aws.Bool(true),
+		ScanIndexForward:         aws.Bool(false),
+		KeyConditionExpression:   aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name": {S: aws.String(name)},
+		},
+		ProjectionExpression: aws.String("version"),
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(0),
+	}, nil)
+
+	mkms.EXPECT().GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:         aws.String(kmsKey),
+		NumberOfBytes: aws.Int64(64),
+	}).Return(&kms.GenerateDataKeyOutput{
+		CiphertextBlob: []byte("blobData"),
+		Plaintext:      []byte{145, 99, 240, 141, 84, 162, 135, 185, 20, 181, 81, 249, 15, 215, 56, 150, 222, 94, 65, 27, 27, 196, 165, 220, 49, 90, 199, 244, 14, 165, 188, 116, 135, 60, 104, 13, 136, 145, 109, 232, 87, 153, 237, 234, 174, 87, 7, 124, 131, 121, 67, 68, 239, 184, 174, 16, 197, 129, 97, 139, 146, 144, 89, 5},
+	}, nil)
+
+	mddb.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item: testutils.MapToItem(map[string]string{
+			"contents": "twnH",
+			"digest":   "SHA256",
+			"hmac":     "01cc6772cf2c889c8c0dae1f0ec3d7659e21103d56cd3436039cf29d18759958",
+			"key":      "YmxvYkRhdGE=",
+			"name":     name,
+			"version":  "0000000000000000001",
+		}),
+		ConditionExpression:      aws.String("attribute_not_exists(#name)"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	}).Return(nil, nil)
+
+	cmd := &MigrateFromSecretsManagerCommand{
+		Meta: Meta{
+			Table:  table,
+			KmsKey: kmsKey,
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms, SecretsManager: msm},
+		},
+	}
+
+	args := []string{"-p", "test.*"}
+	out, err := cmd.RunImpl(args)
+	expected := "test.key -- version 1 has been copied from Secrets Manager\n"
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
+
+func TestMigrateToSsmCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	mssm := mockaws.NewMockSSMAPI(ctrl)
+
+	name := "test.key"
+	table := "credential-store"
+
+	item := map[string]string{
+		"contents": "eBtO1lgLxIe6Yw==",
+		"hmac":     "b23a3efafd4795e50ca87afd7d764f263e9ae456499a8d40eece70a63ed5da27",
+		"key":      "CiDY1vsR456LEdoL3+0p+PrTCleoqi/sutbDfJZNiUSpphLLAQEBAQB42Nb7EeOeixHaC9/tKfj60wpXqKov7LrWw3yWTYlEqaYAAACiMIGfBgkqhkiG9w0BBwaggZEwgY4CAQAwgYgGCSqGSIb3DQEHATAeBglghkgBZQMEAS4wEQQMy/Oc2pOJsR0y9nbhAgEQgFsHECqku7QZiRjLmmeGyhcsgWdWvi7Op3luJu4soi5sP0pqcsjTrBJqOXHLazgyBS9wb6deP8zpXa/41WT0ZpNY9at4gw7+XRtbz8f4Rlh8WnyFnK5RZ7i0mOlD",
+		"name":     name,
+		"version":  "0000000000000000002",
+	}
+
+	mddb.EXPECT().Scan(&dynamodb.ScanInput{
+		TableName:                aws.String(table),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	}).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
+	}, nil)
+
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		Limit:                    aws.Int64(1),
+		ConsistentRead:           aws.Bool(true),
+		ScanIndexForward:         aws.Bool(false),
+		KeyConditionExpression:   aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name": {S: aws.String(name)},
+		},
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(1),
+		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
+	}, nil)
+
+	mkms.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte(gcredstash.B64Decode(item["key"])),
+	}).Return(&kms.DecryptOutput{
+		Plaintext: []byte{188, 163, 172, 238, 203, 68, 210, 84, 58, 152, 145, 235, 42, 23, 204, 164, 62, 139, 115, 220, 63, 85, 98, 228, 48, 229, 82, 62, 72, 86, 255, 162, 53, 75, 177, 91, 204, 232, 206, 127, 200, 23, 43, 148, 246, 221, 240, 247, 94, 72, 147, 211, 60, 139, 50, 150, 18, 100, 28, 24, 240, 2, 199, 121},
+	}, nil)
+
+	mssm.EXPECT().GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String("/app/test/key"),
+		WithDecryption: aws.Bool(true),
+	}).Return(nil, errors.New("ParameterNotFound: "))
+
+	mssm.EXPECT().PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String("/app/test/key"),
+		Value:     aws.String("test.value"),
+		Type:      aws.String("SecureString"),
+		Overwrite: aws.Bool(true),
+	}).Return(&ssm.PutParameterOutput{}, nil)
+
+	cmd := &MigrateToSsmCommand{
+		Meta: Meta{
+			Table:  table,
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms, Ssm: mssm},
+		},
+	}
+
+	args := []string{"--prefix", "/app/", "--nested"}
+	out, err := cmd.RunImpl(args)
+	expected := "test.key -> /app/test/key has been copied to Parameter Store\n"
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != out {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out)
+	}
+}
@@ -0,0 +1,219 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DEFAULT_MOUNT_CACHE_TTL = time.Minute
+
+type MountCommand struct {
+	Meta
+}
+
+type mountArgs struct {
+	dir        string
+	prefix     string
+	cacheTTL   time.Duration
+	allowOther bool
+	context    map[string]string
+}
+
+// MountFS is the content of a `gcredstash mount`: one file per credential
+// whose name starts with Prefix, named after the rest of the name. The
+// names and values are read lazily and kept for the TTL of Cache.
+type MountFS struct {
+	Cache  *gcredstash.SecretCache
+	Prefix string
+
+	mutex  sync.Mutex
+	names  map[string]string
+	listed time.Time
+}
+
+func NewMountFS(cache *gcredstash.SecretCache, prefix string) *MountFS {
+	return &MountFS{Cache: cache, Prefix: prefix}
+}
+
+func (mfs *MountFS) now() time.Time {
+	if mfs.Cache.Driver.Now != nil {
+		return mfs.Cache.Driver.Now()
+	}
+
+	return time.Now()
+}
+
+func (mfs *MountFS) warnf(format string, args ...interface{}) {
+	if logger := mfs.Cache.Driver.Logger; logger != nil {
+		logger.Warnf(format, args...)
+	}
+}
+
+// list returns the credential names by file name, listing them again once
+// the cache TTL has passed. Names that are not valid file names are left
+// out.
+func (mfs *MountFS) list() (map[string]string, error) {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+
+	if mfs.names != nil && mfs.now().Before(mfs.listed.Add(mfs.Cache.TTL)) {
+		return mfs.names, nil
+	}
+
+	items, err := mfs.Cache.Driver.ListSecretsWithPrefix(mfs.Cache.Table, mfs.Prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+
+	for name := range gcredstash.GroupVersions(items) {
+		file := strings.TrimPrefix(name, mfs.Prefix)
+
+		if file == "" || strings.ContainsAny(file, "/\\") || file == "." || file == ".." {
+			mfs.warnf("%s: not mounted, %q is not a valid file name", name, file)
+			continue
+		}
+
+		names[file] = name
+	}
+
+	mfs.names = names
+	mfs.listed = mfs.now()
+
+	return names, nil
+}
+
+// Files returns the sorted file names.
+func (mfs *MountFS) Files() ([]string, error) {
+	names, err := mfs.list()
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+
+	for file := range names {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// Exists reports whether file is one of Files.
+func (mfs *MountFS) Exists(file string) (bool, error) {
+	names, err := mfs.list()
+
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := names[file]
+
+	return ok, nil
+}
+
+// Read returns the latest value of the credential of file. The caller
+// should clear it with gcredstash.Wipe.
+func (mfs *MountFS) Read(file string) ([]byte, error) {
+	names, err := mfs.list()
+
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := names[file]
+
+	if !ok {
+		return nil, &gcredstash.NotFoundError{Name: mfs.Prefix + file}
+	}
+
+	value, err := mfs.Cache.Get(name)
+
+	if err != nil {
+		mfs.warnf("%s: could not read the value: %s", name, err.Error())
+	}
+
+	return value, err
+}
+
+func (c *MountCommand) parseArgs(args []string) (*mountArgs, error) {
+	parsed := &mountArgs{cacheTTL: DEFAULT_MOUNT_CACHE_TTL}
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, cacheTTL, err := ParseOptionWithValue(newArgs, "--cache-ttl")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, parsed.allowOther = HasOption(newArgs, "--allow-other")
+
+	if len(newArgs) < 1 {
+		return nil, fmt.Errorf("too few arguments")
+	}
+
+	if cacheTTL != "" {
+		parsed.cacheTTL, err = gcredstash.ParseAge(cacheTTL)
+
+		if err != nil || parsed.cacheTTL <= 0 {
+			return nil, fmt.Errorf("invalid cache TTL: %s", cacheTTL)
+		}
+	}
+
+	parsed.dir = newArgs[0]
+	parsed.prefix = prefix
+	parsed.context, err = gcredstash.ParseContext(newArgs[1:])
+
+	return parsed, err
+}
+
+func (c *MountCommand) RunImpl(args []string) error {
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	cache := gcredstash.NewSecretCache(c.Driver, c.Table, parsed.context, parsed.cacheTTL)
+	defer cache.Purge()
+
+	fmt.Fprintf(os.Stderr, "mounting credentials on %s; unmount or interrupt to stop\n", parsed.dir)
+
+	return mountSecrets(parsed.dir, NewMountFS(cache, parsed.prefix), parsed.allowOther)
+}
+
+func (c *MountCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *MountCommand) Synopsis() string {
+	return "Mount credentials as read-only files with FUSE"
+}
+
+func (c *MountCommand) Help() string {
+	helpText := `
+usage: gcredstash mount [--prefix PREFIX] [--cache-ttl DURATION] [--allow-other] mountpoint [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,141 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package command
+
+import (
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"context"
+	"errors"
+	"gcredstash"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+type mountRoot struct {
+	mfs *MountFS
+}
+
+type mountDir struct {
+	mfs *MountFS
+}
+
+type mountFile struct {
+	mfs  *MountFS
+	name string
+}
+
+func (root *mountRoot) Root() (fs.Node, error) {
+	return &mountDir{mfs: root.mfs}, nil
+}
+
+func (dir *mountDir) Attr(ctx context.Context, attr *fuse.Attr) error {
+	attr.Inode = 1
+	attr.Mode = os.ModeDir | 0500
+	attr.Uid = uint32(os.Getuid())
+	attr.Gid = uint32(os.Getgid())
+	attr.Valid = dir.mfs.Cache.TTL
+	return nil
+}
+
+func (dir *mountDir) Lookup(ctx context.Context, name string) (fs.Node, error) {
+	ok, err := dir.mfs.Exists(name)
+
+	if err != nil {
+		return nil, fuse.EIO
+	}
+
+	if !ok {
+		return nil, fuse.ENOENT
+	}
+
+	return &mountFile{mfs: dir.mfs, name: name}, nil
+}
+
+func (dir *mountDir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
+	files, err := dir.mfs.Files()
+
+	if err != nil {
+		return nil, fuse.EIO
+	}
+
+	entries := []fuse.Dirent{}
+
+	for _, file := range files {
+		entries = append(entries, fuse.Dirent{Name: file, Type: fuse.DT_File})
+	}
+
+	return entries, nil
+}
+
+func (file *mountFile) Attr(ctx context.Context, attr *fuse.Attr) error {
+	value, err := file.read()
+
+	if err != nil {
+		return err
+	}
+
+	defer gcredstash.Wipe(value)
+
+	attr.Mode = 0400
+	attr.Size = uint64(len(value))
+	attr.Uid = uint32(os.Getuid())
+	attr.Gid = uint32(os.Getgid())
+	attr.Valid = file.mfs.Cache.TTL
+	return nil
+}
+
+func (file *mountFile) ReadAll(ctx context.Context) ([]byte, error) {
+	return file.read()
+}
+
+func (file *mountFile) read() ([]byte, error) {
+	value, err := file.mfs.Read(file.name)
+
+	if errors.Is(err, gcredstash.ErrSecretNotFound) {
+		return nil, fuse.ENOENT
+	}
+
+	if err != nil {
+		return nil, fuse.EIO
+	}
+
+	return value, nil
+}
+
+func mountSecrets(dir string, mfs *MountFS, allowOther bool) error {
+	options := []fuse.MountOption{fuse.ReadOnly(), fuse.FSName("gcredstash"), fuse.Subtype("gcredstash")}
+
+	if allowOther {
+		options = append(options, fuse.AllowOther())
+	}
+
+	conn, err := fuse.Mount(dir, options...)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-interrupt:
+			fuse.Unmount(dir)
+		case <-done:
+		}
+	}()
+
+	// Serve returns once the file system is unmounted, by the signal
+	// handler above or by umount(8).
+	return fs.Serve(conn, &mountRoot{mfs: mfs})
+}
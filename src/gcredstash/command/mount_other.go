@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package command
+
+import "errors"
+
+func mountSecrets(dir string, mfs *MountFS, allowOther bool) error {
+	return errors.New("mount is only supported on Linux, macOS and FreeBSD")
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"errors"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMountFS(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		driver.Now = func() time.Time { return now }
+
+		driver.PutSecret("app/db.password", "100", gcredstash.VersionNumToStr(1), "alias/credstash", "credential-store", nil)
+		driver.PutSecret("app/db.password", "200", gcredstash.VersionNumToStr(2), "alias/credstash", "credential-store", nil)
+		driver.PutSecret("app/tls/key", "300", gcredstash.VersionNumToStr(1), "alias/credstash", "credential-store", nil)
+		driver.PutSecret("other.password", "400", gcredstash.VersionNumToStr(1), "alias/credstash", "credential-store", nil)
+
+		cache := gcredstash.NewSecretCache(driver, "credential-store", nil, time.Minute)
+		mfs := NewMountFS(cache, "app/")
+		files, err := mfs.Files()
+		expected := []string{"db.password"}
+
+		if !reflect.DeepEqual(files, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, files, err)
+		}
+
+		value, err := mfs.Read("db.password")
+
+		if string(value) != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", string(value), err)
+		}
+
+		if _, err := mfs.Read("key"); !errors.Is(err, gcredstash.ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", gcredstash.ErrSecretNotFound, err)
+		}
+
+		driver.PutSecret("app/api.key", "500", gcredstash.VersionNumToStr(1), "alias/credstash", "credential-store", nil)
+
+		if ok, _ := mfs.Exists("api.key"); ok {
+			t.Errorf("\nexpected: %v\ngot: %v\n", false, ok)
+		}
+
+		now = now.Add(time.Minute)
+		files, _ = mfs.Files()
+		expected = []string{"api.key", "db.password"}
+
+		if !reflect.DeepEqual(files, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, files)
+		}
+	})
+}
+
+func TestMountCommandArgs(t *testing.T) {
+	cmd := &MountCommand{}
+
+	for _, tc := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{}, "too few arguments"},
+		{[]string{"--prefix"}, "option requires an argument: --prefix"},
+		{[]string{"--cache-ttl", "0s", "/mnt/secrets"}, "invalid cache TTL: 0s"},
+	} {
+		err := cmd.RunImpl(tc.args)
+
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type NeedsRotationCommand struct {
+	Meta
+}
+
+func (c *NeedsRotationCommand) RunImpl(args []string) (string, error) {
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments")
+	}
+
+	prefix := ""
+
+	if len(args) == 1 {
+		prefix = args[0]
+	}
+
+	now := time.Now()
+	overdue, err := c.Driver.OverdueRotations(c.Table, prefix, now)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(overdue) == 0 {
+		return "", nil
+	}
+
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tCREATED_AT\tROTATE_EVERY\tOVERDUE")
+
+	for _, status := range overdue {
+		createdAt := status.CreatedAt
+		days := "-"
+
+		if createdAt == "" {
+			createdAt = "-"
+		} else {
+			days = fmt.Sprintf("%dd", int(now.Sub(status.DueAt).Hours()/24))
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", status.Name, gcredstash.Atoi(status.Version), createdAt, status.RotateEvery, days)
+	}
+
+	w.Flush()
+
+	return buf.String(), nil
+}
+
+func (c *NeedsRotationCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *NeedsRotationCommand) Synopsis() string {
+	return "List credentials overdue for rotation"
+}
+
+func (c *NeedsRotationCommand) Help() string {
+	helpText := `
+usage: gcredstash needs-rotation [prefix]
+`
+	return strings.TrimSpace(helpText)
+}
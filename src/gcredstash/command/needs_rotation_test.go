@@ -0,0 +1,34 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNeedsRotationCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.Now = func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		put := &PutCommand{Meta: meta}
+		put.RunImpl([]string{"--rotate-every", "90d", "foo.bar", "100"})
+		put.RunImpl([]string{"foo.baz", "200"})
+
+		out, err := (&NeedsRotationCommand{Meta: meta}).RunImpl([]string{"foo."})
+		re := regexp.MustCompile(`\ANAME +VERSION +CREATED_AT +ROTATE_EVERY +OVERDUE\nfoo.bar +1 +2020-01-01T00:00:00Z +90d +\d+d\n\z`)
+
+		if err != nil || !re.MatchString(out) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", re, out, err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type NodeAttrsCommand struct {
+	Meta
+}
+
+func (c *NodeAttrsCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.checkTerminalOutput(force); err != nil {
+		return "", err
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	if err != nil {
+		return "", err
+	}
+
+	creds, err := c.Driver.GetSecretsByPattern(prefix+"*", c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	attrs, err := gcredstash.NestAttributes(creds, prefix)
+
+	if err != nil {
+		return "", err
+	}
+
+	return gcredstash.ToJson(attrs) + "\n", nil
+}
+
+func (c *NodeAttrsCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *NodeAttrsCommand) Synopsis() string {
+	return "Print credentials as nested JSON attributes"
+}
+
+func (c *NodeAttrsCommand) Help() string {
+	helpText := `
+usage: gcredstash node-attrs [--prefix PREFIX] [--force-stdout] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
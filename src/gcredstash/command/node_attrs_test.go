@@ -0,0 +1,40 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestNodeAttrsCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("app.db.password", "s3cr3t", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("app.secret_key", "key", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("other.token", "token", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &NodeAttrsCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		out, err := cmd.RunImpl([]string{"--prefix", "app."})
+
+		expected := `{
+  "db": {
+    "password": "s3cr3t"
+  },
+  "secret_key": "key"
+}
+`
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+	})
+}
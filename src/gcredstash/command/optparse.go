@@ -0,0 +1,166 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"strconv"
+	"strings"
+)
+
+func ParseOptionWithValue(args []string, key string) ([]string, string, error) {
+	newArgs := []string{}
+	val := ""
+	nextOpt := false
+
+	for _, arg := range args {
+		if nextOpt {
+			if strings.HasPrefix(arg, "-") {
+				return nil, "", fmt.Errorf("option requires an argument: %s", key)
+			}
+
+			val = arg
+			nextOpt = false
+		} else if arg == key {
+			nextOpt = true
+		} else {
+			newArgs = append(newArgs, arg)
+		}
+	}
+
+	if nextOpt {
+		return nil, "", fmt.Errorf("option requires an argument: %s", key)
+	}
+
+	return newArgs, val, nil
+}
+
+// ParseOptionWithValues is like ParseOptionWithValue for an option that
+// can be given several times. It returns the values in order.
+func ParseOptionWithValues(args []string, key string) ([]string, []string, error) {
+	newArgs := []string{}
+	vals := []string{}
+
+	for i := 0; i < len(args); i++ {
+		if args[i] != key {
+			newArgs = append(newArgs, args[i])
+			continue
+		}
+
+		if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+			return nil, nil, fmt.Errorf("option requires an argument: %s", key)
+		}
+
+		vals = append(vals, args[i+1])
+		i++
+	}
+
+	return newArgs, vals, nil
+}
+
+// SplitGlobalArgs splits args at the command name: the first argument that
+// is neither an option nor the value of one of valueOpts. Options after the
+// command name belong to the command, even if a global option has the same
+// name.
+func SplitGlobalArgs(args []string, valueOpts ...string) ([]string, []string) {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			return args[:i:i], args[i:]
+		}
+
+		for _, opt := range valueOpts {
+			if args[i] == opt {
+				i++
+				break
+			}
+		}
+	}
+
+	return args, nil
+}
+
+func ParseVersion(args []string) ([]string, string, error) {
+	newArgs, version, err := ParseOptionWithValue(args, "-v")
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if version != "" {
+		ver, err := strconv.Atoi(version)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		version = fmt.Sprintf("%019d", ver)
+	}
+
+	return newArgs, version, nil
+}
+
+func HasOption(args []string, opt string) ([]string, bool) {
+	newArgs := []string{}
+	hasOpt := false
+
+	for _, arg := range args {
+		if arg == opt {
+			hasOpt = true
+		} else {
+			newArgs = append(newArgs, arg)
+		}
+	}
+
+	return newArgs, hasOpt
+}
+
+// ParseOptionWithOptionalNumber removes opt and, if the argument after it
+// is a positive integer, that number as well.
+func ParseOptionWithOptionalNumber(args []string, opt string) ([]string, bool, int) {
+	newArgs := []string{}
+	hasOpt := false
+	num := 0
+
+	for i := 0; i < len(args); i++ {
+		if args[i] != opt {
+			newArgs = append(newArgs, args[i])
+			continue
+		}
+
+		hasOpt = true
+
+		if i+1 < len(args) {
+			if n, err := strconv.Atoi(args[i+1]); err == nil && n > 0 {
+				num = n
+				i++
+			}
+		}
+	}
+
+	return newArgs, hasOpt, num
+}
+
+func ParseReplicas(str string, kmsKey string) ([]gcredstash.Replica, error) {
+	replicas := []gcredstash.Replica{}
+
+	for _, r := range strings.Split(str, ",") {
+		kv := strings.SplitN(r, "=", 2)
+		region := strings.TrimSpace(kv[0])
+		key := kmsKey
+
+		if region == "" {
+			return nil, fmt.Errorf("invalid region: %s", r)
+		}
+
+		if len(kv) == 2 {
+			key = strings.TrimSpace(kv[1])
+
+			if key == "" {
+				return nil, fmt.Errorf("invalid region: %s", r)
+			}
+		}
+
+		replicas = append(replicas, gcredstash.Replica{Region: region, KmsKey: key})
+	}
+
+	return replicas, nil
+}
@@ -0,0 +1,257 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"reflect"
+	"testing"
+)
+
+func TestParseOptionWithValue1(t *testing.T) {
+	args := []string{"-a", "-b", "BBB", "-c", "CCC"}
+	expectedArgs := []string{"-a", "-c", "CCC"}
+	expectedValue := "BBB"
+
+	newAags, value, err := ParseOptionWithValue(args, "-b")
+
+	if !reflect.DeepEqual(expectedArgs, newAags) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedArgs, newAags)
+	}
+
+	if expectedValue != value {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedValue, value)
+	}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestParseOptionWithValue2(t *testing.T) {
+	args := []string{"-a", "-c", "CCC"}
+	expectedArgs := []string{"-a", "-c", "CCC"}
+	expectedValue := ""
+
+	newAags, value, err := ParseOptionWithValue(args, "-b")
+
+	if !reflect.DeepEqual(expectedArgs, newAags) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedArgs, newAags)
+	}
+
+	if expectedValue != value {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedValue, value)
+	}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestErrParseOptionWithValue1(t *testing.T) {
+	args := []string{"-a", "-b", "-c", "CCC"}
+	expected := "option requires an argument: -b"
+
+	_, _, err := ParseOptionWithValue(args, "-b")
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestErrParseOptionWithValue2(t *testing.T) {
+	args := []string{"-a", "-b", "-c"}
+	expected := "option requires an argument: -c"
+
+	_, _, err := ParseOptionWithValue(args, "-c")
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestParseVersion1(t *testing.T) {
+	args := []string{"-a", "-v", "1", "-c", "CCC"}
+	expectedArgs := []string{"-a", "-c", "CCC"}
+	expectedVersion := "0000000000000000001"
+
+	newAags, version, err := ParseVersion(args)
+
+	if !reflect.DeepEqual(expectedArgs, newAags) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedArgs, newAags)
+	}
+
+	if expectedVersion != version {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedVersion, version)
+	}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestParseVersion2(t *testing.T) {
+	args := []string{"-a", "-c", "CCC"}
+	expectedArgs := []string{"-a", "-c", "CCC"}
+	expectedVersion := ""
+
+	newAags, version, err := ParseVersion(args)
+
+	if !reflect.DeepEqual(expectedArgs, newAags) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedArgs, newAags)
+	}
+
+	if expectedVersion != version {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedVersion, version)
+	}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestErrParseVersion1(t *testing.T) {
+	args := []string{"-a", "-v", "-c", "CCC"}
+	expected := "option requires an argument: -v"
+
+	_, _, err := ParseVersion(args)
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestErrParseVersion2(t *testing.T) {
+	args := []string{"-a", "-v", "X", "-c", "CCC"}
+	expected := `strconv.Atoi: parsing "X": invalid syntax`
+
+	_, _, err := ParseVersion(args)
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestHasOption1(t *testing.T) {
+	args := []string{"-a", "-b", "BBB", "-c", "CCC"}
+	expectedArgs := []string{"-b", "BBB", "-c", "CCC"}
+	expectedValue := true
+
+	newAags, value := HasOption(args, "-a")
+
+	if !reflect.DeepEqual(expectedArgs, newAags) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedArgs, newAags)
+	}
+
+	if expectedValue != value {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedValue, value)
+	}
+}
+
+func TestHasOption2(t *testing.T) {
+	args := []string{"-b", "BBB", "-c", "CCC"}
+	expectedArgs := []string{"-b", "BBB", "-c", "CCC"}
+	expectedValue := false
+
+	newAags, value := HasOption(args, "-a")
+
+	if !reflect.DeepEqual(expectedArgs, newAags) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedArgs, newAags)
+	}
+
+	if expectedValue != value {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedValue, value)
+	}
+}
+
+func TestParseOptionWithOptionalNumber(t *testing.T) {
+	for _, tc := range []struct {
+		args         []string
+		expectedArgs []string
+		expectedOpt  bool
+		expectedNum  int
+	}{
+		{[]string{"-g", "32", "foo.bar"}, []string{"foo.bar"}, true, 32},
+		{[]string{"-g", "foo.bar"}, []string{"foo.bar"}, true, 0},
+		{[]string{"foo.bar", "-g"}, []string{"foo.bar"}, true, 0},
+		{[]string{"-g", "0", "foo.bar"}, []string{"0", "foo.bar"}, true, 0},
+		{[]string{"foo.bar", "32"}, []string{"foo.bar", "32"}, false, 0},
+	} {
+		newArgs, opt, num := ParseOptionWithOptionalNumber(tc.args, "-g")
+
+		if !reflect.DeepEqual(tc.expectedArgs, newArgs) || tc.expectedOpt != opt || tc.expectedNum != num {
+			t.Errorf("\nexpected: %v %v %v\ngot: %v %v %v\n", tc.expectedArgs, tc.expectedOpt, tc.expectedNum, newArgs, opt, num)
+		}
+	}
+}
+
+func TestParseOptionWithValues(t *testing.T) {
+	newArgs, vals, err := ParseOptionWithValues([]string{"--set", "a=1", "foo.bar", "--set", "b=2"}, "--set")
+
+	if !reflect.DeepEqual(newArgs, []string{"foo.bar"}) || !reflect.DeepEqual(vals, []string{"a=1", "b=2"}) || err != nil {
+		t.Errorf("\nexpected: %v %v\ngot: %v %v %v\n", []string{"foo.bar"}, []string{"a=1", "b=2"}, newArgs, vals, err)
+	}
+
+	_, _, err = ParseOptionWithValues([]string{"foo.bar", "--set"}, "--set")
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestParseReplicas(t *testing.T) {
+	expected := []gcredstash.Replica{
+		{Region: "us-east-1", KmsKey: "alias/credstash"},
+		{Region: "eu-west-1", KmsKey: "alias/credstash-dr"},
+	}
+
+	actual, err := ParseReplicas("us-east-1,eu-west-1=alias/credstash-dr", "alias/credstash")
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestErrParseReplicas(t *testing.T) {
+	expected := "invalid region: eu-west-1="
+
+	_, err := ParseReplicas("us-east-1,eu-west-1=", "alias/credstash")
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestSplitGlobalArgs(t *testing.T) {
+	for _, tc := range []struct {
+		args            []string
+		expectedGlobal  []string
+		expectedCommand []string
+	}{
+		{[]string{"--namespace", "team-a/", "k8s-secret", "--namespace", "prod", "db.password"}, []string{"--namespace", "team-a/"}, []string{"k8s-secret", "--namespace", "prod", "db.password"}},
+		{[]string{"k8s-secret", "--namespace", "prod", "db.password"}, []string{}, []string{"k8s-secret", "--namespace", "prod", "db.password"}},
+		{[]string{"--verbose", "--namespace", "team-a/"}, []string{"--verbose", "--namespace", "team-a/"}, nil},
+	} {
+		global, command := SplitGlobalArgs(tc.args, "--namespace")
+
+		if !reflect.DeepEqual(global, tc.expectedGlobal) || !reflect.DeepEqual(command, tc.expectedCommand) {
+			t.Errorf("\nexpected: %v %v\ngot: %v %v\n", tc.expectedGlobal, tc.expectedCommand, global, command)
+		}
+	}
+}
+
+func TestSplitGlobalArgsChildOptions(t *testing.T) {
+	args := []string{"--dry-run", "--env", "prod", "chamber", "exec", "app", "--", "migrate", "--dry-run", "--debug"}
+	global, command := SplitGlobalArgs(args, "--env", "--store", "--namespace")
+	global, dryRun := HasOption(global, "--dry-run")
+
+	expectedGlobal := []string{"--env", "prod"}
+	expectedCommand := []string{"chamber", "exec", "app", "--", "migrate", "--dry-run", "--debug"}
+
+	if !dryRun || !reflect.DeepEqual(global, expectedGlobal) || !reflect.DeepEqual(command, expectedCommand) {
+		t.Errorf("\nexpected: %v %v %v\ngot: %v %v %v\n", true, expectedGlobal, expectedCommand, dryRun, global, command)
+	}
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type PolicyCommand struct {
+	Meta
+}
+
+func splitPatterns(str string) []string {
+	patterns := []string{}
+
+	if str == "" {
+		return patterns
+	}
+
+	for _, pattern := range strings.Split(str, ",") {
+		patterns = append(patterns, strings.TrimSpace(pattern))
+	}
+
+	return patterns
+}
+
+func (c *PolicyCommand) parseArgs(args []string) ([]string, []string, map[string]string, error) {
+	newArgs, readers, err := ParseOptionWithValue(args, "--reader")
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	newArgs, writers, err := ParseOptionWithValue(newArgs, "--writer")
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if readers == "" && writers == "" {
+		return nil, nil, nil, fmt.Errorf("--reader or --writer is required")
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return splitPatterns(readers), splitPatterns(writers), context, nil
+}
+
+func (c *PolicyCommand) RunImpl(args []string) (string, error) {
+	readers, writers, context, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	policy, err := c.Driver.GeneratePolicy(c.Table, c.KmsKey, readers, writers, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return policy.String() + "\n", nil
+}
+
+func (c *PolicyCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *PolicyCommand) Synopsis() string {
+	return "Print an IAM policy for reading or writing credentials"
+}
+
+func (c *PolicyCommand) Help() string {
+	helpText := `
+usage: gcredstash policy [--reader PATTERN,...] [--writer PATTERN,...] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
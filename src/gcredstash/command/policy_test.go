@@ -0,0 +1,96 @@
+package command
+
+import (
+	"encoding/json"
+	"gcredstash"
+	. "gcredstash/command"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"reflect"
+	"testing"
+)
+
+func TestPolicyCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	table := "credential-store"
+	tableArn := "arn:aws:dynamodb:us-east-1:123456789012:table/credential-store"
+	keyArn := "arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4"
+
+	mddb.EXPECT().DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	}).Return(&dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{TableArn: aws.String(tableArn)},
+	}, nil)
+
+	mkms.EXPECT().DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String("alias/credstash"),
+	}).Return(&kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{Arn: aws.String(keyArn)},
+	}, nil)
+
+	cmd := &PolicyCommand{
+		Meta: Meta{
+			Table:  table,
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	out, err := cmd.RunImpl([]string{"--reader", "app.*,db.*", "role=webserver"})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	actual := map[string]interface{}{}
+	json.Unmarshal([]byte(out), &actual)
+
+	expected := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []interface{}{
+			map[string]interface{}{
+				"Sid":      "ReadCredentials",
+				"Effect":   "Allow",
+				"Action":   []interface{}{"dynamodb:GetItem", "dynamodb:Query"},
+				"Resource": tableArn,
+				"Condition": map[string]interface{}{
+					"ForAllValues:StringLike": map[string]interface{}{
+						"dynamodb:LeadingKeys": []interface{}{"app.*", "db.*"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"Sid":      "DecryptCredentials",
+				"Effect":   "Allow",
+				"Action":   []interface{}{"kms:Decrypt"},
+				"Resource": keyArn,
+				"Condition": map[string]interface{}{
+					"StringEquals": map[string]interface{}{
+						"kms:EncryptionContext:role": "webserver",
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestPolicyCommandWithoutPatterns(t *testing.T) {
+	cmd := &PolicyCommand{}
+	_, err := cmd.RunImpl([]string{})
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
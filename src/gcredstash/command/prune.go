@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PruneCommand struct {
+	Meta
+}
+
+func (c *PruneCommand) parseArgs(args []string) (string, int, time.Duration, error) {
+	newArgs, keepStr, err := ParseOptionWithValue(args, "--keep")
+
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	newArgs, olderThanStr, err := ParseOptionWithValue(newArgs, "--older-than")
+
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	if keepStr == "" && olderThanStr == "" {
+		return "", 0, 0, fmt.Errorf("--keep or --older-than is required")
+	}
+
+	keep := 1
+
+	if keepStr != "" {
+		keep, err = strconv.Atoi(keepStr)
+
+		if err != nil || keep < 1 {
+			return "", 0, 0, fmt.Errorf("--keep must be a positive number: %s", keepStr)
+		}
+	}
+
+	var olderThan time.Duration
+
+	if olderThanStr != "" {
+		olderThan, err = gcredstash.ParseAge(olderThanStr)
+
+		if err != nil {
+			return "", 0, 0, err
+		}
+	}
+
+	if len(newArgs) < 1 {
+		return "", 0, 0, fmt.Errorf("too few arguments")
+	}
+
+	if len(newArgs) > 1 {
+		return "", 0, 0, fmt.Errorf("too many arguments")
+	}
+
+	return newArgs[0], keep, olderThan, nil
+}
+
+func (c *PruneCommand) RunImpl(args []string) error {
+	credential, keep, olderThan, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Driver.PruneVersions(credential, keep, olderThan, c.Table)
+
+	return err
+}
+
+func (c *PruneCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *PruneCommand) Synopsis() string {
+	return "Delete old versions of a credential"
+}
+
+func (c *PruneCommand) Help() string {
+	helpText := `
+usage: gcredstash prune [--keep N] [--older-than AGE] credential
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"sort"
+	"strings"
+)
+
+type PurgeCommand struct {
+	Meta
+}
+
+func (c *PurgeCommand) RunImpl(args []string) (string, error) {
+	newArgs, all := HasOption(args, "--all")
+	targets := map[string]string{}
+
+	if all {
+		if len(newArgs) > 0 {
+			return "", fmt.Errorf("--all cannot be combined with a credential")
+		}
+
+		items, err := c.Driver.DeletedSecrets(c.Table, "")
+
+		if err != nil {
+			return "", err
+		}
+
+		for name := range gcredstash.GroupVersions(items) {
+			targets[name] = ""
+		}
+	} else {
+		credential, version, err := parseNameVersion(newArgs)
+
+		if err != nil {
+			return "", err
+		}
+
+		targets[credential] = version
+	}
+
+	names := []string{}
+
+	for name := range targets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	lines := []string{}
+
+	for _, name := range names {
+		purged, err := c.Driver.PurgeSecrets(name, targets[name], c.Table)
+
+		for _, version := range purged {
+			lines = append(lines, fmt.Sprintf("Purged %s -- version %d\n", name, gcredstash.Atoi(version)))
+		}
+
+		if err != nil {
+			return strings.Join(lines, ""), err
+		}
+	}
+
+	return strings.Join(lines, ""), nil
+}
+
+func (c *PurgeCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *PurgeCommand) Synopsis() string {
+	return "Permanently remove soft-deleted credentials"
+}
+
+func (c *PurgeCommand) Help() string {
+	helpText := `
+usage: gcredstash purge credential [version]
+       gcredstash purge --all
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestPurgeCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.Events = &gcredstash.NopEventHandler{}
+		driver.SoftDelete = true
+
+		table := "credential-store"
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("foo.bar", "200", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("foo.baz", "300", "0000000000000000001", "alias/credstash", table, nil)
+		driver.DeleteSecrets("foo.bar", "0000000000000000001", table)
+		driver.DeleteSecrets("foo.baz", "", table)
+
+		cmd := &PurgeCommand{Meta: Meta{Table: table, Driver: driver}}
+		out, err := cmd.RunImpl([]string{"foo.bar", "2"})
+		expected := "foo.bar version 2 is not deleted"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, err = cmd.RunImpl([]string{"--all"})
+		expected = "Purged foo.bar -- version 1\nPurged foo.baz -- version 1\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		items, _ := driver.DeletedSecrets(table, "")
+
+		if len(items) != 0 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 0, gcredstash.GroupVersions(items))
+		}
+
+		value, _ := driver.GetSecret("foo.bar", "", table, nil)
+
+		if value != "200" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "200", value)
+		}
+	})
+}
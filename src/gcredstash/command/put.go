@@ -4,57 +4,399 @@ import (
 	"fmt"
 	"gcredstash"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type PutCommand struct {
 	Meta
 }
 
-func (c *PutCommand) parseArgs(args []string) (string, string, string, map[string]string, bool, error) {
-	argsWithoutA, autoVersion := gcredstash.HasOption(args, "-a")
-	newArgs, version, err := gcredstash.ParseVersion(argsWithoutA)
+type putArgs struct {
+	credential  string
+	value       string
+	version     string
+	context     map[string]string
+	autoVersion bool
+	ifVersion   int
+	checkVer    bool
+	skipIfSame  bool
+	noNormalize bool
+	idempotent  bool
+	passphrase  bool
+	stripLF     bool
+	keepLF      bool
+	generate    bool
+	genLength   int
+	genCharset  string
+	replicas    []gcredstash.Replica
+	opts        *gcredstash.PutOptions
+}
+
+func (c *PutCommand) parseArgs(args []string) (*putArgs, error) {
+	parsed := &putArgs{opts: &gcredstash.PutOptions{}}
+
+	argsWithoutA, autoVersion := HasOption(args, "-a")
+	parsed.autoVersion = autoVersion
+	argsWithoutA, parsed.skipIfSame = HasOption(argsWithoutA, "--skip-if-unchanged")
+	argsWithoutA, parsed.opts.RequireContext = HasOption(argsWithoutA, "--require-context")
+	argsWithoutA, parsed.stripLF = HasOption(argsWithoutA, "--strip-newline")
+	argsWithoutA, parsed.keepLF = HasOption(argsWithoutA, "--keep-newline")
+	argsWithoutA, parsed.noNormalize = HasOption(argsWithoutA, "--no-normalize")
+	argsWithoutA, parsed.idempotent = HasOption(argsWithoutA, "--idempotent")
+	argsWithoutA, parsed.passphrase = HasOption(argsWithoutA, "--passphrase")
+
+	if parsed.stripLF && parsed.keepLF {
+		return nil, fmt.Errorf("--strip-newline and --keep-newline are mutually exclusive")
+	}
+
+	argsWithoutA, parsed.generate, parsed.genLength = ParseOptionWithOptionalNumber(argsWithoutA, "--generate")
+
+	var err error
+	argsWithoutA, parsed.genCharset, err = ParseOptionWithValue(argsWithoutA, "--charset")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.genCharset != "" && !parsed.generate {
+		return nil, fmt.Errorf("--charset requires --generate")
+	}
+
+	if parsed.generate && parsed.skipIfSame {
+		return nil, fmt.Errorf("--generate cannot be combined with --skip-if-unchanged")
+	}
+
+	if parsed.passphrase && parsed.skipIfSame {
+		return nil, fmt.Errorf("--passphrase cannot be combined with --skip-if-unchanged")
+	}
+
+	if os.Getenv("GCREDSTASH_REQUIRE_CONTEXT") == "1" {
+		parsed.opts.RequireContext = true
+	}
+
+	argsWithoutAR, regions, err := ParseOptionWithValue(argsWithoutA, "--regions")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if regions != "" {
+		parsed.replicas, err = ParseReplicas(regions, c.KmsKey)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	argsWithoutARS, scheme, err := ParseOptionWithValue(argsWithoutAR, "--scheme")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcredstash.ValidateScheme(scheme)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.opts.Scheme = scheme
+
+	argsWithoutARS, parsed.opts.Compression, err = ParseOptionWithValue(argsWithoutARS, "--compress")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcredstash.ValidateCompression(parsed.opts.Compression)
+
+	if err != nil {
+		return nil, err
+	}
+
+	argsWithoutARSD, digest, err := ParseOptionWithValue(argsWithoutARS, "-d")
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.opts.Digest, err = gcredstash.NormalizeDigest(digest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	argsWithoutARSDC, comment, err := ParseOptionWithValue(argsWithoutARSD, "--comment")
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.opts.Comment = comment
+
+	argsWithoutARSDC, ttl, err := ParseOptionWithValue(argsWithoutARSDC, "--ttl")
+
+	if err != nil {
+		return nil, err
+	}
+
+	argsWithoutARSDC, parsed.opts.AutoDelete = HasOption(argsWithoutARSDC, "--auto-delete")
+	argsWithoutARSDC, parsed.opts.RotateEvery, err = ParseOptionWithValue(argsWithoutARSDC, "--rotate-every")
+
+	if err != nil {
+		return nil, err
+	}
+
+	argsWithoutARSDC, tags, err := ParseOptionWithValues(argsWithoutARSDC, "--tag")
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.opts.Tags, err = gcredstash.ParseTags(tags)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl != "" {
+		age, err := gcredstash.ParseAge(ttl)
+
+		if err != nil || age <= 0 {
+			return nil, fmt.Errorf("invalid ttl: %s", ttl)
+		}
+
+		parsed.opts.ExpiresAt = time.Now().Add(age)
+	} else if parsed.opts.AutoDelete {
+		return nil, fmt.Errorf("--auto-delete requires --ttl")
+	}
+
+	argsWithoutARSDC, pattern, err := ParseOptionWithValue(argsWithoutARSDC, "--validate")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pattern != "" {
+		validator, err := gcredstash.NewPatternValidator(pattern)
+
+		if err != nil {
+			return nil, err
+		}
+
+		parsed.opts.Validators = append(parsed.opts.Validators, validator)
+	}
+
+	argsWithoutARSDC, maxLength, err := ParseOptionWithValue(argsWithoutARSDC, "--max-length")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if maxLength != "" {
+		num, err := strconv.Atoi(maxLength)
+
+		if err != nil || num < 1 {
+			return nil, fmt.Errorf("invalid max length: %s", maxLength)
+		}
+
+		parsed.opts.Validators = append(parsed.opts.Validators, &gcredstash.MaxLengthValidator{MaxLength: num})
+	}
+
+	argsWithoutARSDC, ifVersion, err := ParseOptionWithValue(argsWithoutARSDC, "--if-version")
 
 	if err != nil {
-		return "", "", "", nil, false, err
+		return nil, err
+	}
+
+	if ifVersion != "" {
+		parsed.ifVersion, err = strconv.Atoi(ifVersion)
+
+		if err != nil || parsed.ifVersion < 0 {
+			return nil, fmt.Errorf("invalid version: %s", ifVersion)
+		}
+
+		parsed.checkVer = true
+	}
+
+	newArgs, version, err := ParseVersion(argsWithoutARSDC)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.checkVer && (version != "" || parsed.autoVersion || len(parsed.replicas) > 0) {
+		return nil, fmt.Errorf("--if-version cannot be combined with -a, -v or --regions")
+	}
+
+	if parsed.idempotent && (version != "" || parsed.autoVersion || parsed.checkVer || parsed.skipIfSame || len(parsed.replicas) > 0) {
+		return nil, fmt.Errorf("--idempotent cannot be combined with -a, -v, --if-version, --skip-if-unchanged or --regions")
+	}
+
+	if parsed.generate {
+		if len(newArgs) < 1 {
+			return nil, fmt.Errorf("too few arguments")
+		}
+
+		// The generated value takes the place of the value argument.
+		newArgs = append([]string{newArgs[0], ""}, newArgs[1:]...)
 	}
 
 	if len(newArgs) < 2 {
-		return "", "", "", nil, false, fmt.Errorf("too few arguments")
+		return nil, fmt.Errorf("too few arguments")
 	}
 
-	credential := newArgs[0]
-	value := newArgs[1]
-	context, err := gcredstash.ParseContext(newArgs[2:])
+	parsed.version = version
+	parsed.credential = newArgs[0]
+	parsed.value = newArgs[1]
+	parsed.context, err = gcredstash.ParseContext(newArgs[2:])
+
+	if err != nil {
+		return nil, err
+	}
 
-	return credential, value, version, context, autoVersion, err
+	return parsed, nil
 }
 
 func (c *PutCommand) RunImpl(args []string) error {
-	credential, value, version, context, autoVersion, err := c.parseArgs(args)
+	parsed, err := c.parseArgs(args)
 
 	if err != nil {
 		return err
 	}
 
-	if value == "-" {
-		value = gcredstash.ReadStdin()
+	credential := parsed.credential
+	value := parsed.value
+
+	if !parsed.noNormalize {
+		credential = c.Driver.Naming.Normalize(credential)
+	}
+	version := parsed.version
+
+	fromInput := true
+
+	if parsed.generate {
+		length := parsed.genLength
+
+		if length == 0 {
+			length = gcredstash.DEFAULT_GENERATE_LEN
+		}
+
+		generated, err := gcredstash.GenerateSecret(length, parsed.genCharset)
+
+		if err != nil {
+			return err
+		}
+
+		value = string(generated)
+		gcredstash.Wipe(generated)
+		fromInput = false
+	} else if value == "-" {
+		value = ReadStdinRaw()
+	} else if strings.HasPrefix(value, "@") {
+		value, err = gcredstash.ReadFile(value[1:])
+
+		if err != nil {
+			return err
+		}
+	} else {
+		fromInput = false
+	}
+
+	if parsed.stripLF || (fromInput && !parsed.keepLF) {
+		value = gcredstash.StripNewline(value)
+	}
+
+	if parsed.passphrase {
+		if c.Driver.Passphrase == nil {
+			return fmt.Errorf("--passphrase needs GCREDSTASH_PASSPHRASE_FILE or GCREDSTASH_PASSPHRASE")
+		}
+
+		parsed.opts.Passphrase, err = c.Driver.Passphrase(credential)
+
+		if err != nil {
+			return err
+		}
+
+		defer gcredstash.Wipe(parsed.opts.Passphrase)
 	}
 
-	if autoVersion {
-		latestVersion, err := c.Driver.GetHighestVersion(credential, c.Table)
+	if parsed.skipIfSame {
+		if len(parsed.replicas) > 0 {
+			return fmt.Errorf("--skip-if-unchanged cannot be combined with --regions")
+		}
+
+		unchanged, err := c.Driver.LatestValueEquals(credential, value, c.Table, parsed.context)
 
 		if err != nil {
 			return err
 		}
 
-		latestVersion += 1
-		version = gcredstash.VersionNumToStr(latestVersion)
-	} else if version == "" {
+		if unchanged {
+			fmt.Printf("%s is unchanged\n", credential)
+			return nil
+		}
+	}
+
+	if parsed.autoVersion && len(parsed.replicas) > 0 {
+		latestVersion, err := c.Driver.GetHighestVersionInRegions(credential, c.Table, parsed.replicas)
+
+		if err != nil {
+			return err
+		}
+
+		version = gcredstash.VersionNumToStr(latestVersion + 1)
+	} else if version == "" && !parsed.autoVersion {
 		version = gcredstash.VersionNumToStr(1)
 	}
 
-	err = c.Driver.PutSecret(credential, value, version, c.KmsKey, c.Table, context)
+	if len(parsed.replicas) > 0 {
+		err = c.Driver.ReplicatePut(credential, value, version, c.Table, parsed.context, parsed.replicas, parsed.opts)
+
+		if err != nil {
+			return err
+		}
+
+		for _, replica := range parsed.replicas {
+			fmt.Printf("%s has been stored in %s\n", credential, replica.Region)
+		}
+
+		if parsed.generate {
+			fmt.Println(value)
+		}
+
+		return nil
+	}
+
+	if parsed.idempotent {
+		version, stored, err := c.Driver.PutSecretIdempotent(credential, value, c.KmsKey, c.Table, parsed.context, parsed.opts)
+
+		if err != nil {
+			return err
+		}
+
+		if stored {
+			fmt.Printf("%s has been stored -- version: %d\n", credential, gcredstash.Atoi(version))
+		} else {
+			fmt.Printf("%s is unchanged -- version: %d\n", credential, gcredstash.Atoi(version))
+		}
+
+		if parsed.generate {
+			fmt.Println(value)
+		}
+
+		return nil
+	}
+
+	if parsed.checkVer {
+		_, err = c.Driver.PutSecretIfVersion(credential, value, parsed.ifVersion, c.KmsKey, c.Table, parsed.context, parsed.opts)
+	} else if parsed.autoVersion {
+		_, err = c.Driver.PutSecretNextVersion(credential, value, c.KmsKey, c.Table, parsed.context, parsed.opts)
+	} else {
+		err = c.Driver.PutSecretWithOptions(credential, value, version, c.KmsKey, c.Table, parsed.context, parsed.opts)
+	}
 
 	if err != nil {
 		return err
@@ -62,6 +404,10 @@ func (c *PutCommand) RunImpl(args []string) error {
 
 	fmt.Printf("%s has been stored\n", credential)
 
+	if parsed.generate {
+		fmt.Println(value)
+	}
+
 	return nil
 }
 
@@ -70,7 +416,7 @@ func (c *PutCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	return 0
@@ -82,7 +428,7 @@ func (c *PutCommand) Synopsis() string {
 
 func (c *PutCommand) Help() string {
 	helpText := `
-usage: gcredstash put [-k KEY] [-v VERSION] [-a] credential value [context [context ...]]
+usage: gcredstash put [-k KEY] [-v VERSION] [-a] [--skip-if-unchanged] [--require-context] [--strip-newline|--keep-newline] [--no-normalize] [--generate [LENGTH] [--charset alnum|hex|base64]] [--regions REGION[=KEY],...] [--scheme aes-ctr|aes-gcm] [--compress gzip|zstd] [-d DIGEST] [--comment COMMENT] [--ttl AGE [--auto-delete]] [--rotate-every AGE] [--tag KEY=VALUE ...] [--if-version N] [--idempotent] [--passphrase] [--validate REGEX] [--max-length N] credential [value] [context [context ...]]
 `
 	return strings.TrimSpace(helpText)
 }
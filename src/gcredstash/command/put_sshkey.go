@@ -0,0 +1,90 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+type PutSSHKeyCommand struct {
+	Meta
+}
+
+func (c *PutSSHKeyCommand) RunImpl(args []string) (string, error) {
+	newArgs, version, err := ParseVersion(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(newArgs) < 2 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	credential := newArgs[0]
+	keyFile := newArgs[1]
+	context, err := gcredstash.ParseContext(newArgs[2:])
+
+	if err != nil {
+		return "", err
+	}
+
+	var key []byte
+
+	if keyFile == "-" {
+		key, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		key, err = ioutil.ReadFile(keyFile)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	defer gcredstash.Wipe(key)
+	key, err = gcredstash.NormalizeSSHPrivateKey(key)
+
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", keyFile, err.Error())
+	}
+
+	defer gcredstash.Wipe(key)
+
+	if version == "" {
+		_, err = c.Driver.PutSecretBytesNextVersion(credential, key, c.KmsKey, c.Table, context, nil)
+	} else {
+		err = c.Driver.PutSecretBytesWithOptions(credential, key, version, c.KmsKey, c.Table, context, nil)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s has been stored\n", credential), nil
+}
+
+func (c *PutSSHKeyCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *PutSSHKeyCommand) Synopsis() string {
+	return "Store an SSH private key file"
+}
+
+func (c *PutSSHKeyCommand) Help() string {
+	helpText := `
+usage: gcredstash put-sshkey [-v VERSION] credential key_file|- [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"errors"
 	"gcredstash"
 	. "gcredstash/command"
 	"gcredstash/testutils"
@@ -9,6 +10,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/golang/mock/gomock"
 	"mockaws"
+	"os"
+	"regexp"
 	"testing"
 )
 
@@ -28,6 +31,7 @@ func TestPutCommand(t *testing.T) {
 
 	item := map[string]string{
 		"contents": "twnH",
+		"digest":   "SHA256",
 		"hmac":     "01cc6772cf2c889c8c0dae1f0ec3d7659e21103d56cd3436039cf29d18759958",
 		"key":      "CiDY1vsR456LEdoL3+0p+PrTCleoqi/sutbDfJZNiUSpphLLAQEBAQB42Nb7EeOeixHaC9/tKfj60wpXqKov7LrWw3yWTYlEqaYAAACiMIGfBgkqhkiG9w0BBwaggZEwgY4CAQAwgYgGCSqGSIb3DQEHATAeBglghkgBZQMEAS4wEQQMeq7h5wZtkuXM8PpxAgEQgFusrxgmwCbvRObKTdbH2yvma5kNrgx3bF3ghmu7pjq6ZhPao8gZJAG2YdwwTvdbjr/wck++u0W8utaP6r07Pe8M8+oUGwWxit9X6UzxfOR6Q4eoW8g2hRUncOgF",
 		"name":     name,
@@ -82,3 +86,356 @@ func TestPutCommand(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
 	}
 }
+
+func TestPutCommandSkipIfUnchanged(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, value := range []string{"100", "100", "200"} {
+			err := cmd.RunImpl([]string{"-a", "--skip-if-unchanged", "test.key", value})
+
+			if err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		version, _ := driver.GetHighestVersion("test.key", "credential-store")
+
+		if version != 2 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 2, version)
+		}
+	})
+}
+
+func TestPutCommandRequireContext(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		err := cmd.RunImpl([]string{"--require-context", "test.key", "100"})
+
+		if err != gcredstash.ErrContextRequired {
+			t.Errorf("\nexpected: %v\ngot: %v\n", gcredstash.ErrContextRequired, err)
+		}
+
+		err = cmd.RunImpl([]string{"--require-context", "test.key", "100", "role=webserver"})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+	})
+}
+
+func TestPutCommandValidate(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"--validate", `^\S+$`, "test.key", ""}, `test.key: value does not match ^\S+$`},
+			{[]string{"--validate", `^\S+$`, "test.key", "100\n"}, `test.key: value does not match ^\S+$`},
+			{[]string{"--max-length", "3", "test.key", "1000"}, "test.key: value is longer than 3 bytes"},
+			{[]string{"--max-length", "0", "test.key", "100"}, "invalid max length: 0"},
+			{[]string{"--validate", "(", "test.key", "100"}, "invalid pattern: ("},
+		} {
+			err := cmd.RunImpl(tc.args)
+
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+			}
+		}
+
+		err := cmd.RunImpl([]string{"--validate", `^\S+$`, "--max-length", "3", "test.key", "100"})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		version, _ := driver.GetHighestVersion("test.key", "credential-store")
+
+		if version != 1 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 1, version)
+		}
+	})
+}
+
+func TestPutCommandFromFile(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		testutils.TempFile("100\r\n", func(value *os.File) {
+			for _, tc := range []struct {
+				args     []string
+				expected string
+			}{
+				{[]string{"test.key", "@" + value.Name(), "-a"}, "100"},
+				{[]string{"--keep-newline", "test.key", "@" + value.Name(), "-a"}, "100\r\n"},
+				{[]string{"test.key", "200\n", "-a"}, "200\n"},
+				{[]string{"--strip-newline", "test.key", "200\n", "-a"}, "200"},
+			} {
+				err := cmd.RunImpl(tc.args)
+
+				if err != nil {
+					t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+				}
+
+				actual, _ := driver.GetSecret("test.key", "", "credential-store", nil)
+
+				if actual != tc.expected {
+					t.Errorf("\nexpected: %q\ngot: %q\n", tc.expected, actual)
+				}
+			}
+		})
+
+		err := cmd.RunImpl([]string{"--strip-newline", "--keep-newline", "test.key", "100"})
+		expected := "--strip-newline and --keep-newline are mutually exclusive"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
+
+func TestPutCommandGenerate(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		err := cmd.RunImpl([]string{"--generate", "16", "--charset", "hex", "test.key", "role=webserver"})
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		actual, _ := driver.GetSecret("test.key", "", "credential-store", map[string]string{"role": "webserver"})
+
+		if !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(actual) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "16 hex characters", actual)
+		}
+
+		err = cmd.RunImpl([]string{"--charset", "hex", "test.key", "100"})
+		expected := "--charset requires --generate"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
+
+func TestPutCommandIfVersion(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, args := range [][]string{
+			{"--if-version", "0", "test.key", "100"},
+			{"--if-version", "1", "test.key", "200"},
+		} {
+			if err := cmd.RunImpl(args); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		err := cmd.RunImpl([]string{"--if-version", "1", "test.key", "300"})
+
+		if !errors.Is(err, gcredstash.ErrVersionConflict) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", gcredstash.ErrVersionConflict, err)
+		}
+
+		actual, _ := driver.GetSecret("test.key", "", "credential-store", nil)
+
+		if actual != "200" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "200", actual)
+		}
+
+		err = cmd.RunImpl([]string{"--if-version", "2", "-a", "test.key", "300"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
+
+func TestPutCommandNaming(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		naming, _ := gcredstash.NewNamingRules("a-zA-Z0-9._-", 16, true, []string{"internal."})
+
+		driver.Naming = naming
+
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, args := range [][]string{
+			{"-a", "DB.Pass", "100"},
+			{"-a", "--no-normalize", "DB.Pass", "200"},
+		} {
+			if err := cmd.RunImpl(args); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		for name, expected := range map[string]string{"db.pass": "100", "DB.Pass": "200"} {
+			actual, _ := driver.GetSecret(name, "", "credential-store", nil)
+
+			if actual != expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+			}
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"db pass", "100"}, "invalid name: db pass (allowed characters: a-zA-Z0-9._-)"},
+			{[]string{"a.very.long.name.here", "100"}, "invalid name: a.very.long.name.here (longer than 16 characters)"},
+			{[]string{"internal.key", "100"}, "invalid name: internal.key (prefix internal. is reserved)"},
+		} {
+			err := cmd.RunImpl(tc.args)
+
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+			}
+		}
+	})
+}
+
+func TestPutCommandIdempotent(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected int
+		}{
+			{[]string{"--idempotent", "test.key", "100"}, 1},
+			{[]string{"--idempotent", "test.key", "100"}, 1},
+			{[]string{"--idempotent", "test.key", "100", "env=prod"}, 2},
+			{[]string{"--idempotent", "test.key", "200", "env=prod"}, 3},
+		} {
+			if err := cmd.RunImpl(tc.args); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			version, _ := driver.GetHighestVersion("test.key", "credential-store")
+
+			if version != tc.expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, version)
+			}
+		}
+
+		expected := "--idempotent cannot be combined with -a, -v, --if-version, --skip-if-unchanged or --regions"
+		err := cmd.RunImpl([]string{"--idempotent", "-a", "test.key", "100"})
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
+
+func TestPutCommandPassphrase(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		expected := "--passphrase needs GCREDSTASH_PASSPHRASE_FILE or GCREDSTASH_PASSPHRASE"
+		err := cmd.RunImpl([]string{"--passphrase", "test.key", "100"})
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		driver.Passphrase = func(name string) ([]byte, error) { return []byte("correct horse"), nil }
+
+		if err := cmd.RunImpl([]string{"--passphrase", "test.key", "100"}); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		material, _ := driver.GetMaterialWithVersion("test.key", "0000000000000000001", "credential-store")
+		value, err := driver.GetSecret("test.key", "", "credential-store", nil)
+
+		if !gcredstash.IsPassphraseProtected(material) || value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+	})
+}
+
+func TestPutCommandCompress(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &PutCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		expected := "invalid compression: lz4 (must be gzip or zstd)"
+		err := cmd.RunImpl([]string{"--compress", "lz4", "test.key", "100"})
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		if err := cmd.RunImpl([]string{"--compress", "zstd", "test.key", "100"}); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		material, _ := driver.GetMaterialWithVersion("test.key", "0000000000000000001", "credential-store")
+		value, err := driver.GetSecret("test.key", "", "credential-store", nil)
+
+		if *material["compression"].S != gcredstash.COMPRESSION_ZSTD || value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type PutallCommand struct {
+	Meta
+}
+
+func (c *PutallCommand) parseArgs(args []string) (string, string, map[string]string, *gcredstash.PutOptions, error) {
+	opts := &gcredstash.PutOptions{}
+	newArgs, scheme, err := ParseOptionWithValue(args, "--scheme")
+
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	opts.Scheme = scheme
+	newArgs, digest, err := ParseOptionWithValue(newArgs, "-d")
+
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	opts.Digest = digest
+	newArgs, opts.Comment, err = ParseOptionWithValue(newArgs, "--comment")
+
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	newArgs, version, err := ParseVersion(newArgs)
+
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	if len(newArgs) < 1 {
+		return "", "", nil, nil, fmt.Errorf("too few arguments")
+	}
+
+	context, err := gcredstash.ParseContext(newArgs[1:])
+
+	return newArgs[0], version, context, opts, err
+}
+
+func (c *PutallCommand) readSecrets(filename string) (map[string]string, error) {
+	var content string
+	var err error
+
+	if filename == "-" {
+		content = ReadStdin()
+	} else {
+		content, err = gcredstash.ReadFile(filename)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	secrets := map[string]string{}
+	err = json.Unmarshal([]byte(content), &secrets)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: expected a JSON object of credential names to values: %s", filename, err.Error())
+	}
+
+	return secrets, nil
+}
+
+func (c *PutallCommand) RunImpl(args []string) (string, error) {
+	filename, version, context, opts, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	secrets, err := c.readSecrets(filename)
+
+	if err != nil {
+		return "", err
+	}
+
+	version, err = c.Driver.PutSecrets(secrets, version, c.KmsKey, c.Table, context, opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d credentials have been stored -- version: %d\n", len(secrets), gcredstash.Atoi(version)), nil
+}
+
+func (c *PutallCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *PutallCommand) Synopsis() string {
+	return "Put several credentials at one version in a transaction"
+}
+
+func (c *PutallCommand) Help() string {
+	helpText := `
+usage: gcredstash putall [-v VERSION] [--scheme aes-ctr|aes-gcm] [-d DIGEST] [--comment COMMENT] file [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
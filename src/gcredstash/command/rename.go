@@ -0,0 +1,143 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type RenameCommand struct {
+	Meta
+}
+
+type renameArgs struct {
+	from       string
+	to         string
+	fromPrefix string
+	toPrefix   string
+	deleteOld  bool
+}
+
+func (c *RenameCommand) parseArgs(args []string) (*renameArgs, error) {
+	parsed := &renameArgs{}
+	newArgs, deleteOld := HasOption(args, "--delete")
+	parsed.deleteOld = deleteOld
+
+	newArgs, fromPrefix, err := ParseOptionWithValue(newArgs, "--from-prefix")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// --to-prefix may be empty, to drop the prefix.
+	newArgs, toPrefixes, err := ParseOptionWithValues(newArgs, "--to-prefix")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromPrefix != "" || len(toPrefixes) > 0 {
+		if fromPrefix == "" || len(toPrefixes) != 1 {
+			return nil, fmt.Errorf("--from-prefix and --to-prefix must be given together")
+		}
+
+		if len(newArgs) > 0 {
+			return nil, fmt.Errorf("too many arguments")
+		}
+
+		parsed.fromPrefix = fromPrefix
+		parsed.toPrefix = toPrefixes[0]
+
+		return parsed, nil
+	}
+
+	if len(newArgs) < 2 {
+		return nil, fmt.Errorf("too few arguments")
+	}
+
+	if len(newArgs) > 2 {
+		return nil, fmt.Errorf("too many arguments")
+	}
+
+	parsed.from = newArgs[0]
+	parsed.to = newArgs[1]
+
+	return parsed, nil
+}
+
+func (c *RenameCommand) RunImpl(args []string) (string, error) {
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	var renamed []*gcredstash.RenamedSecret
+
+	if parsed.fromPrefix != "" {
+		renamed, err = c.Driver.RenameSecrets(parsed.fromPrefix, parsed.toPrefix, c.Table, parsed.deleteOld)
+	} else {
+		var secret *gcredstash.RenamedSecret
+		secret, err = c.Driver.RenameSecret(parsed.from, parsed.to, c.Table, parsed.deleteOld)
+
+		if secret != nil {
+			renamed = append(renamed, secret)
+		}
+	}
+
+	verb := "copied"
+
+	if parsed.deleteOld {
+		verb = "renamed"
+	}
+
+	lines := []string{}
+
+	for _, secret := range renamed {
+		for _, version := range secret.Versions {
+			lines = append(lines, fmt.Sprintf("%s -- version %d has been %s to %s", secret.Name, gcredstash.Atoi(version), verb, secret.NewName))
+		}
+	}
+
+	out := ""
+
+	if len(lines) > 0 {
+		out = strings.Join(lines, "\n") + "\n"
+	}
+
+	if err != nil {
+		return out, err
+	}
+
+	if len(renamed) == 0 {
+		return out, fmt.Errorf("no credentials start with %s", parsed.fromPrefix)
+	}
+
+	return out, nil
+}
+
+func (c *RenameCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *RenameCommand) Synopsis() string {
+	return "Copy or move all versions of credentials to new names"
+}
+
+func (c *RenameCommand) Help() string {
+	helpText := `
+usage: gcredstash rename [--delete] credential new-name
+       gcredstash rename [--delete] --from-prefix PREFIX --to-prefix PREFIX
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestRenameCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		put := &PutCommand{Meta: meta}
+		put.RunImpl([]string{"old.db.pass", "100"})
+		put.RunImpl([]string{"old.db.pass", "200", "-a"})
+		cmd := &RenameCommand{Meta: meta}
+
+		out, err := cmd.RunImpl([]string{"--delete", "old.db.pass", "db.pass"})
+		expected := "old.db.pass -- version 1 has been renamed to db.pass\nold.db.pass -- version 2 has been renamed to db.pass\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, err = cmd.RunImpl([]string{"--from-prefix", "db.", "--to-prefix", "app.db."})
+		expected = "db.pass -- version 1 has been copied to app.db.pass\ndb.pass -- version 2 has been copied to app.db.pass\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		_, err = cmd.RunImpl([]string{"--from-prefix", "old.", "--to-prefix", "new."})
+
+		if err == nil || err.Error() != "no credentials start with old." {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "no credentials start with old.", err)
+		}
+
+		_, err = cmd.RunImpl([]string{"--from-prefix", "db."})
+
+		if err == nil || err.Error() != "--from-prefix and --to-prefix must be given together" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "--from-prefix and --to-prefix must be given together", err)
+		}
+	})
+}
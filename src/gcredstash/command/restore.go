@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type RestoreCommand struct {
+	Meta
+}
+
+// parseNameVersion parses "credential [version]".
+func parseNameVersion(args []string) (string, string, error) {
+	if len(args) < 1 {
+		return "", "", fmt.Errorf("too few arguments")
+	}
+
+	if len(args) > 2 {
+		return "", "", fmt.Errorf("too many arguments")
+	}
+
+	version := ""
+
+	if len(args) == 2 {
+		ver, err := strconv.Atoi(args[1])
+
+		if err != nil {
+			return "", "", fmt.Errorf("invalid version: %s", args[1])
+		}
+
+		version = gcredstash.VersionNumToStr(ver)
+	}
+
+	return args[0], version, nil
+}
+
+func (c *RestoreCommand) RunImpl(args []string) (string, error) {
+	credential, version, err := parseNameVersion(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	restored, err := c.Driver.RestoreSecrets(credential, version, c.Table)
+	lines := []string{}
+
+	for _, version := range restored {
+		lines = append(lines, fmt.Sprintf("Restored %s -- version %d\n", credential, gcredstash.Atoi(version)))
+	}
+
+	return strings.Join(lines, ""), err
+}
+
+func (c *RestoreCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *RestoreCommand) Synopsis() string {
+	return "Restore soft-deleted versions of a credential"
+}
+
+func (c *RestoreCommand) Help() string {
+	helpText := `
+usage: gcredstash restore credential [version]
+`
+	return strings.TrimSpace(helpText)
+}
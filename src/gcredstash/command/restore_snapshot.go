@@ -0,0 +1,60 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+type RestoreSnapshotCommand struct {
+	Meta
+}
+
+func (c *RestoreSnapshotCommand) RunImpl(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments")
+	}
+
+	archive, err := ioutil.ReadFile(args[0])
+
+	if err != nil {
+		return "", err
+	}
+
+	meta, restored, err := c.Driver.RestoreSnapshot(archive, c.Table)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d of %d items from the snapshot of %s taken at %s have been restored to %s\n", restored, meta.ItemCount, meta.Table, meta.CreatedAt, c.Table), nil
+}
+
+func (c *RestoreSnapshotCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *RestoreSnapshotCommand) Synopsis() string {
+	return "Put the items of a snapshot back into the store"
+}
+
+func (c *RestoreSnapshotCommand) Help() string {
+	helpText := `
+usage: gcredstash restore-snapshot FILE
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.Events = &gcredstash.NopEventHandler{}
+		driver.SoftDelete = true
+
+		table := "credential-store"
+		driver.PutSecret("test.key", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("test.key", "200", "0000000000000000002", "alias/credstash", table, nil)
+		driver.DeleteSecrets("test.key", "", table)
+
+		meta := Meta{Table: table, Driver: driver}
+		out, err := (&ListCommand{Meta: meta}).RunImpl([]string{"--deleted"})
+		expected := "test.key -- version: 1\ntest.key -- version: 2"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		cmd := &RestoreCommand{Meta: meta}
+		out, err = cmd.RunImpl([]string{"test.key", "1"})
+		expected = "Restored test.key -- version 1\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		value, _ := driver.GetSecret("test.key", "", table, nil)
+
+		if value != "100" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "100", value)
+		}
+
+		out, err = cmd.RunImpl([]string{"test.key"})
+		expected = "Restored test.key -- version 2\n"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		_, err = cmd.RunImpl([]string{"test.key"})
+		expected = "test.key has no deleted versions"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		_, err = cmd.RunImpl([]string{"test.key", "x"})
+		expected = "invalid version: x"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
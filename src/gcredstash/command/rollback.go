@@ -0,0 +1,82 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type RollbackCommand struct {
+	Meta
+}
+
+func (c *RollbackCommand) parseArgs(args []string) (string, string, map[string]string, error) {
+	if len(args) < 1 {
+		return "", "", nil, fmt.Errorf("too few arguments")
+	}
+
+	credential := args[0]
+	rest := args[1:]
+	version := ""
+
+	if len(rest) > 0 && !strings.Contains(rest[0], "=") {
+		ver, err := strconv.Atoi(rest[0])
+
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid version: %s", rest[0])
+		}
+
+		version = gcredstash.VersionNumToStr(ver)
+		rest = rest[1:]
+	}
+
+	context, err := gcredstash.ParseContext(rest)
+
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	return credential, version, context, nil
+}
+
+func (c *RollbackCommand) RunImpl(args []string) (string, error) {
+	credential, version, context, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	newVersion, err := c.Driver.Rollback(credential, version, c.KmsKey, c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s has been rolled back as version %d\n", credential, gcredstash.Atoi(newVersion)), nil
+}
+
+func (c *RollbackCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *RollbackCommand) Synopsis() string {
+	return "Store an earlier version of a credential as the latest one"
+}
+
+func (c *RollbackCommand) Help() string {
+	helpText := `
+usage: gcredstash rollback credential [version] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
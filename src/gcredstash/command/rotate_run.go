@@ -0,0 +1,169 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"gcredstash"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const ROTATE_PLUGIN_PREFIX = "gcredstash-rotate-"
+
+type RotateRunCommand struct {
+	Meta
+}
+
+// commandRotator runs a shell command or a plugin executable to rotate a
+// credential. It reads the current value on stdin and prints the next one;
+// the verify command reads the next value on stdin and must exit with 0.
+type commandRotator struct {
+	command string
+	plugin  string
+	verify  string
+	version string
+}
+
+func (rotator *commandRotator) run(cmd *exec.Cmd, name string, stdin []byte) ([]byte, error) {
+	stdout := &bytes.Buffer{}
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"GCREDSTASH_NAME="+name,
+		"GCREDSTASH_VERSION="+rotator.version,
+	)
+
+	err := cmd.Run()
+
+	return stdout.Bytes(), err
+}
+
+func (rotator *commandRotator) Rotate(name string, current []byte) ([]byte, error) {
+	cmd := exec.Command("sh", "-c", rotator.command)
+
+	if rotator.plugin != "" {
+		cmd = exec.Command(rotator.plugin, name)
+	}
+
+	out, err := rotator.run(cmd, name, current)
+
+	if err != nil {
+		gcredstash.Wipe(out)
+		return nil, err
+	}
+
+	return []byte(gcredstash.StripNewline(string(out))), nil
+}
+
+func (rotator *commandRotator) Verify(name string, next []byte) error {
+	if rotator.verify == "" {
+		return nil
+	}
+
+	out, err := rotator.run(exec.Command("sh", "-c", rotator.verify), name, next)
+	os.Stderr.Write(out)
+
+	return err
+}
+
+func (c *RotateRunCommand) parseArgs(args []string) (*commandRotator, string, map[string]string, error) {
+	newArgs, command, err := ParseOptionWithValue(args, "--command")
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	newArgs, plugin, err := ParseOptionWithValue(newArgs, "--plugin")
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	newArgs, verify, err := ParseOptionWithValue(newArgs, "--verify")
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if (command == "") == (plugin == "") {
+		return nil, "", nil, fmt.Errorf("either --command or --plugin is required")
+	}
+
+	if plugin != "" {
+		path, err := exec.LookPath(ROTATE_PLUGIN_PREFIX + plugin)
+
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("plugin not found: %s", ROTATE_PLUGIN_PREFIX+plugin)
+		}
+
+		plugin = path
+	}
+
+	if len(newArgs) < 1 {
+		return nil, "", nil, fmt.Errorf("too few arguments")
+	}
+
+	context, err := gcredstash.ParseContext(newArgs[1:])
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return &commandRotator{command: command, plugin: plugin, verify: verify}, newArgs[0], context, nil
+}
+
+func (c *RotateRunCommand) RunImpl(args []string) (string, error) {
+	rotator, credential, context, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	latest, err := c.Driver.GetHighestVersion(credential, c.Table)
+
+	if err != nil {
+		return "", err
+	}
+
+	rotator.version = fmt.Sprintf("%d", latest+1)
+
+	// The rotation command changes the secret outside the store, so a dry
+	// run must not start it.
+	if c.Driver.DryRun {
+		return fmt.Sprintf("dry-run: rotate %s to version %d in %s\n", credential, latest+1, c.Table), nil
+	}
+
+	version, err := c.Driver.RotateSecret(credential, rotator, c.KmsKey, c.Table, context, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s has been rotated to version %d\n", credential, gcredstash.Atoi(version)), nil
+}
+
+func (c *RotateRunCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *RotateRunCommand) Synopsis() string {
+	return "Rotate a credential with a command and store the new value"
+}
+
+func (c *RotateRunCommand) Help() string {
+	helpText := `
+usage: gcredstash rotate-run (--command CMD | --plugin NAME) [--verify CMD] credential [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
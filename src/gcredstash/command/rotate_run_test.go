@@ -0,0 +1,50 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestRotateRunCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &RotateRunCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		driver.PutSecret("db.password", "old", "0000000000000000001", "alias/credstash", "credential-store", nil)
+
+		out, err := cmd.RunImpl([]string{"--command", `echo "$(cat)-$GCREDSTASH_VERSION"`, "--verify", `grep -q '^old-2$'`, "db.password"})
+		expected := "db.password has been rotated to version 2\n"
+
+		if err != nil || out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		value, _ := driver.GetSecret("db.password", "", "credential-store", nil)
+
+		if value != "old-2" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "old-2", value)
+		}
+
+		_, err = cmd.RunImpl([]string{"--command", "echo new", "--verify", "false", "db.password"})
+		expected = "db.password: verify: exit status 1"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		_, err = cmd.RunImpl([]string{"--plugin", "no-such-plugin", "db.password"})
+		expected = "plugin not found: gcredstash-rotate-no-such-plugin"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"sort"
+	"strings"
+)
+
+type SearchCommand struct {
+	Meta
+}
+
+func (c *SearchCommand) parseArgs(args []string) (string, string, map[string]string, error) {
+	mode := gcredstash.SEARCH_SUBSTRING
+	modes := 0
+
+	args, tagArgs, err := ParseOptionWithValues(args, "--tag")
+
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	tags, err := gcredstash.ParseTags(tagArgs)
+
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	for _, opt := range []string{gcredstash.SEARCH_GLOB, gcredstash.SEARCH_REGEX, gcredstash.SEARCH_FUZZY} {
+		var hasOpt bool
+		args, hasOpt = HasOption(args, "--"+opt)
+
+		if hasOpt {
+			mode = opt
+			modes++
+		}
+	}
+
+	if modes > 1 {
+		return "", "", nil, fmt.Errorf("--glob, --regex and --fuzzy cannot be combined")
+	}
+
+	if len(args) < 1 {
+		return "", "", nil, fmt.Errorf("too few arguments")
+	}
+
+	if len(args) > 1 {
+		return "", "", nil, fmt.Errorf("too many arguments")
+	}
+
+	return args[0], mode, tags, nil
+}
+
+func (c *SearchCommand) RunImpl(args []string) (string, error) {
+	term, mode, tags, err := c.parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	match, err := gcredstash.NewNameMatcher(term, mode)
+
+	if err != nil {
+		return "", err
+	}
+
+	found, err := c.Driver.SearchSecrets(c.Table, match)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(tags) > 0 {
+		tagged, err := c.Driver.TaggedNames(c.Table, "", tags)
+
+		if err != nil {
+			return "", err
+		}
+
+		for name := range found {
+			if !tagged[name] {
+				delete(found, name)
+			}
+		}
+	}
+
+	names := []string{}
+	maxNameLen := 0
+
+	for name := range found {
+		names = append(names, name)
+
+		if len(name) > maxNameLen {
+			maxNameLen = len(name)
+		}
+	}
+
+	sort.Strings(names)
+	lines := []string{}
+
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%-*s -- version: %d", maxNameLen, name, found[name]))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (c *SearchCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	if out != "" {
+		fmt.Println(out)
+	}
+
+	return 0
+}
+
+func (c *SearchCommand) Synopsis() string {
+	return "Search credential names"
+}
+
+func (c *SearchCommand) Help() string {
+	helpText := `
+usage: gcredstash search [--glob|--regex|--fuzzy] [--tag KEY=VALUE ...] term
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestSearchCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("app.db.password", "s3cr3t", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("app.db.password", "s3cr3t", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("app.db.user", "admin", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("web.admin_Password", "pass", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &SearchCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"PASSWORD"}, "app.db.password    -- version: 2\nweb.admin_Password -- version: 1"},
+			{[]string{"--glob", "*.DB.*"}, "app.db.password -- version: 2\napp.db.user     -- version: 1"},
+			{[]string{"--regex", "^web\\."}, "web.admin_Password -- version: 1"},
+			{[]string{"--fuzzy", "dbpw"}, "app.db.password -- version: 2"},
+			{[]string{"nothing"}, ""},
+		} {
+			out, err := cmd.RunImpl(tc.args)
+
+			if out != tc.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", tc.expected, out, err)
+			}
+		}
+
+		for _, args := range [][]string{
+			{},
+			{"--glob", "--regex", "a"},
+			{"--regex", "("},
+		} {
+			if _, err := cmd.RunImpl(args); err == nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+			}
+		}
+	})
+}
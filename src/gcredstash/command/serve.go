@@ -0,0 +1,151 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const DEFAULT_SERVE_LISTEN = ":8443"
+
+type ServeCommand struct {
+	Meta
+}
+
+type serveArgs struct {
+	listen   string
+	certFile string
+	keyFile  string
+	clientCA string
+	cacheTTL time.Duration
+}
+
+func (c *ServeCommand) parseArgs(args []string) (*serveArgs, error) {
+	return parseServeArgs(args, DEFAULT_SERVE_LISTEN)
+}
+
+// parseServeArgs parses the options shared by serve and serve-grpc.
+func parseServeArgs(args []string, defaultListen string) (*serveArgs, error) {
+	parsed := &serveArgs{}
+	newArgs := args
+	var err error
+
+	for _, opt := range []struct {
+		name  string
+		value *string
+	}{
+		{"--listen", &parsed.listen},
+		{"--cert", &parsed.certFile},
+		{"--key", &parsed.keyFile},
+		{"--client-ca", &parsed.clientCA},
+	} {
+		newArgs, *opt.value, err = ParseOptionWithValue(newArgs, opt.name)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newArgs, cacheTTL, err := ParseOptionWithValue(newArgs, "--cache-ttl")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(newArgs) > 0 {
+		return nil, fmt.Errorf("too many arguments")
+	}
+
+	if parsed.certFile == "" || parsed.keyFile == "" || parsed.clientCA == "" {
+		return nil, fmt.Errorf("--cert, --key and --client-ca are required")
+	}
+
+	if parsed.listen == "" {
+		parsed.listen = defaultListen
+	}
+
+	if cacheTTL != "" {
+		parsed.cacheTTL, err = gcredstash.ParseAge(cacheTTL)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parsed, nil
+}
+
+func (c *ServeCommand) NewServer(cacheTTL time.Duration) *gcredstash.Server {
+	server := &gcredstash.Server{
+		Client:  &gcredstash.Client{Driver: c.Driver, Table: c.Table, KmsKey: c.KmsKey},
+		Metrics: gcredstash.NewMetrics(),
+		Logger:  c.Driver.Logger,
+	}
+
+	// The AWS clients are only instrumented when they are the SDK's own.
+	if svc, ok := c.Driver.Ddb.(*dynamodb.DynamoDB); ok {
+		server.Metrics.AddHandlers(&svc.Handlers)
+	}
+
+	if svc, ok := c.Driver.Kms.(*kms.KMS); ok {
+		server.Metrics.AddHandlers(&svc.Handlers)
+	}
+
+	if cacheTTL > 0 {
+		server.Cache = gcredstash.NewSecretCache(c.Driver, c.Table, nil, cacheTTL)
+	}
+
+	return server
+}
+
+func (c *ServeCommand) RunImpl(args []string) error {
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := gcredstash.NewServerTLSConfig(parsed.certFile, parsed.keyFile, parsed.clientCA)
+
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Addr:              parsed.listen,
+		Handler:           c.NewServer(parsed.cacheTTL),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(os.Stderr, "listening on %s\n", parsed.listen)
+
+	return httpServer.ListenAndServeTLS("", "")
+}
+
+func (c *ServeCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *ServeCommand) Synopsis() string {
+	return "Serve the credential store over HTTPS with client certificates"
+}
+
+func (c *ServeCommand) Help() string {
+	helpText := `
+usage: gcredstash serve [--listen ADDR] --cert FILE --key FILE --client-ca FILE [--cache-ttl DURATION]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,258 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gcredstash"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"os"
+	"secretspb"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DEFAULT_SERVE_GRPC_LISTEN = ":50051"
+
+type ServeGrpcCommand struct {
+	Meta
+}
+
+// GrpcServer implements the Secrets service of secretspb with a Client, as
+// gcredstash.Server does over HTTP. Reads of the latest version without a
+// context are served from Cache when it is set.
+type GrpcServer struct {
+	secretspb.UnimplementedSecretsServer
+
+	Client *gcredstash.Client
+	Cache  *gcredstash.SecretCache
+	Logger gcredstash.Logger
+}
+
+func (server *GrpcServer) logger() gcredstash.Logger {
+	if server.Logger == nil {
+		return &gcredstash.NopLogger{}
+	}
+
+	return server.Logger
+}
+
+// grpcError maps an error to a status with the code a client can act on.
+func grpcError(err error) error {
+	code := codes.Internal
+
+	switch {
+	case errors.Is(err, gcredstash.ErrSecretNotFound), errors.Is(err, gcredstash.ErrVersionNotFound):
+		code = codes.NotFound
+	case errors.Is(err, gcredstash.ErrVersionExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, gcredstash.ErrReadOnly), gcredstash.IsAccessDenied(err):
+		code = codes.PermissionDenied
+	case errors.Is(err, gcredstash.ErrContextRequired):
+		code = codes.InvalidArgument
+	case errors.Is(err, gcredstash.ErrExpired):
+		code = codes.FailedPrecondition
+	case gcredstash.IsThrottle(err):
+		code = codes.ResourceExhausted
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+
+	return status.Error(code, err.Error())
+}
+
+func versionOptions(name string, version int64) ([]gcredstash.CallOption, error) {
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if version < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid version: %d", version)
+	}
+
+	if version == 0 {
+		return nil, nil
+	}
+
+	return []gcredstash.CallOption{gcredstash.WithVersion(int(version))}, nil
+}
+
+func (server *GrpcServer) Get(ctx context.Context, req *secretspb.GetRequest) (*secretspb.Secret, error) {
+	opts, err := versionOptions(req.GetName(), req.GetVersion())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	var version string
+
+	if server.Cache != nil && len(opts) == 0 && len(req.GetContext()) == 0 {
+		value, version, err = server.Cache.GetWithVersion(req.GetName())
+	} else {
+		opts = append(opts, gcredstash.WithEncryptionContext(req.GetContext()))
+		value, version, err = server.Client.GetBytesWithVersion(ctx, req.GetName(), opts...)
+	}
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &secretspb.Secret{Name: req.GetName(), Version: int64(gcredstash.Atoi(version)), Value: value}, nil
+}
+
+func (server *GrpcServer) Put(ctx context.Context, req *secretspb.PutRequest) (*secretspb.Secret, error) {
+	opts, err := versionOptions(req.GetName(), req.GetVersion())
+
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, gcredstash.WithEncryptionContext(req.GetContext()))
+	version, err := server.Client.PutBytes(ctx, req.GetName(), req.GetValue(), opts...)
+	gcredstash.Wipe(req.GetValue())
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if server.Cache != nil {
+		server.Cache.Invalidate(req.GetName())
+	}
+
+	return &secretspb.Secret{Name: req.GetName(), Version: int64(version)}, nil
+}
+
+func (server *GrpcServer) List(ctx context.Context, req *secretspb.ListRequest) (*secretspb.ListResponse, error) {
+	names, err := server.Client.List(ctx)
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	res := &secretspb.ListResponse{}
+
+	for name, strs := range names {
+		secret := &secretspb.SecretVersions{Name: name}
+
+		for _, str := range strs {
+			secret.Versions = append(secret.Versions, int64(gcredstash.Atoi(str)))
+		}
+
+		res.Secrets = append(res.Secrets, secret)
+	}
+
+	sort.Slice(res.Secrets, func(i, j int) bool {
+		return res.Secrets[i].Name < res.Secrets[j].Name
+	})
+
+	return res, nil
+}
+
+func (server *GrpcServer) Delete(ctx context.Context, req *secretspb.DeleteRequest) (*secretspb.DeleteResponse, error) {
+	opts, err := versionOptions(req.GetName(), req.GetVersion())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.Client.Delete(ctx, req.GetName(), opts...); err != nil {
+		return nil, grpcError(err)
+	}
+
+	if server.Cache != nil {
+		server.Cache.Invalidate(req.GetName())
+	}
+
+	return &secretspb.DeleteResponse{}, nil
+}
+
+// LogRequest logs each call with the common name of the client certificate.
+func (server *GrpcServer) LogRequest(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	client := ""
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			client = tlsInfo.State.PeerCertificates[0].Subject.CommonName
+		}
+	}
+
+	if client != "" {
+		server.logger().Verbosef("%s client=%s", info.FullMethod, client)
+	} else {
+		server.logger().Verbosef("%s", info.FullMethod)
+	}
+
+	return handler(ctx, req)
+}
+
+func (c *ServeGrpcCommand) NewServer(cacheTTL time.Duration) *GrpcServer {
+	server := &GrpcServer{
+		Client: &gcredstash.Client{Driver: c.Driver, Table: c.Table, KmsKey: c.KmsKey},
+		Logger: c.Driver.Logger,
+	}
+
+	if cacheTTL > 0 {
+		server.Cache = gcredstash.NewSecretCache(c.Driver, c.Table, nil, cacheTTL)
+	}
+
+	return server
+}
+
+func (c *ServeGrpcCommand) RunImpl(args []string) error {
+	parsed, err := parseServeArgs(args, DEFAULT_SERVE_GRPC_LISTEN)
+
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := gcredstash.NewServerTLSConfig(parsed.certFile, parsed.keyFile, parsed.clientCA)
+
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", parsed.listen)
+
+	if err != nil {
+		return err
+	}
+
+	server := c.NewServer(parsed.cacheTTL)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)), grpc.UnaryInterceptor(server.LogRequest))
+	secretspb.RegisterSecretsServer(grpcServer, server)
+
+	fmt.Fprintf(os.Stderr, "listening on %s\n", parsed.listen)
+
+	return grpcServer.Serve(listener)
+}
+
+func (c *ServeGrpcCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *ServeGrpcCommand) Synopsis() string {
+	return "Serve the credential store over gRPC with client certificates"
+}
+
+func (c *ServeGrpcCommand) Help() string {
+	helpText := `
+usage: gcredstash serve-grpc [--listen ADDR] --cert FILE --key FILE --client-ca FILE [--cache-ttl DURATION]
+`
+	return strings.TrimSpace(helpText)
+}
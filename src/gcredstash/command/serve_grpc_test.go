@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"reflect"
+	"secretspb"
+	"testing"
+	"time"
+)
+
+func TestGrpcServer(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &ServeGrpcCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		server := cmd.NewServer(time.Minute)
+		ctx := context.Background()
+
+		for _, value := range []string{"100", "200"} {
+			if _, err := server.Put(ctx, &secretspb.PutRequest{Name: "foo.bar", Value: []byte(value)}); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+		}
+
+		secret, err := server.Put(ctx, &secretspb.PutRequest{Name: "foo.baz", Value: []byte("300"), Context: map[string]string{"app": "web"}})
+
+		if secret.GetVersion() != 1 || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", 1, secret.GetVersion(), err)
+		}
+
+		for _, tc := range []struct {
+			req     *secretspb.GetRequest
+			version int64
+			value   string
+		}{
+			{&secretspb.GetRequest{Name: "foo.bar"}, 2, "200"},
+			{&secretspb.GetRequest{Name: "foo.bar", Version: 1}, 1, "100"},
+			{&secretspb.GetRequest{Name: "foo.baz", Context: map[string]string{"app": "web"}}, 1, "300"},
+		} {
+			secret, err := server.Get(ctx, tc.req)
+
+			if secret.GetVersion() != tc.version || string(secret.GetValue()) != tc.value || err != nil {
+				t.Errorf("\nexpected: %v %v\ngot: %v %v %v\n", tc.version, tc.value, secret.GetVersion(), string(secret.GetValue()), err)
+			}
+		}
+
+		list, _ := server.List(ctx, &secretspb.ListRequest{})
+		expected := []*secretspb.SecretVersions{{Name: "foo.bar", Versions: []int64{1, 2}}, {Name: "foo.baz", Versions: []int64{1}}}
+
+		if !reflect.DeepEqual(list.GetSecrets(), expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, list.GetSecrets())
+		}
+
+		if _, err := server.Delete(ctx, &secretspb.DeleteRequest{Name: "foo.bar", Version: 1}); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		for _, tc := range []struct {
+			req  *secretspb.GetRequest
+			code codes.Code
+		}{
+			{&secretspb.GetRequest{Name: "foo.bar", Version: 1}, codes.NotFound},
+			{&secretspb.GetRequest{Name: "foo.qux"}, codes.NotFound},
+			{&secretspb.GetRequest{Name: "foo.bar", Version: -1}, codes.InvalidArgument},
+			{&secretspb.GetRequest{}, codes.InvalidArgument},
+		} {
+			if _, err := server.Get(ctx, tc.req); status.Code(err) != tc.code {
+				t.Errorf("\nexpected: %v\ngot: %v\n", tc.code, err)
+			}
+		}
+
+		driver.ReadOnly = true
+		_, err = server.Put(ctx, &secretspb.PutRequest{Name: "foo.bar", Value: []byte("400")})
+
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("\nexpected: %v\ngot: %v\n", codes.PermissionDenied, err)
+		}
+	})
+}
+
+func TestServeGrpcCommandArgs(t *testing.T) {
+	cmd := &ServeGrpcCommand{}
+
+	for _, tc := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--cert", "server.pem"}, "--cert, --key and --client-ca are required"},
+		{[]string{"extra"}, "too many arguments"},
+	} {
+		err := cmd.RunImpl(tc.args)
+
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"gcredstash"
 	"os"
 	"strings"
 )
@@ -10,12 +11,41 @@ type SetupCommand struct {
 	Meta
 }
 
+func (c *SetupCommand) parseArgs(args []string) (*gcredstash.TableOptions, error) {
+	argsWithoutB, billingMode, err := ParseOptionWithValue(args, "--billing-mode")
+
+	if err != nil {
+		return nil, err
+	}
+
+	argsWithoutBS, sse := HasOption(argsWithoutB, "--sse")
+	argsWithoutBSP, pitr := HasOption(argsWithoutBS, "--pitr")
+	argsWithoutBSPS, stream := HasOption(argsWithoutBSP, "--stream")
+	newArgs, ttl := HasOption(argsWithoutBSPS, "--ttl")
+
+	if len(newArgs) > 0 {
+		return nil, fmt.Errorf("too many arguments")
+	}
+
+	opts := &gcredstash.TableOptions{
+		BillingMode:         strings.ToUpper(billingMode),
+		SSEEnabled:          sse,
+		PointInTimeRecovery: pitr,
+		StreamEnabled:       stream,
+		TimeToLive:          ttl,
+	}
+
+	return opts, nil
+}
+
 func (c *SetupCommand) RunImpl(args []string) error {
-	if len(args) > 0 {
-		return fmt.Errorf("too many arguments")
+	opts, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
 	}
 
-	err := c.Driver.CreateDdbTable(c.Meta.Table)
+	err = c.Driver.CreateDdbTableWithOptions(c.Meta.Table, opts)
 
 	if err != nil {
 		return err
@@ -29,7 +59,7 @@ func (c *SetupCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	return 0
@@ -41,7 +71,7 @@ func (c *SetupCommand) Synopsis() string {
 
 func (c *SetupCommand) Help() string {
 	helpText := `
-usage: gcredstash setup
+usage: gcredstash setup [--billing-mode PROVISIONED|PAY_PER_REQUEST] [--sse] [--pitr] [--stream] [--ttl]
 `
 	return strings.TrimSpace(helpText)
 }
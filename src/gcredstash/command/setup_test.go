@@ -74,3 +74,90 @@ func TestSetupCommand(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
 	}
 }
+
+func TestSetupCommandWithBillingMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	table := "credential-store"
+
+	mddb.EXPECT().ListTablesPages(
+		&dynamodb.ListTablesInput{},
+		gomock.Any(),
+	).Return(nil)
+
+	mddb.EXPECT().CreateTable(&dynamodb.CreateTableInput{
+		TableName: aws.String(table),
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("name"),
+				KeyType:       aws.String("HASH"),
+			},
+			{
+				AttributeName: aws.String("version"),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("name"),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String("version"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		BillingMode: aws.String("PAY_PER_REQUEST"),
+		SSESpecification: &dynamodb.SSESpecification{
+			Enabled: aws.Bool(true),
+		},
+	}).Return(nil, nil)
+
+	mddb.EXPECT().DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	}).Return(&dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{
+			TableStatus: aws.String("ACTIVE"),
+		},
+	}, nil)
+
+	cmd := &SetupCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms},
+		},
+	}
+
+	args := []string{"--billing-mode", "pay_per_request", "--sse"}
+	err := cmd.RunImpl(args)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestSetupCommandReadOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	cmd := &SetupCommand{
+		Meta: Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: &gcredstash.Driver{Ddb: mddb, Kms: mkms, ReadOnly: true},
+		},
+	}
+
+	err := cmd.RunImpl([]string{})
+
+	if err != gcredstash.ErrReadOnly {
+		t.Errorf("\nexpected: %v\ngot: %v\n", gcredstash.ErrReadOnly, err)
+	}
+}
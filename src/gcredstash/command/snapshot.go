@@ -0,0 +1,64 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type SnapshotCommand struct {
+	Meta
+}
+
+func (c *SnapshotCommand) RunImpl(args []string) (string, error) {
+	newArgs, file, err := ParseOptionWithValue(args, "-o")
+
+	if err != nil {
+		return "", err
+	}
+
+	if file == "" {
+		return "", fmt.Errorf("-o is required")
+	}
+
+	if len(newArgs) > 0 {
+		return "", fmt.Errorf("too many arguments")
+	}
+
+	archive, meta, err := c.Driver.Snapshot(c.Table, c.KmsKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := gcredstash.WriteSnapshot(file, archive); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d items of %s have been written to %s\n", meta.ItemCount, meta.Table, file), nil
+}
+
+func (c *SnapshotCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *SnapshotCommand) Synopsis() string {
+	return "Export every item, still encrypted, to a signed archive"
+}
+
+func (c *SnapshotCommand) Help() string {
+	helpText := `
+usage: gcredstash snapshot -o FILE
+`
+	return strings.TrimSpace(helpText)
+}
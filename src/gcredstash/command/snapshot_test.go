@@ -0,0 +1,44 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestSnapshotCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", "credential-store", nil)
+
+		testutils.TempFile("", func(archive *os.File) {
+			meta := Meta{Table: "credential-store", KmsKey: "alias/credstash", Driver: driver}
+			out, err := (&SnapshotCommand{Meta: meta}).RunImpl([]string{"-o", archive.Name()})
+			expected := "1 items of credential-store have been written to " + archive.Name() + "\n"
+
+			if out != expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+			}
+
+			meta.Table = "restored"
+			out, err = (&RestoreSnapshotCommand{Meta: meta}).RunImpl([]string{archive.Name()})
+
+			if err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			value, _ := driver.GetSecret("foo.bar", "", "restored", nil)
+
+			if value != "100" {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "100", value)
+			}
+		})
+
+		_, err := (&SnapshotCommand{Meta: Meta{Driver: driver}}).RunImpl([]string{})
+
+		if err == nil || err.Error() != "-o is required" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "-o is required", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+)
+
+type StatusCommand struct {
+	Meta
+}
+
+func (c *StatusCommand) format(checks []*gcredstash.StatusCheck) string {
+	version := c.Version
+
+	if version == "" {
+		version = "unknown"
+	}
+
+	lines := []string{fmt.Sprintf("%-9s gcredstash %s", "Version:", version)}
+
+	for _, check := range checks {
+		lines = append(lines, fmt.Sprintf("%-9s %s: %s", check.Name+":", check.Detail, checkResult(check.Err)))
+
+		if check.Hint != "" {
+			lines = append(lines, fmt.Sprintf("%-9s -> %s", "", check.Hint))
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (c *StatusCommand) RunImpl(args []string) (string, error) {
+	newArgs, credential, err := ParseOptionWithValue(args, "--credential")
+
+	if err != nil {
+		return "", err
+	}
+
+	context, err := gcredstash.ParseContext(newArgs)
+
+	if err != nil {
+		return "", err
+	}
+
+	checks := c.Driver.Status(c.Table, c.KmsKey, credential, context)
+	failed := 0
+
+	for _, check := range checks {
+		if check.Err != nil {
+			failed++
+		}
+	}
+
+	out := c.format(checks)
+
+	if failed > 0 {
+		return out, fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+
+	return out, nil
+}
+
+func (c *StatusCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	fmt.Print(out)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *StatusCommand) Synopsis() string {
+	return "Check that the table, KMS key and permissions work"
+}
+
+func (c *StatusCommand) Help() string {
+	helpText := `
+usage: gcredstash status [--credential CREDENTIAL] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
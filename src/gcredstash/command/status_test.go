@@ -0,0 +1,49 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestStatusCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &StatusCommand{
+			Meta: Meta{
+				Table:   "credential-store",
+				KmsKey:  "alias/credstash",
+				Version: "0.3.5",
+				Driver:  driver,
+			},
+		}
+
+		out, err := cmd.RunImpl([]string{})
+		expected := `Version:  gcredstash 0.3.5
+Table:    credential-store (not DynamoDB, skipped): OK
+KMS key:  alias/credstash (local master key, skipped): OK
+Query:    gcredstash-status: OK
+GetItem:  gcredstash-status -- version 1: OK
+Decrypt:  a data key of alias/credstash: OK
+`
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, err = cmd.RunImpl([]string{"--credential", "test.key"})
+		expected = `Version:  gcredstash 0.3.5
+Table:    credential-store (not DynamoDB, skipped): OK
+KMS key:  alias/credstash (local master key, skipped): OK
+Query:    test.key: NG (Item {'name': 'test.key'} couldn't be found.)
+          -> check the credential name and GCREDSTASH_NAMESPACE
+GetItem:  test.key -- version 1: OK
+Decrypt:  a data key of alias/credstash: OK
+`
+
+		if out != expected || err == nil || err.Error() != "1 of 5 checks failed" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+	})
+}
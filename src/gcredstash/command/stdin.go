@@ -0,0 +1,24 @@
+package command
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func ReadStdin() string {
+	return strings.TrimRight(ReadStdinRaw(), "\n")
+}
+
+// ReadStdinRaw reads stdin without removing trailing newlines.
+func ReadStdinRaw() string {
+	reader := bufio.NewReader(os.Stdin)
+	input, err := ioutil.ReadAll(reader)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return string(input)
+}
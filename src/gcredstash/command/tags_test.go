@@ -0,0 +1,59 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestTagFilters(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		meta := Meta{
+			Table:  "credential-store",
+			KmsKey: "alias/credstash",
+			Driver: driver,
+		}
+
+		put := &PutCommand{Meta: meta}
+		put.RunImpl([]string{"db.pass", "100", "--tag", "team=payments", "--tag", "env=prod"})
+		put.RunImpl([]string{"db.user", "admin", "--tag", "team=payments", "--tag", "env=dev"})
+		put.RunImpl([]string{"web.token", "abc"})
+
+		if err := put.RunImpl([]string{"web.key", "abc", "--tag", "team"}); err == nil || err.Error() != "invalid tag: team (must be key=value)" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "invalid tag: team (must be key=value)", err)
+		}
+
+		out, err := (&ListCommand{Meta: meta}).RunImpl([]string{"--tag", "team=payments"})
+		expected := "db.pass -- version: 1\ndb.user -- version: 1"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, err = (&SearchCommand{Meta: meta}).RunImpl([]string{"--tag", "env=prod", "db"})
+		expected = "db.pass -- version: 1"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		del := &DeleteCommand{Meta: meta}
+
+		if err := del.RunImpl([]string{"--tag", "env=dev", "-v", "1"}); err == nil || err.Error() != "-v cannot be combined with --tag" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "-v cannot be combined with --tag", err)
+		}
+
+		if err := del.RunImpl([]string{"--tag", "team=payments", "db."}); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		out, err = (&ListCommand{Meta: meta}).RunImpl([]string{})
+		expected = "web.token -- version: 1"
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+	})
+}
@@ -17,7 +17,7 @@ type TemplateCommand struct {
 }
 
 func (c *TemplateCommand) parseArgs(args []string) (string, bool, error) {
-	newArgs, inPlace := gcredstash.HasOption(args, "-i")
+	newArgs, inPlace := HasOption(args, "-i")
 
 	if len(newArgs) < 1 {
 		return "", false, fmt.Errorf("too few arguments")
@@ -36,7 +36,7 @@ func (c *TemplateCommand) readTemplate(filename string) (string, error) {
 	var content string
 
 	if filename == "-" {
-		content = gcredstash.ReadStdin()
+		content = ReadStdin()
 	} else {
 		var err error
 		content, err = gcredstash.ReadFile(filename)
@@ -167,12 +167,19 @@ func (c *TemplateCommand) executeTemplate(name string, content string) (string,
 }
 
 func (c *TemplateCommand) RunImpl(args []string) (string, error) {
+	args, force := HasOption(args, "--force-stdout")
 	tmplFile, inPlace, err := c.parseArgs(args)
 
 	if err != nil {
 		return "", err
 	}
 
+	if !inPlace {
+		if err := c.checkTerminalOutput(force); err != nil {
+			return "", err
+		}
+	}
+
 	tmplContent, err := c.readTemplate(tmplFile)
 
 	if err != nil {
@@ -194,7 +201,7 @@ func (c *TemplateCommand) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
 	fmt.Print(out)
@@ -208,7 +215,7 @@ func (c *TemplateCommand) Synopsis() string {
 
 func (c *TemplateCommand) Help() string {
 	helpText := `
-usage: gcredstash template [-i] template_file
+usage: gcredstash template [-i | --force-stdout] template_file
 `
 	return strings.TrimSpace(helpText)
 }
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type TfDataCommand struct {
+	Meta
+}
+
+func (c *TfDataCommand) RunImpl(args []string) (string, error) {
+	if len(args) > 0 {
+		return "", fmt.Errorf("too many arguments")
+	}
+
+	query, err := gcredstash.ParseTerraformQuery(ReadStdinRaw())
+
+	if err != nil {
+		return "", err
+	}
+
+	if query.Prefix != "" {
+		creds, err := c.Driver.GetSecretsByPattern(query.Prefix+"*", c.Table, query.Context)
+
+		if err != nil {
+			return "", err
+		}
+
+		return gcredstash.MapToJson(creds) + "\n", nil
+	}
+
+	value, version, err := c.Driver.GetSecretBytesWithVersion(query.Name, query.Version, c.Table, query.Context)
+
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]string{
+		"name":    query.Name,
+		"value":   string(value),
+		"version": strconv.Itoa(gcredstash.Atoi(version)),
+	}
+
+	return gcredstash.MapToJson(result) + "\n", nil
+}
+
+func (c *TfDataCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *TfDataCommand) Synopsis() string {
+	return "Answer a Terraform external data source query"
+}
+
+func (c *TfDataCommand) Help() string {
+	helpText := `
+usage: gcredstash tf-data < query.json
+
+query: {"name": "NAME", "version": "VERSION", ...context}
+       {"prefix": "PREFIX", ...context}
+`
+	return strings.TrimSpace(helpText)
+}
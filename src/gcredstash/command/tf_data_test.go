@@ -0,0 +1,73 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func runTfData(cmd *TfDataCommand, query string) (string, error) {
+	var out string
+	var err error
+
+	testutils.TempFile(query, func(f *os.File) {
+		f.Seek(0, 0)
+		stdin := os.Stdin
+		os.Stdin = f
+		defer func() { os.Stdin = stdin }()
+
+		out, err = cmd.RunImpl([]string{})
+	})
+
+	return out, err
+}
+
+func TestTfDataCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("app.db.password", "old", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("app.db.password", "s3cr3t", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("app.db.user", "app", "0000000000000000001", "alias/credstash", table, nil)
+
+		cmd := &TfDataCommand{
+			Meta: Meta{
+				Table:  table,
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		out, err := runTfData(cmd, `{"name": "app.db.password"}`)
+
+		expected := `{
+  "name": "app.db.password",
+  "value": "s3cr3t",
+  "version": "2"
+}
+`
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, _ = runTfData(cmd, `{"name": "app.db.password", "version": "1"}`)
+
+		if out != "{\n  \"name\": \"app.db.password\",\n  \"value\": \"old\",\n  \"version\": \"1\"\n}\n" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "old", out)
+		}
+
+		out, err = runTfData(cmd, `{"prefix": "app.db."}`)
+
+		expected = `{
+  "app.db.password": "s3cr3t",
+  "app.db.user": "app"
+}
+`
+
+		if out != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+	})
+}
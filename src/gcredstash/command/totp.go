@@ -0,0 +1,112 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+	"time"
+)
+
+type TotpCommand struct {
+	Meta
+}
+
+func (c *TotpCommand) RunImpl(args []string) (string, error) {
+	newArgs, put := HasOption(args, "--put")
+
+	if put {
+		return c.put(newArgs)
+	}
+
+	newArgs, version, err := ParseVersion(newArgs)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(newArgs) < 1 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	credential := newArgs[0]
+	context, err := gcredstash.ParseContext(newArgs[1:])
+
+	if err != nil {
+		return "", err
+	}
+
+	seed, err := c.Driver.GetSecret(credential, version, c.Table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	key, err := gcredstash.ParseTOTPKey(seed)
+
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", credential, err.Error())
+	}
+
+	code, _ := key.Code(time.Now())
+
+	return code + "\n", nil
+}
+
+func (c *TotpCommand) put(args []string) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("too few arguments")
+	}
+
+	credential := args[0]
+	seed := args[1]
+
+	if seed == "-" {
+		seed = ReadStdin()
+	}
+
+	seed = strings.TrimSpace(seed)
+
+	if _, err := gcredstash.ParseTOTPKey(seed); err != nil {
+		return "", err
+	}
+
+	context, err := gcredstash.ParseContext(args[2:])
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.Driver.PutSecretNextVersion(credential, seed, c.KmsKey, c.Table, context, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s has been stored\n", credential), nil
+}
+
+func (c *TotpCommand) Run(args []string) int {
+	out, err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	fmt.Print(out)
+
+	return 0
+}
+
+func (c *TotpCommand) Synopsis() string {
+	return "Store a TOTP seed or print its current code"
+}
+
+func (c *TotpCommand) Help() string {
+	helpText := `
+usage: gcredstash totp [-v VERSION] credential [context [context ...]]
+       gcredstash totp --put credential seed|otpauth-uri|- [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"gcredstash"
+	. "gcredstash/command"
+	"gcredstash/testutils"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestTotpCommand(t *testing.T) {
+	testutils.TempDriver(func(driver *gcredstash.Driver, f *os.File) {
+		cmd := &TotpCommand{
+			Meta: Meta{
+				Table:  "credential-store",
+				KmsKey: "alias/credstash",
+				Driver: driver,
+			},
+		}
+
+		out, err := cmd.RunImpl([]string{"--put", "svc.2fa", "JBSWY3DPEHPK3PXP"})
+		expected := "svc.2fa has been stored\n"
+
+		if err != nil || out != expected {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, out, err)
+		}
+
+		out, err = cmd.RunImpl([]string{"svc.2fa"})
+
+		if err != nil || !regexp.MustCompile(`^\d{6}\n$`).MatchString(out) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "6 digits", out, err)
+		}
+
+		_, err = cmd.RunImpl([]string{"--put", "svc.2fa", "not a seed!"})
+		expected = "invalid TOTP seed: must be base32"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
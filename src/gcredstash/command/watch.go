@@ -0,0 +1,115 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const DEFAULT_WATCH_INTERVAL = 5 * time.Second
+
+type WatchCommand struct {
+	Meta
+}
+
+func (c *WatchCommand) parseArgs(args []string) (string, time.Duration, string, error) {
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	newArgs, intervalStr, err := ParseOptionWithValue(newArgs, "--interval")
+
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	newArgs, hook, err := ParseOptionWithValue(newArgs, "--exec")
+
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	if len(newArgs) > 0 {
+		return "", 0, "", fmt.Errorf("too many arguments")
+	}
+
+	interval := DEFAULT_WATCH_INTERVAL
+
+	if intervalStr != "" {
+		interval, err = gcredstash.ParseAge(intervalStr)
+
+		if err != nil || interval <= 0 {
+			return "", 0, "", fmt.Errorf("invalid interval: %s", intervalStr)
+		}
+	}
+
+	return prefix, interval, hook, nil
+}
+
+func FormatChangeEvent(event *gcredstash.ChangeEvent) string {
+	return fmt.Sprintf("%s %s -- version: %d", event.Type, event.Name, gcredstash.Atoi(event.Version))
+}
+
+// runHook runs the hook command with sh, passing the event in the
+// environment. A failing hook is reported but does not stop the watch.
+func (c *WatchCommand) runHook(hook string, event *gcredstash.ChangeEvent) {
+	cmd := exec.Command("sh", "-c", hook)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"GCREDSTASH_EVENT="+event.Type,
+		"GCREDSTASH_NAME="+event.Name,
+		"GCREDSTASH_VERSION="+fmt.Sprintf("%d", gcredstash.Atoi(event.Version)),
+	)
+
+	err := cmd.Run()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s: %s\n", hook, err.Error())
+	}
+}
+
+func (c *WatchCommand) RunImpl(args []string) error {
+	prefix, interval, hook, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Driver.WatchChanges(c.Table, prefix, interval, func(event *gcredstash.ChangeEvent) error {
+		if hook == "" {
+			fmt.Println(FormatChangeEvent(event))
+		} else {
+			c.runHook(hook, event)
+		}
+
+		return nil
+	})
+}
+
+func (c *WatchCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *WatchCommand) Synopsis() string {
+	return "Print or run a command on credential changes"
+}
+
+func (c *WatchCommand) Help() string {
+	helpText := `
+usage: gcredstash watch [--prefix PREFIX] [--interval DURATION] [--exec COMMAND]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,128 @@
+package command
+
+import (
+	"fmt"
+	"gcredstash"
+	"os"
+	"strings"
+	"time"
+)
+
+type WriteFilesCommand struct {
+	Meta
+}
+
+type writeFilesArgs struct {
+	prefix   string
+	dir      string
+	watch    bool
+	interval time.Duration
+	context  map[string]string
+}
+
+func (c *WriteFilesCommand) parseArgs(args []string) (*writeFilesArgs, error) {
+	parsed := &writeFilesArgs{interval: DEFAULT_WATCH_INTERVAL}
+	newArgs, prefix, err := ParseOptionWithValue(args, "--prefix")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, dir, err := ParseOptionWithValue(newArgs, "--dir")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, intervalStr, err := ParseOptionWithValue(newArgs, "--interval")
+
+	if err != nil {
+		return nil, err
+	}
+
+	newArgs, parsed.watch = HasOption(newArgs, "--watch")
+
+	if dir == "" {
+		return nil, fmt.Errorf("--dir is required")
+	}
+
+	if intervalStr != "" {
+		parsed.interval, err = gcredstash.ParseAge(intervalStr)
+
+		if err != nil || parsed.interval <= 0 {
+			return nil, fmt.Errorf("invalid interval: %s", intervalStr)
+		}
+	}
+
+	parsed.prefix = prefix
+	parsed.dir = dir
+	parsed.context, err = gcredstash.ParseContext(newArgs)
+
+	return parsed, err
+}
+
+func FormatFileChange(change gcredstash.FileChange) string {
+	if change.Removed {
+		return fmt.Sprintf("%s -- %s has been removed", change.Name, change.Path)
+	}
+
+	return fmt.Sprintf("%s -- version: %d has been written to %s", change.Name, gcredstash.Atoi(change.Version), change.Path)
+}
+
+func (c *WriteFilesCommand) sync(parsed *writeFilesArgs, versions map[string]string) error {
+	changes, err := c.Driver.SyncSecretFiles(parsed.dir, parsed.prefix, c.Table, parsed.context, versions)
+
+	for _, change := range changes {
+		fmt.Println(FormatFileChange(change))
+	}
+
+	return err
+}
+
+func (c *WriteFilesCommand) RunImpl(args []string) error {
+	parsed, err := c.parseArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	versions := map[string]string{}
+	err = c.sync(parsed, versions)
+
+	if err != nil || !parsed.watch {
+		return err
+	}
+
+	// Keep serving the files already written if the store cannot be read
+	// for a while.
+	for {
+		time.Sleep(parsed.interval)
+		err = c.sync(parsed, versions)
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		}
+	}
+}
+
+func (c *WriteFilesCommand) Run(args []string) int {
+	err := c.RunImpl(args)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return ExitCode(err)
+	}
+
+	return 0
+}
+
+func (c *WriteFilesCommand) Synopsis() string {
+	return "Write credentials to files in a directory"
+}
+
+func (c *WriteFilesCommand) Help() string {
+	helpText := `
+usage: gcredstash write-files [--prefix PREFIX] --dir DIR [--watch] [--interval DURATION] [context [context ...]]
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,112 @@
+package gcredstash
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	COMPRESSION_GZIP = "gzip"
+	COMPRESSION_ZSTD = "zstd"
+)
+
+// MAX_DECOMPRESSED_SIZE caps what Decompress inflates a value to, so that
+// a crafted item cannot exhaust the memory of the reader.
+const MAX_DECOMPRESSED_SIZE = 64 * 1024 * 1024
+
+func ValidateCompression(compression string) error {
+	switch compression {
+	case "", COMPRESSION_GZIP, COMPRESSION_ZSTD:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression: %s (must be gzip or zstd)", compression)
+	}
+}
+
+// Compress compresses plaintext with the named algorithm. An empty name
+// returns plaintext as is.
+func Compress(compression string, plaintext []byte) ([]byte, error) {
+	switch compression {
+	case "":
+		return plaintext, nil
+	case COMPRESSION_GZIP:
+		var buf bytes.Buffer
+		writer, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := writer.Write(plaintext); err != nil {
+			return nil, err
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	case COMPRESSION_ZSTD:
+		encoder, err := zstd.NewWriter(nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer encoder.Close()
+
+		return encoder.EncodeAll(plaintext, nil), nil
+	}
+
+	return nil, ValidateCompression(compression)
+}
+
+// Decompress reverses Compress. It fails when the value inflates to more
+// than MAX_DECOMPRESSED_SIZE.
+func Decompress(compression string, compressed []byte) ([]byte, error) {
+	switch compression {
+	case "":
+		return compressed, nil
+	case COMPRESSION_GZIP:
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer reader.Close()
+
+		return readDecompressed(reader)
+	case COMPRESSION_ZSTD:
+		decoder, err := zstd.NewReader(bytes.NewReader(compressed), zstd.WithDecoderMaxMemory(MAX_DECOMPRESSED_SIZE))
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer decoder.Close()
+
+		return readDecompressed(decoder)
+	}
+
+	return nil, ValidateCompression(compression)
+}
+
+func readDecompressed(reader io.Reader) ([]byte, error) {
+	plaintext, err := ioutil.ReadAll(io.LimitReader(reader, MAX_DECOMPRESSED_SIZE+1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plaintext) > MAX_DECOMPRESSED_SIZE {
+		Wipe(plaintext)
+		return nil, fmt.Errorf("the decompressed value exceeds %d bytes", MAX_DECOMPRESSED_SIZE)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,102 @@
+package gcredstash
+
+import (
+	"fmt"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	plaintext := []byte(strings.Repeat(`{"key":"value"}`, 1000))
+
+	for _, compression := range []string{"", COMPRESSION_GZIP, COMPRESSION_ZSTD} {
+		compressed, err := Compress(compression, plaintext)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		decompressed, err := Decompress(compression, compressed)
+
+		if string(decompressed) != string(plaintext) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "the same plaintext", compression, err)
+		}
+	}
+
+	compressed, _ := Compress(COMPRESSION_GZIP, plaintext)
+
+	if len(compressed) >= len(plaintext)/10 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "a smaller value", len(compressed))
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	err := ValidateCompression("lz4")
+	expected := "invalid compression: lz4 (must be gzip or zstd)"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+
+	if _, err := Decompress("lz4", nil); err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestDecompressLimit(t *testing.T) {
+	expected := fmt.Sprintf("the decompressed value exceeds %d bytes", MAX_DECOMPRESSED_SIZE)
+
+	for _, compression := range []string{COMPRESSION_GZIP, COMPRESSION_ZSTD} {
+		compressed, _ := Compress(compression, make([]byte, MAX_DECOMPRESSED_SIZE+1))
+		_, err := Decompress(compression, compressed)
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	}
+}
+
+func TestPutCompressed(t *testing.T) {
+	table := "credential-store"
+	value := strings.Repeat("-----BEGIN CERTIFICATE-----\n", 100)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		opts := &PutOptions{Compression: COMPRESSION_GZIP, Passphrase: []byte("correct horse")}
+
+		err := driver.PutSecretWithOptions("app.bundle", value, VersionNumToStr(1), "alias/credstash", table, nil, opts)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		material, _ := driver.GetMaterialWithVersion("app.bundle", VersionNumToStr(1), table)
+
+		if *material["compression"].S != COMPRESSION_GZIP || len(*material["contents"].S) >= len(value) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "compressed contents", material)
+		}
+
+		driver.Passphrase = func(name string) ([]byte, error) { return []byte("correct horse"), nil }
+		got, err := driver.GetSecret("app.bundle", "", table, nil)
+
+		if got != value || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", value, got, err)
+		}
+
+		driver.PutSecret("app.bundle", "plain", VersionNumToStr(2), "alias/credstash", table, nil)
+		version, err := driver.Rollback("app.bundle", VersionNumToStr(1), "alias/credstash", table, nil)
+		material, _ = driver.GetMaterialWithVersion("app.bundle", version, table)
+
+		if material["compression"] == nil || *material["compression"].S != COMPRESSION_GZIP || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "a rollback that keeps the compression", material, err)
+		}
+
+		err = driver.PutSecretWithOptions("app.bundle", value, VersionNumToStr(9), "alias/credstash", table, nil, &PutOptions{Compression: "lz4"})
+
+		if err == nil || err.Error() != "invalid compression: lz4 (must be gzip or zstd)" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "invalid compression: lz4 (must be gzip or zstd)", err)
+		}
+	})
+}
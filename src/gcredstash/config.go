@@ -0,0 +1,696 @@
+package gcredstash
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const DEFAULT_CONFIG_FILE = ".gcredstash.yml"
+
+// ConfigProfile holds the settings that can be given at the top level of the
+// config file, per store or per environment. Store names the store an
+// environment (or the top level) uses; Env is the default environment and
+// can only be set at the top level.
+type ConfigProfile struct {
+	Env       string
+	Table     string
+	KmsKey    string
+	Region    string
+	Profile   string
+	Store     string
+	RateLimit string
+	Namespace string
+	// NamespaceKeys maps name prefixes to the KMS key used on put.
+	NamespaceKeys map[string]string
+	// Imds, SharedConfig, WebIdentityTokenFile and RoleArn control how
+	// AWS credentials are sourced. See CredentialOptions.
+	Imds                 string
+	SharedConfig         string
+	WebIdentityTokenFile string
+	RoleArn              string
+	// AwsSdk selects the AWS SDK used for DynamoDB: v1 (the default) or
+	// v2. See DynamoDBV2Backend.
+	AwsSdk string
+	// NameCharset, NameMaxLength, NameLowercase and NameReservedPrefixes
+	// (comma-separated) are the NamingRules enforced on put.
+	NameCharset          string
+	NameMaxLength        string
+	NameLowercase        string
+	NameReservedPrefixes string
+	// SoftDelete ("true" or "false") makes delete and prune only mark
+	// versions as deleted.
+	SoftDelete string
+	// ChunkedStorage ("true" or "false") stores credentials too large for
+	// a DynamoDB item as several items.
+	ChunkedStorage string
+	// S3Bucket is where values over S3Threshold (e.g. 100KB) are stored;
+	// see Driver.S3Bucket.
+	S3Bucket    string
+	S3Threshold string
+	// ReadKmsKey, if set, is the only KMS key reads decrypt with; KmsKey
+	// stays the key puts use.
+	ReadKmsKey string
+	// RecoveryKmsKey wraps the data key of every put a second time; see
+	// Driver.RecoveryKmsKey.
+	RecoveryKmsKey string
+	// Timeout limits each AWS operation, retries included, e.g. 10s;
+	// DEFAULT_TIMEOUT when empty, none when 0.
+	Timeout string
+	// OfflineCache ("true" or "false") keeps the data keys reads decrypt
+	// in a DataKeyCache, for reads with Driver.Offline.
+	OfflineCache string
+	// KeychainCacheTTL, e.g. 1h, keeps the credentials reads decrypt in the
+	// OS keychain for that long; see Driver.KeychainCache.
+	KeychainCacheTTL string
+	// RefuseTerminalOutput ("true" or "false") makes the CLI refuse to
+	// print plaintext to a terminal without --force-stdout.
+	RefuseTerminalOutput string
+	// DaxEndpoint is a DAX cluster reads go through; see Driver.Dax.
+	DaxEndpoint string
+	// ReadConsistency ("strong" or "eventual") is how the latest version
+	// is read; see Driver.ReadConsistency.
+	ReadConsistency string
+	// FallbackStores (comma-separated store names) are read in order when
+	// a credential is not in this store; see Driver.Fallbacks.
+	FallbackStores string
+	Context        map[string]string
+}
+
+// ConfigFile is the content of ~/.gcredstash.yml:
+//
+//	env: dev
+//	table: credential-store-{env}
+//	kms_key: alias/credstash
+//	read_kms_key: arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab
+//	recovery_kms_key: arn:aws:kms:us-west-2:444455556666:alias/credstash-recovery
+//	region: us-east-1
+//	rate_limit: 20
+//	timeout: 5s
+//	offline_cache: true
+//	keychain_cache_ttl: 1h
+//	refuse_terminal_output: true
+//	dax_endpoint: dax://credstash.abc123.dax-clusters.us-east-1.amazonaws.com
+//	read_consistency: eventual
+//	fallback_stores: shared
+//	namespace: team1/
+//	namespace_keys:
+//	  team1/: alias/credstash-team1
+//	imds: v2
+//	shared_config: false
+//	aws_sdk: v2
+//	name_charset: a-z0-9._-
+//	name_max_length: 64
+//	name_lowercase: true
+//	name_reserved_prefixes: aws.,internal.
+//	soft_delete: true
+//	chunked_storage: true
+//	s3_bucket: credstash-large-values
+//	s3_threshold: 100KB
+//	context:
+//	  app: web
+//	stores:
+//	  prod:
+//	    table: credential-store-prod
+//	    profile: prod
+//	  shared:
+//	    table: credential-store-shared
+//	environments:
+//	  dev:
+//	    context:
+//	      env: dev
+//	  prod:
+//	    store: prod
+//	    context:
+//	      env: prod
+type ConfigFile struct {
+	ConfigProfile
+	Stores       map[string]*ConfigProfile
+	Environments map[string]*ConfigProfile
+}
+
+// ConfigFilePath returns GCREDSTASH_CONFIG if set, or ~/.gcredstash.yml.
+func ConfigFilePath() string {
+	if path := os.Getenv("GCREDSTASH_CONFIG"); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, DEFAULT_CONFIG_FILE)
+}
+
+// LoadConfigFile reads a config file. A missing file is an empty config.
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	cfg := &ConfigFile{
+		Stores:       map[string]*ConfigProfile{},
+		Environments: map[string]*ConfigProfile{},
+	}
+
+	if path == "" {
+		return cfg, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := parseYamlMap(string(content))
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	err = cfg.decode(tree)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	return cfg, nil
+}
+
+func decodeStringMap(key string, value interface{}) (map[string]string, error) {
+	tree, ok := value.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map", key)
+	}
+
+	m := map[string]string{}
+
+	for k, v := range tree {
+		str, ok := v.(string)
+
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be a string", key, k)
+		}
+
+		m[k] = str
+	}
+
+	return m, nil
+}
+
+func (profile *ConfigProfile) decodeKey(key string, value interface{}) (bool, error) {
+	if key == "context" {
+		context, err := decodeStringMap(key, value)
+
+		if err != nil {
+			return true, err
+		}
+
+		profile.Context = context
+
+		return true, nil
+	}
+
+	if key == "namespace_keys" {
+		keys, err := decodeStringMap(key, value)
+
+		if err != nil {
+			return true, err
+		}
+
+		profile.NamespaceKeys = keys
+
+		return true, nil
+	}
+
+	fields := map[string]*string{
+		"env":        &profile.Env,
+		"table":      &profile.Table,
+		"kms_key":    &profile.KmsKey,
+		"region":     &profile.Region,
+		"profile":    &profile.Profile,
+		"store":      &profile.Store,
+		"rate_limit": &profile.RateLimit,
+		"namespace":  &profile.Namespace,
+
+		"imds":                    &profile.Imds,
+		"shared_config":           &profile.SharedConfig,
+		"web_identity_token_file": &profile.WebIdentityTokenFile,
+		"role_arn":                &profile.RoleArn,
+		"aws_sdk":                 &profile.AwsSdk,
+
+		"name_charset":           &profile.NameCharset,
+		"name_max_length":        &profile.NameMaxLength,
+		"name_lowercase":         &profile.NameLowercase,
+		"name_reserved_prefixes": &profile.NameReservedPrefixes,
+
+		"soft_delete":     &profile.SoftDelete,
+		"chunked_storage": &profile.ChunkedStorage,
+
+		"s3_bucket":    &profile.S3Bucket,
+		"s3_threshold": &profile.S3Threshold,
+
+		"read_kms_key":     &profile.ReadKmsKey,
+		"recovery_kms_key": &profile.RecoveryKmsKey,
+
+		"timeout":       &profile.Timeout,
+		"offline_cache": &profile.OfflineCache,
+
+		"keychain_cache_ttl": &profile.KeychainCacheTTL,
+
+		"refuse_terminal_output": &profile.RefuseTerminalOutput,
+
+		"dax_endpoint": &profile.DaxEndpoint,
+
+		"read_consistency": &profile.ReadConsistency,
+
+		"fallback_stores": &profile.FallbackStores,
+	}
+
+	field, ok := fields[key]
+
+	if !ok {
+		return false, nil
+	}
+
+	str, ok := value.(string)
+
+	if !ok {
+		return true, fmt.Errorf("%s must be a string", key)
+	}
+
+	if key == "rate_limit" {
+		if _, err := ParseRateLimit(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "timeout" {
+		if _, err := ParseTimeout(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "keychain_cache_ttl" {
+		if _, err := ParseKeychainCacheTTL(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "imds" {
+		if err := ValidateIMDS(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "shared_config" {
+		if err := ValidateSharedConfig(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "aws_sdk" {
+		if err := ValidateAwsSdk(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "read_consistency" {
+		if err := ValidateReadConsistency(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "soft_delete" {
+		if _, err := strconv.ParseBool(str); err != nil {
+			return true, fmt.Errorf("invalid soft_delete: %s (must be true or false)", str)
+		}
+	}
+
+	if key == "s3_threshold" {
+		if _, err := ParseS3Threshold(str); err != nil {
+			return true, err
+		}
+	}
+
+	if key == "chunked_storage" {
+		if _, err := strconv.ParseBool(str); err != nil {
+			return true, fmt.Errorf("invalid chunked_storage: %s (must be true or false)", str)
+		}
+	}
+
+	if key == "offline_cache" {
+		if _, err := strconv.ParseBool(str); err != nil {
+			return true, fmt.Errorf("invalid offline_cache: %s (must be true or false)", str)
+		}
+	}
+
+	if key == "refuse_terminal_output" {
+		if _, err := strconv.ParseBool(str); err != nil {
+			return true, fmt.Errorf("invalid refuse_terminal_output: %s (must be true or false)", str)
+		}
+	}
+
+	*field = str
+
+	if strings.HasPrefix(key, "name_") {
+		if _, err := profile.NamingRules(); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+func decodeProfiles(section string, value interface{}, allowStore bool) (map[string]*ConfigProfile, error) {
+	profiles := map[string]*ConfigProfile{}
+	tree, ok := value.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map", section)
+	}
+
+	for name, v := range tree {
+		profileTree, ok := v.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be a map", section, name)
+		}
+
+		profile := &ConfigProfile{}
+
+		for key, value := range profileTree {
+			known, err := profile.decodeKey(key, value)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s.%s", section, name, err.Error())
+			}
+
+			if !known || (key == "store" && !allowStore) || key == "env" {
+				return nil, fmt.Errorf("unknown key: %s.%s.%s", section, name, key)
+			}
+		}
+
+		profiles[name] = profile
+	}
+
+	return profiles, nil
+}
+
+func (cfg *ConfigFile) decode(tree map[string]interface{}) error {
+	var err error
+
+	for key, value := range tree {
+		switch key {
+		case "stores":
+			cfg.Stores, err = decodeProfiles(key, value, false)
+		case "environments":
+			cfg.Environments, err = decodeProfiles(key, value, true)
+		default:
+			var known bool
+			known, err = cfg.ConfigProfile.decodeKey(key, value)
+
+			if err == nil && !known {
+				err = fmt.Errorf("unknown key: %s", key)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (resolved *ConfigProfile) merge(profile *ConfigProfile) {
+	for _, field := range []struct{ dst, src *string }{
+		{&resolved.Env, &profile.Env},
+		{&resolved.Table, &profile.Table},
+		{&resolved.KmsKey, &profile.KmsKey},
+		{&resolved.Region, &profile.Region},
+		{&resolved.Profile, &profile.Profile},
+		{&resolved.Store, &profile.Store},
+		{&resolved.RateLimit, &profile.RateLimit},
+		{&resolved.Namespace, &profile.Namespace},
+		{&resolved.Imds, &profile.Imds},
+		{&resolved.SharedConfig, &profile.SharedConfig},
+		{&resolved.WebIdentityTokenFile, &profile.WebIdentityTokenFile},
+		{&resolved.RoleArn, &profile.RoleArn},
+		{&resolved.AwsSdk, &profile.AwsSdk},
+		{&resolved.NameCharset, &profile.NameCharset},
+		{&resolved.NameMaxLength, &profile.NameMaxLength},
+		{&resolved.NameLowercase, &profile.NameLowercase},
+		{&resolved.NameReservedPrefixes, &profile.NameReservedPrefixes},
+		{&resolved.SoftDelete, &profile.SoftDelete},
+		{&resolved.ChunkedStorage, &profile.ChunkedStorage},
+		{&resolved.S3Bucket, &profile.S3Bucket},
+		{&resolved.S3Threshold, &profile.S3Threshold},
+		{&resolved.ReadKmsKey, &profile.ReadKmsKey},
+		{&resolved.RecoveryKmsKey, &profile.RecoveryKmsKey},
+		{&resolved.Timeout, &profile.Timeout},
+		{&resolved.OfflineCache, &profile.OfflineCache},
+		{&resolved.KeychainCacheTTL, &profile.KeychainCacheTTL},
+		{&resolved.RefuseTerminalOutput, &profile.RefuseTerminalOutput},
+		{&resolved.DaxEndpoint, &profile.DaxEndpoint},
+		{&resolved.ReadConsistency, &profile.ReadConsistency},
+		{&resolved.FallbackStores, &profile.FallbackStores},
+	} {
+		if *field.src != "" {
+			*field.dst = *field.src
+		}
+	}
+
+	for k, v := range profile.Context {
+		resolved.Context[k] = v
+	}
+
+	for k, v := range profile.NamespaceKeys {
+		if resolved.NamespaceKeys == nil {
+			resolved.NamespaceKeys = map[string]string{}
+		}
+
+		resolved.NamespaceKeys[k] = v
+	}
+}
+
+// Resolve returns the top-level settings overridden by those of the store
+// and then of the named environment. Context keys are merged. An empty store
+// uses the one named by the environment or the top level, and an empty env
+// the top-level env. When the file has no environments section, any env can
+// be used for {env} placeholders.
+func (cfg *ConfigFile) Resolve(env string, store string) (*ConfigProfile, error) {
+	resolved := &ConfigProfile{Context: map[string]string{}}
+	resolved.merge(&cfg.ConfigProfile)
+
+	if env == "" {
+		env = cfg.Env
+	}
+
+	resolved.Env = env
+
+	var envProfile *ConfigProfile
+
+	if env != "" {
+		var ok bool
+		envProfile, ok = cfg.Environments[env]
+
+		if !ok && len(cfg.Environments) > 0 {
+			return nil, fmt.Errorf("unknown environment: %s", env)
+		}
+
+		if envProfile != nil && envProfile.Store != "" {
+			resolved.Store = envProfile.Store
+		}
+	}
+
+	if store != "" {
+		resolved.Store = store
+	}
+
+	if resolved.Store != "" {
+		storeProfile, ok := cfg.Stores[resolved.Store]
+
+		if !ok {
+			return nil, fmt.Errorf("unknown store: %s", resolved.Store)
+		}
+
+		resolved.merge(storeProfile)
+	}
+
+	if envProfile != nil {
+		store := resolved.Store
+		resolved.merge(envProfile)
+		resolved.Store = store
+	}
+
+	return resolved, nil
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// Expand replaces the {env}, {store} and {region} placeholders in str with
+// the profile's settings, e.g. credential-store-{env}.
+func (profile *ConfigProfile) Expand(str string) (string, error) {
+	var err error
+
+	expanded := templatePlaceholder.ReplaceAllStringFunc(str, func(placeholder string) string {
+		var value string
+
+		switch placeholder {
+		case "{env}":
+			value = profile.Env
+		case "{store}":
+			value = profile.Store
+		case "{region}":
+			value = profile.Region
+		default:
+			if err == nil {
+				err = fmt.Errorf("%s: unknown placeholder: %s", str, placeholder)
+			}
+
+			return placeholder
+		}
+
+		if value == "" && err == nil {
+			err = fmt.Errorf("%s: %s is not set", str, strings.Trim(placeholder, "{}"))
+		}
+
+		return value
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return expanded, nil
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	key    string
+	value  string
+}
+
+// parseYamlMap parses the subset of YAML used by the config file: nested
+// maps of scalar values, with comments and optionally quoted strings.
+func parseYamlMap(content string) (map[string]interface{}, error) {
+	lines := []yamlLine{}
+
+	for i, raw := range strings.Split(content, "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "\t") || strings.HasPrefix(trimmed, "- ") {
+			return nil, fmt.Errorf("line %d: unsupported syntax", i+1)
+		}
+
+		kv := strings.SplitN(trimmed, ":", 2)
+
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("line %d: expected key: value", i+1)
+		}
+
+		value, err := yamlScalar(strings.TrimSpace(kv[1]))
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+1, err.Error())
+		}
+
+		lines = append(lines, yamlLine{
+			num:    i + 1,
+			indent: len(line) - len(trimmed),
+			key:    strings.TrimSpace(kv[0]),
+			value:  value,
+		})
+	}
+
+	tree, rest, err := parseYamlLines(lines, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("line %d: unexpected indentation", rest[0].num)
+	}
+
+	return tree, nil
+}
+
+func parseYamlLines(lines []yamlLine, indent int) (map[string]interface{}, []yamlLine, error) {
+	tree := map[string]interface{}{}
+
+	for len(lines) > 0 {
+		line := lines[0]
+
+		if line.indent < indent {
+			break
+		}
+
+		if line.indent > indent {
+			return nil, nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+
+		if _, ok := tree[line.key]; ok {
+			return nil, nil, fmt.Errorf("line %d: duplicate key: %s", line.num, line.key)
+		}
+
+		lines = lines[1:]
+
+		if line.value != "" || len(lines) == 0 || lines[0].indent <= indent {
+			tree[line.key] = line.value
+			continue
+		}
+
+		child, rest, err := parseYamlLines(lines, lines[0].indent)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tree[line.key] = child
+		lines = rest
+	}
+
+	return tree, lines, nil
+}
+
+func yamlScalar(value string) (string, error) {
+	if strings.HasPrefix(value, "\"") {
+		str, err := strconv.Unquote(value)
+
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted string: %s", value)
+		}
+
+		return str, nil
+	}
+
+	if strings.HasPrefix(value, "'") {
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("invalid quoted string: %s", value)
+		}
+
+		return strings.Replace(value[1:len(value)-1], "''", "'", -1), nil
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value, nil
+}
@@ -0,0 +1,280 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	testutils.TempFile(`
+# defaults
+table: credential-store
+kms_key: "alias/credstash"
+region: us-east-1
+context:
+  app: web
+
+environments:
+  prod:
+    table: credential-store-prod  # production
+    profile: 'prod'
+    context:
+      env: prod
+  staging:
+    region: us-west-2
+    rate_limit: 5
+`, func(f *os.File) {
+		cfg, err := LoadConfigFile(f.Name())
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		prod, err := cfg.Resolve("prod", "")
+
+		expected := &ConfigProfile{
+			Env:     "prod",
+			Table:   "credential-store-prod",
+			KmsKey:  "alias/credstash",
+			Region:  "us-east-1",
+			Profile: "prod",
+			Context: map[string]string{"app": "web", "env": "prod"},
+		}
+
+		if !reflect.DeepEqual(prod, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, prod, err)
+		}
+
+		staging, _ := cfg.Resolve("staging", "")
+
+		if staging.Region != "us-west-2" || staging.Table != "credential-store" || staging.RateLimit != "5" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "us-west-2 credential-store", staging)
+		}
+
+		_, err = cfg.Resolve("dev", "")
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	for _, content := range []string{
+		"tabel: credential-store\n",
+		"table: a\ntable: b\n",
+		"context:\n  app: web\n    env: prod\n",
+		"environments:\n  prod: x\n",
+		"- table\n",
+		"rate_limit: fast\n",
+		"stores:\n  prod:\n    env: prod\n",
+		"imds: v3\n",
+		"shared_config: no\n",
+		"aws_sdk: v3\n",
+		"soft_delete: maybe\n",
+		"chunked_storage: maybe\n",
+		"s3_threshold: big\n",
+		"timeout: forever\n",
+		"offline_cache: maybe\n",
+		"keychain_cache_ttl: a while\n",
+		"refuse_terminal_output: sometimes\n",
+		"read_consistency: weak\n",
+	} {
+		testutils.TempFile(content, func(f *os.File) {
+			_, err := LoadConfigFile(f.Name())
+
+			if err == nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileNotExist(t *testing.T) {
+	cfg, err := LoadConfigFile("/nonexistent/.gcredstash.yml")
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	resolved, _ := cfg.Resolve("", "")
+
+	if resolved.Table != "" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "", resolved.Table)
+	}
+}
+
+func TestDriverDefaultContext(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Context = map[string]string{"env": "prod"}
+		table := "credential-store"
+
+		driver.PutSecret("foo.bar", "baz", "0000000000000000001", "alias/credstash", table, nil)
+
+		value, err := driver.GetSecret("foo.bar", "", table, nil)
+
+		if value != "baz" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "baz", value, err)
+		}
+
+		value, err = driver.GetSecret("foo.bar", "", table, map[string]string{"env": "prod"})
+
+		if value != "baz" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "baz", value, err)
+		}
+
+		plain := &Driver{Backend: driver.Backend, Kms: driver.Kms}
+		_, err = plain.GetSecret("foo.bar", "", table, nil)
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
+
+func TestConfigFileResolveStore(t *testing.T) {
+	testutils.TempFile(`
+kms_key: alias/credstash
+stores:
+  prod:
+    table: credential-store-prod
+    region: us-east-1
+  staging:
+    table: credential-store-staging
+    region: us-west-2
+environments:
+  prod:
+    store: prod
+    context:
+      env: prod
+`, func(f *os.File) {
+		cfg, err := LoadConfigFile(f.Name())
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		prod, _ := cfg.Resolve("prod", "")
+
+		expected := &ConfigProfile{
+			Env:     "prod",
+			Table:   "credential-store-prod",
+			KmsKey:  "alias/credstash",
+			Region:  "us-east-1",
+			Store:   "prod",
+			Context: map[string]string{"env": "prod"},
+		}
+
+		if !reflect.DeepEqual(prod, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, prod)
+		}
+
+		staging, _ := cfg.Resolve("", "staging")
+
+		if staging.Table != "credential-store-staging" || staging.Region != "us-west-2" || staging.KmsKey != "alias/credstash" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "staging store", staging)
+		}
+
+		_, err = cfg.Resolve("", "dev")
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
+
+func TestConfigProfileExpand(t *testing.T) {
+	testutils.TempFile(`
+env: dev
+table: credential-store-{env}
+kms_key: alias/credstash-{env}
+region: us-east-1
+`, func(f *os.File) {
+		cfg, _ := LoadConfigFile(f.Name())
+
+		for _, tc := range []struct {
+			env      string
+			expected string
+		}{
+			{"", "credential-store-dev"},
+			{"prod", "credential-store-prod"},
+		} {
+			profile, err := cfg.Resolve(tc.env, "")
+
+			if err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			table, err := profile.Expand(profile.Table)
+
+			if table != tc.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", tc.expected, table, err)
+			}
+		}
+	})
+
+	profile := &ConfigProfile{Region: "us-east-1"}
+	str, err := profile.Expand("credential-store-{region}")
+
+	if str != "credential-store-us-east-1" || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "credential-store-us-east-1", str, err)
+	}
+
+	for str, expected := range map[string]string{
+		"credential-store-{env}":  "credential-store-{env}: env is not set",
+		"credential-store-{user}": "credential-store-{user}: unknown placeholder: {user}",
+	} {
+		_, err := profile.Expand(str)
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	}
+}
+
+func TestConfigProfileCredentialOptions(t *testing.T) {
+	testutils.TempFile(`
+imds: v2
+environments:
+  eks:
+    shared_config: false
+    web_identity_token_file: /var/run/secrets/eks.amazonaws.com/serviceaccount/token
+    role_arn: arn:aws:iam::123456789012:role/gcredstash
+`, func(f *os.File) {
+		cfg, err := LoadConfigFile(f.Name())
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		profile, _ := cfg.Resolve("eks", "")
+		opts, err := profile.CredentialOptions()
+
+		expected := CredentialOptions{
+			IMDSv2Only:           true,
+			DisableSharedConfig:  true,
+			WebIdentityTokenFile: "/var/run/secrets/eks.amazonaws.com/serviceaccount/token",
+			RoleArn:              "arn:aws:iam::123456789012:role/gcredstash",
+		}
+
+		if opts != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, opts, err)
+		}
+	})
+
+	for _, profile := range []*ConfigProfile{
+		{Imds: "v3"},
+		{SharedConfig: "no"},
+		{RoleArn: "arn:aws:iam::123456789012:role/gcredstash"},
+		{WebIdentityTokenFile: "/tmp/token"},
+	} {
+		_, err := profile.CredentialOptions()
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	}
+}
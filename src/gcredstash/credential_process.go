@@ -0,0 +1,54 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	CREDENTIAL_PROCESS_ACCESS_KEY_ID     = "access_key_id"
+	CREDENTIAL_PROCESS_SECRET_ACCESS_KEY = "secret_access_key"
+	CREDENTIAL_PROCESS_SESSION_TOKEN     = "session_token"
+)
+
+// CredentialProcessOutput is the JSON document that the AWS CLI and SDKs
+// read from the command set as credential_process in ~/.aws/config.
+type CredentialProcessOutput struct {
+	Version         int
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string `json:",omitempty"`
+}
+
+// NewCredentialProcessOutput builds the output from the credentials
+// prefix+"access_key_id", prefix+"secret_access_key" and, if present,
+// prefix+"session_token".
+func NewCredentialProcessOutput(creds map[string]string, prefix string) (*CredentialProcessOutput, error) {
+	output := &CredentialProcessOutput{
+		Version:         1,
+		AccessKeyId:     creds[prefix+CREDENTIAL_PROCESS_ACCESS_KEY_ID],
+		SecretAccessKey: creds[prefix+CREDENTIAL_PROCESS_SECRET_ACCESS_KEY],
+		SessionToken:    creds[prefix+CREDENTIAL_PROCESS_SESSION_TOKEN],
+	}
+
+	if output.AccessKeyId == "" {
+		return nil, fmt.Errorf("%s%s not found", prefix, CREDENTIAL_PROCESS_ACCESS_KEY_ID)
+	}
+
+	if output.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%s%s not found", prefix, CREDENTIAL_PROCESS_SECRET_ACCESS_KEY)
+	}
+
+	return output, nil
+}
+
+// JSON returns the output as the AWS CLI expects it.
+func (output *CredentialProcessOutput) JSON() (string, error) {
+	data, err := json.Marshal(output)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,109 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+const DEFAULT_ROLE_SESSION_NAME = "gcredstash"
+
+// CredentialOptions controls how a session sources its AWS credentials, so
+// that the behavior in containers and on instances with restricted metadata
+// access does not depend on what the SDK happens to find.
+type CredentialOptions struct {
+	// IMDSv2Only disables the fallback to IMDSv1 when the instance metadata
+	// token cannot be fetched.
+	IMDSv2Only bool
+	// DisableSharedConfig ignores ~/.aws/config. ~/.aws/credentials is
+	// still read for the profile.
+	DisableSharedConfig bool
+	// WebIdentityTokenFile and RoleArn assume a role with a web identity
+	// token, as with IAM roles for EKS service accounts.
+	WebIdentityTokenFile string
+	RoleArn              string
+}
+
+// ValidateIMDS checks an imds setting: "v2" forces IMDSv2 and "v1" allows
+// the fallback to IMDSv1, the SDK default.
+func ValidateIMDS(str string) error {
+	switch str {
+	case "", "v1", "v2":
+		return nil
+	}
+
+	return fmt.Errorf("imds must be v1 or v2: %s", str)
+}
+
+// ValidateSharedConfig checks a shared_config setting: "true" or "false".
+func ValidateSharedConfig(str string) error {
+	switch str {
+	case "", "true", "false":
+		return nil
+	}
+
+	return fmt.Errorf("shared_config must be true or false: %s", str)
+}
+
+// CredentialOptions returns the credential sourcing settings of a profile.
+func (profile *ConfigProfile) CredentialOptions() (CredentialOptions, error) {
+	opts := CredentialOptions{
+		IMDSv2Only:           profile.Imds == "v2",
+		DisableSharedConfig:  profile.SharedConfig == "false",
+		WebIdentityTokenFile: profile.WebIdentityTokenFile,
+		RoleArn:              profile.RoleArn,
+	}
+
+	if err := ValidateIMDS(profile.Imds); err != nil {
+		return opts, err
+	}
+
+	if err := ValidateSharedConfig(profile.SharedConfig); err != nil {
+		return opts, err
+	}
+
+	return opts, opts.Validate()
+}
+
+// Validate checks that the web identity token file and role are set together.
+func (opts CredentialOptions) Validate() error {
+	if (opts.WebIdentityTokenFile == "") != (opts.RoleArn == "") {
+		return fmt.Errorf("web_identity_token_file and role_arn must be set together")
+	}
+
+	return nil
+}
+
+func (opts CredentialOptions) apply(options *session.Options) {
+	if opts.IMDSv2Only {
+		options.Config.EC2MetadataEnableFallback = aws.Bool(false)
+	}
+
+	if opts.DisableSharedConfig {
+		options.SharedConfigState = session.SharedConfigDisable
+	}
+}
+
+// newSessionWithCredentials creates a session with the credential options
+// applied. A web identity role replaces the credentials of the session.
+func newSessionWithCredentials(options session.Options, opts CredentialOptions) (*session.Session, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts.apply(&options)
+
+	sess, err := session.NewSessionWithOptions(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.RoleArn != "" {
+		sess.Config.Credentials = stscreds.NewWebIdentityCredentials(
+			sess, opts.RoleArn, DEFAULT_ROLE_SESSION_NAME, opts.WebIdentityTokenFile)
+	}
+
+	return sess, nil
+}
@@ -4,9 +4,41 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
 )
 
+const (
+	SCHEME_AES_CTR = "aes-ctr"
+	SCHEME_AES_GCM = "aes-gcm"
+	DEFAULT_DIGEST = "SHA256"
+)
+
+// Digest algorithm names as stored in the credstash "digest" attribute.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"MD5":    md5.New,
+	"SHA":    sha1.New,
+	"SHA224": sha256.New224,
+	"SHA256": sha256.New,
+	"SHA384": sha512.New384,
+	"SHA512": sha512.New,
+}
+
+// Wipe overwrites b with zeros. It is best effort: copies made by string
+// conversions or the garbage collector are not affected.
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
 func Digest(message []byte, key []byte) []byte {
 	mac := hmac.New(sha256.New, key)
 	mac.Write(message)
@@ -18,6 +50,43 @@ func ValidateHMAC(message []byte, digest []byte, key []byte) bool {
 	return hmac.Equal(digest, expected)
 }
 
+func NormalizeDigest(alg string) (string, error) {
+	if alg == "" {
+		return DEFAULT_DIGEST, nil
+	}
+
+	alg = strings.ToUpper(alg)
+
+	if _, ok := digestAlgorithms[alg]; !ok {
+		return "", fmt.Errorf("unsupported digest algorithm: %s", alg)
+	}
+
+	return alg, nil
+}
+
+func DigestWith(alg string, message []byte, key []byte) ([]byte, error) {
+	alg, err := NormalizeDigest(alg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(digestAlgorithms[alg], key)
+	mac.Write(message)
+
+	return mac.Sum(nil), nil
+}
+
+func ValidateHMACWith(alg string, message []byte, digest []byte, key []byte) (bool, error) {
+	expected, err := DigestWith(alg, message, key)
+
+	if err != nil {
+		return false, err
+	}
+
+	return hmac.Equal(digest, expected), nil
+}
+
 func Crypt(contents []byte, key []byte) []byte {
 	block, err := aes.NewCipher(key)
 
@@ -33,3 +102,56 @@ func Crypt(contents []byte, key []byte) []byte {
 
 	return text
 }
+
+func ValidateScheme(scheme string) error {
+	switch scheme {
+	case "", SCHEME_AES_CTR, SCHEME_AES_GCM:
+		return nil
+	default:
+		return fmt.Errorf("unsupported encryption scheme: %s", scheme)
+	}
+}
+
+func GcmEncrypt(plaintext []byte, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func GcmDecrypt(contents []byte, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contents) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce := contents[:aead.NonceSize()]
+
+	return aead.Open(nil, nonce, contents[aead.NonceSize():], nil)
+}
@@ -38,3 +38,95 @@ func TestCreypt(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
 	}
 }
+
+func TestGcmEncryptDecrypt(t *testing.T) {
+	message := []byte("London Bridge is broken down")
+	key := []byte("My fair lady.000My fair lady.000")
+
+	encrypted, err := GcmEncrypt(message, key)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	actual, err := GcmDecrypt(encrypted, key)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if !bytes.Equal(message, actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", message, actual)
+	}
+
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = GcmDecrypt(encrypted, key)
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestValidateScheme(t *testing.T) {
+	if err := ValidateScheme("aes-gcm"); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := "unsupported encryption scheme: aes-xts"
+	err := ValidateScheme("aes-xts")
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestDigestWith(t *testing.T) {
+	message := []byte("London Bridge is broken down")
+	key := []byte("My fair lady.")
+
+	actual, err := DigestWith("sha256", message, key)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if !bytes.Equal(Digest(message, key), actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", Digest(message, key), actual)
+	}
+
+	actual, err = DigestWith("SHA512", message, key)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if len(actual) != 64 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", 64, len(actual))
+	}
+
+	valid, err := ValidateHMACWith("SHA512", message, actual, key)
+
+	if !valid || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v (%v)\n", true, valid, err)
+	}
+}
+
+func TestDigestWithUnsupportedAlgorithm(t *testing.T) {
+	expected := "unsupported digest algorithm: WHIRLPOOL"
+	_, err := DigestWith("WHIRLPOOL", []byte("message"), []byte("key"))
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestWipe(t *testing.T) {
+	b := []byte("secret")
+	Wipe(b)
+
+	expected := make([]byte, 6)
+
+	if !bytes.Equal(b, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, b)
+	}
+}
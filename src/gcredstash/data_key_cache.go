@@ -0,0 +1,235 @@
+package gcredstash
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DATA_KEY_CACHE_MAX_AGE is how long a data key stays in the cache after it
+// was last decrypted with KMS.
+const DATA_KEY_CACHE_MAX_AGE = 30 * 24 * time.Hour
+
+// DATA_KEY_CACHE_ACCOUNT is the keychain item of the key that encrypts the
+// data key cache.
+const DATA_KEY_CACHE_ACCOUNT = "data-key-cache"
+
+var ErrDataKeyNotCached = errors.New("the data key is not cached")
+
+type dataKeyCacheEntry struct {
+	Key      string `json:"key"`
+	CachedAt string `json:"cached_at"`
+}
+
+// DataKeyCache keeps the data keys KMS decrypted, so that the items they
+// belong to can be decrypted without KMS; see Driver.Offline. Entries are
+// keyed by the wrapped data key and the encryption context, and encrypted
+// with AES-GCM under a local key kept in Keychain. They expire MaxAge
+// (DATA_KEY_CACHE_MAX_AGE when 0) after they were last put.
+type DataKeyCache struct {
+	Path     string
+	Keychain Keychain
+	MaxAge   time.Duration
+	Now      func() time.Time
+
+	mutex sync.Mutex
+}
+
+// DefaultDataKeyCachePath returns gcredstash/data-keys.json in the user's
+// cache directory.
+func DefaultDataKeyCachePath() string {
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "gcredstash", "data-keys.json")
+}
+
+// NewDataKeyCache returns a DataKeyCache in path with its key in keychain.
+func NewDataKeyCache(path string, keychain Keychain) *DataKeyCache {
+	return &DataKeyCache{Path: path, Keychain: keychain}
+}
+
+func (cache *DataKeyCache) now() time.Time {
+	if cache.Now == nil {
+		return time.Now()
+	}
+
+	return cache.Now()
+}
+
+func (cache *DataKeyCache) maxAge() time.Duration {
+	if cache.MaxAge == 0 {
+		return DATA_KEY_CACHE_MAX_AGE
+	}
+
+	return cache.MaxAge
+}
+
+func dataKeyCacheId(wrappedKey []byte, context map[string]string) string {
+	keys := []string{}
+
+	for k := range context {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	hash := sha256.New()
+	hash.Write(wrappedKey)
+
+	for _, k := range keys {
+		hash.Write([]byte("\x00" + k + "\x00" + context[k]))
+	}
+
+	return HexEncode(hash.Sum(nil))
+}
+
+// localKey returns the key of the cache from the keychain, creating it when
+// create is set.
+func (cache *DataKeyCache) localKey(create bool) ([]byte, error) {
+	key, err := cache.Keychain.Get(KEYCHAIN_SERVICE, DATA_KEY_CACHE_ACCOUNT)
+
+	if !errors.Is(err, ErrKeychainItemNotFound) || !create {
+		return key, err
+	}
+
+	key = make([]byte, 32)
+
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	if err := cache.Keychain.Set(KEYCHAIN_SERVICE, DATA_KEY_CACHE_ACCOUNT, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (cache *DataKeyCache) load() (map[string]*dataKeyCacheEntry, error) {
+	entries := map[string]*dataKeyCacheEntry{}
+	content, err := ioutil.ReadFile(cache.Path)
+
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (cache *DataKeyCache) expired(entry *dataKeyCacheEntry) bool {
+	cachedAt, err := time.Parse(time.RFC3339, entry.CachedAt)
+	return err != nil || cache.now().Sub(cachedAt) > cache.maxAge()
+}
+
+// Get returns the data key and HMAC key of wrappedKey under context, or
+// ErrDataKeyNotCached.
+func (cache *DataKeyCache) Get(wrappedKey []byte, context map[string]string) ([]byte, []byte, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entries, err := cache.load()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, ok := entries[dataKeyCacheId(wrappedKey, context)]
+
+	if !ok || cache.expired(entry) {
+		return nil, nil, ErrDataKeyNotCached
+	}
+
+	key, err := cache.localKey(false)
+
+	if errors.Is(err, ErrKeychainItemNotFound) {
+		return nil, nil, ErrDataKeyNotCached
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer Wipe(key)
+
+	plaintext, err := GcmDecrypt(B64Decode(entry.Key), key)
+
+	if err != nil || len(plaintext) != 64 {
+		return nil, nil, &IntegrityError{Message: "data key cache: could not decrypt an entry"}
+	}
+
+	defer Wipe(plaintext)
+
+	return append([]byte{}, plaintext[:32]...), append([]byte{}, plaintext[32:]...), nil
+}
+
+// Put caches the data key and HMAC key of wrappedKey under context, and
+// drops expired entries.
+func (cache *DataKeyCache) Put(wrappedKey []byte, context map[string]string, dataKey []byte, hmacKey []byte) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entries, err := cache.load()
+
+	if err != nil {
+		return err
+	}
+
+	key, err := cache.localKey(true)
+
+	if err != nil {
+		return err
+	}
+
+	defer Wipe(key)
+
+	plaintext := append(append([]byte{}, dataKey...), hmacKey...)
+	defer Wipe(plaintext)
+
+	sealed, err := GcmEncrypt(plaintext, key)
+
+	if err != nil {
+		return err
+	}
+
+	for id, entry := range entries {
+		if cache.expired(entry) {
+			delete(entries, id)
+		}
+	}
+
+	entries[dataKeyCacheId(wrappedKey, context)] = &dataKeyCacheEntry{
+		Key:      B64Encode(sealed),
+		CachedAt: cache.now().UTC().Format(time.RFC3339),
+	}
+
+	content, err := json.MarshalIndent(entries, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cache.Path), 0700); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(cache.Path, append(content, '\n'), 0600)
+}
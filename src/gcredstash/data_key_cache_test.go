@@ -0,0 +1,146 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryKeychain is a Keychain in memory. Like an OS keychain, it returns
+// copies, which the caller may wipe.
+type memoryKeychain map[string][]byte
+
+func (keychain memoryKeychain) Get(service string, account string) ([]byte, error) {
+	secret, ok := keychain[service+"/"+account]
+
+	if !ok {
+		return nil, ErrKeychainItemNotFound
+	}
+
+	return append([]byte{}, secret...), nil
+}
+
+func (keychain memoryKeychain) Set(service string, account string, secret []byte) error {
+	keychain[service+"/"+account] = append([]byte{}, secret...)
+	return nil
+}
+
+func (keychain memoryKeychain) Delete(service string, account string) error {
+	delete(keychain, service+"/"+account)
+	return nil
+}
+
+// unavailableKms is LocalKms during a KMS outage.
+type unavailableKms struct {
+	*LocalKms
+	down bool
+}
+
+func (svc *unavailableKms) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	if svc.down {
+		return nil, awserr.New("KMSInternalException", "service unavailable", nil)
+	}
+
+	return svc.LocalKms.Decrypt(input)
+}
+
+func TestDataKeyCache(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "gcredstash")
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	keychain := memoryKeychain{}
+	cache := NewDataKeyCache(filepath.Join(dir, "cache", "data-keys.json"), keychain)
+	cache.Now = func() time.Time { return now }
+	wrapped := []byte("wrapped")
+	context := map[string]string{"env": "prod"}
+
+	if _, _, err := cache.Get(wrapped, context); !errors.Is(err, ErrDataKeyNotCached) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", ErrDataKeyNotCached, err)
+	}
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	hmacKey := []byte("fedcba9876543210fedcba9876543210")
+
+	if err := cache.Put(wrapped, context, dataKey, hmacKey); err != nil {
+		t.Fatalf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if len(keychain[KEYCHAIN_SERVICE+"/"+DATA_KEY_CACHE_ACCOUNT]) != 32 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "a 32-byte local key", keychain)
+	}
+
+	if info, _ := os.Stat(cache.Path); info == nil || info.Mode().Perm() != 0600 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "-rw-------", info)
+	}
+
+	content, _ := ioutil.ReadFile(cache.Path)
+
+	if len(content) == 0 || strings.Contains(string(content), string(dataKey)) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "an encrypted cache", string(content))
+	}
+
+	gotDataKey, gotHmacKey, err := cache.Get(wrapped, context)
+
+	if err != nil || string(gotDataKey) != string(dataKey) || string(gotHmacKey) != string(hmacKey) {
+		t.Errorf("\nexpected: %v\ngot: %v %v %v\n", string(dataKey), string(gotDataKey), string(gotHmacKey), err)
+	}
+
+	if _, _, err := cache.Get(wrapped, map[string]string{"env": "dev"}); !errors.Is(err, ErrDataKeyNotCached) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", ErrDataKeyNotCached, err)
+	}
+
+	now = now.Add(DATA_KEY_CACHE_MAX_AGE + time.Second)
+
+	if _, _, err := cache.Get(wrapped, context); !errors.Is(err, ErrDataKeyNotCached) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", ErrDataKeyNotCached, err)
+	}
+}
+
+func TestDriverOffline(t *testing.T) {
+	table := "credential-store"
+	dir, _ := ioutil.TempDir("", "gcredstash")
+	defer os.RemoveAll(dir)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		svc := &unavailableKms{LocalKms: driver.Kms.(*LocalKms)}
+		driver.Kms = svc
+		driver.DataKeyCache = NewDataKeyCache(filepath.Join(dir, "data-keys.json"), memoryKeychain{})
+
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("api.key", "200", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		if value, err := driver.GetSecret("db.pass", "", table, nil); value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+
+		svc.down = true
+		driver.Offline = true
+
+		if value, err := driver.GetSecret("db.pass", "", table, nil); value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+
+		_, err := driver.GetSecret("api.key", "", table, nil)
+		expected := "api.key: the data key of version 1 is not cached; read it once online first, with the same encryption context: " +
+			"the data key is not cached"
+
+		if err == nil || err.Error() != expected || !errors.Is(err, ErrDataKeyNotCached) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		err = driver.PutSecret("db.pass", "300", VersionNumToStr(2), "alias/credstash", table, nil)
+
+		if !errors.Is(err, ErrOffline) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrOffline, err)
+		}
+	})
+}
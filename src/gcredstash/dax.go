@@ -0,0 +1,20 @@
+package gcredstash
+
+import (
+	"github.com/aws/aws-dax-go/dax"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+// NewDaxClient returns a client of the DAX cluster at endpoint, e.g.
+// dax://my-cluster.abc123.dax-clusters.us-east-1.amazonaws.com, with the
+// region and credentials of awsSession. See Driver.Dax.
+func NewDaxClient(awsSession *session.Session, endpoint string) (dynamodbiface.DynamoDBAPI, error) {
+	cfg := dax.DefaultConfig()
+	cfg.HostPorts = []string{endpoint}
+	cfg.Region = aws.StringValue(awsSession.Config.Region)
+	cfg.Credentials = awsSession.Config.Credentials
+
+	return dax.New(cfg)
+}
@@ -0,0 +1,18 @@
+// Package gcredstash reads and writes credentials stored in the credstash
+// format (DynamoDB items encrypted with KMS data keys).
+//
+// Programs should use a Client:
+//
+//	client, err := gcredstash.New(&gcredstash.Config{Region: "us-east-1"})
+//	value, err := client.Get(ctx, "db.password")
+//	version, err := client.Put(ctx, "db.password", "s3cr3t")
+//
+// Functions that run many times in one process, such as AWS Lambda
+// handlers, can call Configure once and use the package-level Get and Put,
+// which share one Client and its AWS session.
+//
+// The lower-level Driver type is what the gcredstash command is built on.
+//
+// The package does not depend on the command-line tool: argument parsing and
+// github.com/mitchellh/cli are only used by gcredstash/command.
+package gcredstash
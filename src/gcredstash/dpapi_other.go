@@ -0,0 +1,16 @@
+//go:build !windows
+// +build !windows
+
+package gcredstash
+
+import "errors"
+
+var errDpapiUnsupported = errors.New("DPAPI is only available on windows")
+
+func dpapiProtect(data []byte) ([]byte, error) {
+	return nil, errDpapiUnsupported
+}
+
+func dpapiUnprotect(data []byte) ([]byte, error) {
+	return nil, errDpapiUnsupported
+}
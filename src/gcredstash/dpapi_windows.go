@@ -0,0 +1,70 @@
+//go:build windows
+// +build windows
+
+package gcredstash
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = kernel32.NewProc("LocalFree")
+)
+
+// CRYPTPROTECT_UI_FORBIDDEN
+const cryptProtectUiForbidden = 0x1
+
+// dataBlob is DATA_BLOB.
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+func newDataBlob(data []byte) *dataBlob {
+	if len(data) == 0 {
+		return &dataBlob{}
+	}
+
+	return &dataBlob{size: uint32(len(data)), data: &data[0]}
+}
+
+// take copies the blob out of memory allocated by DPAPI and frees it.
+func (blob *dataBlob) take() []byte {
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(blob.data)))
+
+	data := make([]byte, blob.size)
+	copy(data, (*[1 << 30]byte)(unsafe.Pointer(blob.data))[:blob.size:blob.size])
+
+	return data
+}
+
+// dpapiProtect encrypts data with the DPAPI key of the current user.
+func dpapiProtect(data []byte) ([]byte, error) {
+	out := &dataBlob{}
+	r, _, err := procCryptProtectData.Call(uintptr(unsafe.Pointer(newDataBlob(data))), 0, 0, 0, 0,
+		cryptProtectUiForbidden, uintptr(unsafe.Pointer(out)))
+
+	if r == 0 {
+		return nil, err
+	}
+
+	return out.take(), nil
+}
+
+// dpapiUnprotect decrypts data encrypted by dpapiProtect.
+func dpapiUnprotect(data []byte) ([]byte, error) {
+	out := &dataBlob{}
+	r, _, err := procCryptUnprotectData.Call(uintptr(unsafe.Pointer(newDataBlob(data))), 0, 0, 0, 0,
+		cryptProtectUiForbidden, uintptr(unsafe.Pointer(out)))
+
+	if r == 0 {
+		return nil, err
+	}
+
+	return out.take(), nil
+}
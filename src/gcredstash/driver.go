@@ -1,144 +1,464 @@
 package gcredstash
 
 import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams/dynamodbstreamsiface"
 	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/ryanuber/go-glob"
+	"io"
+	"math/rand"
 	"strings"
+	"time"
 )
 
 type Driver struct {
-	Ddb dynamodbiface.DynamoDBAPI
-	Kms kmsiface.KMSAPI
+	Ddb             dynamodbiface.DynamoDBAPI
+	Streams         dynamodbstreamsiface.DynamoDBStreamsAPI
+	Backend         Backend
+	Kms             kmsiface.KMSAPI
+	SecretsManager  secretsmanageriface.SecretsManagerAPI
+	Ssm             ssmiface.SSMAPI
+	Sts             stsiface.STSAPI
+	S3              s3iface.S3API
+	Logger          Logger
+	ReadOnly        bool
+	DryRun          bool
+	SoftDelete      bool
+	Now             func() time.Time
+	Author          string
+	Context         map[string]string
+	AccessLog       AccessLogger
+	Events          EventHandler
+	DriverForRegion func(region string) *Driver
+	// OnExpired is what reading a credential past its expires_at does:
+	// EXPIRED_WARN (the default), EXPIRED_FAIL or EXPIRED_IGNORE.
+	OnExpired string
+	// Naming is checked on every put. Nil allows any name.
+	Naming *NamingRules
+	// Namespace is prefixed to every name; see NamespaceBackend.
+	Namespace string
+	// NamespaceKeys maps name prefixes (including the Namespace) to the
+	// KMS key to put with; see KmsKeyFor.
+	NamespaceKeys map[string]string
+	// ReadKmsKey, if set, is the only KMS key reads decrypt with. It can
+	// differ from the key puts use.
+	ReadKmsKey string
+	// RecoveryKmsKey, if set, wraps the data key of every put a second
+	// time, so that it can be decrypted when the primary key is disabled
+	// or scheduled for deletion. It is usually a key ARN in another
+	// account or region.
+	RecoveryKmsKey string
+	// Passphrase returns the passphrase of a credential protected with
+	// PutOptions.Passphrase. Reading one fails when it is nil.
+	Passphrase func(name string) ([]byte, error)
+	// DataKeyCache, if set, keeps the data keys KMS decrypts. With
+	// Offline, reads decrypt with it instead of KMS, and puts fail.
+	DataKeyCache *DataKeyCache
+	Offline      bool
+	// Dax, if set, is a DAX cluster in front of the table (see
+	// NewDaxClient) that GetMaterialWithVersion and
+	// GetMaterialWithoutVersion read through. Its reads are eventually
+	// consistent: a new version can take up to the query cache TTL of the
+	// cluster to be returned as the latest. Puts and other reads still go
+	// to Ddb.
+	Dax dynamodbiface.DynamoDBAPI
+	// KeychainCache, if set, keeps the credentials GetSecret reads in the
+	// OS keychain, and answers later reads from it until they expire.
+	KeychainCache *KeychainCache
+	// ReadConsistency is READ_CONSISTENCY_STRONG (the default) or
+	// READ_CONSISTENCY_EVENTUAL, which makes GetMaterialWithoutVersion
+	// query eventually consistent: half the read cost, but a version put
+	// in the last second or so may not be returned as the latest yet.
+	// Puts always allocate versions with consistent reads.
+	ReadConsistency string
+	// Fallbacks are the stores GetSecret reads in order when a credential
+	// or version is not in the table, e.g. a team table and then one shared
+	// by the organization. Puts, deletes and listing only use the table.
+	Fallbacks []*FallbackStore
+	// ChunkedStorage stores versions too large for a DynamoDB item as
+	// several items; see ChunkingBackend. Without it, putting one fails
+	// with an ItemTooLargeError.
+	ChunkedStorage bool
+	// S3Bucket, if set, is where puts store values over S3Threshold bytes
+	// (DEFAULT_S3_THRESHOLD when 0), keeping a pointer in the item; see
+	// S3OverflowBackend. Reading them needs S3.
+	S3Bucket    string
+	S3Threshold int
+
+	identity *callerIdentity
 }
 
-func (driver *Driver) GetMaterialWithoutVersion(name string, table string) (map[string]*dynamodb.AttributeValue, error) {
-	params := &dynamodb.QueryInput{
-		TableName:                aws.String(table),
-		Limit:                    aws.Int64(1),
-		ConsistentRead:           aws.Bool(true),
-		ScanIndexForward:         aws.Bool(false),
-		KeyConditionExpression:   aws.String("#name = :name"),
-		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":name": {S: aws.String(name)},
-		},
+const (
+	EXPIRED_WARN   = "warn"
+	EXPIRED_FAIL   = "fail"
+	EXPIRED_IGNORE = "ignore"
+)
+
+const (
+	READ_CONSISTENCY_STRONG   = "strong"
+	READ_CONSISTENCY_EVENTUAL = "eventual"
+)
+
+// MAX_VERSION_RETRIES is how often PutSecretBytesNextVersion retries when
+// concurrent writers take the version it allocated.
+const MAX_VERSION_RETRIES = 10
+
+func ValidateExpiredPolicy(policy string) error {
+	switch policy {
+	case "", EXPIRED_WARN, EXPIRED_FAIL, EXPIRED_IGNORE:
+		return nil
+	default:
+		return fmt.Errorf("invalid expired policy: %s (expected warn, fail or ignore)", policy)
+	}
+}
+
+func ValidateReadConsistency(consistency string) error {
+	switch consistency {
+	case "", READ_CONSISTENCY_STRONG, READ_CONSISTENCY_EVENTUAL:
+		return nil
+	default:
+		return fmt.Errorf("invalid read_consistency: %s (must be strong or eventual)", consistency)
+	}
+}
+
+var ErrReadOnly = errors.New("the credential store is in read-only mode")
+
+var ErrContextRequired = errors.New("an encryption context is required")
+
+func (driver *Driver) checkWritable() error {
+	if driver.ReadOnly {
+		return ErrReadOnly
+	}
+
+	return nil
+}
+
+// encryptionContext merges the given context over the driver's default
+// context.
+func (driver *Driver) encryptionContext(context map[string]string) map[string]string {
+	if len(driver.Context) == 0 {
+		return context
+	}
+
+	merged := map[string]string{}
+
+	for k, v := range driver.Context {
+		merged[k] = v
+	}
+
+	for k, v := range context {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func (driver *Driver) logger() Logger {
+	if driver.Logger == nil {
+		return NewStdLogger(LOG_LEVEL_INFO)
 	}
 
-	resp, err := driver.Ddb.Query(params)
+	return driver.Logger
+}
+
+func (driver *Driver) GetMaterialWithoutVersion(name string, table string) (map[string]*dynamodb.AttributeValue, error) {
+	items, err := driver.readBackend().Query(table, name, &QueryOptions{
+		Limit:                1,
+		Descending:           true,
+		EventuallyConsistent: driver.ReadConsistency == READ_CONSISTENCY_EVENTUAL,
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	if *resp.Count == 0 {
-		return nil, fmt.Errorf("Item {'name': '%s'} couldn't be found.", name)
+	if len(items) == 0 {
+		return nil, &NotFoundError{Name: name}
 	}
 
-	return resp.Items[0], nil
+	return items[0], nil
 }
 
 func (driver *Driver) GetMaterialWithVersion(name string, version string, table string) (map[string]*dynamodb.AttributeValue, error) {
-	params := &dynamodb.GetItemInput{
-		TableName: aws.String(table),
-		Key: map[string]*dynamodb.AttributeValue{
-			"name":    {S: aws.String(name)},
-			"version": {S: aws.String(version)},
-		},
+	item, err := driver.readBackend().GetItem(table, name, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, &NotFoundError{Name: name, Version: version}
 	}
 
-	resp, err := driver.Ddb.GetItem(params)
+	return item, nil
+}
+
+func (driver *Driver) DecryptMaterial(name string, material map[string]*dynamodb.AttributeValue, context map[string]string) (string, error) {
+	decrypted, err := driver.DecryptMaterialBytes(name, material, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer Wipe(decrypted)
+
+	return string(decrypted), nil
+}
+
+// DecryptMaterialBytes returns the plaintext without converting it to a
+// string, so the caller can wipe it with Wipe after use.
+func (driver *Driver) DecryptMaterialBytes(name string, material map[string]*dynamodb.AttributeValue, context map[string]string) ([]byte, error) {
+	decrypted, err := driver.decryptEnvelope(name, material, context)
+
+	if err == nil && IsPassphraseProtected(material) {
+		locked := decrypted
+		decrypted, err = driver.unlockWithPassphrase(name, material, locked)
+		Wipe(locked)
+	}
+
+	compression := stringAttr(material, "compression")
+
+	if err != nil || compression == "" {
+		return decrypted, err
+	}
+
+	defer Wipe(decrypted)
+
+	plaintext, err := Decompress(compression, decrypted)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not decompress the value: %s", name, err.Error())
+	}
+
+	return plaintext, nil
+}
+
+// decryptEnvelope decrypts the KMS envelope of material and verifies its
+// HMAC, but leaves the passphrase layer in place.
+func (driver *Driver) decryptEnvelope(name string, material map[string]*dynamodb.AttributeValue, context map[string]string) ([]byte, error) {
+	if IsAlias(material) {
+		return nil, fmt.Errorf("%s is an alias of %s", name, stringAttr(material, "alias_of"))
+	}
+
+	dataKey, hmacKey, err := driver.materialKeys(name, material, context)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.Item == nil {
-		return nil, fmt.Errorf("Item {'name': '%s'} couldn't be found.", name)
+	defer Wipe(dataKey)
+	defer Wipe(hmacKey)
+
+	contents := B64Decode(*material["contents"].S)
+	hmac := HexDecode(*material["hmac"].S)
+	scheme := ""
+
+	if attr, ok := material["scheme"]; ok && attr.S != nil {
+		scheme = *attr.S
+	}
+
+	err = ValidateScheme(scheme)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
+	}
+
+	digest := DEFAULT_DIGEST
+
+	if attr, ok := material["digest"]; ok && attr.S != nil {
+		digest = *attr.S
 	}
 
-	return resp.Item, nil
+	valid, err := ValidateHMACWith(digest, hmacMessage(scheme, contents), hmac, hmacKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
+	}
+
+	if !valid {
+		return nil, &IntegrityError{Name: name, Message: fmt.Sprintf("Computed HMAC on %s does not match stored HMAC", name)}
+	}
+
+	if scheme == SCHEME_AES_GCM {
+		decrypted, err := GcmDecrypt(contents, dataKey)
+
+		if err != nil {
+			return nil, &IntegrityError{Name: name, Message: fmt.Sprintf("%s: Could not decrypt contents: %s", name, err.Error())}
+		}
+
+		return decrypted, nil
+	}
+
+	return Crypt(contents, dataKey), nil
 }
 
-func (driver *Driver) DecryptMaterial(name string, material map[string]*dynamodb.AttributeValue, context map[string]string) (string, error) {
+// materialKeys decrypts the data key and HMAC key of material, offline
+// or with KMS.
+func (driver *Driver) materialKeys(name string, material map[string]*dynamodb.AttributeValue, context map[string]string) ([]byte, []byte, error) {
+	if err := driver.preflightKmsKey(driver.ReadKmsKey); err != nil {
+		return nil, nil, err
+	}
+
 	data := B64Decode(*material["key"].S)
-	dataKey, hmacKey, err := KmsDecrypt(driver.Kms, data, context)
+	context = driver.encryptionContext(context)
+
+	if driver.Offline {
+		return driver.offlineDataKey(name, material, data, context)
+	}
+
+	return driver.kmsDataKey(name, material, data, context)
+}
+
+// kmsDataKey decrypts the data key and HMAC key of material with KMS, or
+// with its recovery key when the KMS key is unavailable.
+func (driver *Driver) kmsDataKey(name string, material map[string]*dynamodb.AttributeValue, data []byte, context map[string]string) ([]byte, []byte, error) {
+	dataKey, hmacKey, keyId, err := KmsDecryptWithKey(driver.Kms, driver.ReadKmsKey, data, context)
+
+	if IsKmsKeyUnavailable(err) && stringAttr(material, "recovery_key") != "" {
+		driver.logger().Warnf("%s: the KMS key is unavailable (%s), decrypting with the recovery key %s", name, err.Error(), stringAttr(material, "recovery_kms_key"))
+		dataKey, hmacKey, err = driver.recoverDataKey(name, material, context)
+	}
+
+	if err == nil && keyId != "" {
+		driver.logger().Verbosef("decrypt name=%s kms_key=%s", name, keyId)
+	}
 
 	if err != nil {
 		if strings.Contains(err.Error(), "InvalidCiphertextException") {
 			if len(context) < 1 {
-				return "", fmt.Errorf("%s: Could not decrypt hmac key with KMS. The credential may require that an encryption context be provided to decrypt it.", name)
+				return nil, nil, &ContextMismatchError{Name: name, Message: fmt.Sprintf("%s: Could not decrypt hmac key with KMS. The credential may require that an encryption context be provided to decrypt it.", name)}
 			} else {
-				return "", fmt.Errorf("%s: Could not decrypt hmac key with KMS. The encryption context provided may not match the one used when the credential was stored.", name)
+				return nil, nil, &ContextMismatchError{Name: name, Message: fmt.Sprintf("%s: Could not decrypt hmac key with KMS. The encryption context provided may not match the one used when the credential was stored.", name)}
 			}
+		} else if strings.Contains(err.Error(), "IncorrectKeyException") {
+			return nil, nil, fmt.Errorf("%s: was not encrypted with the read KMS key(%s)", name, driver.ReadKmsKey)
 		} else {
-			return "", err
+			return nil, nil, driver.kmsAccessError(name, "kms:Decrypt", driver.ReadKmsKey, err)
 		}
 	}
 
-	contents := B64Decode(*material["contents"].S)
-	hmac := HexDecode(*material["hmac"].S)
+	driver.cacheDataKey(name, data, context, dataKey, hmacKey)
+
+	return dataKey, hmacKey, nil
+}
 
-	if !ValidateHMAC(contents, hmac, hmacKey) {
-		return "", fmt.Errorf("Computed HMAC on %s does not match stored HMAC", name)
+// LatestValueEquals reports whether the latest stored version of name
+// decrypts to value. It returns false when the credential does not exist.
+func (driver *Driver) LatestValueEquals(name string, value string, table string, context map[string]string) (bool, error) {
+	latest, err := driver.latestItem(name, table, false)
+
+	if err != nil || latest == nil {
+		return false, err
 	}
 
-	decrypted := Crypt(contents, dataKey)
+	return driver.materialEquals(name, latest, []byte(value), context)
+}
 
-	return string(decrypted), nil
+// latestItem returns the highest version of name, or nil when there is
+// none. Soft-deleted versions are only returned with includeDeleted.
+func (driver *Driver) latestItem(name string, table string, includeDeleted bool) (map[string]*dynamodb.AttributeValue, error) {
+	items, err := driver.backend().Query(table, name, &QueryOptions{Limit: 1, Descending: true, IncludeDeleted: includeDeleted})
+
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	return items[0], nil
 }
 
-func (driver *Driver) GetHighestVersion(name string, table string) (int, error) {
-	params := &dynamodb.QueryInput{
-		TableName:                aws.String(table),
-		Limit:                    aws.Int64(1),
-		ConsistentRead:           aws.Bool(true),
-		ScanIndexForward:         aws.Bool(false),
-		KeyConditionExpression:   aws.String("#name = :name"),
-		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":name": {S: aws.String(name)},
-		},
-		ProjectionExpression: aws.String("version"),
+// materialEquals reports whether material holds value with context. A
+// version with a content_hash is compared by it, which only needs its HMAC
+// key; others are decrypted.
+func (driver *Driver) materialEquals(name string, material map[string]*dynamodb.AttributeValue, value []byte, context map[string]string) (bool, error) {
+	if hash := stringAttr(material, "content_hash"); hash != "" {
+		dataKey, hmacKey, err := driver.materialKeys(name, material, context)
+
+		if err != nil {
+			return false, err
+		}
+
+		Wipe(dataKey)
+		defer Wipe(hmacKey)
+
+		return hmac.Equal(HexDecode(hash), ContentHash(hmacKey, value, driver.encryptionContext(context))), nil
+	}
+
+	current, err := driver.DecryptMaterialBytes(name, material, context)
+
+	if err != nil {
+		return false, err
 	}
 
-	resp, err := driver.Ddb.Query(params)
+	defer Wipe(current)
+
+	return subtle.ConstantTimeCompare(current, value) == 1, nil
+}
+
+// GetHighestVersion returns the highest version of name, or 0 when there
+// is none. Soft-deleted versions count, as their versions are still taken.
+func (driver *Driver) GetHighestVersion(name string, table string) (int, error) {
+	items, err := driver.backend().Query(table, name, &QueryOptions{
+		Limit:          1,
+		Descending:     true,
+		Attributes:     []string{"version"},
+		IncludeDeleted: true,
+	})
 
 	if err != nil {
 		return -1, err
 	}
 
-	if *resp.Count == 0 {
+	if len(items) == 0 {
 		return 0, nil
 	}
 
-	version := *resp.Items[0]["version"].S
+	version := *items[0]["version"].S
 	versionNum := Atoi(version)
 
 	return versionNum, nil
 }
 
 func (driver *Driver) PutItem(name string, version string, key []byte, contents []byte, hmac []byte, table string) error {
+	return driver.PutItemWithAttributes(name, version, key, contents, hmac, nil, table)
+}
+
+func newItem(name string, version string, key []byte, contents []byte, hmac []byte, attrs map[string]*dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
 	b64key := B64Encode(key)
 	b64contents := B64Encode(contents)
 	hexHmac := HexEncode(hmac)
 
-	params := &dynamodb.PutItemInput{
-		TableName: aws.String(table),
-		Item: map[string]*dynamodb.AttributeValue{
-			"name":     {S: aws.String(name)},
-			"version":  {S: aws.String(version)},
-			"key":      {S: aws.String(b64key)},
-			"contents": {S: aws.String(b64contents)},
-			"hmac":     {S: aws.String(hexHmac)},
-		},
-		ConditionExpression:      aws.String("attribute_not_exists(#name)"),
-		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	item := map[string]*dynamodb.AttributeValue{
+		"name":     {S: aws.String(name)},
+		"version":  {S: aws.String(version)},
+		"key":      {S: aws.String(b64key)},
+		"contents": {S: aws.String(b64contents)},
+		"hmac":     {S: aws.String(hexHmac)},
+	}
+
+	for attr, value := range attrs {
+		item[attr] = value
+	}
+
+	return item
+}
+
+func (driver *Driver) PutItemWithAttributes(name string, version string, key []byte, contents []byte, hmac []byte, attrs map[string]*dynamodb.AttributeValue, table string) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
 	}
 
-	_, err := driver.Ddb.PutItem(params)
+	item := newItem(name, version, key, contents, hmac, attrs)
+	err := driver.backend().PutItem(table, item)
 
 	if err != nil {
 		return err
@@ -149,28 +469,17 @@ func (driver *Driver) PutItem(name string, version string, key []byte, contents
 
 func (driver *Driver) GetDeleteTargetWithoutVersion(name string, table string) (map[*string]*string, error) {
 	items := map[*string]*string{}
-
-	params := &dynamodb.QueryInput{
-		TableName:                aws.String(table),
-		ConsistentRead:           aws.Bool(true),
-		KeyConditionExpression:   aws.String("#name = :name"),
-		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":name": {S: aws.String(name)},
-		},
-	}
-
-	resp, err := driver.Ddb.Query(params)
+	resp, err := driver.backend().Query(table, name, &QueryOptions{Attributes: []string{"name", "version"}})
 
 	if err != nil {
 		return nil, err
 	}
 
-	if *resp.Count == 0 {
-		return nil, fmt.Errorf("Item {'name': '%s'} couldn't be found.", name)
+	if len(resp) == 0 {
+		return nil, &NotFoundError{Name: name}
 	}
 
-	for _, i := range resp.Items {
+	for _, i := range resp {
 		items[i["name"].S] = i["version"].S
 	}
 
@@ -178,43 +487,34 @@ func (driver *Driver) GetDeleteTargetWithoutVersion(name string, table string) (
 }
 
 func (driver *Driver) GetDeleteTargetWithVersion(name string, version string, table string) (map[*string]*string, error) {
-	params := &dynamodb.GetItemInput{
-		TableName: aws.String(table),
-		Key: map[string]*dynamodb.AttributeValue{
-			"name":    {S: aws.String(name)},
-			"version": {S: aws.String(version)},
-		},
-	}
-
-	resp, err := driver.Ddb.GetItem(params)
+	item, err := driver.backend().GetItem(table, name, version)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.Item == nil {
-		versionNum := Atoi(version)
-		return nil, fmt.Errorf("Item {'name': '%s', 'version': %d} couldn't be found.", name, versionNum)
+	if item == nil {
+		return nil, &NotFoundError{Name: name, Version: version}
 	}
 
 	items := map[*string]*string{}
-	items[resp.Item["name"].S] = resp.Item["version"].S
+	items[item["name"].S] = item["version"].S
 
 	return items, nil
 }
 
+// DeleteItem deletes a version of name, or only marks it as deleted when
+// driver.SoftDelete is set.
 func (driver *Driver) DeleteItem(name string, version string, table string) error {
-	svc := driver.Ddb
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
 
-	params := &dynamodb.DeleteItemInput{
-		TableName: aws.String(table),
-		Key: map[string]*dynamodb.AttributeValue{
-			"name":    {S: aws.String(name)},
-			"version": {S: aws.String(version)},
-		},
+	if driver.SoftDelete {
+		return driver.softDeleteItem(name, version, table)
 	}
 
-	_, err := svc.DeleteItem(params)
+	err := driver.backend().Delete(table, name, version)
 
 	if err != nil {
 		return err
@@ -224,6 +524,16 @@ func (driver *Driver) DeleteItem(name string, version string, table string) erro
 }
 
 func (driver *Driver) DeleteSecrets(name string, version string, table string) error {
+	err := driver.deleteSecrets(name, version, table)
+
+	return driver.logAccess("delete", name, version, table, err)
+}
+
+func (driver *Driver) deleteSecrets(name string, version string, table string) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
+
 	var items map[*string]*string
 	var err error
 
@@ -244,46 +554,460 @@ func (driver *Driver) DeleteSecrets(name string, version string, table string) e
 			return err
 		}
 
-		versionNum := Atoi(*version)
-		fmt.Printf("Deleting %s -- version %d\n", *name, versionNum)
+		driver.events().OnDelete(*name, *version)
 	}
 
 	return nil
 }
 
+type PutOptions struct {
+	Scheme  string
+	Digest  string
+	Comment string
+	// RequireContext refuses to store a credential without an encryption
+	// context, so that it cannot be read by policies that scope on one.
+	RequireContext bool
+	// Validators check the plaintext; the first error is returned and
+	// nothing is stored.
+	Validators []Validator
+	// ExpiresAt is stored as expires_at unless zero. With AutoDelete it is
+	// also stored as the epoch seconds in ttl, for DynamoDB time to live.
+	ExpiresAt  time.Time
+	AutoDelete bool
+	// RotateEvery is an age such as "90d" after which the credential is
+	// due for rotation; see OverdueRotations.
+	RotateEvery string
+	// Passphrase, if set, encrypts the plaintext with a key derived from
+	// it before KMS envelope encryption, so that reading the credential
+	// takes the passphrase as well as KMS access.
+	Passphrase []byte
+	// Tags are stored as the tags map attribute; see CredentialTags.
+	Tags map[string]string
+	// Compression, gzip or zstd, compresses the plaintext before it is
+	// encrypted, and is stored as compression so that reads decompress it.
+	Compression string
+	// ContentHash stores the ContentHash of the plaintext and encryption
+	// context as content_hash, so that idempotent puts can compare values
+	// without decrypting them.
+	ContentHash bool
+}
+
+// hmacMessage returns the message the stored HMAC is computed over. For
+// non-legacy schemes the scheme name is prepended, so readers that do not
+// know the scheme fail HMAC validation instead of returning garbage.
+func hmacMessage(scheme string, contents []byte) []byte {
+	if scheme == "" || scheme == SCHEME_AES_CTR {
+		return contents
+	}
+
+	return append([]byte(scheme+":"), contents...)
+}
+
 func (driver *Driver) PutSecret(name string, secret string, version string, kmsKey string, table string, context map[string]string) error {
+	return driver.PutSecretWithOptions(name, secret, version, kmsKey, table, context, nil)
+}
+
+func (driver *Driver) PutSecretWithOptions(name string, secret string, version string, kmsKey string, table string, context map[string]string, opts *PutOptions) error {
+	plaintext := []byte(secret)
+	defer Wipe(plaintext)
+
+	return driver.PutSecretBytesWithOptions(name, plaintext, version, kmsKey, table, context, opts)
+}
+
+func (driver *Driver) encryptItem(name string, secret []byte, version string, kmsKey string, context map[string]string, opts *PutOptions) (map[string]*dynamodb.AttributeValue, error) {
+	if opts == nil {
+		opts = &PutOptions{}
+	}
+
+	if driver.Offline {
+		return nil, ErrOffline
+	}
+
+	if err := driver.Naming.Validate(name); err != nil {
+		return nil, err
+	}
+
+	kmsKey = driver.KmsKeyFor(name, kmsKey)
+
+	for _, validator := range opts.Validators {
+		if err := validator.Validate(name, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	err := ValidateScheme(opts.Scheme)
+
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := NormalizeDigest(opts.Digest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	context = driver.encryptionContext(context)
+
+	if opts.RequireContext && len(context) == 0 {
+		return nil, ErrContextRequired
+	}
+
+	if err := ValidateCompression(opts.Compression); err != nil {
+		return nil, err
+	}
+
+	plaintext := secret
+
+	if opts.Compression != "" {
+		secret, err = Compress(opts.Compression, secret)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer Wipe(secret)
+	}
+
+	var passphraseAttrs map[string]*dynamodb.AttributeValue
+
+	if len(opts.Passphrase) > 0 {
+		secret, passphraseAttrs, err = lockWithPassphrase(secret, opts.Passphrase)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := driver.preflightKmsKey(kmsKey); err != nil {
+		return nil, err
+	}
+
 	dataKey, hmacKey, wrappedKey, err := KmsGenerateDataKey(driver.Kms, kmsKey, context)
 
+	if IsAccessDenied(err) {
+		return nil, driver.kmsAccessError(name, "kms:GenerateDataKey", kmsKey, err)
+	}
+
 	if err != nil {
-		return fmt.Errorf("Could not generate key using KMS key(%s): %s", kmsKey, err.Error())
+		return nil, fmt.Errorf("Could not generate key using KMS key(%s): %s", kmsKey, err.Error())
 	}
 
-	cipherText := Crypt([]byte(secret), dataKey)
-	hmac := Digest(cipherText, hmacKey)
+	defer Wipe(dataKey)
+	defer Wipe(hmacKey)
+
+	var cipherText []byte
 
-	err = driver.PutItem(name, version, wrappedKey, cipherText, hmac, table)
+	attrs := map[string]*dynamodb.AttributeValue{
+		"digest": {S: aws.String(digest)},
+	}
+
+	for attr, value := range passphraseAttrs {
+		attrs[attr] = value
+	}
+
+	if driver.RecoveryKmsKey != "" {
+		recovery, err := driver.recoveryAttrs(name, dataKey, hmacKey, context)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for attr, value := range recovery {
+			attrs[attr] = value
+		}
+	}
+
+	if driver.Now != nil {
+		attrs["created_at"] = &dynamodb.AttributeValue{S: aws.String(driver.Now().UTC().Format(time.RFC3339))}
+	}
+
+	if driver.Author != "" {
+		attrs["created_by"] = &dynamodb.AttributeValue{S: aws.String(driver.Author)}
+	}
+
+	if opts.ContentHash {
+		attrs["content_hash"] = &dynamodb.AttributeValue{S: aws.String(HexEncode(ContentHash(hmacKey, plaintext, context)))}
+	}
+
+	if opts.Comment != "" {
+		attrs["comment"] = &dynamodb.AttributeValue{S: aws.String(opts.Comment)}
+	}
+
+	if opts.RotateEvery != "" {
+		if _, err := ParseAge(opts.RotateEvery); err != nil {
+			return nil, fmt.Errorf("invalid rotation interval: %s", opts.RotateEvery)
+		}
+
+		attrs["rotate_every"] = &dynamodb.AttributeValue{S: aws.String(opts.RotateEvery)}
+	}
+
+	if len(opts.Tags) > 0 {
+		attrs["tags"] = tagsAttr(opts.Tags)
+	}
+
+	if opts.Compression != "" {
+		attrs["compression"] = &dynamodb.AttributeValue{S: aws.String(opts.Compression)}
+	}
+
+	if !opts.ExpiresAt.IsZero() {
+		attrs["expires_at"] = &dynamodb.AttributeValue{S: aws.String(opts.ExpiresAt.UTC().Format(time.RFC3339))}
+
+		if opts.AutoDelete {
+			attrs["ttl"] = &dynamodb.AttributeValue{N: aws.String(fmt.Sprint(opts.ExpiresAt.Unix()))}
+		}
+	}
+
+	if opts.Scheme == SCHEME_AES_GCM {
+		cipherText, err = GcmEncrypt(secret, dataKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attrs["scheme"] = &dynamodb.AttributeValue{S: aws.String(opts.Scheme)}
+	} else {
+		cipherText = Crypt(secret, dataKey)
+	}
+
+	hmac, err := DigestWith(digest, hmacMessage(opts.Scheme, cipherText), hmacKey)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "ConditionalCheckFailedException") {
-			latestVersion, err := driver.GetHighestVersion(name, table)
+		return nil, err
+	}
 
-			if err != nil {
-				return err
-			}
+	return newItem(name, version, wrappedKey, cipherText, hmac, attrs), nil
+}
 
-			return fmt.Errorf(
-				"%s version %d is already in the credential store. Use the -v flag to specify a new version",
-				name,
-				latestVersion)
-		} else {
+func (driver *Driver) PutSecretBytesWithOptions(name string, secret []byte, version string, kmsKey string, table string, context map[string]string, opts *PutOptions) error {
+	err := driver.putSecretBytes(name, secret, version, kmsKey, table, context, opts)
+
+	return driver.logAccess("put", name, version, table, err)
+}
+
+func (driver *Driver) putSecretBytes(name string, secret []byte, version string, kmsKey string, table string, context map[string]string, opts *PutOptions) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
+
+	driver.logger().Verbosef("put name=%s version=%s table=%s kms_key=%s", name, version, table, driver.KmsKeyFor(name, kmsKey))
+
+	item, err := driver.encryptItem(name, secret, version, kmsKey, context, opts)
+
+	if err != nil {
+		return err
+	}
+
+	err = driver.backend().PutItem(table, item)
+
+	if IsConditionalCheckFailed(err) {
+		latestVersion, err := driver.GetHighestVersion(name, table)
+
+		if err != nil {
 			return err
 		}
+
+		return &VersionExistsError{Name: name, Version: version, Latest: latestVersion}
 	}
 
-	return nil
+	return err
+}
+
+func (driver *Driver) PutSecretNextVersion(name string, secret string, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	plaintext := []byte(secret)
+	defer Wipe(plaintext)
+
+	return driver.PutSecretBytesNextVersion(name, plaintext, kmsKey, table, context, opts)
+}
+
+// PutSecretBytesNextVersion stores secret as the version after the highest
+// stored one and returns that version. Puts are conditional on the version
+// not existing yet, so when a concurrent writer takes the same version
+// first, the highest version is read again and the put retried, up to
+// MAX_VERSION_RETRIES times.
+func (driver *Driver) PutSecretBytesNextVersion(name string, secret []byte, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	version, err := driver.putSecretBytesNextVersion(name, secret, kmsKey, table, context, opts)
+
+	return version, driver.logAccess("put", name, version, table, err)
+}
+
+func (driver *Driver) putSecretBytesNextVersion(name string, secret []byte, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", err
+	}
+
+	item, err := driver.encryptItem(name, secret, "", kmsKey, context, opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	for retries := 0; ; retries++ {
+		latestVersion, err := driver.GetHighestVersion(name, table)
+
+		if err != nil {
+			return "", err
+		}
+
+		version := VersionNumToStr(latestVersion + 1)
+		item["version"] = &dynamodb.AttributeValue{S: aws.String(version)}
+
+		driver.logger().Verbosef("put name=%s version=%s table=%s kms_key=%s", name, version, table, kmsKey)
+		err = driver.backend().PutItem(table, item)
+
+		if !IsConditionalCheckFailed(err) {
+			return version, err
+		}
+
+		if retries >= MAX_VERSION_RETRIES {
+			return "", &VersionExistsError{Name: name, Version: version, Latest: latestVersion + 1}
+		}
+
+		driver.logger().Verbosef("put name=%s version=%s was taken by another writer, retrying", name, version)
+		time.Sleep(time.Duration(rand.Intn(20*(retries+1))) * time.Millisecond)
+	}
+}
+
+func (driver *Driver) PutSecretIfVersion(name string, secret string, expected int, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	plaintext := []byte(secret)
+	defer Wipe(plaintext)
+
+	return driver.PutSecretBytesIfVersion(name, plaintext, expected, kmsKey, table, context, opts)
+}
+
+// PutSecretBytesIfVersion stores secret as the version after expected, but
+// only if expected is the highest stored version (0 for a credential that
+// does not exist yet). Otherwise, or when another writer stores the next
+// version first, it returns a *VersionConflictError, so that a value read
+// at version expected can be modified and written back safely.
+func (driver *Driver) PutSecretBytesIfVersion(name string, secret []byte, expected int, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	latestVersion, err := driver.GetHighestVersion(name, table)
+
+	if err != nil {
+		return "", err
+	}
+
+	if latestVersion != expected {
+		return "", &VersionConflictError{Name: name, Expected: expected, Latest: latestVersion}
+	}
+
+	version := VersionNumToStr(expected + 1)
+	err = driver.PutSecretBytesWithOptions(name, secret, version, kmsKey, table, context, opts)
+
+	var exists *VersionExistsError
+
+	if errors.As(err, &exists) {
+		return "", &VersionConflictError{Name: name, Expected: expected, Latest: exists.Latest}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return version, nil
 }
 
 func (driver *Driver) GetSecret(name string, version string, table string, context map[string]string) (string, error) {
+	value, err := driver.GetSecretBytes(name, version, table, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer Wipe(value)
+
+	return string(value), nil
+}
+
+// GetSecretOrDefault is like GetSecret, but returns defaultValue instead of
+// an error when name is not stored. A missing version of a stored name is
+// still an error.
+func (driver *Driver) GetSecretOrDefault(name string, version string, table string, context map[string]string, defaultValue string) (string, error) {
+	value, err := driver.GetSecret(name, version, table, context)
+
+	if errors.Is(err, ErrSecretNotFound) {
+		driver.logger().Verbosef("get name=%s is not stored; using the default", name)
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetSecretTo writes the plaintext to w and wipes it afterwards.
+func (driver *Driver) GetSecretTo(w io.Writer, name string, version string, table string, context map[string]string) error {
+	value, err := driver.GetSecretBytes(name, version, table, context)
+
+	if err != nil {
+		return err
+	}
+
+	defer Wipe(value)
+
+	_, err = w.Write(value)
+
+	return err
+}
+
+func (driver *Driver) GetSecureSecret(name string, version string, table string, context map[string]string) (*SecureBytes, error) {
+	value, err := driver.GetSecretBytes(name, version, table, context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecureBytes(value), nil
+}
+
+func (driver *Driver) GetSecretBytes(name string, version string, table string, context map[string]string) ([]byte, error) {
+	value, _, err := driver.GetSecretBytesWithVersion(name, version, table, context)
+	return value, err
+}
+
+// checkExpiry reports a credential whose expires_at has passed according to
+// driver.OnExpired.
+func (driver *Driver) checkExpiry(name string, version string, material map[string]*dynamodb.AttributeValue) error {
+	expiresAt, err := time.Parse(time.RFC3339, stringAttr(material, "expires_at"))
+
+	if err != nil || driver.OnExpired == EXPIRED_IGNORE {
+		return nil
+	}
+
+	now := time.Now()
+
+	if driver.Now != nil {
+		now = driver.Now()
+	}
+
+	if now.Before(expiresAt) {
+		return nil
+	}
+
+	if driver.OnExpired == EXPIRED_FAIL {
+		return &ExpiredError{Name: name, Version: version, ExpiresAt: expiresAt}
+	}
+
+	driver.events().OnExpired(name, version, expiresAt)
+
+	return nil
+}
+
+// GetSecretBytesWithVersion is like GetSecretBytes but also returns the
+// version that was read, which is the latest one when version is empty.
+func (driver *Driver) GetSecretBytesWithVersion(name string, version string, table string, context map[string]string) ([]byte, string, error) {
+	value, storedVersion, err := driver.getSecretBytesWithVersion(name, version, table, context)
+
+	return driver.fromFallbacks(name, version, table, context, value, storedVersion, err)
+}
+
+func (driver *Driver) getSecretBytesWithVersion(name string, version string, table string, context map[string]string) ([]byte, string, error) {
+	driver.logger().Verbosef("get name=%s version=%s table=%s", name, version, table)
+
+	if value, cachedVersion, ok := driver.keychainCached(name, version, table, context); ok {
+		return value, cachedVersion, driver.logAccess("get", name, cachedVersion, table, nil)
+	}
+
+	requestedName, requestedVersion := name, version
+
 	var material map[string]*dynamodb.AttributeValue
 	var err error
 
@@ -294,28 +1018,44 @@ func (driver *Driver) GetSecret(name string, version string, table string, conte
 	}
 
 	if err != nil {
-		return "", err
+		return nil, "", driver.logAccess("get", name, version, table, err)
 	}
 
-	value, err := driver.DecryptMaterial(name, material, context)
+	target, material, err := driver.resolveAlias(name, material, table)
 
 	if err != nil {
-		return "", err
+		return nil, "", driver.logAccess("get", name, version, table, err)
 	}
 
-	return value, nil
-}
+	name = target
 
-func (driver *Driver) ListSecrets(table string) (map[*string]*string, error) {
-	svc := driver.Ddb
+	version = stringAttr(material, "version")
 
-	params := &dynamodb.ScanInput{
-		TableName:                aws.String(table),
-		ProjectionExpression:     aws.String("#name,version"),
-		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	if err := driver.checkExpiry(name, version, material); err != nil {
+		return nil, "", driver.logAccess("get", name, version, table, err)
 	}
 
-	resp, err := svc.Scan(params)
+	value, err := driver.DecryptMaterialBytes(name, material, context)
+	err = driver.logAccess("get", name, version, table, err)
+
+	if err != nil {
+		Wipe(value)
+		return nil, "", err
+	}
+
+	driver.keepInKeychain(requestedName, requestedVersion, table, context, material, value)
+
+	return value, version, nil
+}
+
+func (driver *Driver) ListSecrets(table string) (map[*string]*string, error) {
+	return driver.ListSecretsWithPrefix(table, "")
+}
+
+// ListSecretsWithPrefix filters by name prefix on the server side.
+// It still reads the whole table, but only matching items are returned.
+func (driver *Driver) ListSecretsWithPrefix(table string, prefix string) (map[*string]*string, error) {
+	resp, err := driver.backend().Scan(table, &ScanOptions{Attributes: []string{"name", "version"}, Prefix: prefix})
 
 	if err != nil {
 		return nil, err
@@ -323,9 +1063,35 @@ func (driver *Driver) ListSecrets(table string) (map[*string]*string, error) {
 
 	items := map[*string]*string{}
 
-	for _, i := range resp.Items {
+	for _, i := range resp {
 		items[i["name"].S] = i["version"].S
 	}
 
 	return items, nil
 }
+
+func (driver *Driver) GetSecretsByPattern(pattern string, table string, context map[string]string) (map[string]string, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	creds := map[string]string{}
+
+	for name, _ := range GroupVersions(items) {
+		if !glob.Glob(pattern, name) {
+			continue
+		}
+
+		value, err := driver.GetSecret(name, "", table, context)
+
+		if err != nil {
+			return nil, err
+		}
+
+		creds[name] = value
+	}
+
+	return creds, nil
+}
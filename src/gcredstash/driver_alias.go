@@ -0,0 +1,172 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MAX_ALIAS_DEPTH is how many aliases a read follows before giving up, so
+// that a cycle of aliases fails instead of looping.
+const MAX_ALIAS_DEPTH = 8
+
+// IsAlias reports whether an item is an alias rather than a ciphertext.
+func IsAlias(material map[string]*dynamodb.AttributeValue) bool {
+	return stringAttr(material, "alias_of") != ""
+}
+
+// ParseAliasTarget splits "name@vN" (or "name@N") into the name and the
+// padded version. The version is empty for a plain name, which makes the
+// alias follow the latest version of the target.
+func ParseAliasTarget(target string) (string, string, error) {
+	i := strings.LastIndex(target, "@")
+
+	if i < 0 {
+		return target, "", nil
+	}
+
+	name := target[:i]
+	num, err := strconv.Atoi(strings.TrimPrefix(target[i+1:], "v"))
+
+	if name == "" || err != nil || num < 1 {
+		return "", "", fmt.Errorf("invalid alias target: %s (expected name or name@vN)", target)
+	}
+
+	return name, VersionNumToStr(num), nil
+}
+
+// resolveAlias follows material to the credential it points at and returns
+// that credential's name and material. Material that is not an alias is
+// returned as is.
+func (driver *Driver) resolveAlias(name string, material map[string]*dynamodb.AttributeValue, table string) (string, map[string]*dynamodb.AttributeValue, error) {
+	names, material, err := driver.aliasChain(name, material, table)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return names[len(names)-1], material, nil
+}
+
+// aliasChain is like resolveAlias but returns every name on the way,
+// starting with name itself.
+func (driver *Driver) aliasChain(name string, material map[string]*dynamodb.AttributeValue, table string) ([]string, map[string]*dynamodb.AttributeValue, error) {
+	names := []string{name}
+
+	for IsAlias(material) {
+		if len(names) > MAX_ALIAS_DEPTH {
+			return nil, nil, fmt.Errorf("%s: too many levels of aliases", name)
+		}
+
+		target := stringAttr(material, "alias_of")
+		version := stringAttr(material, "alias_version")
+		driver.logger().Verbosef("alias name=%s target=%s version=%s", names[len(names)-1], target, version)
+
+		var err error
+
+		if version == "" {
+			material, err = driver.GetMaterialWithoutVersion(target, table)
+		} else {
+			material, err = driver.GetMaterialWithVersion(target, version, table)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		names = append(names, target)
+	}
+
+	return names, material, nil
+}
+
+// PutAlias stores the next version of name as an alias of target. With an
+// empty targetVersion the alias follows the latest version of target,
+// otherwise it is pinned to that version. Reads of name resolve the alias
+// transparently, so re-pointing it promotes another secret without copying
+// its ciphertext. It returns the new version of name.
+func (driver *Driver) PutAlias(name string, target string, targetVersion string, table string) (string, error) {
+	version, err := driver.putAlias(name, target, targetVersion, table)
+
+	return version, driver.logAccess("put", name, version, table, err)
+}
+
+func (driver *Driver) putAlias(name string, target string, targetVersion string, table string) (string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", err
+	}
+
+	if err := driver.Naming.Validate(name); err != nil {
+		return "", err
+	}
+
+	var material map[string]*dynamodb.AttributeValue
+	var err error
+
+	if targetVersion == "" {
+		material, err = driver.GetMaterialWithoutVersion(target, table)
+	} else {
+		material, err = driver.GetMaterialWithVersion(target, targetVersion, table)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	names, _, err := driver.aliasChain(target, material, table)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, n := range names {
+		if n == name {
+			return "", fmt.Errorf("%s cannot be an alias of %s: it would point back at itself", name, target)
+		}
+	}
+
+	item := map[string]*dynamodb.AttributeValue{
+		"name":     {S: aws.String(name)},
+		"alias_of": {S: aws.String(target)},
+	}
+
+	if targetVersion != "" {
+		item["alias_version"] = &dynamodb.AttributeValue{S: aws.String(targetVersion)}
+	}
+
+	if driver.Now != nil {
+		item["created_at"] = &dynamodb.AttributeValue{S: aws.String(driver.Now().UTC().Format(time.RFC3339))}
+	}
+
+	if driver.Author != "" {
+		item["created_by"] = &dynamodb.AttributeValue{S: aws.String(driver.Author)}
+	}
+
+	for retries := 0; ; retries++ {
+		latestVersion, err := driver.GetHighestVersion(name, table)
+
+		if err != nil {
+			return "", err
+		}
+
+		version := VersionNumToStr(latestVersion + 1)
+		item["version"] = &dynamodb.AttributeValue{S: aws.String(version)}
+
+		driver.logger().Verbosef("alias name=%s version=%s table=%s target=%s", name, version, table, target)
+		err = driver.backend().PutItem(table, item)
+
+		if !IsConditionalCheckFailed(err) {
+			return version, err
+		}
+
+		if retries >= MAX_VERSION_RETRIES {
+			return "", &VersionExistsError{Name: name, Version: version, Latest: latestVersion + 1}
+		}
+
+		time.Sleep(time.Duration(rand.Intn(20*(retries+1))) * time.Millisecond)
+	}
+}
@@ -0,0 +1,94 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestParseAliasTarget(t *testing.T) {
+	for _, tc := range []struct {
+		target  string
+		name    string
+		version string
+	}{
+		{"db.pass", "db.pass", ""},
+		{"db.pass@v7", "db.pass", "0000000000000000007"},
+		{"db.pass@7", "db.pass", "0000000000000000007"},
+		{"user@example.com@v2", "user@example.com", "0000000000000000002"},
+	} {
+		name, version, err := ParseAliasTarget(tc.target)
+
+		if name != tc.name || version != tc.version || err != nil {
+			t.Errorf("\nexpected: %v %v\ngot: %v %v %v\n", tc.name, tc.version, name, version, err)
+		}
+	}
+
+	for _, target := range []string{"@v1", "db.pass@v0", "db.pass@latest"} {
+		if _, _, err := ParseAliasTarget(target); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	}
+}
+
+func TestPutAlias(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("db.pass", "200", VersionNumToStr(2), "alias/credstash", table, nil)
+
+		for _, tc := range []struct {
+			alias    string
+			target   string
+			version  string
+			expected string
+		}{
+			{"db.pass.current", "db.pass", VersionNumToStr(1), "100"},
+			{"db.pass.current", "db.pass", VersionNumToStr(2), "200"},
+			{"db.pass.latest", "db.pass", "", "200"},
+			{"db.pass.stable", "db.pass.current", "", "200"},
+		} {
+			if _, err := driver.PutAlias(tc.alias, tc.target, tc.version, table); err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			value, err := driver.GetSecret(tc.alias, "", table, nil)
+
+			if value != tc.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", tc.expected, value, err)
+			}
+		}
+
+		value, err := driver.GetSecret("db.pass.current", VersionNumToStr(1), table, nil)
+
+		if value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+
+		driver.PutSecret("db.pass", "300", VersionNumToStr(3), "alias/credstash", table, nil)
+
+		for alias, expected := range map[string]string{"db.pass.current": "200", "db.pass.latest": "300"} {
+			value, _ := driver.GetSecret(alias, "", table, nil)
+
+			if value != expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expected, value)
+			}
+		}
+
+		_, err = driver.PutAlias("db.pass.current", "db.pass.stable", "", table)
+		expected := "db.pass.current cannot be an alias of db.pass.stable: it would point back at itself"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		_, err = driver.PutAlias("db.pass.other", "db.pass", VersionNumToStr(9), table)
+
+		if !errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package gcredstash
+
+import (
+	"sort"
+)
+
+type AuditResult struct {
+	Name    string
+	Version string
+	Err     error
+}
+
+// AuditSecrets decrypts the data key and verifies the HMAC of every stored
+// version whose name starts with prefix. The passphrase layer is not
+// removed, so no passphrase is needed. Plaintext is wiped as soon as it
+// has been checked. A result is returned for each version; Err is nil for
+// the ones that passed.
+func (driver *Driver) AuditSecrets(table string, prefix string, context map[string]string) ([]*AuditResult, error) {
+	items, err := driver.backend().Scan(table, &ScanOptions{Prefix: prefix})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if *items[i]["name"].S != *items[j]["name"].S {
+			return *items[i]["name"].S < *items[j]["name"].S
+		}
+
+		return *items[i]["version"].S < *items[j]["version"].S
+	})
+
+	results := []*AuditResult{}
+
+	for _, item := range items {
+		name := *item["name"].S
+		result := &AuditResult{Name: name, Version: *item["version"].S}
+
+		if IsAlias(item) {
+			_, _, result.Err = driver.resolveAlias(name, item, table)
+			results = append(results, result)
+			continue
+		}
+
+		for _, attr := range []string{"key", "contents", "hmac"} {
+			if stringAttr(item, attr) == "" {
+				result.Err = &IntegrityError{Name: name, Message: name + ": missing " + attr}
+				break
+			}
+		}
+
+		if result.Err == nil {
+			plaintext, err := driver.decryptEnvelope(name, item, context)
+			Wipe(plaintext)
+			result.Err = err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
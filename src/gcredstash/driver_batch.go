@@ -0,0 +1,61 @@
+package gcredstash
+
+import (
+	"sort"
+	"sync"
+)
+
+// At most this many credentials are fetched and decrypted at once.
+const MAX_CONCURRENT_GETS = 10
+
+// GetSecrets fetches the latest version of every name concurrently. If any
+// of them fails, the error of the first failing name in names is returned.
+func (driver *Driver) GetSecrets(names []string, table string, context map[string]string) (map[string]string, error) {
+	return driver.getSecrets(names, nil, table, context)
+}
+
+// GetSecretsWithVersions is like GetSecrets but fetches the version that
+// versions maps each name to, such as the ones read from a lock file.
+func (driver *Driver) GetSecretsWithVersions(versions map[string]string, table string, context map[string]string) (map[string]string, error) {
+	names := []string{}
+
+	for name, _ := range versions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return driver.getSecrets(names, versions, table, context)
+}
+
+func (driver *Driver) getSecrets(names []string, versions map[string]string, table string, context map[string]string) (map[string]string, error) {
+	values := make([]string, len(names))
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, MAX_CONCURRENT_GETS)
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			values[i], errs[i] = driver.GetSecret(name, versions[name], table, context)
+		}(i, name)
+	}
+
+	wg.Wait()
+
+	creds := map[string]string{}
+
+	for i, name := range names {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		creds[name] = values[i]
+	}
+
+	return creds, nil
+}
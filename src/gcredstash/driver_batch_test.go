@@ -0,0 +1,32 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGetSecrets(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("db.pass", "p4ss", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("api.key", "old", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("api.key", "k3y", "0000000000000000002", "alias/credstash", table, nil)
+
+		creds, err := driver.GetSecrets([]string{"db.pass", "api.key"}, table, nil)
+		expected := map[string]string{"db.pass": "p4ss", "api.key": "k3y"}
+
+		if err != nil || !reflect.DeepEqual(creds, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, creds, err)
+		}
+
+		_, err = driver.GetSecrets([]string{"db.pass", "mail.token"}, table, nil)
+
+		if !errors.Is(err, ErrSecretNotFound) || err.Error() != "Item {'name': 'mail.token'} couldn't be found." {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+	})
+}
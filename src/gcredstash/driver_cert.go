@@ -0,0 +1,147 @@
+package gcredstash
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Suffixes of the credentials a certificate bundle is stored as.
+const (
+	CERT_SUFFIX  = ".crt"
+	KEY_SUFFIX   = ".key"
+	CHAIN_SUFFIX = ".chain"
+)
+
+// CertBundle is a PEM certificate, its private key and an optional chain.
+type CertBundle struct {
+	Cert  string
+	Key   string
+	Chain string
+}
+
+// CertInfo describes the leaf certificate stored under Name.
+type CertInfo struct {
+	Name     string
+	Version  string
+	Subject  string
+	Issuer   string
+	DNSNames []string
+	NotAfter time.Time
+}
+
+func (info *CertInfo) DaysLeft(now time.Time) int {
+	return int(info.NotAfter.Sub(now).Hours() / 24)
+}
+
+// ParseCertificate returns the first certificate in a PEM block list.
+func ParseCertificate(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("not a PEM encoded certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// Validate checks that the certificate and chain parse and that the key
+// belongs to the certificate.
+func (bundle *CertBundle) Validate() error {
+	if _, err := ParseCertificate(bundle.Cert); err != nil {
+		return fmt.Errorf("certificate: %s", err.Error())
+	}
+
+	if _, err := tls.X509KeyPair([]byte(bundle.Cert), []byte(bundle.Key)); err != nil {
+		return fmt.Errorf("key: %s", err.Error())
+	}
+
+	if bundle.Chain != "" {
+		if _, err := ParseCertificate(bundle.Chain); err != nil {
+			return fmt.Errorf("chain: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// PutCertBundle validates bundle and stores it as name.crt, name.key and,
+// if there is a chain, name.chain at one version in a single transaction.
+func (driver *Driver) PutCertBundle(name string, bundle *CertBundle, version string, kmsKey string, table string, context map[string]string) (string, error) {
+	if err := bundle.Validate(); err != nil {
+		return "", fmt.Errorf("%s: %s", name, err.Error())
+	}
+
+	secrets := map[string]string{
+		name + CERT_SUFFIX: bundle.Cert,
+		name + KEY_SUFFIX:  bundle.Key,
+	}
+
+	if bundle.Chain != "" {
+		secrets[name+CHAIN_SUFFIX] = bundle.Chain
+	}
+
+	return driver.PutSecrets(secrets, version, kmsKey, table, context, nil)
+}
+
+// GetCertInfo reads name.crt at version (latest if empty).
+func (driver *Driver) GetCertInfo(name string, version string, table string, context map[string]string) (*CertInfo, error) {
+	value, version, err := driver.GetSecretBytesWithVersion(name+CERT_SUFFIX, version, table, context)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := ParseCertificate(string(value))
+	Wipe(value)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name+CERT_SUFFIX, err.Error())
+	}
+
+	return &CertInfo{
+		Name:     name,
+		Version:  version,
+		Subject:  cert.Subject.String(),
+		Issuer:   cert.Issuer.String(),
+		DNSNames: cert.DNSNames,
+		NotAfter: cert.NotAfter,
+	}, nil
+}
+
+// ListCertInfo returns the latest certificate of every bundle whose name
+// starts with prefix, sorted by name.
+func (driver *Driver) ListCertInfo(prefix string, table string, context map[string]string) ([]*CertInfo, error) {
+	items, err := driver.ListSecretsWithPrefix(table, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for name, _ := range GroupVersions(items) {
+		if strings.HasSuffix(name, CERT_SUFFIX) {
+			names = append(names, strings.TrimSuffix(name, CERT_SUFFIX))
+		}
+	}
+
+	sort.Strings(names)
+	infos := []*CertInfo{}
+
+	for _, name := range names {
+		info, err := driver.GetCertInfo(name, "", table, context)
+
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,51 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPutCertBundle(t *testing.T) {
+	notAfter := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Second)
+	cert, key := testutils.SelfSignedCert("www.example.com", notAfter)
+	chain, otherKey := testutils.SelfSignedCert("Example CA", notAfter)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+
+		version, err := driver.PutCertBundle("www", &CertBundle{Cert: cert, Key: key, Chain: chain}, "", "alias/credstash", table, nil)
+
+		if err != nil || version != "0000000000000000001" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "0000000000000000001", version, err)
+		}
+
+		for name, expected := range map[string]string{"www.crt": cert, "www.key": key, "www.chain": chain} {
+			actual, _ := driver.GetSecret(name, version, table, nil)
+
+			if actual != expected {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+			}
+		}
+
+		info, err := driver.GetCertInfo("www", "", table, nil)
+
+		if err != nil || info.Subject != "CN=www.example.com" || !info.NotAfter.Equal(notAfter) || info.DaysLeft(time.Now()) != 9 {
+			t.Errorf("\nexpected: %v %v\ngot: %+v %v\n", "CN=www.example.com", notAfter, info, err)
+		}
+
+		infos, err := driver.ListCertInfo("", table, nil)
+
+		if err != nil || len(infos) != 1 || infos[0].Name != "www" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "www", infos, err)
+		}
+
+		_, err = driver.PutCertBundle("other", &CertBundle{Cert: cert, Key: otherKey}, "", "alias/credstash", table, nil)
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "key mismatch error", err)
+		}
+	})
+}
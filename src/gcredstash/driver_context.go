@@ -0,0 +1,120 @@
+package gcredstash
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// WithContext returns a copy of the driver whose AWS requests are made with
+// ctx, so that cancelling ctx aborts them. The AWS SDK clients, the
+// aws-sdk-go-v2 backend, Dax, and the drivers of DriverForRegion and
+// Fallbacks use ctx; other implementations, such as LocalKms or
+// FileBackend, ignore it.
+func (driver *Driver) WithContext(ctx context.Context) *Driver {
+	// The copy shares the caller ARN, rather than looking it up again.
+	driver.callerIdentity()
+	copied := *driver
+
+	switch svc := driver.Ddb.(type) {
+	case *dynamodb.DynamoDB:
+		// Copied to keep its endpoint discovery cache.
+		ddb := *svc
+		ddb.Client = contextClient(svc.Client, ctx)
+		copied.Ddb = &ddb
+	}
+
+	switch svc := driver.Streams.(type) {
+	case *dynamodbstreams.DynamoDBStreams:
+		copied.Streams = &dynamodbstreams.DynamoDBStreams{Client: contextClient(svc.Client, ctx)}
+	}
+
+	switch svc := driver.Kms.(type) {
+	case *kms.KMS:
+		copied.Kms = &kms.KMS{Client: contextClient(svc.Client, ctx)}
+	}
+
+	switch svc := driver.SecretsManager.(type) {
+	case *secretsmanager.SecretsManager:
+		copied.SecretsManager = &secretsmanager.SecretsManager{Client: contextClient(svc.Client, ctx)}
+	}
+
+	switch svc := driver.Ssm.(type) {
+	case *ssm.SSM:
+		copied.Ssm = &ssm.SSM{Client: contextClient(svc.Client, ctx)}
+	}
+
+	switch svc := driver.Sts.(type) {
+	case *sts.STS:
+		copied.Sts = &sts.STS{Client: contextClient(svc.Client, ctx)}
+	}
+
+	switch svc := driver.S3.(type) {
+	case *s3.S3:
+		copied.S3 = &s3.S3{Client: contextClient(svc.Client, ctx)}
+	}
+
+	switch backend := driver.Backend.(type) {
+	case *DynamoDBV2Backend:
+		v2 := *backend
+		v2.Context = ctx
+		copied.Backend = &v2
+	}
+
+	if driver.Dax != nil {
+		copied.Dax = &contextDax{DynamoDBAPI: driver.Dax, ctx: ctx}
+	}
+
+	if driver.DriverForRegion != nil {
+		copied.DriverForRegion = func(region string) *Driver {
+			return driver.DriverForRegion(region).WithContext(ctx)
+		}
+	}
+
+	if len(driver.Fallbacks) > 0 {
+		copied.Fallbacks = []*FallbackStore{}
+
+		for _, store := range driver.Fallbacks {
+			copied.Fallbacks = append(copied.Fallbacks, &FallbackStore{Name: store.Name, Driver: store.Driver.WithContext(ctx), Table: store.Table})
+		}
+	}
+
+	return &copied
+}
+
+// contextClient returns a copy of c that makes its requests with ctx.
+func contextClient(c *client.Client, ctx context.Context) *client.Client {
+	copied := *c
+	copied.Handlers = c.Handlers.Copy()
+
+	// In front of the handler of AddTimeoutHandlers, which bounds the
+	// context of the request with the timeout.
+	copied.Handlers.Validate.PushFront(func(r *request.Request) {
+		r.SetContext(ctx)
+	})
+
+	return &copied
+}
+
+// contextDax makes the reads of a DAX client, the only calls Driver makes
+// on Dax, with ctx.
+type contextDax struct {
+	dynamodbiface.DynamoDBAPI
+	ctx context.Context
+}
+
+func (svc *contextDax) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	return svc.DynamoDBAPI.GetItemWithContext(svc.ctx, input)
+}
+
+func (svc *contextDax) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	return svc.DynamoDBAPI.QueryWithContext(svc.ctx, input)
+}
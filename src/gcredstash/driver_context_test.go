@@ -0,0 +1,52 @@
+package gcredstash
+
+import (
+	"context"
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDriverWithContext(t *testing.T) {
+	// An endpoint that does not answer until the test ends.
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	awsSession := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+		MaxRetries:  aws.Int(0),
+	}))
+
+	AddTimeoutHandlers(&awsSession.Handlers, 5*time.Second)
+	driver := NewDriver(awsSession, &NopLogger{})
+	validate := driver.Ddb.(*dynamodb.DynamoDB).Handlers.Validate.Len()
+	client := &Client{Driver: driver, Table: DEFAULT_TABLE, KmsKey: DEFAULT_KMS_KEY}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Get(ctx, "test.key")
+
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != request.CanceledErrorCode || time.Since(start) > time.Second {
+		t.Errorf("\nexpected: %v\ngot: %v after %s\n", request.CanceledErrorCode, err, time.Since(start))
+	}
+
+	if driver.Ddb.(*dynamodb.DynamoDB).Handlers.Validate.Len() != validate {
+		t.Errorf("\nexpected: %v\ngot: %v\n", validate, driver.Ddb.(*dynamodb.DynamoDB).Handlers.Validate.Len())
+	}
+}
@@ -0,0 +1,97 @@
+package gcredstash
+
+import (
+	"github.com/ryanuber/go-glob"
+	"sort"
+)
+
+type CopiedSecret struct {
+	Name          string
+	SourceVersion string
+	Version       string
+}
+
+func (driver *Driver) copySecret(name string, version string, table string, target *Driver, targetVersion string, targetTable string, targetKmsKey string, context map[string]string) error {
+	material, err := driver.GetMaterialWithVersion(name, version, table)
+
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := driver.DecryptMaterialBytes(name, material, context)
+
+	if err != nil {
+		return err
+	}
+
+	defer Wipe(plaintext)
+
+	opts := &PutOptions{
+		Scheme:      stringAttr(material, "scheme"),
+		Digest:      stringAttr(material, "digest"),
+		Compression: stringAttr(material, "compression"),
+		Comment:     stringAttr(material, "comment"),
+	}
+
+	if err := driver.keepPassphrase(name, material, opts); err != nil {
+		return err
+	}
+
+	return target.PutSecretBytesWithOptions(name, plaintext, targetVersion, targetKmsKey, targetTable, context, opts)
+}
+
+// CopySecrets re-encrypts the credentials matching pattern with the target's
+// KMS key and stores them in the target table. By default the latest version
+// is stored as the next version in the target; with keepVersions every
+// version is copied with its own number.
+func (driver *Driver) CopySecrets(pattern string, table string, target *Driver, targetTable string, targetKmsKey string, context map[string]string, keepVersions bool) ([]CopiedSecret, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := GroupVersions(items)
+	names := []string{}
+
+	for name := range versions {
+		if glob.Glob(pattern, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	copied := []CopiedSecret{}
+
+	for _, name := range names {
+		sourceVersions := versions[name]
+
+		if !keepVersions {
+			sourceVersions = sourceVersions[len(sourceVersions)-1:]
+		}
+
+		for _, version := range sourceVersions {
+			targetVersion := version
+
+			if !keepVersions {
+				latest, err := target.GetHighestVersion(name, targetTable)
+
+				if err != nil {
+					return copied, err
+				}
+
+				targetVersion = VersionNumToStr(latest + 1)
+			}
+
+			err := driver.copySecret(name, version, table, target, targetVersion, targetTable, targetKmsKey, context)
+
+			if err != nil {
+				return copied, err
+			}
+
+			copied = append(copied, CopiedSecret{name, version, targetVersion})
+		}
+	}
+
+	return copied, nil
+}
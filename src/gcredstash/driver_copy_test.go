@@ -0,0 +1,69 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestCopySecrets(t *testing.T) {
+	sourceKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	targetKms, _ := NewLocalKms("ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA=")
+
+	testutils.TempFile("", func(sf *os.File) {
+		testutils.TempFile("", func(tf *os.File) {
+			source := &Driver{Backend: &FileBackend{Path: sf.Name()}, Kms: sourceKms, Logger: &NopLogger{}}
+			target := &Driver{Backend: &FileBackend{Path: tf.Name()}, Kms: targetKms, Logger: &NopLogger{}}
+			table := "credential-store"
+
+			source.PutSecret("db.user", "app", "0000000000000000001", "alias/credstash", table, nil)
+			source.PutSecret("db.password", "old", "0000000000000000001", "alias/credstash", table, nil)
+			source.PutSecret("db.password", "new", "0000000000000000002", "alias/credstash", table, nil)
+			source.PutSecret("api.token", "xyz", "0000000000000000001", "alias/credstash", table, nil)
+			target.PutSecret("db.user", "other", "0000000000000000001", "alias/credstash", table, nil)
+
+			copied, err := source.CopySecrets("db.*", table, target, table, "alias/credstash", nil, false)
+
+			expected := []CopiedSecret{
+				{Name: "db.password", SourceVersion: "0000000000000000002", Version: "0000000000000000001"},
+				{Name: "db.user", SourceVersion: "0000000000000000001", Version: "0000000000000000002"},
+			}
+
+			if !reflect.DeepEqual(copied, expected) || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, copied, err)
+			}
+
+			value, _ := target.GetSecret("db.password", "", table, nil)
+
+			if value != "new" {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "new", value)
+			}
+
+			value, _ = target.GetSecret("db.user", "", table, nil)
+
+			if value != "app" {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "app", value)
+			}
+
+			copied, err = source.CopySecrets("db.password", table, target, "backup", "alias/credstash", nil, true)
+
+			if len(copied) != 2 || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", 2, copied, err)
+			}
+
+			value, _ = target.GetSecret("db.password", "0000000000000000001", "backup", nil)
+
+			if value != "old" {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "old", value)
+			}
+
+			_, err = source.CopySecrets("db.password", table, target, "backup", "alias/credstash", nil, true)
+
+			if err == nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,87 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"strings"
+	"testing"
+)
+
+// recordingDdb records the reads and writes made through it, and stores
+// nothing.
+type recordingDdb struct {
+	dynamodbiface.DynamoDBAPI
+	calls []string
+}
+
+func (ddb *recordingDdb) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	if aws.BoolValue(input.ConsistentRead) {
+		ddb.calls = append(ddb.calls, "Query(consistent)")
+	} else {
+		ddb.calls = append(ddb.calls, "Query")
+	}
+
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (ddb *recordingDdb) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	ddb.calls = append(ddb.calls, "GetItem")
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (ddb *recordingDdb) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	ddb.calls = append(ddb.calls, "PutItem")
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func TestDriverDax(t *testing.T) {
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	table, dax := &recordingDdb{}, &recordingDdb{}
+	driver := &Driver{Ddb: table, Dax: dax, Kms: localKms, Logger: &NopLogger{}}
+
+	_, err := driver.GetSecret("db.pass", "", "credential-store", nil)
+
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+	}
+
+	driver.GetSecret("db.pass", VersionNumToStr(1), "credential-store", nil)
+	driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", "credential-store", nil)
+	driver.GetHighestVersion("db.pass", "credential-store")
+
+	for _, c := range []struct {
+		ddb      *recordingDdb
+		expected []string
+	}{
+		{dax, []string{"Query", "GetItem"}},
+		{table, []string{"PutItem", "Query(consistent)"}},
+	} {
+		if strings.Join(c.ddb.calls, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", c.expected, c.ddb.calls)
+		}
+	}
+}
+
+func TestDriverReadConsistency(t *testing.T) {
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	ddb := &recordingDdb{}
+	driver := &Driver{Ddb: ddb, Kms: localKms, Logger: &NopLogger{}}
+
+	driver.GetSecret("db.pass", "", "credential-store", nil)
+	driver.ReadConsistency = READ_CONSISTENCY_EVENTUAL
+	driver.GetSecret("db.pass", "", "credential-store", nil)
+	driver.GetHighestVersion("db.pass", "credential-store")
+	expected := []string{"Query(consistent)", "Query", "Query(consistent)"}
+
+	if strings.Join(ddb.calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, ddb.calls)
+	}
+
+	if err := ValidateReadConsistency("weak"); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package gcredstash
+
+import (
+	"sort"
+)
+
+const (
+	DIFF_ONLY_IN_SOURCE = "only in source"
+	DIFF_ONLY_IN_TARGET = "only in target"
+	DIFF_HMAC_MISMATCH  = "hmac differs"
+)
+
+type SecretDiff struct {
+	Name    string
+	Version string
+	Reason  string
+}
+
+type secretKey struct {
+	name    string
+	version string
+}
+
+func (driver *Driver) listHmacs(table string) (map[secretKey]string, error) {
+	items, err := driver.backend().Scan(table, &ScanOptions{Attributes: []string{"name", "version", "hmac"}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	hmacs := map[secretKey]string{}
+
+	for _, i := range items {
+		hmac := ""
+
+		if attr, ok := i["hmac"]; ok && attr.S != nil {
+			hmac = *attr.S
+		}
+
+		hmacs[secretKey{*i["name"].S, *i["version"].S}] = hmac
+	}
+
+	return hmacs, nil
+}
+
+func (driver *Driver) DiffSecrets(table string, target *Driver, targetTable string) ([]SecretDiff, error) {
+	source, err := driver.listHmacs(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dest, err := target.listHmacs(targetTable)
+
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []SecretDiff{}
+
+	for key, hmac := range source {
+		destHmac, ok := dest[key]
+
+		if !ok {
+			diffs = append(diffs, SecretDiff{key.name, key.version, DIFF_ONLY_IN_SOURCE})
+		} else if destHmac != hmac {
+			diffs = append(diffs, SecretDiff{key.name, key.version, DIFF_HMAC_MISMATCH})
+		}
+	}
+
+	for key := range dest {
+		if _, ok := source[key]; !ok {
+			diffs = append(diffs, SecretDiff{key.name, key.version, DIFF_ONLY_IN_TARGET})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Name != diffs[j].Name {
+			return diffs[i].Name < diffs[j].Name
+		}
+
+		return diffs[i].Version < diffs[j].Version
+	})
+
+	return diffs, nil
+}
@@ -0,0 +1,137 @@
+package gcredstash
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type FileChange struct {
+	Name    string
+	Version string
+	Path    string
+	Removed bool
+}
+
+func writeFileAtomic(path string, content []byte, mode os.FileMode) error {
+	tmpfile, err := ioutil.TempFile(filepath.Dir(path), ".gcredstash")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.Write(content)
+
+	if err != nil {
+		tmpfile.Close()
+		return err
+	}
+
+	err = tmpfile.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpfile.Name(), mode)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpfile.Name(), path)
+}
+
+// SyncSecretFiles writes the latest version of every credential whose name
+// starts with prefix to a read-only file in dir named after the rest of the
+// name. versions maps names to the versions already written and is updated
+// in place: unchanged credentials are not decrypted again, and the files of
+// credentials that no longer exist are removed.
+func (driver *Driver) SyncSecretFiles(dir string, prefix string, table string, context map[string]string, versions map[string]string) ([]FileChange, error) {
+	items, err := driver.ListSecretsWithPrefix(table, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	latest := map[string]string{}
+
+	for name, nameVersions := range GroupVersions(items) {
+		file := strings.TrimPrefix(name, prefix)
+
+		if file == "" || strings.ContainsAny(file, "/\\") || file == "." || file == ".." {
+			return nil, fmt.Errorf("%s: invalid file name: %q", name, file)
+		}
+
+		latest[name] = nameVersions[len(nameVersions)-1]
+	}
+
+	err = os.MkdirAll(dir, 0700)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for name := range latest {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	changes := []FileChange{}
+
+	for _, name := range names {
+		version := latest[name]
+
+		if versions[name] == version {
+			continue
+		}
+
+		value, err := driver.GetSecretBytes(name, version, table, context)
+
+		if err != nil {
+			return changes, err
+		}
+
+		path := filepath.Join(dir, strings.TrimPrefix(name, prefix))
+		err = writeFileAtomic(path, value, 0400)
+		Wipe(value)
+
+		if err != nil {
+			return changes, err
+		}
+
+		versions[name] = version
+		changes = append(changes, FileChange{Name: name, Version: version, Path: path})
+	}
+
+	removed := []string{}
+
+	for name := range versions {
+		if _, ok := latest[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	sort.Strings(removed)
+
+	for _, name := range removed {
+		path := filepath.Join(dir, strings.TrimPrefix(name, prefix))
+		err := os.Remove(path)
+
+		if err != nil && !os.IsNotExist(err) {
+			return changes, err
+		}
+
+		delete(versions, name)
+		changes = append(changes, FileChange{Name: name, Path: path, Removed: true})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,78 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSyncSecretFiles(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		dir, _ := ioutil.TempDir("", "gcredstash")
+		defer os.RemoveAll(dir)
+
+		table := "credential-store"
+
+		driver.PutSecret("app.db.password", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("app.token", "200", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("other.token", "300", "0000000000000000001", "alias/credstash", table, nil)
+
+		versions := map[string]string{}
+		changes, err := driver.SyncSecretFiles(dir, "app.", table, nil, versions)
+
+		expected := []FileChange{
+			{Name: "app.db.password", Version: "0000000000000000001", Path: filepath.Join(dir, "db.password")},
+			{Name: "app.token", Version: "0000000000000000001", Path: filepath.Join(dir, "token")},
+		}
+
+		if !reflect.DeepEqual(changes, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, changes, err)
+		}
+
+		content, _ := ioutil.ReadFile(filepath.Join(dir, "db.password"))
+
+		if string(content) != "100" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "100", string(content))
+		}
+
+		info, _ := os.Stat(filepath.Join(dir, "token"))
+
+		if info.Mode().Perm() != 0400 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", os.FileMode(0400), info.Mode().Perm())
+		}
+
+		driver.PutSecret("app.db.password", "101", "0000000000000000002", "alias/credstash", table, nil)
+		driver.DeleteSecrets("app.token", "", table)
+
+		changes, err = driver.SyncSecretFiles(dir, "app.", table, nil, versions)
+
+		expected = []FileChange{
+			{Name: "app.db.password", Version: "0000000000000000002", Path: filepath.Join(dir, "db.password")},
+			{Name: "app.token", Path: filepath.Join(dir, "token"), Removed: true},
+		}
+
+		if !reflect.DeepEqual(changes, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, changes, err)
+		}
+
+		content, _ = ioutil.ReadFile(filepath.Join(dir, "db.password"))
+
+		if string(content) != "101" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "101", string(content))
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "token")); !os.IsNotExist(err) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "not exist", err)
+		}
+
+		changes, err = driver.SyncSecretFiles(dir, "app.", table, nil, versions)
+
+		if len(changes) != 0 || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "no changes", changes, err)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+func GrantOperations(read bool, write bool) []string {
+	operations := []string{}
+
+	if read {
+		operations = append(operations, kms.GrantOperationDecrypt)
+	}
+
+	if write {
+		operations = append(operations, kms.GrantOperationGenerateDataKey)
+	}
+
+	return operations
+}
+
+func (driver *Driver) CreateGrant(principal string, operations []string, kmsKey string) (string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", err
+	}
+
+	if len(operations) < 1 {
+		return "", fmt.Errorf("no grant operations specified")
+	}
+
+	// CreateGrant does not accept aliases, so resolve the key ARN first.
+	keyArn, err := KmsDescribeKeyArn(driver.Kms, kmsKey)
+
+	if err != nil {
+		return "", fmt.Errorf("Could not resolve KMS key(%s): %s", kmsKey, err.Error())
+	}
+
+	grantId, err := KmsCreateGrant(driver.Kms, keyArn, principal, operations)
+
+	if err != nil {
+		return "", fmt.Errorf("Could not create grant on KMS key(%s): %s", keyArn, err.Error())
+	}
+
+	return grantId, nil
+}
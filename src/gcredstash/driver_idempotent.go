@@ -0,0 +1,150 @@
+package gcredstash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// ContentHash returns the HMAC-SHA256, keyed with the HMAC key of a
+// version, of context, sorted by key, and plaintext. Being keyed, it
+// cannot be used to guess values by anyone who can only read the table.
+func ContentHash(hmacKey []byte, plaintext []byte, context map[string]string) []byte {
+	keys := []string{}
+
+	for k := range context {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	mac := hmac.New(sha256.New, hmacKey)
+
+	for _, k := range keys {
+		mac.Write([]byte(k + "\x00" + context[k] + "\x00"))
+	}
+
+	mac.Write([]byte{0})
+	mac.Write(plaintext)
+
+	return mac.Sum(nil)
+}
+
+// latestHolds returns the highest version of name and whether it holds
+// secret with context. A version stored with another context, with or
+// without a passphrase unlike the put, or one that has been soft-deleted,
+// does not hold it.
+func (driver *Driver) latestHolds(name string, secret []byte, table string, context map[string]string, locked bool) (int, bool, error) {
+	latest, err := driver.latestItem(name, table, true)
+
+	if err != nil {
+		return -1, false, err
+	}
+
+	if latest == nil {
+		return 0, false, nil
+	}
+
+	latestVersion := Atoi(stringAttr(latest, "version"))
+
+	if isTombstone(latest) || IsPassphraseProtected(latest) != locked {
+		return latestVersion, false, nil
+	}
+
+	equal, err := driver.materialEquals(name, latest, secret, context)
+
+	if errors.Is(err, ErrContextMismatch) {
+		return latestVersion, false, nil
+	}
+
+	if err != nil {
+		return -1, false, err
+	}
+
+	return latestVersion, equal, nil
+}
+
+func (driver *Driver) PutSecretIdempotent(name string, secret string, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, bool, error) {
+	plaintext := []byte(secret)
+	defer Wipe(plaintext)
+
+	return driver.PutSecretBytesIdempotent(name, plaintext, kmsKey, table, context, opts)
+}
+
+// PutSecretBytesIdempotent makes secret, encrypted with context, the latest
+// version of name. When the latest version already holds secret with
+// context, nothing is stored and that version is returned; otherwise secret
+// is stored as the next version, with a conditional put. It also reports
+// whether anything was stored. Concurrent puts of the same content store it
+// once. Versions are stored with a content_hash, so the next put only
+// decrypts the HMAC key of the latest version to compare, and needs no
+// passphrase.
+// The content decides whether a version is stored, not which: versions stay
+// sequential, as the latest is the highest, so going back to an earlier
+// value stores it again as a new version.
+func (driver *Driver) PutSecretBytesIdempotent(name string, secret []byte, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, bool, error) {
+	version, stored, err := driver.putSecretBytesIdempotent(name, secret, kmsKey, table, context, opts)
+
+	if err == nil && !stored {
+		return version, false, nil
+	}
+
+	return version, stored, driver.logAccess("put", name, version, table, err)
+}
+
+func (driver *Driver) putSecretBytesIdempotent(name string, secret []byte, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, bool, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", false, err
+	}
+
+	var item map[string]*dynamodb.AttributeValue
+
+	for retries := 0; ; retries++ {
+		latestVersion, holds, err := driver.latestHolds(name, secret, table, context, opts != nil && len(opts.Passphrase) > 0)
+
+		if err != nil {
+			return "", false, err
+		}
+
+		if holds {
+			driver.logger().Verbosef("put name=%s version=%s table=%s unchanged", name, VersionNumToStr(latestVersion), table)
+			return VersionNumToStr(latestVersion), false, nil
+		}
+
+		if item == nil {
+			hashed := PutOptions{}
+
+			if opts != nil {
+				hashed = *opts
+			}
+
+			hashed.ContentHash = true
+			item, err = driver.encryptItem(name, secret, "", kmsKey, context, &hashed)
+
+			if err != nil {
+				return "", false, err
+			}
+		}
+
+		version := VersionNumToStr(latestVersion + 1)
+		item["version"] = &dynamodb.AttributeValue{S: aws.String(version)}
+
+		driver.logger().Verbosef("put name=%s version=%s table=%s kms_key=%s", name, version, table, driver.KmsKeyFor(name, kmsKey))
+		err = driver.backend().PutItem(table, item)
+
+		if !IsConditionalCheckFailed(err) {
+			return version, err == nil, err
+		}
+
+		if retries >= MAX_VERSION_RETRIES {
+			return "", false, &VersionExistsError{Name: name, Version: version, Latest: latestVersion + 1}
+		}
+
+		driver.logger().Verbosef("put name=%s version=%s was taken by another writer, retrying", name, version)
+		time.Sleep(time.Duration(rand.Intn(20*(retries+1))) * time.Millisecond)
+	}
+}
@@ -0,0 +1,122 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestPutSecretIdempotent(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		for _, tc := range []struct {
+			value    string
+			context  map[string]string
+			version  string
+			expected bool
+		}{
+			{"100", nil, "0000000000000000001", true},
+			{"100", nil, "0000000000000000001", false},
+			{"200", nil, "0000000000000000002", true},
+			{"200", map[string]string{"env": "prod"}, "0000000000000000003", true},
+			{"200", map[string]string{"env": "prod"}, "0000000000000000003", false},
+			{"100", nil, "0000000000000000004", true},
+		} {
+			version, stored, err := driver.PutSecretIdempotent("test.key", tc.value, "alias/credstash", table, tc.context, nil)
+
+			if version != tc.version || stored != tc.expected || err != nil {
+				t.Errorf("\nexpected: %v %v\ngot: %v %v %v\n", tc.version, tc.expected, version, stored, err)
+			}
+		}
+	})
+}
+
+func TestPutSecretIdempotentContentHash(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		opts := &PutOptions{Passphrase: []byte("correct horse")}
+
+		driver.PutSecretIdempotent("test.key", "100", "alias/credstash", table, nil, opts)
+		material, _ := driver.GetMaterialWithVersion("test.key", VersionNumToStr(1), table)
+
+		if material["content_hash"] == nil || len(*material["content_hash"].S) != 64 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "a content_hash", material["content_hash"])
+		}
+
+		// No passphrase is needed to compare with the hash.
+		version, stored, err := driver.PutSecretIdempotent("test.key", "100", "alias/credstash", table, nil, opts)
+
+		if version != VersionNumToStr(1) || stored || err != nil {
+			t.Errorf("\nexpected: %v %v\ngot: %v %v %v\n", VersionNumToStr(1), false, version, stored, err)
+		}
+
+		for value, expected := range map[string]bool{"100": true, "200": false} {
+			if equal, err := driver.LatestValueEquals("test.key", value, table, nil); equal != expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, equal, err)
+			}
+		}
+
+		hash := ContentHash([]byte("key"), []byte("100"), nil)
+
+		if string(hash) == string(ContentHash([]byte("key"), []byte("100"), map[string]string{"env": "prod"})) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "a hash that covers the context", hash)
+		}
+	})
+}
+
+func TestPutSecretIdempotentConcurrent(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		wg := &sync.WaitGroup{}
+		stored := make(chan bool, 5)
+
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				_, ok, err := driver.PutSecretIdempotent("test.key", "100", "alias/credstash", table, nil, nil)
+
+				if err != nil {
+					t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+				}
+
+				stored <- ok
+			}()
+		}
+
+		wg.Wait()
+		close(stored)
+		count := 0
+
+		for ok := range stored {
+			if ok {
+				count++
+			}
+		}
+
+		if count != 1 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 1, count)
+		}
+	})
+}
+
+func TestContextMismatchError(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.PutSecret("test.key", "100", "0000000000000000001", "alias/credstash", table, map[string]string{"env": "prod"})
+
+		_, err := driver.GetSecret("test.key", "", table, nil)
+
+		if !errors.Is(err, ErrContextMismatch) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrContextMismatch, err)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package gcredstash
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+type KeyInfo struct {
+	Alias           string
+	Metadata        *kms.KeyMetadata
+	RotationEnabled bool
+	RotationErr     error
+	Grants          []*kms.GrantListEntry
+	GrantsErr       error
+	GenerateErr     error
+	DecryptErr      error
+}
+
+func (info *KeyInfo) IsUsable() bool {
+	return info.GenerateErr == nil && info.DecryptErr == nil
+}
+
+func (driver *Driver) InspectKey(kmsKey string, context map[string]string) (*KeyInfo, error) {
+	if err := driver.preflightKmsKey(kmsKey); err != nil {
+		return nil, err
+	}
+
+	metadata, err := KmsDescribeKey(driver.Kms, kmsKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not resolve KMS key(%s): %s", kmsKey, err.Error())
+	}
+
+	info := &KeyInfo{
+		Alias:    kmsKey,
+		Metadata: metadata,
+	}
+
+	info.RotationEnabled, info.RotationErr = KmsGetKeyRotationStatus(driver.Kms, *metadata.Arn)
+	info.Grants, info.GrantsErr = KmsListGrants(driver.Kms, *metadata.Arn)
+
+	// Round-trip a data key to check that the caller can both write and read.
+	dataKey, hmacKey, wrappedKey, err := KmsGenerateDataKey(driver.Kms, kmsKey, context)
+
+	if err != nil {
+		info.GenerateErr = driver.kmsAccessError("", "kms:GenerateDataKey", kmsKey, err)
+		info.DecryptErr = fmt.Errorf("skipped")
+		return info, nil
+	}
+
+	defer Wipe(dataKey)
+	defer Wipe(hmacKey)
+
+	decryptedDataKey, decryptedHmacKey, err := KmsDecrypt(driver.Kms, wrappedKey, context)
+
+	if err != nil {
+		info.DecryptErr = driver.kmsAccessError("", "kms:Decrypt", kmsKey, err)
+	} else if !bytes.Equal(dataKey, decryptedDataKey) || !bytes.Equal(hmacKey, decryptedHmacKey) {
+		info.DecryptErr = fmt.Errorf("decrypted data key does not match the generated one")
+	}
+
+	return info, nil
+}
@@ -0,0 +1,134 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"sort"
+	"time"
+)
+
+type SecretInfo struct {
+	Name        string
+	Version     string
+	CreatedAt   string
+	CreatedBy   string
+	Comment     string
+	ExpiresAt   string
+	RotateEvery string
+	// Tags are those of this version; see CredentialTags for those of the
+	// credential.
+	Tags map[string]string
+}
+
+func stringAttr(item map[string]*dynamodb.AttributeValue, attr string) string {
+	if value, ok := item[attr]; ok && value.S != nil {
+		return *value.S
+	}
+
+	return ""
+}
+
+// secretInfoAttributes are the attributes a SecretInfo is read from.
+var secretInfoAttributes = []string{"name", "version", "created_at", "created_by", "comment", "expires_at", "rotate_every", "tags"}
+
+func secretInfo(item map[string]*dynamodb.AttributeValue) *SecretInfo {
+	return &SecretInfo{
+		Name:        stringAttr(item, "name"),
+		Version:     stringAttr(item, "version"),
+		CreatedAt:   stringAttr(item, "created_at"),
+		CreatedBy:   stringAttr(item, "created_by"),
+		Comment:     stringAttr(item, "comment"),
+		ExpiresAt:   stringAttr(item, "expires_at"),
+		RotateEvery: stringAttr(item, "rotate_every"),
+		Tags:        mapAttr(item, "tags"),
+	}
+}
+
+func (driver *Driver) ListSecretsWithMetadata(table string, prefix string) ([]*SecretInfo, error) {
+	items, err := driver.backend().Scan(table, &ScanOptions{
+		Attributes: secretInfoAttributes,
+		Prefix:     prefix,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	infos := []*SecretInfo{}
+
+	for _, i := range items {
+		infos = append(infos, secretInfo(i))
+	}
+
+	return infos, nil
+}
+
+// ExpiringSecrets returns the latest version of every credential whose
+// expires_at is before the given time, including ones that have already
+// expired, sorted by expiry.
+func (driver *Driver) ExpiringSecrets(table string, prefix string, before time.Time) ([]*SecretInfo, error) {
+	infos, err := driver.ListSecretsWithMetadata(table, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	latest := map[string]*SecretInfo{}
+
+	for _, info := range infos {
+		if current, ok := latest[info.Name]; !ok || info.Version > current.Version {
+			latest[info.Name] = info
+		}
+	}
+
+	expiring := []*SecretInfo{}
+
+	for _, info := range latest {
+		expiresAt, err := time.Parse(time.RFC3339, info.ExpiresAt)
+
+		if err == nil && expiresAt.Before(before) {
+			expiring = append(expiring, info)
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		if expiring[i].ExpiresAt != expiring[j].ExpiresAt {
+			return expiring[i].ExpiresAt < expiring[j].ExpiresAt
+		}
+
+		return expiring[i].Name < expiring[j].Name
+	})
+
+	return expiring, nil
+}
+
+func SortSecretInfos(infos []*SecretInfo, key string) error {
+	var field func(info *SecretInfo) string
+
+	switch key {
+	case "", "name":
+		field = func(info *SecretInfo) string { return info.Name }
+	case "version":
+		field = func(info *SecretInfo) string { return info.Version }
+	case "date":
+		field = func(info *SecretInfo) string { return info.CreatedAt }
+	default:
+		return fmt.Errorf("invalid sort key: %s (expected name, version or date)", key)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+
+		if field(a) != field(b) {
+			return field(a) < field(b)
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		return a.Version < b.Version
+	})
+
+	return nil
+}
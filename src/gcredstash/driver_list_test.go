@@ -0,0 +1,102 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListSecretsWithMetadata(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Now = func() time.Time { return time.Date(2026, 10, 14, 9, 12, 3, 0, time.UTC) }
+		driver.Author = "alice"
+
+		table := "credential-store"
+		driver.PutSecretWithOptions("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{Comment: "initial"})
+
+		infos, err := driver.ListSecretsWithMetadata(table, "")
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		expected := []*SecretInfo{{
+			Name:      "foo.bar",
+			Version:   "0000000000000000001",
+			CreatedAt: "2026-10-14T09:12:03Z",
+			CreatedBy: "alice",
+			Comment:   "initial",
+		}}
+
+		if !reflect.DeepEqual(infos, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected[0], infos[0])
+		}
+	})
+}
+
+func TestExpiringSecrets(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 12, 3, 0, time.UTC)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+		day := 24 * time.Hour
+
+		driver.PutSecretWithOptions("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{ExpiresAt: now.Add(day)})
+		driver.PutSecretWithOptions("foo.bar", "200", "0000000000000000002", "alias/credstash", table, nil, &PutOptions{ExpiresAt: now.Add(30 * day)})
+		driver.PutSecretWithOptions("foo.baz", "300", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{ExpiresAt: now.Add(-day)})
+		driver.PutSecretWithOptions("foo.qux", "400", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{ExpiresAt: now.Add(3 * day)})
+		driver.PutSecret("foo.zoo", "500", "0000000000000000001", "alias/credstash", table, nil)
+
+		infos, err := driver.ExpiringSecrets(table, "", now.Add(7*day))
+		names := []string{}
+
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+
+		expected := []string{"foo.baz", "foo.qux"}
+
+		if err != nil || !reflect.DeepEqual(names, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, names, err)
+		}
+	})
+}
+
+func TestSortSecretInfos(t *testing.T) {
+	infos := []*SecretInfo{
+		{Name: "b", Version: "0000000000000000001", CreatedAt: "2026-01-02T00:00:00Z"},
+		{Name: "a", Version: "0000000000000000002", CreatedAt: "2026-01-03T00:00:00Z"},
+		{Name: "c", Version: "0000000000000000003", CreatedAt: "2026-01-01T00:00:00Z"},
+	}
+
+	tests := map[string][]string{
+		"name":    {"a", "b", "c"},
+		"version": {"b", "a", "c"},
+		"date":    {"c", "b", "a"},
+	}
+
+	for key, expected := range tests {
+		err := SortSecretInfos(infos, key)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		names := []string{}
+
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, names)
+		}
+	}
+
+	if SortSecretInfos(infos, "size") == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", nil)
+	}
+}
@@ -0,0 +1,55 @@
+package gcredstash
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// getProjected reads only attrs of the latest version of name, or of
+// version unless it is "". Unlike GetMaterialWithVersion, which gets the
+// whole item, it never fetches the key and contents.
+func (driver *Driver) getProjected(name string, version string, table string, attrs []string) (map[string]*dynamodb.AttributeValue, error) {
+	items, err := driver.readBackend().Query(table, name, &QueryOptions{
+		Limit:                1,
+		Descending:           true,
+		Attributes:           attrs,
+		Version:              version,
+		EventuallyConsistent: driver.ReadConsistency == READ_CONSISTENCY_EVENTUAL,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, &NotFoundError{Name: name, Version: version}
+	}
+
+	return items[0], nil
+}
+
+// Exists reports whether name, or the given version of it, is stored. Only
+// the keys of the item are read, and nothing is decrypted, so it needs no
+// KMS permissions.
+func (driver *Driver) Exists(name string, version string, table string) (bool, error) {
+	_, err := driver.getProjected(name, version, table, []string{"name", "version"})
+	var notFound *NotFoundError
+
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// GetMetadata returns the metadata of the latest version of name, or of
+// the given version, without reading its contents.
+func (driver *Driver) GetMetadata(name string, version string, table string) (*SecretInfo, error) {
+	item, err := driver.getProjected(name, version, table, secretInfoAttributes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return secretInfo(item), nil
+}
@@ -0,0 +1,87 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"os"
+	"testing"
+)
+
+func TestDriverExists(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		svc := &unavailableKms{LocalKms: driver.Kms.(*LocalKms)}
+		driver.Kms = svc
+		driver.PutSecretWithOptions("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil, &PutOptions{Comment: "initial"})
+		driver.PutSecret("db.pass", "200", VersionNumToStr(2), "alias/credstash", table, nil)
+		svc.down = true
+
+		for _, c := range []struct {
+			name     string
+			version  string
+			expected bool
+		}{
+			{"db.pass", "", true},
+			{"db.pass", VersionNumToStr(1), true},
+			{"db.pass", VersionNumToStr(3), false},
+			{"db.user", "", false},
+		} {
+			if exists, err := driver.Exists(c.name, c.version, table); exists != c.expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", c.expected, exists, err)
+			}
+		}
+
+		info, err := driver.GetMetadata("db.pass", VersionNumToStr(1), table)
+
+		if err != nil || info.Version != VersionNumToStr(1) || info.Comment != "initial" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "version 1 with its comment", info, err)
+		}
+
+		if info, err := driver.GetMetadata("db.pass", "", table); err != nil || info.Version != VersionNumToStr(2) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", VersionNumToStr(2), info, err)
+		}
+
+		if _, err := driver.GetMetadata("db.pass", VersionNumToStr(3), table); !errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionNotFound, err)
+		}
+	})
+}
+
+// queryInputDdb records the queries made through it, and stores nothing.
+type queryInputDdb struct {
+	dynamodbiface.DynamoDBAPI
+	inputs []*dynamodb.QueryInput
+}
+
+func (ddb *queryInputDdb) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	ddb.inputs = append(ddb.inputs, input)
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func TestDriverExistsProjection(t *testing.T) {
+	ddb := &queryInputDdb{}
+	driver := &Driver{Ddb: ddb, Logger: &NopLogger{}}
+
+	if exists, err := driver.Exists("db.pass", VersionNumToStr(2), "credential-store"); exists || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", false, exists, err)
+	}
+
+	input := ddb.inputs[0]
+
+	if aws.StringValue(input.ProjectionExpression) != "#name,version,deleted_at" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "#name,version,deleted_at", aws.StringValue(input.ProjectionExpression))
+	}
+
+	if aws.StringValue(input.KeyConditionExpression) != "#name = :name AND version = :version" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "a key condition on the version", aws.StringValue(input.KeyConditionExpression))
+	}
+
+	if aws.StringValue(input.ExpressionAttributeValues[":version"].S) != VersionNumToStr(2) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", VersionNumToStr(2), input.ExpressionAttributeValues)
+	}
+}
@@ -0,0 +1,44 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+var ErrOffline = errors.New("cannot put offline: a new data key needs KMS")
+
+// offlineDataKey returns the data key and HMAC key of material from
+// DataKeyCache, without calling KMS.
+func (driver *Driver) offlineDataKey(name string, material map[string]*dynamodb.AttributeValue, data []byte, context map[string]string) ([]byte, []byte, error) {
+	if driver.DataKeyCache == nil {
+		return nil, nil, fmt.Errorf("%s: offline reads need the data key cache", name)
+	}
+
+	dataKey, hmacKey, err := driver.DataKeyCache.Get(data, context)
+
+	if errors.Is(err, ErrDataKeyNotCached) {
+		return nil, nil, fmt.Errorf("%s: the data key of version %d is not cached; read it once online first, with the same encryption context: %w",
+			name, Atoi(stringAttr(material, "version")), err)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	driver.logger().Verbosef("decrypt name=%s offline", name)
+
+	return dataKey, hmacKey, nil
+}
+
+// cacheDataKey keeps a data key KMS decrypted in DataKeyCache. Failures
+// only warn, as the read itself succeeded.
+func (driver *Driver) cacheDataKey(name string, data []byte, context map[string]string, dataKey []byte, hmacKey []byte) {
+	if driver.DataKeyCache == nil {
+		return
+	}
+
+	if err := driver.DataKeyCache.Put(data, context, dataKey, hmacKey); err != nil {
+		driver.logger().Warnf("%s: could not cache the data key: %s", name, err.Error())
+	}
+}
@@ -0,0 +1,68 @@
+package gcredstash
+
+import (
+	"fmt"
+	"time"
+)
+
+// PruneVersions deletes all but the keep most recent versions of name.
+// When olderThan is positive, only versions whose created_at is older than
+// that are deleted; versions without created_at are kept. The latest
+// version is never deleted. It returns the deleted versions.
+func (driver *Driver) PruneVersions(name string, keep int, olderThan time.Duration, table string) ([]string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	if keep < 1 {
+		return nil, fmt.Errorf("at least one version must be kept")
+	}
+
+	items, err := driver.backend().Query(table, name, &QueryOptions{
+		Descending: true,
+		Attributes: []string{"name", "version", "created_at"},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, &NotFoundError{Name: name}
+	}
+
+	now := time.Now
+
+	if driver.Now != nil {
+		now = driver.Now
+	}
+
+	cutoff := now().Add(-olderThan)
+	deleted := []string{}
+
+	for i, item := range items {
+		if i < keep {
+			continue
+		}
+
+		if olderThan > 0 {
+			createdAt, err := time.Parse(time.RFC3339, stringAttr(item, "created_at"))
+
+			if err != nil || !createdAt.Before(cutoff) {
+				continue
+			}
+		}
+
+		version := stringAttr(item, "version")
+		err := driver.DeleteItem(name, version, table)
+
+		if err != nil {
+			return deleted, err
+		}
+
+		driver.events().OnDelete(name, version)
+		deleted = append(deleted, version)
+	}
+
+	return deleted, nil
+}
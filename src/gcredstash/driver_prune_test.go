@@ -0,0 +1,47 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPruneVersions(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		now := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+		table := "credential-store"
+
+		driver.PutSecret("foo.bar", "0", "0000000000000000001", "alias/credstash", table, nil)
+
+		for i, age := range []int{200, 100, 10, 1} {
+			driver.Now = func() time.Time { return now.AddDate(0, 0, -age) }
+			driver.PutSecret("foo.bar", "x", VersionNumToStr(i+2), "alias/credstash", table, nil)
+		}
+
+		driver.Now = func() time.Time { return now }
+		deleted, err := driver.PruneVersions("foo.bar", 2, 90*24*time.Hour, table)
+		expected := []string{"0000000000000000003", "0000000000000000002"}
+
+		if !reflect.DeepEqual(deleted, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, deleted, err)
+		}
+
+		deleted, err = driver.PruneVersions("foo.bar", 2, 0, table)
+		expected = []string{"0000000000000000001"}
+
+		if !reflect.DeepEqual(deleted, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, deleted, err)
+		}
+
+		items, _ := driver.ListSecrets(table)
+		versions := GroupVersions(items)["foo.bar"]
+		expected = []string{"0000000000000000004", "0000000000000000005"}
+
+		if !reflect.DeepEqual(versions, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, versions)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+// kmsFor returns the KMS client for kmsKey: the driver's own, or that of the
+// region of the key when it is an ARN in another region.
+func (driver *Driver) kmsFor(kmsKey string) kmsiface.KMSAPI {
+	arn, err := ParseKmsKeyArn(kmsKey)
+
+	if err != nil || arn == nil || driver.DriverForRegion == nil {
+		return driver.Kms
+	}
+
+	if region := driver.kmsRegion(); region == "" || region == arn.Region {
+		return driver.Kms
+	}
+
+	return driver.DriverForRegion(arn.Region).Kms
+}
+
+// recoveryAttrs wraps the data key of a new item with RecoveryKmsKey as
+// well, so that it can still be decrypted when the primary key is disabled
+// or scheduled for deletion.
+func (driver *Driver) recoveryAttrs(name string, dataKey []byte, hmacKey []byte, context map[string]string) (map[string]*dynamodb.AttributeValue, error) {
+	if err := ValidateKmsKey(driver.RecoveryKmsKey); err != nil {
+		return nil, err
+	}
+
+	plaintext := append(append([]byte{}, dataKey...), hmacKey...)
+	defer Wipe(plaintext)
+
+	blob, err := KmsEncrypt(driver.kmsFor(driver.RecoveryKmsKey), driver.RecoveryKmsKey, plaintext, context)
+
+	if IsAccessDenied(err) {
+		return nil, driver.kmsAccessError(name, "kms:Encrypt", driver.RecoveryKmsKey, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not wrap the data key with the recovery KMS key(%s): %s", driver.RecoveryKmsKey, err.Error())
+	}
+
+	return map[string]*dynamodb.AttributeValue{
+		"recovery_key":     {S: aws.String(B64Encode(blob))},
+		"recovery_kms_key": {S: aws.String(driver.RecoveryKmsKey)},
+	}, nil
+}
+
+// recoverDataKey decrypts the data key of material with the recovery key it
+// was stored with. It is used when the primary key is unavailable.
+func (driver *Driver) recoverDataKey(name string, material map[string]*dynamodb.AttributeValue, context map[string]string) ([]byte, []byte, error) {
+	kmsKey := stringAttr(material, "recovery_kms_key")
+	blob := B64Decode(stringAttr(material, "recovery_key"))
+	dataKey, hmacKey, _, err := KmsDecryptWithKey(driver.kmsFor(kmsKey), kmsKey, blob, context)
+
+	if err != nil {
+		return nil, nil, driver.kmsAccessError(name, "kms:Decrypt", kmsKey, err)
+	}
+
+	return dataKey, hmacKey, nil
+}
@@ -0,0 +1,66 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"os"
+	"testing"
+)
+
+// disabledKms is LocalKms whose primary key, used when Decrypt is called
+// without a key ID, has been disabled.
+type disabledKms struct {
+	*LocalKms
+	disabled bool
+}
+
+func (svc *disabledKms) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	if svc.disabled && aws.StringValue(input.KeyId) == "" {
+		return nil, awserr.New("DisabledException", "the key is disabled", nil)
+	}
+
+	return svc.LocalKms.Decrypt(input)
+}
+
+func TestRecoveryKmsKey(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		svc := &disabledKms{LocalKms: driver.Kms.(*LocalKms)}
+		driver.Kms = svc
+
+		driver.PutSecret("old.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		driver.RecoveryKmsKey = "alias/credstash-recovery"
+		driver.PutSecret("db.pass", "200", VersionNumToStr(1), "alias/credstash", table, map[string]string{"env": "prod"})
+
+		material, _ := driver.GetMaterialWithVersion("db.pass", VersionNumToStr(1), table)
+
+		if material["recovery_key"] == nil || *material["recovery_kms_key"].S != "alias/credstash-recovery" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "recovery_key and recovery_kms_key", material)
+		}
+
+		svc.disabled = true
+
+		value, err := driver.GetSecret("db.pass", "", table, map[string]string{"env": "prod"})
+
+		if value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+
+		_, err = driver.GetSecret("db.pass", "", table, map[string]string{"env": "dev"})
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "an error", err)
+		}
+
+		_, err = driver.GetSecret("old.pass", "", table, nil)
+
+		if !IsKmsKeyUnavailable(err) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "DisabledException", err)
+		}
+	})
+}
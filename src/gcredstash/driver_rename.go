@@ -0,0 +1,360 @@
+package gcredstash
+
+import (
+	"fmt"
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbv2 "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"sort"
+	"strings"
+)
+
+// MovingBackend is implemented by backends that can store some items and
+// delete others all-or-nothing, which a rename with deleteOld needs.
+type MovingBackend interface {
+	MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error
+}
+
+func (backend *DynamoDBBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	params := &dynamodb.TransactWriteItemsInput{}
+
+	for _, item := range items {
+		params.TransactItems = append(params.TransactItems, &dynamodb.TransactWriteItem{
+			Put: &dynamodb.Put{
+				TableName:                aws.String(table),
+				Item:                     item,
+				ConditionExpression:      aws.String("attribute_not_exists(#name)"),
+				ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+			},
+		})
+	}
+
+	for _, item := range from {
+		params.TransactItems = append(params.TransactItems, &dynamodb.TransactWriteItem{
+			Delete: &dynamodb.Delete{
+				TableName: aws.String(table),
+				Key: map[string]*dynamodb.AttributeValue{
+					"name":    item["name"],
+					"version": item["version"],
+				},
+				ConditionExpression:      aws.String("attribute_exists(#name)"),
+				ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+			},
+		})
+	}
+
+	_, err := backend.Ddb.TransactWriteItems(params)
+
+	return err
+}
+
+func (backend *DynamoDBV2Backend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	params := &dynamodbv2.TransactWriteItemsInput{}
+
+	for _, item := range items {
+		params.TransactItems = append(params.TransactItems, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:                awsv2.String(table),
+				Item:                     itemToV2(item),
+				ConditionExpression:      awsv2.String("attribute_not_exists(#name)"),
+				ExpressionAttributeNames: map[string]string{"#name": "name"},
+			},
+		})
+	}
+
+	for _, item := range from {
+		params.TransactItems = append(params.TransactItems, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName:                awsv2.String(table),
+				Key:                      keyToV2(stringAttr(item, "name"), stringAttr(item, "version")),
+				ConditionExpression:      awsv2.String("attribute_exists(#name)"),
+				ExpressionAttributeNames: map[string]string{"#name": "name"},
+			},
+		})
+	}
+
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	_, err := backend.Ddb.TransactWriteItems(ctx, params)
+
+	return backend.requestError(ctx, "TransactWriteItems", table, err)
+}
+
+func (backend *FileBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return err
+	}
+
+	moved := map[string]bool{}
+
+	for _, item := range from {
+		moved[stringAttr(item, "name")+"\x00"+stringAttr(item, "version")] = true
+	}
+
+	kept := []map[string]string{}
+
+	for _, stored := range tables[table] {
+		key := stored["name"] + "\x00" + stored["version"]
+
+		if moved[key] {
+			delete(moved, key)
+			continue
+		}
+
+		kept = append(kept, stored)
+	}
+
+	for _, item := range from {
+		if moved[stringAttr(item, "name")+"\x00"+stringAttr(item, "version")] {
+			return fmt.Errorf("TransactionCanceledException: %s version %s is not in %s", stringAttr(item, "name"), stringAttr(item, "version"), backend.Path)
+		}
+	}
+
+	for _, item := range items {
+		m := itemToFileItem(item)
+
+		for _, stored := range kept {
+			if stored["name"] == m["name"] && stored["version"] == m["version"] {
+				return fmt.Errorf("TransactionCanceledException: %s version %s already exists in %s", m["name"], m["version"], backend.Path)
+			}
+		}
+
+		kept = append(kept, m)
+	}
+
+	tables[table] = kept
+
+	return backend.save(tables)
+}
+
+func (backend *DryRunBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	backend.PutItems(table, items)
+
+	for _, item := range from {
+		backend.Delete(table, stringAttr(item, "name"), stringAttr(item, "version"))
+	}
+
+	return nil
+}
+
+func (backend *NamespaceBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	moving, ok := backend.Backend.(MovingBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	prefixedItems := []map[string]*dynamodb.AttributeValue{}
+	prefixedFrom := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		prefixedItems = append(prefixedItems, backend.addPrefix(item))
+	}
+
+	for _, item := range from {
+		prefixedFrom = append(prefixedFrom, backend.addPrefix(item))
+	}
+
+	return moving.MoveItems(table, prefixedItems, prefixedFrom)
+}
+
+func (backend *TombstoneBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	moving, ok := backend.Backend.(MovingBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	return moving.MoveItems(table, items, from)
+}
+
+func (backend *keychainCacheBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	moving, ok := backend.Backend.(MovingBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	err := moving.MoveItems(table, items, from)
+
+	if err == nil {
+		for _, item := range from {
+			backend.invalidate(table, stringAttr(item, "name"), stringAttr(item, "version"))
+		}
+	}
+
+	return err
+}
+
+type RenamedSecret struct {
+	Name     string
+	NewName  string
+	Versions []string
+}
+
+// checkRenameTarget fails when newName has any version, deleted or not, so
+// that a rename never mixes the versions of two credentials.
+func (driver *Driver) checkRenameTarget(newName string, table string) error {
+	if err := driver.Naming.Validate(newName); err != nil {
+		return err
+	}
+
+	latest, err := driver.GetHighestVersion(newName, table)
+
+	if err != nil {
+		return err
+	}
+
+	if latest > 0 {
+		return fmt.Errorf("%s already exists", newName)
+	}
+
+	return nil
+}
+
+// RenameSecret stores every version of name under newName, with the same
+// numbers and attributes. The values are not re-encrypted, so no KMS access
+// is needed. With deleteOld the old versions are deleted in the same
+// transaction as the new ones are stored, so the credential is never under
+// both names or neither.
+func (driver *Driver) RenameSecret(name string, newName string, table string, deleteOld bool) (*RenamedSecret, error) {
+	renamed, err := driver.renameSecret(name, newName, table, deleteOld)
+
+	if logErr := driver.logAccess("put", newName, "", table, err); err == nil && logErr != nil {
+		err = logErr
+	}
+
+	if deleteOld {
+		if logErr := driver.logAccess("delete", name, "", table, err); err == nil && logErr != nil {
+			err = logErr
+		}
+	}
+
+	return renamed, err
+}
+
+func (driver *Driver) renameSecret(name string, newName string, table string, deleteOld bool) (*RenamedSecret, error) {
+	if err := driver.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	if name == newName {
+		return nil, fmt.Errorf("cannot rename %s to itself", name)
+	}
+
+	if err := driver.checkRenameTarget(newName, table); err != nil {
+		return nil, err
+	}
+
+	items, err := driver.backend().Query(table, name, &QueryOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, &NotFoundError{Name: name}
+	}
+
+	if deleteOld && len(items)*2 > MAX_TRANSACT_ITEMS {
+		return nil, fmt.Errorf("%s has too many versions to move in one transaction: %d (max %d)", name, len(items), MAX_TRANSACT_ITEMS/2)
+	}
+
+	renamed := &RenamedSecret{Name: name, NewName: newName}
+	newItems := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		newItem := map[string]*dynamodb.AttributeValue{}
+
+		for attr, value := range item {
+			newItem[attr] = value
+		}
+
+		newItem["name"] = &dynamodb.AttributeValue{S: aws.String(newName)}
+		newItems = append(newItems, newItem)
+		renamed.Versions = append(renamed.Versions, stringAttr(item, "version"))
+	}
+
+	driver.logger().Verbosef("rename name=%s new_name=%s versions=%d table=%s delete=%t", name, newName, len(items), table, deleteOld)
+
+	if !deleteOld {
+		for _, item := range newItems {
+			if err := driver.backend().PutItem(table, item); err != nil {
+				return nil, err
+			}
+		}
+
+		return renamed, nil
+	}
+
+	backend, ok := driver.backend().(MovingBackend)
+
+	if !ok {
+		return nil, fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	err = backend.MoveItems(table, newItems, items)
+
+	if err != nil && strings.Contains(err.Error(), "TransactionCanceledException") {
+		err = fmt.Errorf("%s or %s was changed during the rename; nothing was renamed", name, newName)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return renamed, nil
+}
+
+// RenameSecrets renames every credential whose name starts with fromPrefix
+// to start with toPrefix instead, with RenameSecret. All new names are
+// checked before anything is written; each credential is renamed on its own.
+func (driver *Driver) RenameSecrets(fromPrefix string, toPrefix string, table string, deleteOld bool) ([]*RenamedSecret, error) {
+	if fromPrefix == "" {
+		return nil, fmt.Errorf("the prefix to rename from is empty")
+	}
+
+	items, err := driver.ListSecretsWithPrefix(table, fromPrefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for name := range GroupVersions(items) {
+		if strings.HasPrefix(name, fromPrefix) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := driver.checkRenameTarget(toPrefix+strings.TrimPrefix(name, fromPrefix), table); err != nil {
+			return nil, err
+		}
+	}
+
+	renamed := []*RenamedSecret{}
+
+	for _, name := range names {
+		secret, err := driver.RenameSecret(name, toPrefix+strings.TrimPrefix(name, fromPrefix), table, deleteOld)
+
+		if err != nil {
+			return renamed, err
+		}
+
+		renamed = append(renamed, secret)
+	}
+
+	return renamed, nil
+}
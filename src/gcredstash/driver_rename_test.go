@@ -0,0 +1,96 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDriverRenameSecret(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.PutSecretWithOptions("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil, &PutOptions{Comment: "initial"})
+		driver.PutSecret("db.pass", "200", VersionNumToStr(2), "alias/credstash", table, nil)
+		driver.PutSecret("db.user", "admin", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		renamed, err := driver.RenameSecret("db.pass", "db.password", table, false)
+		expected := []string{VersionNumToStr(1), VersionNumToStr(2)}
+
+		if err != nil || !reflect.DeepEqual(renamed.Versions, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, renamed, err)
+		}
+
+		if value, err := driver.GetSecret("db.pass", "", table, nil); value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+
+		if value, err := driver.GetSecret("db.password", VersionNumToStr(1), table, nil); value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+
+		if info, err := driver.GetMetadata("db.password", VersionNumToStr(1), table); err != nil || info.Comment != "initial" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "initial", info, err)
+		}
+
+		_, err = driver.RenameSecret("db.pass", "db.password", table, true)
+
+		if err == nil || err.Error() != "db.password already exists" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "db.password already exists", err)
+		}
+
+		if _, err := driver.RenameSecret("db.user", "db.username", table, true); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if _, err := driver.GetSecret("db.user", "", table, nil); !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+
+		if value, err := driver.GetSecret("db.username", "", table, nil); value != "admin" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "admin", value, err)
+		}
+
+		if _, err := driver.RenameSecret("db.nothing", "db.other", table, true); !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+	})
+}
+
+func TestDriverRenameSecrets(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.PutSecret("staging.db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("staging.db.pass", "200", VersionNumToStr(2), "alias/credstash", table, nil)
+		driver.PutSecret("staging.api.key", "abc", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("prod.api.key", "xyz", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		_, err := driver.RenameSecrets("staging.", "prod.", table, true)
+
+		if err == nil || err.Error() != "prod.api.key already exists" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "prod.api.key already exists", err)
+		}
+
+		renamed, err := driver.RenameSecrets("staging.", "stage.", table, true)
+
+		if err != nil || len(renamed) != 2 || renamed[0].NewName != "stage.api.key" || renamed[1].NewName != "stage.db.pass" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "stage.api.key and stage.db.pass", renamed, err)
+		}
+
+		items, _ := driver.ListSecretsWithPrefix(table, "")
+		versions := GroupVersions(items)
+		expected := map[string][]string{
+			"prod.api.key":  {VersionNumToStr(1)},
+			"stage.api.key": {VersionNumToStr(1)},
+			"stage.db.pass": {VersionNumToStr(1), VersionNumToStr(2)},
+		}
+
+		if !reflect.DeepEqual(versions, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, versions)
+		}
+	})
+}
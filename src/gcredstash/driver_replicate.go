@@ -0,0 +1,64 @@
+package gcredstash
+
+import (
+	"fmt"
+)
+
+type Replica struct {
+	Region string
+	KmsKey string
+}
+
+func (driver *Driver) ForRegion(region string) (*Driver, error) {
+	if driver.DriverForRegion == nil {
+		return nil, fmt.Errorf("multi-region operations are not supported by this driver")
+	}
+
+	return driver.DriverForRegion(region), nil
+}
+
+func (driver *Driver) GetHighestVersionInRegions(name string, table string, replicas []Replica) (int, error) {
+	max := 0
+
+	for _, replica := range replicas {
+		regional, err := driver.ForRegion(replica.Region)
+
+		if err != nil {
+			return -1, err
+		}
+
+		version, err := regional.GetHighestVersion(name, table)
+
+		if err != nil {
+			return -1, fmt.Errorf("%s: %s", replica.Region, err.Error())
+		}
+
+		if version > max {
+			max = version
+		}
+	}
+
+	return max, nil
+}
+
+func (driver *Driver) ReplicatePut(name string, secret string, version string, table string, context map[string]string, replicas []Replica, opts *PutOptions) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
+
+	for _, replica := range replicas {
+		regional, err := driver.ForRegion(replica.Region)
+
+		if err != nil {
+			return err
+		}
+
+		err = regional.PutSecretWithOptions(name, secret, version, replica.KmsKey, table, context, opts)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", replica.Region, err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"testing"
+)
+
+func TestReplicatePut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	table := "credential-store"
+	name := "test.key"
+	version := "0000000000000000001"
+
+	item := map[string]string{
+		"contents": "twnH",
+		"digest":   "SHA256",
+		"hmac":     "01cc6772cf2c889c8c0dae1f0ec3d7659e21103d56cd3436039cf29d18759958",
+		"key":      "YmxvYkRhdGE=",
+		"name":     name,
+		"version":  version,
+	}
+
+	replicas := []Replica{
+		{Region: "us-east-1", KmsKey: "alias/credstash"},
+		{Region: "eu-west-1", KmsKey: "alias/credstash-dr"},
+	}
+
+	drivers := map[string]*Driver{}
+
+	for _, replica := range replicas {
+		mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+		mkms := mockaws.NewMockKMSAPI(ctrl)
+
+		mkms.EXPECT().GenerateDataKey(&kms.GenerateDataKeyInput{
+			KeyId:         aws.String(replica.KmsKey),
+			NumberOfBytes: aws.Int64(64),
+		}).Return(&kms.GenerateDataKeyOutput{
+			CiphertextBlob: []byte("blobData"),
+			Plaintext:      []byte{145, 99, 240, 141, 84, 162, 135, 185, 20, 181, 81, 249, 15, 215, 56, 150, 222, 94, 65, 27, 27, 196, 165, 220, 49, 90, 199, 244, 14, 165, 188, 116, 135, 60, 104, 13, 136, 145, 109, 232, 87, 153, 237, 234, 174, 87, 7, 124, 131, 121, 67, 68, 239, 184, 174, 16, 197, 129, 97, 139, 146, 144, 89, 5},
+		}, nil)
+
+		mddb.EXPECT().PutItem(&dynamodb.PutItemInput{
+			TableName:                aws.String(table),
+			Item:                     testutils.MapToItem(item),
+			ConditionExpression:      aws.String("attribute_not_exists(#name)"),
+			ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		}).Return(nil, nil)
+
+		drivers[replica.Region] = &Driver{Ddb: mddb, Kms: mkms}
+	}
+
+	driver := &Driver{
+		DriverForRegion: func(region string) *Driver {
+			return drivers[region]
+		},
+	}
+
+	err := driver.ReplicatePut(name, "100", version, table, map[string]string{}, replicas, nil)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestReplicatePutWithoutDriverForRegion(t *testing.T) {
+	driver := &Driver{}
+	replicas := []Replica{{Region: "us-east-1", KmsKey: "alias/credstash"}}
+	expected := "multi-region operations are not supported by this driver"
+
+	err := driver.ReplicatePut("test.key", "100", "0000000000000000001", "credential-store", map[string]string{}, replicas, nil)
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestGetHighestVersionInRegions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	table := "credential-store"
+	name := "test.key"
+	versions := map[string]string{
+		"us-east-1": "0000000000000000002",
+		"eu-west-1": "0000000000000000003",
+	}
+
+	replicas := []Replica{
+		{Region: "us-east-1", KmsKey: "alias/credstash"},
+		{Region: "eu-west-1", KmsKey: "alias/credstash"},
+	}
+
+	drivers := map[string]*Driver{}
+
+	for region, version := range versions {
+		mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+
+		mddb.EXPECT().Query(&dynamodb.QueryInput{
+			TableName:                aws.String(table),
+			Limit:                    aws.Int64(1),
+			ConsistentRead:           aws.Bool(true),
+			ScanIndexForward:         aws.Bool(false),
+			KeyConditionExpression:   aws.String("#name = :name"),
+			ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":name": {S: aws.String(name)},
+			},
+			ProjectionExpression: aws.String("version"),
+		}).Return(&dynamodb.QueryOutput{
+			Count: aws.Int64(1),
+			Items: []map[string]*dynamodb.AttributeValue{
+				{"version": {S: aws.String(version)}},
+			},
+		}, nil)
+
+		drivers[region] = &Driver{Ddb: mddb}
+	}
+
+	driver := &Driver{
+		DriverForRegion: func(region string) *Driver {
+			return drivers[region]
+		},
+	}
+
+	actual, err := driver.GetHighestVersionInRegions(name, table, replicas)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if actual != 3 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", 3, actual)
+	}
+}
@@ -0,0 +1,84 @@
+package gcredstash
+
+import (
+	"fmt"
+)
+
+// Rollback stores the plaintext of an earlier version of name as a new
+// highest version, keeping that version's scheme and digest. When version
+// is empty, the version just before the latest one is used. It returns the
+// new version.
+func (driver *Driver) Rollback(name string, version string, kmsKey string, table string, context map[string]string) (string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", err
+	}
+
+	items, err := driver.backend().Query(table, name, &QueryOptions{
+		Limit:      2,
+		Descending: true,
+		Attributes: []string{"version"},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(items) == 0 {
+		return "", &NotFoundError{Name: name}
+	}
+
+	latest := stringAttr(items[0], "version")
+
+	if version == "" {
+		if len(items) < 2 {
+			return "", fmt.Errorf("%s has no version before version %d", name, Atoi(latest))
+		}
+
+		version = stringAttr(items[1], "version")
+	}
+
+	if version == latest {
+		return "", fmt.Errorf("%s version %d is already the latest version", name, Atoi(version))
+	}
+
+	material, err := driver.GetMaterialWithVersion(name, version, table)
+
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := driver.DecryptMaterialBytes(name, material, context)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer Wipe(plaintext)
+
+	opts := &PutOptions{
+		Scheme:      stringAttr(material, "scheme"),
+		Digest:      stringAttr(material, "digest"),
+		Compression: stringAttr(material, "compression"),
+		Comment:     fmt.Sprintf("rollback to version %d", Atoi(version)),
+	}
+
+	if err := driver.keepPassphrase(name, material, opts); err != nil {
+		return "", err
+	}
+
+	// Soft-deleted versions above latest are still taken.
+	highest, err := driver.GetHighestVersion(name, table)
+
+	if err != nil {
+		return "", err
+	}
+
+	newVersion := VersionNumToStr(highest + 1)
+	err = driver.PutSecretBytesWithOptions(name, plaintext, newVersion, kmsKey, table, context, opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	return newVersion, nil
+}
@@ -0,0 +1,48 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+)
+
+func TestRollback(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+
+		for i, value := range []string{"first", "second", "bad"} {
+			driver.PutSecretWithOptions("foo.bar", value, VersionNumToStr(i+1), "alias/credstash", table, nil, &PutOptions{Scheme: SCHEME_AES_GCM})
+		}
+
+		version, err := driver.Rollback("foo.bar", "", "alias/credstash", table, nil)
+
+		if version != "0000000000000000004" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "0000000000000000004", version, err)
+		}
+
+		value, _ := driver.GetSecret("foo.bar", "", table, nil)
+
+		if value != "second" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "second", value)
+		}
+
+		version, err = driver.Rollback("foo.bar", "0000000000000000001", "alias/credstash", table, nil)
+
+		if version != "0000000000000000005" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "0000000000000000005", version, err)
+		}
+
+		value, _ = driver.GetSecret("foo.bar", "", table, nil)
+
+		if value != "first" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "first", value)
+		}
+
+		_, err = driver.Rollback("foo.bar", "0000000000000000005", "alias/credstash", table, nil)
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	})
+}
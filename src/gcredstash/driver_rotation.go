@@ -0,0 +1,132 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RotationStatus describes a credential that is due for rotation. DueAt is
+// zero when the latest version has no created_at to compute it from.
+type RotationStatus struct {
+	Name        string
+	Version     string
+	CreatedAt   string
+	RotateEvery string
+	DueAt       time.Time
+}
+
+// OverdueRotations returns the credentials whose latest version is older
+// than their rotation interval at now, sorted by name. The interval is
+// taken from the newest version that has one, so it need not be repeated
+// on every put.
+func (driver *Driver) OverdueRotations(table string, prefix string, now time.Time) ([]*RotationStatus, error) {
+	infos, err := driver.ListSecretsWithMetadata(table, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	latest := map[string]*SecretInfo{}
+	policies := map[string]*SecretInfo{}
+
+	for _, info := range infos {
+		if current, ok := latest[info.Name]; !ok || info.Version > current.Version {
+			latest[info.Name] = info
+		}
+
+		if info.RotateEvery == "" {
+			continue
+		}
+
+		if current, ok := policies[info.Name]; !ok || info.Version > current.Version {
+			policies[info.Name] = info
+		}
+	}
+
+	overdue := []*RotationStatus{}
+
+	for name, policy := range policies {
+		interval, err := ParseAge(policy.RotateEvery)
+
+		if err != nil {
+			continue
+		}
+
+		info := latest[name]
+		status := &RotationStatus{Name: name, Version: info.Version, CreatedAt: info.CreatedAt, RotateEvery: policy.RotateEvery}
+
+		if createdAt, err := time.Parse(time.RFC3339, info.CreatedAt); err == nil {
+			status.DueAt = createdAt.Add(interval)
+
+			if now.Before(status.DueAt) {
+				continue
+			}
+		}
+
+		overdue = append(overdue, status)
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		return overdue[i].Name < overdue[j].Name
+	})
+
+	return overdue, nil
+}
+
+// Rotator produces the next value of a credential for RotateSecret.
+type Rotator interface {
+	// Rotate returns the next value. current is nil when the credential
+	// does not exist yet.
+	Rotate(name string, current []byte) ([]byte, error)
+	// Verify checks the next value before it is stored.
+	Verify(name string, next []byte) error
+}
+
+// RotateSecret stores the value produced by rotator as the version after
+// the latest one, once rotator has verified it. Nothing is stored when
+// either step fails. It returns the version that was written.
+func (driver *Driver) RotateSecret(name string, rotator Rotator, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", err
+	}
+
+	current, version, err := driver.GetSecretBytesWithVersion(name, "", table, context)
+
+	if errors.Is(err, ErrSecretNotFound) {
+		current, version, err = nil, VersionNumToStr(0), nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	next, err := rotator.Rotate(name, current)
+	Wipe(current)
+
+	if err != nil {
+		return "", fmt.Errorf("%s: rotate: %s", name, err.Error())
+	}
+
+	defer Wipe(next)
+
+	if len(next) == 0 {
+		return "", fmt.Errorf("%s: rotate: the new value is empty", name)
+	}
+
+	err = rotator.Verify(name, next)
+
+	if err != nil {
+		return "", fmt.Errorf("%s: verify: %s", name, err.Error())
+	}
+
+	version = VersionNumToStr(Atoi(version) + 1)
+	err = driver.PutSecretBytesWithOptions(name, next, version, kmsKey, table, context, opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,93 @@
+package gcredstash
+
+import (
+	"fmt"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOverdueRotations(t *testing.T) {
+	created := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Now = func() time.Time { return created }
+
+		table := "credential-store"
+		driver.PutSecretWithOptions("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{RotateEvery: "90d"})
+		driver.PutSecret("foo.bar", "200", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecretWithOptions("foo.baz", "300", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{RotateEvery: "365d"})
+		driver.PutSecret("foo.qux", "400", "0000000000000000001", "alias/credstash", table, nil)
+
+		overdue, err := driver.OverdueRotations(table, "", created.Add(100*24*time.Hour))
+		expected := []*RotationStatus{{
+			Name:        "foo.bar",
+			Version:     "0000000000000000002",
+			CreatedAt:   "2026-06-01T00:00:00Z",
+			RotateEvery: "90d",
+			DueAt:       created.Add(90 * 24 * time.Hour),
+		}}
+
+		if err != nil || !reflect.DeepEqual(overdue, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, overdue, err)
+		}
+
+		err = driver.PutSecretWithOptions("foo.zoo", "500", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{RotateEvery: "often"})
+
+		if err == nil || err.Error() != "invalid rotation interval: often" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "invalid rotation interval: often", err)
+		}
+	})
+}
+
+type testRotator struct {
+	verifyErr error
+	calls     []string
+}
+
+func (rotator *testRotator) Rotate(name string, current []byte) ([]byte, error) {
+	rotator.calls = append(rotator.calls, fmt.Sprintf("rotate %s %q", name, current))
+	return []byte(string(current) + "1"), nil
+}
+
+func (rotator *testRotator) Verify(name string, next []byte) error {
+	rotator.calls = append(rotator.calls, fmt.Sprintf("verify %s %q", name, next))
+	return rotator.verifyErr
+}
+
+func TestRotateSecret(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+		rotator := &testRotator{}
+
+		version, err := driver.RotateSecret("foo.bar", rotator, "alias/credstash", table, nil, nil)
+
+		if err != nil || version != "0000000000000000001" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "0000000000000000001", version, err)
+		}
+
+		version, err = driver.RotateSecret("foo.bar", rotator, "alias/credstash", table, nil, nil)
+		value, _ := driver.GetSecret("foo.bar", "", table, nil)
+
+		if err != nil || version != "0000000000000000002" || value != "11" {
+			t.Errorf("\nexpected: %v %v\ngot: %v %v %v\n", "0000000000000000002", "11", version, value, err)
+		}
+
+		expected := []string{`rotate foo.bar ""`, `verify foo.bar "1"`, `rotate foo.bar "1"`, `verify foo.bar "11"`}
+
+		if !reflect.DeepEqual(rotator.calls, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, rotator.calls)
+		}
+
+		rotator.verifyErr = fmt.Errorf("login failed")
+		_, err = driver.RotateSecret("foo.bar", rotator, "alias/credstash", table, nil, nil)
+		latest, _ := driver.GetHighestVersion("foo.bar", table)
+
+		if err == nil || err.Error() != "foo.bar: verify: login failed" || latest != 2 {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "foo.bar: verify: login failed", err, latest)
+		}
+	})
+}
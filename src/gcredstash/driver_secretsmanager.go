@@ -0,0 +1,193 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/ryanuber/go-glob"
+	"sort"
+	"strings"
+)
+
+const (
+	SECRETS_MANAGER_TOKEN_PREFIX = "gcredstash-version-"
+)
+
+func SecretsManagerVersionToken(version string) string {
+	return SECRETS_MANAGER_TOKEN_PREFIX + version
+}
+
+func SecretsManagerTokenToVersion(token string) (string, bool) {
+	if !strings.HasPrefix(token, SECRETS_MANAGER_TOKEN_PREFIX) {
+		return "", false
+	}
+
+	version := strings.TrimPrefix(token, SECRETS_MANAGER_TOKEN_PREFIX)
+
+	if len(version) != len(VersionNumToStr(0)) {
+		return "", false
+	}
+
+	for _, c := range version {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+
+	return version, true
+}
+
+func (driver *Driver) PutSecretsManagerValue(name string, value string, token string) error {
+	params := &secretsmanager.PutSecretValueInput{
+		SecretId:           aws.String(name),
+		SecretString:       aws.String(value),
+		ClientRequestToken: aws.String(token),
+	}
+
+	_, err := driver.SecretsManager.PutSecretValue(params)
+
+	if err == nil {
+		return nil
+	}
+
+	if !strings.Contains(err.Error(), secretsmanager.ErrCodeResourceNotFoundException) {
+		return err
+	}
+
+	createParams := &secretsmanager.CreateSecretInput{
+		Name:               aws.String(name),
+		SecretString:       aws.String(value),
+		ClientRequestToken: aws.String(token),
+	}
+
+	_, err = driver.SecretsManager.CreateSecret(createParams)
+
+	return err
+}
+
+func (driver *Driver) ListSecretsManagerNames(pattern string) ([]string, error) {
+	names := []string{}
+	params := &secretsmanager.ListSecretsInput{}
+
+	err := driver.SecretsManager.ListSecretsPages(params, func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		for _, entry := range page.SecretList {
+			if glob.Glob(pattern, *entry.Name) {
+				names = append(names, *entry.Name)
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (driver *Driver) GetSecretsManagerValue(name string) (string, string, error) {
+	params := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(name),
+	}
+
+	resp, err := driver.SecretsManager.GetSecretValue(params)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.SecretString == nil {
+		return "", "", fmt.Errorf("%s: binary secrets are not supported", name)
+	}
+
+	return *resp.SecretString, *resp.VersionId, nil
+}
+
+func (driver *Driver) MigrateToSecretsManager(pattern string, table string, context map[string]string) ([]string, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := GroupVersions(items)
+	names := []string{}
+
+	for name, _ := range versions {
+		if glob.Glob(pattern, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	migrated := []string{}
+
+	for _, name := range names {
+		// Oldest first, so the latest version ends up as AWSCURRENT.
+		for _, version := range versions[name] {
+			value, err := driver.GetSecret(name, version, table, context)
+
+			if err != nil {
+				return migrated, err
+			}
+
+			err = driver.PutSecretsManagerValue(name, value, SecretsManagerVersionToken(version))
+
+			if err != nil {
+				return migrated, fmt.Errorf("%s: %s", name, err.Error())
+			}
+
+			migrated = append(migrated, fmt.Sprintf("%s -- version %d", name, Atoi(version)))
+		}
+	}
+
+	return migrated, nil
+}
+
+func (driver *Driver) MigrateFromSecretsManager(pattern string, kmsKey string, table string, context map[string]string) ([]string, error) {
+	names, err := driver.ListSecretsManagerNames(pattern)
+
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := []string{}
+
+	for _, name := range names {
+		value, versionId, err := driver.GetSecretsManagerValue(name)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		latestVersion, err := driver.GetHighestVersion(name, table)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		version, ok := SecretsManagerTokenToVersion(versionId)
+
+		if ok {
+			if Atoi(version) <= latestVersion {
+				// Already migrated from this store
+				continue
+			}
+		} else {
+			version = VersionNumToStr(latestVersion + 1)
+		}
+
+		err = driver.PutSecret(name, value, version, kmsKey, table, context)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated = append(migrated, fmt.Sprintf("%s -- version %d", name, Atoi(version)))
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,54 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"testing"
+)
+
+func TestSecretsManagerTokenToVersion(t *testing.T) {
+	token := SecretsManagerVersionToken("0000000000000000003")
+	version, ok := SecretsManagerTokenToVersion(token)
+
+	if !ok || version != "0000000000000000003" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "0000000000000000003", version)
+	}
+
+	_, ok = SecretsManagerTokenToVersion("EXAMPLE1-90ab-cdef-fedc-ba987SECRET1")
+
+	if ok {
+		t.Errorf("\nexpected: %v\ngot: %v\n", false, ok)
+	}
+}
+
+func TestPutSecretsManagerValueWithNewSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msm := mockaws.NewMockSecretsManagerAPI(ctrl)
+	name := "test.key"
+	token := "gcredstash-version-0000000000000000001"
+
+	msm.EXPECT().PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:           aws.String(name),
+		SecretString:       aws.String("100"),
+		ClientRequestToken: aws.String(token),
+	}).Return(nil, errors.New("ResourceNotFoundException: Secrets Manager can't find the specified secret."))
+
+	msm.EXPECT().CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:               aws.String(name),
+		SecretString:       aws.String("100"),
+		ClientRequestToken: aws.String(token),
+	}).Return(&secretsmanager.CreateSecretOutput{}, nil)
+
+	driver := &Driver{SecretsManager: msm}
+	err := driver.PutSecretsManagerValue(name, "100", token)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
@@ -29,7 +29,36 @@ func (driver *Driver) IsTableExists(table string) (bool, error) {
 	return isExist, nil
 }
 
-func (driver *Driver) CreateTable(table string) error {
+type TableOptions struct {
+	BillingMode         string
+	ReadCapacityUnits   int64
+	WriteCapacityUnits  int64
+	SSEEnabled          bool
+	PointInTimeRecovery bool
+	// StreamEnabled enables a DynamoDB stream of keys for `watch`.
+	StreamEnabled bool
+	// TimeToLive has DynamoDB delete items whose ttl attribute, written by
+	// put with PutOptions.AutoDelete, has passed.
+	TimeToLive bool
+	// CreateTableInput replaces the generated CreateTable parameters entirely.
+	CreateTableInput *dynamodb.CreateTableInput
+}
+
+func NewCreateTableInput(table string, opts *TableOptions) *dynamodb.CreateTableInput {
+	if opts == nil {
+		opts = &TableOptions{}
+	}
+
+	if opts.CreateTableInput != nil {
+		params := opts.CreateTableInput
+
+		if params.TableName == nil {
+			params.TableName = aws.String(table)
+		}
+
+		return params
+	}
+
 	params := &dynamodb.CreateTableInput{
 		TableName: aws.String(table),
 		KeySchema: []*dynamodb.KeySchemaElement{
@@ -52,10 +81,64 @@ func (driver *Driver) CreateTable(table string) error {
 				AttributeType: aws.String("S"),
 			},
 		},
-		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
-			ReadCapacityUnits:  aws.Int64(1),
-			WriteCapacityUnits: aws.Int64(1),
-		},
+	}
+
+	if opts.BillingMode == dynamodb.BillingModePayPerRequest {
+		params.BillingMode = aws.String(dynamodb.BillingModePayPerRequest)
+	} else {
+		rcu := opts.ReadCapacityUnits
+		wcu := opts.WriteCapacityUnits
+
+		if rcu < 1 {
+			rcu = 1
+		}
+
+		if wcu < 1 {
+			wcu = 1
+		}
+
+		if opts.BillingMode != "" {
+			params.BillingMode = aws.String(opts.BillingMode)
+		}
+
+		params.ProvisionedThroughput = &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(rcu),
+			WriteCapacityUnits: aws.Int64(wcu),
+		}
+	}
+
+	if opts.SSEEnabled {
+		params.SSESpecification = &dynamodb.SSESpecification{
+			Enabled: aws.Bool(true),
+		}
+	}
+
+	if opts.StreamEnabled {
+		params.StreamSpecification = &dynamodb.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: aws.String(dynamodb.StreamViewTypeKeysOnly),
+		}
+	}
+
+	return params
+}
+
+func ValidateBillingMode(billingMode string) error {
+	switch billingMode {
+	case "", dynamodb.BillingModeProvisioned, dynamodb.BillingModePayPerRequest:
+		return nil
+	default:
+		return fmt.Errorf("invalid billing mode: %s", billingMode)
+	}
+}
+
+func (driver *Driver) CreateTable(table string) error {
+	return driver.CreateTableWithInput(NewCreateTableInput(table, nil))
+}
+
+func (driver *Driver) CreateTableWithInput(params *dynamodb.CreateTableInput) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
 	}
 
 	_, err := driver.Ddb.CreateTable(params)
@@ -63,6 +146,41 @@ func (driver *Driver) CreateTable(table string) error {
 	return err
 }
 
+func (driver *Driver) EnablePointInTimeRecovery(table string) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
+
+	params := &dynamodb.UpdateContinuousBackupsInput{
+		TableName: aws.String(table),
+		PointInTimeRecoverySpecification: &dynamodb.PointInTimeRecoverySpecification{
+			PointInTimeRecoveryEnabled: aws.Bool(true),
+		},
+	}
+
+	_, err := driver.Ddb.UpdateContinuousBackups(params)
+
+	return err
+}
+
+func (driver *Driver) EnableTimeToLive(table string) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
+
+	params := &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(table),
+		TimeToLiveSpecification: &dynamodb.TimeToLiveSpecification{
+			AttributeName: aws.String("ttl"),
+			Enabled:       aws.Bool(true),
+		},
+	}
+
+	_, err := driver.Ddb.UpdateTimeToLive(params)
+
+	return err
+}
+
 func (driver *Driver) WaitUntilTableExists(table string) error {
 	delay := 20 * time.Second
 	maxAttempts := 25
@@ -79,6 +197,8 @@ func (driver *Driver) WaitUntilTableExists(table string) error {
 			return err
 		}
 
+		driver.logger().Verbosef("table=%s status=%s attempt=%d", table, *resp.Table.TableStatus, i+1)
+
 		if *resp.Table.TableStatus == "ACTIVE" {
 			isCreated = true
 			break
@@ -95,6 +215,29 @@ func (driver *Driver) WaitUntilTableExists(table string) error {
 }
 
 func (driver *Driver) CreateDdbTable(table string) error {
+	return driver.CreateDdbTableWithOptions(table, nil)
+}
+
+func (driver *Driver) CreateDdbTableWithOptions(table string, opts *TableOptions) error {
+	if err := driver.checkWritable(); err != nil {
+		return err
+	}
+
+	if _, ok := driver.baseBackend().(*DynamoDBBackend); !ok || driver.DryRun {
+		driver.events().OnSetupSkipped(table)
+		return nil
+	}
+
+	if opts == nil {
+		opts = &TableOptions{}
+	}
+
+	err := ValidateBillingMode(opts.BillingMode)
+
+	if err != nil {
+		return err
+	}
+
 	tableIsExist, err := driver.IsTableExists(table)
 
 	if err != nil {
@@ -105,14 +248,14 @@ func (driver *Driver) CreateDdbTable(table string) error {
 		return fmt.Errorf("Credential Store table already exists: %s", table)
 	}
 
-	err = driver.CreateTable(table)
+	err = driver.CreateTableWithInput(NewCreateTableInput(table, opts))
 
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Creating table...")
-	fmt.Println("Waiting for table to be created...")
+	driver.events().OnTableCreating(table)
+	driver.events().OnTableWaiting(table)
 
 	err = driver.WaitUntilTableExists(table)
 
@@ -120,7 +263,27 @@ func (driver *Driver) CreateDdbTable(table string) error {
 		return err
 	}
 
-	fmt.Println("Table has been created. Go read the README about how to create your KMS key")
+	if opts.PointInTimeRecovery {
+		err = driver.EnablePointInTimeRecovery(table)
+
+		if err != nil {
+			return err
+		}
+
+		driver.events().OnPointInTimeRecoveryEnabled(table)
+	}
+
+	if opts.TimeToLive {
+		err = driver.EnableTimeToLive(table)
+
+		if err != nil {
+			return err
+		}
+
+		driver.events().OnTimeToLiveEnabled(table)
+	}
+
+	driver.events().OnTableCreated(table)
 
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/golang/mock/gomock"
 	"mockaws"
+	"reflect"
 	"testing"
 )
 
@@ -174,3 +175,160 @@ func TestCreateDdbTable(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
 	}
 }
+
+func TestNewCreateTableInputWithPayPerRequest(t *testing.T) {
+	table := "credential-store"
+
+	expected := &dynamodb.CreateTableInput{
+		TableName: aws.String(table),
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("name"),
+				KeyType:       aws.String("HASH"),
+			},
+			{
+				AttributeName: aws.String("version"),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("name"),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String("version"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		BillingMode: aws.String("PAY_PER_REQUEST"),
+		SSESpecification: &dynamodb.SSESpecification{
+			Enabled: aws.Bool(true),
+		},
+	}
+
+	actual := NewCreateTableInput(table, &TableOptions{
+		BillingMode: "PAY_PER_REQUEST",
+		SSEEnabled:  true,
+	})
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestNewCreateTableInputWithOverride(t *testing.T) {
+	table := "credential-store"
+
+	override := &dynamodb.CreateTableInput{
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+	}
+
+	actual := NewCreateTableInput(table, &TableOptions{
+		BillingMode:      "PAY_PER_REQUEST",
+		CreateTableInput: override,
+	})
+
+	if actual != override {
+		t.Errorf("\nexpected: %v\ngot: %v\n", override, actual)
+	}
+
+	if *actual.TableName != table {
+		t.Errorf("\nexpected: %v\ngot: %v\n", table, *actual.TableName)
+	}
+
+	if actual.BillingMode != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, actual.BillingMode)
+	}
+}
+
+func TestCreateDdbTableWithOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	table := "credential-store"
+
+	mddb.EXPECT().ListTablesPages(
+		&dynamodb.ListTablesInput{},
+		gomock.Any(),
+	).Return(nil)
+
+	mddb.EXPECT().CreateTable(&dynamodb.CreateTableInput{
+		TableName: aws.String(table),
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("name"),
+				KeyType:       aws.String("HASH"),
+			},
+			{
+				AttributeName: aws.String("version"),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("name"),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String("version"),
+				AttributeType: aws.String("S"),
+			},
+		},
+		BillingMode: aws.String("PAY_PER_REQUEST"),
+	}).Return(nil, nil)
+
+	mddb.EXPECT().DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	}).Return(&dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{
+			TableStatus: aws.String("ACTIVE"),
+		},
+	}, nil)
+
+	mddb.EXPECT().UpdateContinuousBackups(&dynamodb.UpdateContinuousBackupsInput{
+		TableName: aws.String(table),
+		PointInTimeRecoverySpecification: &dynamodb.PointInTimeRecoverySpecification{
+			PointInTimeRecoveryEnabled: aws.Bool(true),
+		},
+	}).Return(nil, nil)
+
+	driver := &Driver{
+		Ddb: mddb,
+		Kms: mkms,
+	}
+
+	err := driver.CreateDdbTableWithOptions(table, &TableOptions{
+		BillingMode:         "PAY_PER_REQUEST",
+		PointInTimeRecovery: true,
+	})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+}
+
+func TestCreateDdbTableWithInvalidBillingMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	driver := &Driver{
+		Ddb: mddb,
+		Kms: mkms,
+	}
+
+	err := driver.CreateDdbTableWithOptions("credential-store", &TableOptions{BillingMode: "ON_DEMAND"})
+	expected := "invalid billing mode: ON_DEMAND"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
@@ -0,0 +1,252 @@
+package gcredstash
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"sort"
+	"time"
+)
+
+const SNAPSHOT_FORMAT = "gcredstash-snapshot-1"
+
+// SnapshotMetadata describes the table a snapshot was taken from.
+type SnapshotMetadata struct {
+	Format    string `json:"format"`
+	Table     string `json:"table"`
+	Namespace string `json:"namespace,omitempty"`
+	KmsKey    string `json:"kms_key"`
+	CreatedAt string `json:"created_at"`
+	CreatedBy string `json:"created_by,omitempty"`
+	ItemCount int    `json:"item_count"`
+}
+
+type snapshotAttribute struct {
+	S    *string                       `json:"S,omitempty"`
+	N    *string                       `json:"N,omitempty"`
+	B    []byte                        `json:"B,omitempty"`
+	BOOL *bool                         `json:"BOOL,omitempty"`
+	M    map[string]*snapshotAttribute `json:"M,omitempty"`
+}
+
+func newSnapshotAttribute(value *dynamodb.AttributeValue) *snapshotAttribute {
+	attr := &snapshotAttribute{S: value.S, N: value.N, B: value.B, BOOL: value.BOOL}
+
+	if value.M != nil {
+		attr.M = map[string]*snapshotAttribute{}
+
+		for key, v := range value.M {
+			attr.M[key] = newSnapshotAttribute(v)
+		}
+	}
+
+	return attr
+}
+
+func (attr *snapshotAttribute) attributeValue() *dynamodb.AttributeValue {
+	value := &dynamodb.AttributeValue{S: attr.S, N: attr.N, B: attr.B, BOOL: attr.BOOL}
+
+	if attr.M != nil {
+		value.M = map[string]*dynamodb.AttributeValue{}
+
+		for key, v := range attr.M {
+			value.M[key] = v.attributeValue()
+		}
+	}
+
+	return value
+}
+
+type snapshotBody struct {
+	Metadata *SnapshotMetadata               `json:"metadata"`
+	Items    []map[string]*snapshotAttribute `json:"items"`
+}
+
+// snapshotFile is the archive. Body is signed in its compact form, so the
+// archive can be indented without breaking the signature.
+type snapshotFile struct {
+	Body json.RawMessage `json:"body"`
+	Key  string          `json:"key"`
+	Hmac string          `json:"hmac"`
+}
+
+func (driver *Driver) snapshotContext(table string) map[string]string {
+	return driver.encryptionContext(map[string]string{"gcredstash_snapshot": table})
+}
+
+// Snapshot returns an archive of every item of table, including
+// soft-deleted ones, as stored: values stay encrypted and nothing is
+// decrypted. The archive is signed with an HMAC key from kmsKey, so
+// RestoreSnapshot can detect tampering.
+func (driver *Driver) Snapshot(table string, kmsKey string) ([]byte, *SnapshotMetadata, error) {
+	items, err := driver.backend().Scan(table, &ScanOptions{IncludeDeleted: true})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if stringAttr(items[i], "name") != stringAttr(items[j], "name") {
+			return stringAttr(items[i], "name") < stringAttr(items[j], "name")
+		}
+
+		return stringAttr(items[i], "version") < stringAttr(items[j], "version")
+	})
+
+	now := time.Now
+
+	if driver.Now != nil {
+		now = driver.Now
+	}
+
+	body := &snapshotBody{
+		Metadata: &SnapshotMetadata{
+			Format:    SNAPSHOT_FORMAT,
+			Table:     table,
+			Namespace: driver.Namespace,
+			KmsKey:    kmsKey,
+			CreatedAt: now().UTC().Format(time.RFC3339),
+			CreatedBy: driver.Author,
+			ItemCount: len(items),
+		},
+		Items: []map[string]*snapshotAttribute{},
+	}
+
+	for _, item := range items {
+		attrs := map[string]*snapshotAttribute{}
+
+		for attr, value := range item {
+			attrs[attr] = newSnapshotAttribute(value)
+		}
+
+		body.Items = append(body.Items, attrs)
+	}
+
+	content, err := json.Marshal(body)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataKey, hmacKey, wrappedKey, err := KmsGenerateDataKey(driver.Kms, kmsKey, driver.snapshotContext(table))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer Wipe(dataKey)
+	defer Wipe(hmacKey)
+
+	archive, err := json.MarshalIndent(&snapshotFile{
+		Body: content,
+		Key:  B64Encode(wrappedKey),
+		Hmac: HexEncode(Digest(content, hmacKey)),
+	}, "", "  ")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(archive, '\n'), body.Metadata, nil
+}
+
+// verifySnapshot checks the signature of an archive and returns its body.
+func (driver *Driver) verifySnapshot(archive []byte) (*snapshotBody, error) {
+	file := &snapshotFile{}
+
+	if err := json.Unmarshal(archive, file); err != nil || len(file.Body) == 0 {
+		return nil, fmt.Errorf("not a snapshot")
+	}
+
+	body := &snapshotBody{}
+
+	if err := json.Unmarshal(file.Body, body); err != nil || body.Metadata == nil {
+		return nil, fmt.Errorf("not a snapshot")
+	}
+
+	if body.Metadata.Format != SNAPSHOT_FORMAT {
+		return nil, fmt.Errorf("unsupported snapshot format: %s", body.Metadata.Format)
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(file.Key)
+
+	if err != nil {
+		return nil, &IntegrityError{Message: "snapshot: invalid signing key"}
+	}
+
+	signature, err := hex.DecodeString(file.Hmac)
+
+	if err != nil {
+		return nil, &IntegrityError{Message: "snapshot: signature does not match"}
+	}
+
+	dataKey, hmacKey, err := KmsDecrypt(driver.Kms, wrappedKey, driver.snapshotContext(body.Metadata.Table))
+
+	if err != nil {
+		return nil, &IntegrityError{Message: fmt.Sprintf("snapshot: could not decrypt the signing key: %s", err.Error())}
+	}
+
+	defer Wipe(dataKey)
+	defer Wipe(hmacKey)
+
+	content := &bytes.Buffer{}
+
+	if err := json.Compact(content, file.Body); err != nil {
+		return nil, fmt.Errorf("not a snapshot")
+	}
+
+	if !ValidateHMAC(content.Bytes(), signature, hmacKey) {
+		return nil, &IntegrityError{Message: "snapshot: signature does not match"}
+	}
+
+	return body, nil
+}
+
+// RestoreSnapshot verifies an archive written by Snapshot and puts its items
+// into table. Items whose name and version are already stored are left
+// alone. It returns the metadata of the snapshot and the number of items
+// that were put.
+func (driver *Driver) RestoreSnapshot(archive []byte, table string) (*SnapshotMetadata, int, error) {
+	if err := driver.checkWritable(); err != nil {
+		return nil, 0, err
+	}
+
+	body, err := driver.verifySnapshot(archive)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	restored := 0
+
+	for _, attrs := range body.Items {
+		item := map[string]*dynamodb.AttributeValue{}
+
+		for attr, value := range attrs {
+			item[attr] = value.attributeValue()
+		}
+
+		err := driver.backend().PutItem(table, item)
+
+		if IsConditionalCheckFailed(err) {
+			driver.logger().Verbosef("restore-snapshot name=%s version=%s already exists", stringAttr(item, "name"), stringAttr(item, "version"))
+			continue
+		}
+
+		if err != nil {
+			return body.Metadata, restored, err
+		}
+
+		restored++
+	}
+
+	return body.Metadata, restored, nil
+}
+
+// WriteSnapshot writes an archive readable only by the owner.
+func WriteSnapshot(path string, archive []byte) error {
+	return writeFileAtomic(path, archive, 0600)
+}
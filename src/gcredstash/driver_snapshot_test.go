@@ -0,0 +1,73 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Events = &NopEventHandler{}
+		driver.Author = "alice"
+		driver.Now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("db.pass", "200", VersionNumToStr(2), "alias/credstash", table, map[string]string{"env": "prod"})
+		driver.PutSecret("db.user", "app", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		archive, meta, err := driver.Snapshot(table, "alias/credstash")
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if meta.ItemCount != 3 || meta.Table != table || meta.CreatedAt != "2026-01-02T03:04:05Z" || meta.CreatedBy != "alice" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "3 items of credential-store", meta)
+		}
+
+		if strings.Contains(string(archive), "\"100\"") || strings.Contains(string(archive), "app\"") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "no plaintext", string(archive))
+		}
+
+		testutils.TempDriver(func(restoreDriver *Driver, g *os.File) {
+			restoreDriver.PutSecret("db.user", "app", VersionNumToStr(1), "alias/credstash", "restored", nil)
+
+			meta, restored, err := restoreDriver.RestoreSnapshot(archive, "restored")
+
+			if meta == nil || meta.ItemCount != 3 || restored != 2 || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v %v\n", 2, meta, restored, err)
+			}
+
+			value, err := restoreDriver.GetSecret("db.pass", "", "restored", map[string]string{"env": "prod"})
+
+			if value != "200" || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+			}
+		})
+
+		tampered := strings.Replace(string(archive), `"S": "db.user"`, `"S": "db.admin"`, 1)
+
+		if tampered == string(archive) {
+			t.Fatalf("\nexpected: %v\ngot: %v\n", "a tampered archive", string(archive))
+		}
+
+		_, _, err = driver.RestoreSnapshot([]byte(tampered), table)
+
+		if !errors.Is(err, ErrIntegrity) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrIntegrity, err)
+		}
+
+		_, _, err = driver.RestoreSnapshot([]byte("{}"), table)
+
+		if err == nil || err.Error() != "not a snapshot" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "not a snapshot", err)
+		}
+	})
+}
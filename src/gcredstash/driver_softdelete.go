@@ -0,0 +1,192 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"time"
+)
+
+// softDeleteItem marks a version of name as deleted by storing it again
+// with deleted_at, so that RestoreSecrets can bring it back.
+func (driver *Driver) softDeleteItem(name string, version string, table string) error {
+	backend, ok := driver.backend().(ReplacingBackend)
+
+	if !ok {
+		return errSoftDeleteUnsupported
+	}
+
+	item, err := driver.backend().GetItem(table, name, version)
+
+	if err != nil {
+		return err
+	}
+
+	if item == nil {
+		return &NotFoundError{Name: name, Version: version}
+	}
+
+	now := time.Now
+
+	if driver.Now != nil {
+		now = driver.Now
+	}
+
+	tombstone := map[string]*dynamodb.AttributeValue{}
+
+	for attr, value := range item {
+		tombstone[attr] = value
+	}
+
+	tombstone["deleted_at"] = &dynamodb.AttributeValue{S: aws.String(now().UTC().Format(time.RFC3339))}
+
+	if driver.Author != "" {
+		tombstone["deleted_by"] = &dynamodb.AttributeValue{S: aws.String(driver.Author)}
+	}
+
+	return backend.ReplaceItem(table, tombstone)
+}
+
+// DeletedSecrets returns the soft-deleted versions whose name starts with
+// prefix, in the form of ListSecrets.
+func (driver *Driver) DeletedSecrets(table string, prefix string) (map[*string]*string, error) {
+	resp, err := driver.backend().Scan(table, &ScanOptions{
+		Attributes:     []string{"name", "version", "deleted_at"},
+		Prefix:         prefix,
+		IncludeDeleted: true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[*string]*string{}
+
+	for _, i := range resp {
+		if isTombstone(i) {
+			items[i["name"].S] = i["version"].S
+		}
+	}
+
+	return items, nil
+}
+
+// deletedVersions returns the soft-deleted versions of name, or only the
+// given one, oldest first.
+func (driver *Driver) deletedVersions(name string, version string, table string) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, err := driver.backend().Query(table, name, &QueryOptions{IncludeDeleted: true})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones := []map[string]*dynamodb.AttributeValue{}
+	found := false
+
+	for _, item := range items {
+		if version != "" && stringAttr(item, "version") != version {
+			continue
+		}
+
+		found = true
+
+		if isTombstone(item) {
+			tombstones = append(tombstones, item)
+		}
+	}
+
+	if len(tombstones) > 0 {
+		return tombstones, nil
+	}
+
+	if !found {
+		return nil, &NotFoundError{Name: name, Version: version}
+	}
+
+	if version == "" {
+		return nil, fmt.Errorf("%s has no deleted versions", name)
+	}
+
+	return nil, fmt.Errorf("%s version %d is not deleted", name, Atoi(version))
+}
+
+// RestoreSecrets brings back the soft-deleted versions of name, or only
+// the given version. It returns the restored versions.
+func (driver *Driver) RestoreSecrets(name string, version string, table string) ([]string, error) {
+	restored, err := driver.restoreSecrets(name, version, table)
+
+	return restored, driver.logAccess("restore", name, version, table, err)
+}
+
+func (driver *Driver) restoreSecrets(name string, version string, table string) ([]string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	backend, ok := driver.backend().(ReplacingBackend)
+
+	if !ok {
+		return nil, errSoftDeleteUnsupported
+	}
+
+	tombstones, err := driver.deletedVersions(name, version, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	restored := []string{}
+
+	for _, tombstone := range tombstones {
+		item := map[string]*dynamodb.AttributeValue{}
+
+		for attr, value := range tombstone {
+			if attr != "deleted_at" && attr != "deleted_by" {
+				item[attr] = value
+			}
+		}
+
+		if err := backend.ReplaceItem(table, item); err != nil {
+			return restored, err
+		}
+
+		restored = append(restored, stringAttr(item, "version"))
+	}
+
+	return restored, nil
+}
+
+// PurgeSecrets permanently removes the soft-deleted versions of name, or
+// only the given version. Versions that are not deleted are never purged.
+// It returns the purged versions.
+func (driver *Driver) PurgeSecrets(name string, version string, table string) ([]string, error) {
+	purged, err := driver.purgeSecrets(name, version, table)
+
+	return purged, driver.logAccess("purge", name, version, table, err)
+}
+
+func (driver *Driver) purgeSecrets(name string, version string, table string) ([]string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	tombstones, err := driver.deletedVersions(name, version, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	purged := []string{}
+
+	for _, tombstone := range tombstones {
+		version := stringAttr(tombstone, "version")
+
+		if err := driver.backend().Delete(table, name, version); err != nil {
+			return purged, err
+		}
+
+		purged = append(purged, version)
+	}
+
+	return purged, nil
+}
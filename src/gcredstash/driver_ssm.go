@@ -0,0 +1,187 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/ryanuber/go-glob"
+	"sort"
+	"strings"
+)
+
+type SsmMapping struct {
+	Prefix string
+	// Nested maps "app.db.pass" to "<prefix>app/db/pass" instead of "<prefix>app.db.pass".
+	Nested bool
+}
+
+func (m *SsmMapping) ParameterName(name string) string {
+	if m.Nested {
+		name = strings.Replace(name, ".", "/", -1)
+	}
+
+	return m.Prefix + name
+}
+
+func (m *SsmMapping) CredentialName(parameter string) string {
+	name := strings.TrimPrefix(parameter, m.Prefix)
+
+	if m.Nested {
+		name = strings.Replace(name, "/", ".", -1)
+	}
+
+	return name
+}
+
+func (driver *Driver) GetSsmParameter(parameter string) (string, bool, error) {
+	params := &ssm.GetParameterInput{
+		Name:           aws.String(parameter),
+		WithDecryption: aws.Bool(true),
+	}
+
+	resp, err := driver.Ssm.GetParameter(params)
+
+	if err != nil {
+		if strings.Contains(err.Error(), ssm.ErrCodeParameterNotFound) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	return *resp.Parameter.Value, true, nil
+}
+
+func (driver *Driver) PutSsmParameter(parameter string, value string) error {
+	params := &ssm.PutParameterInput{
+		Name:      aws.String(parameter),
+		Value:     aws.String(value),
+		Type:      aws.String(ssm.ParameterTypeSecureString),
+		Overwrite: aws.Bool(true),
+	}
+
+	_, err := driver.Ssm.PutParameter(params)
+
+	return err
+}
+
+func (driver *Driver) GetSsmParametersByPath(path string) (map[string]string, error) {
+	parameters := map[string]string{}
+
+	params := &ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	}
+
+	err := driver.Ssm.GetParametersByPathPages(params, func(page *ssm.GetParametersByPathOutput, lastPage bool) bool {
+		for _, p := range page.Parameters {
+			parameters[*p.Name] = *p.Value
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+func (driver *Driver) MigrateToSsm(pattern string, mapping *SsmMapping, table string, context map[string]string) ([]string, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for name, _ := range GroupVersions(items) {
+		if glob.Glob(pattern, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	migrated := []string{}
+
+	for _, name := range names {
+		value, err := driver.GetSecret(name, "", table, context)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		parameter := mapping.ParameterName(name)
+		current, exists, err := driver.GetSsmParameter(parameter)
+
+		if err != nil {
+			return migrated, fmt.Errorf("%s: %s", parameter, err.Error())
+		}
+
+		if exists && current == value {
+			continue
+		}
+
+		err = driver.PutSsmParameter(parameter, value)
+
+		if err != nil {
+			return migrated, fmt.Errorf("%s: %s", parameter, err.Error())
+		}
+
+		migrated = append(migrated, fmt.Sprintf("%s -> %s", name, parameter))
+	}
+
+	return migrated, nil
+}
+
+func (driver *Driver) MigrateFromSsm(pattern string, mapping *SsmMapping, kmsKey string, table string, context map[string]string) ([]string, error) {
+	parameters, err := driver.GetSsmParametersByPath(mapping.Prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	paramNames := []string{}
+
+	for parameter, _ := range parameters {
+		if glob.Glob(pattern, mapping.CredentialName(parameter)) {
+			paramNames = append(paramNames, parameter)
+		}
+	}
+
+	sort.Strings(paramNames)
+	migrated := []string{}
+
+	for _, parameter := range paramNames {
+		name := mapping.CredentialName(parameter)
+		value := parameters[parameter]
+
+		latestVersion, err := driver.GetHighestVersion(name, table)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		if latestVersion > 0 {
+			current, err := driver.GetSecret(name, "", table, context)
+
+			if err == nil && current == value {
+				continue
+			}
+		}
+
+		version := VersionNumToStr(latestVersion + 1)
+		err = driver.PutSecret(name, value, version, kmsKey, table, context)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated = append(migrated, fmt.Sprintf("%s -> %s -- version %d", parameter, name, latestVersion+1))
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,134 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"reflect"
+	"testing"
+)
+
+func TestSsmMapping(t *testing.T) {
+	flat := &SsmMapping{Prefix: "/app/"}
+	nested := &SsmMapping{Prefix: "/app/", Nested: true}
+
+	if actual := flat.ParameterName("db.pass"); actual != "/app/db.pass" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "/app/db.pass", actual)
+	}
+
+	if actual := nested.ParameterName("db.pass"); actual != "/app/db/pass" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "/app/db/pass", actual)
+	}
+
+	if actual := nested.CredentialName("/app/db/pass"); actual != "db.pass" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "db.pass", actual)
+	}
+}
+
+func TestGetSsmParametersByPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mssm := mockaws.NewMockSSMAPI(ctrl)
+
+	mssm.EXPECT().GetParametersByPathPages(&ssm.GetParametersByPathInput{
+		Path:           aws.String("/app/"),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	}, gomock.Any()).Do(func(params *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool) {
+		fn(&ssm.GetParametersByPathOutput{
+			Parameters: []*ssm.Parameter{
+				{Name: aws.String("/app/db/pass"), Value: aws.String("100")},
+			},
+		}, true)
+	}).Return(nil)
+
+	driver := &Driver{Ssm: mssm}
+	actual, err := driver.GetSsmParametersByPath("/app/")
+	expected := map[string]string{"/app/db/pass": "100"}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestMigrateFromSsm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+	mssm := mockaws.NewMockSSMAPI(ctrl)
+
+	table := "credential-store"
+	kmsKey := "alias/credstash"
+	name := "db.pass"
+
+	mssm.EXPECT().GetParametersByPathPages(gomock.Any(), gomock.Any()).Do(func(params *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool) {
+		fn(&ssm.GetParametersByPathOutput{
+			Parameters: []*ssm.Parameter{
+				{Name: aws.String("/app/db/pass"), Value: aws.String("100")},
+			},
+		}, true)
+	}).Return(nil)
+
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		Limit:                    aws.Int64(1),
+		ConsistentRead:           aws.Bool(true),
+		ScanIndexForward:         aws.Bool(false),
+		KeyConditionExpression:   aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name": {S: aws.String(name)},
+		},
+		ProjectionExpression: aws.String("version"),
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(0),
+	}, nil)
+
+	mkms.EXPECT().GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:         aws.String(kmsKey),
+		NumberOfBytes: aws.Int64(64),
+	}).Return(&kms.GenerateDataKeyOutput{
+		CiphertextBlob: []byte("blobData"),
+		Plaintext:      []byte{145, 99, 240, 141, 84, 162, 135, 185, 20, 181, 81, 249, 15, 215, 56, 150, 222, 94, 65, 27, 27, 196, 165, 220, 49, 90, 199, 244, 14, 165, 188, 116, 135, 60, 104, 13, 136, 145, 109, 232, 87, 153, 237, 234, 174, 87, 7, 124, 131, 121, 67, 68, 239, 184, 174, 16, 197, 129, 97, 139, 146, 144, 89, 5},
+	}, nil)
+
+	mddb.EXPECT().PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item: testutils.MapToItem(map[string]string{
+			"contents": "twnH",
+			"digest":   "SHA256",
+			"hmac":     "01cc6772cf2c889c8c0dae1f0ec3d7659e21103d56cd3436039cf29d18759958",
+			"key":      "YmxvYkRhdGE=",
+			"name":     name,
+			"version":  "0000000000000000001",
+		}),
+		ConditionExpression:      aws.String("attribute_not_exists(#name)"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+	}).Return(nil, nil)
+
+	driver := &Driver{Ddb: mddb, Kms: mkms, Ssm: mssm}
+	mapping := &SsmMapping{Prefix: "/app/", Nested: true}
+
+	actual, err := driver.MigrateFromSsm("*", mapping, kmsKey, table, map[string]string{})
+	expected := []string{"/app/db/pass -> db.pass -- version 1"}
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
@@ -0,0 +1,258 @@
+package gcredstash
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// TABLE_SCHEMA_VERSION is the version of the table layout gcredstash
+// creates: a string name hash key and a string version range key.
+const TABLE_SCHEMA_VERSION = 1
+
+// STATUS_PROBE_NAME is the credential Status queries when it is not given
+// one. It does not need to exist.
+const STATUS_PROBE_NAME = "gcredstash-status"
+
+// StatusCheck is the result of one check of Status. Err is nil when it
+// passed; Hint then is empty, otherwise it says what to do about it.
+type StatusCheck struct {
+	Name   string
+	Detail string
+	Err    error
+	Hint   string
+}
+
+// Status checks that the credential store can be used: the table exists,
+// is ACTIVE and has the credential store's keys, the KMS key resolves, and
+// the caller can Query, GetItem and Decrypt. With a credential name its
+// latest version is read and decrypted (without any passphrase layer);
+// otherwise Decrypt is checked with a data key of kmsKey. Each check runs
+// even when an earlier one fails.
+func (driver *Driver) Status(table string, kmsKey string, name string, context map[string]string) []*StatusCheck {
+	checks := []*StatusCheck{driver.checkTable(table), driver.checkKmsKey(kmsKey)}
+
+	probe := name
+
+	if probe == "" {
+		probe = STATUS_PROBE_NAME
+	}
+
+	query := &StatusCheck{Name: "Query", Detail: probe}
+	items, err := driver.backend().Query(table, probe, &QueryOptions{Limit: 1, Descending: true})
+	query.Err, query.Hint = err, accessHint(err, "dynamodb:Query", table)
+
+	if err == nil && name != "" && len(items) == 0 {
+		query.Err = &NotFoundError{Name: name}
+		query.Hint = "check the credential name and GCREDSTASH_NAMESPACE"
+	}
+
+	checks = append(checks, query)
+
+	version := VersionNumToStr(1)
+
+	if len(items) > 0 {
+		version = stringAttr(items[0], "version")
+	}
+
+	getItem := &StatusCheck{Name: "GetItem", Detail: fmt.Sprintf("%s -- version %d", probe, Atoi(version))}
+	material, err := driver.backend().GetItem(table, probe, version)
+	getItem.Err, getItem.Hint = err, accessHint(err, "dynamodb:GetItem", table)
+	checks = append(checks, getItem)
+
+	if name != "" && material != nil {
+		checks = append(checks, driver.checkDecrypt(name, material, table, context))
+	} else {
+		checks = append(checks, driver.checkDataKey(kmsKey, context))
+	}
+
+	return checks
+}
+
+func (driver *Driver) checkTable(table string) *StatusCheck {
+	check := &StatusCheck{Name: "Table", Detail: table}
+
+	switch driver.baseBackend().(type) {
+	case *DynamoDBBackend, *DynamoDBV2Backend:
+	default:
+		check.Detail = fmt.Sprintf("%s (not DynamoDB, skipped)", table)
+		return check
+	}
+
+	resp, err := driver.Ddb.DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	})
+
+	if err != nil && hasErrorCode(err, []string{dynamodb.ErrCodeResourceNotFoundException}) {
+		check.Err = err
+		check.Hint = "create it with `gcredstash setup`, or check GCREDSTASH_TABLE and the region"
+		return check
+	}
+
+	if err != nil {
+		check.Err, check.Hint = err, accessHint(err, "dynamodb:DescribeTable", table)
+		return check
+	}
+
+	status := aws.StringValue(resp.Table.TableStatus)
+	check.Detail = fmt.Sprintf("%s (%s, schema version %d)", table, status, TABLE_SCHEMA_VERSION)
+
+	if !hasCredentialStoreKeys(resp.Table) {
+		check.Detail = fmt.Sprintf("%s (%s, unknown schema)", table, status)
+		check.Err = errors.New("the table does not have a name hash key and a version range key of type string")
+		check.Hint = "check GCREDSTASH_TABLE: the table is not a credential store"
+		return check
+	}
+
+	if status != dynamodb.TableStatusActive {
+		check.Err = fmt.Errorf("the table is %s", status)
+		check.Hint = "wait until the table is ACTIVE"
+	}
+
+	return check
+}
+
+func hasCredentialStoreKeys(desc *dynamodb.TableDescription) bool {
+	expected := NewCreateTableInput("", nil)
+	types := map[string]string{}
+
+	for _, def := range desc.AttributeDefinitions {
+		types[aws.StringValue(def.AttributeName)] = aws.StringValue(def.AttributeType)
+	}
+
+	if len(desc.KeySchema) != len(expected.KeySchema) {
+		return false
+	}
+
+	for i, key := range expected.KeySchema {
+		actual := desc.KeySchema[i]
+
+		if aws.StringValue(actual.AttributeName) != *key.AttributeName || aws.StringValue(actual.KeyType) != *key.KeyType ||
+			types[*key.AttributeName] != dynamodb.ScalarAttributeTypeS {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (driver *Driver) checkKmsKey(kmsKey string) *StatusCheck {
+	check := &StatusCheck{Name: "KMS key", Detail: kmsKey}
+
+	if err := driver.preflightKmsKey(kmsKey); err != nil {
+		check.Err = err
+		check.Hint = "fix GCREDSTASH_KMS_KEY or kms_key"
+		return check
+	}
+
+	if _, ok := driver.Kms.(*LocalKms); ok {
+		check.Detail = fmt.Sprintf("%s (local master key, skipped)", kmsKey)
+		return check
+	}
+
+	metadata, err := KmsDescribeKey(driver.Kms, kmsKey)
+
+	if err != nil && hasErrorCode(err, []string{"NotFoundException"}) {
+		check.Err = err
+		check.Hint = "create the key or alias, or check GCREDSTASH_KMS_KEY and the region"
+		return check
+	}
+
+	if err != nil {
+		check.Err, check.Hint = err, accessHint(err, "kms:DescribeKey", kmsKey)
+		return check
+	}
+
+	state := aws.StringValue(metadata.KeyState)
+	check.Detail = fmt.Sprintf("%s -> %s (%s)", kmsKey, aws.StringValue(metadata.Arn), state)
+
+	switch state {
+	case "Enabled":
+	case "PendingDeletion":
+		check.Err = errors.New("the key is scheduled for deletion")
+		check.Hint = "cancel the deletion of the key"
+	default:
+		check.Err = fmt.Errorf("the key is %s", state)
+		check.Hint = "enable the key"
+	}
+
+	return check
+}
+
+func (driver *Driver) checkDecrypt(name string, material map[string]*dynamodb.AttributeValue, table string, context map[string]string) *StatusCheck {
+	check := &StatusCheck{Name: "Decrypt", Detail: fmt.Sprintf("%s -- version %d", name, Atoi(stringAttr(material, "version")))}
+
+	if IsAlias(material) {
+		target, resolved, err := driver.resolveAlias(name, material, table)
+
+		if err != nil {
+			check.Err = err
+			return check
+		}
+
+		check.Detail += fmt.Sprintf(" (alias of %s)", target)
+		name, material = target, resolved
+	}
+
+	plaintext, err := driver.decryptEnvelope(name, material, context)
+	Wipe(plaintext)
+	check.Err = err
+
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrContextMismatch):
+		check.Hint = "give the encryption context the credential was stored with as key=value arguments"
+	case errors.Is(err, ErrIntegrity):
+		check.Hint = "the stored credential is damaged; run `gcredstash audit`"
+	default:
+		check.Hint = accessHint(err, "kms:Decrypt", "the KMS key of the credential")
+	}
+
+	return check
+}
+
+func (driver *Driver) checkDataKey(kmsKey string, context map[string]string) *StatusCheck {
+	check := &StatusCheck{Name: "Decrypt", Detail: fmt.Sprintf("a data key of %s", kmsKey)}
+	context = driver.encryptionContext(context)
+	dataKey, hmacKey, wrappedKey, err := KmsGenerateDataKey(driver.Kms, kmsKey, context)
+
+	if err != nil {
+		check.Err = driver.kmsAccessError("", "kms:GenerateDataKey", kmsKey, err)
+		check.Hint = "give --credential NAME to check Decrypt without kms:GenerateDataKey"
+		return check
+	}
+
+	defer Wipe(dataKey)
+	defer Wipe(hmacKey)
+
+	decryptedDataKey, decryptedHmacKey, err := KmsDecrypt(driver.Kms, wrappedKey, context)
+
+	if err != nil {
+		check.Err, check.Hint = driver.kmsAccessError("", "kms:Decrypt", kmsKey, err), accessHint(err, "kms:Decrypt", kmsKey)
+		return check
+	}
+
+	defer Wipe(decryptedDataKey)
+	defer Wipe(decryptedHmacKey)
+
+	if !bytes.Equal(dataKey, decryptedDataKey) || !bytes.Equal(hmacKey, decryptedHmacKey) {
+		check.Err = errors.New("decrypted data key does not match the generated one")
+	}
+
+	return check
+}
+
+// accessHint returns what to grant when err is an access denial of action,
+// naming the resource by the ARN AWS reported when there is one.
+func accessHint(err error, action string, resource string) string {
+	if !IsAccessDenied(err) {
+		return ""
+	}
+
+	denied := &AccessDeniedError{}
+	errors.As(NewAccessDeniedError(action, resource, err), &denied)
+
+	return denied.Hint()
+}
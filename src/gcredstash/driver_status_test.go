@@ -0,0 +1,124 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"os"
+	"testing"
+)
+
+func statusErrors(checks []*StatusCheck) map[string]error {
+	errs := map[string]error{}
+
+	for _, check := range checks {
+		if check.Err != nil {
+			errs[check.Name] = check.Err
+		}
+	}
+
+	return errs
+}
+
+func TestStatus(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, map[string]string{"env": "prod"})
+
+		checks := driver.Status(table, "alias/credstash", "", nil)
+		names := []string{}
+
+		for _, check := range checks {
+			names = append(names, check.Name)
+		}
+
+		if len(names) != 5 || names[0] != "Table" || names[4] != "Decrypt" || len(statusErrors(checks)) != 0 {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "5 passed checks", names, statusErrors(checks))
+		}
+
+		checks = driver.Status(table, "alias/credstash", "db.pass", map[string]string{"env": "prod"})
+
+		if len(statusErrors(checks)) != 0 || checks[4].Detail != "db.pass -- version 1" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "db.pass -- version 1", checks[4].Detail, statusErrors(checks))
+		}
+
+		checks = driver.Status(table, "alias/credstash", "db.pass", nil)
+
+		if !errors.Is(statusErrors(checks)["Decrypt"], ErrContextMismatch) || checks[4].Hint == "" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrContextMismatch, statusErrors(checks))
+		}
+
+		checks = driver.Status(table, "alias/credstash", "db.user", nil)
+
+		if !errors.Is(statusErrors(checks)["Query"], ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, statusErrors(checks))
+		}
+	})
+}
+
+type statusDdb struct {
+	dynamodbiface.DynamoDBAPI
+	table *dynamodb.TableDescription
+}
+
+func (ddb *statusDdb) DescribeTable(input *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+	if ddb.table == nil {
+		return nil, awserr.New(dynamodb.ErrCodeResourceNotFoundException, "Requested resource not found", nil)
+	}
+
+	return &dynamodb.DescribeTableOutput{Table: ddb.table}, nil
+}
+
+func (ddb *statusDdb) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (ddb *statusDdb) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func TestStatusTable(t *testing.T) {
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	ddb := &statusDdb{}
+	driver := &Driver{Ddb: ddb, Kms: localKms, Logger: &NopLogger{}}
+
+	checks := driver.Status("credential-store", "alias/credstash", "", nil)
+
+	if statusErrors(checks)["Table"] == nil || checks[0].Hint != "create it with `gcredstash setup`, or check GCREDSTASH_TABLE and the region" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "a missing table", checks[0])
+	}
+
+	keys := NewCreateTableInput("credential-store", nil)
+	ddb.table = &dynamodb.TableDescription{
+		TableStatus:          aws.String("CREATING"),
+		KeySchema:            keys.KeySchema,
+		AttributeDefinitions: keys.AttributeDefinitions,
+	}
+
+	checks = driver.Status("credential-store", "alias/credstash", "", nil)
+
+	if checks[0].Detail != "credential-store (CREATING, schema version 1)" || checks[0].Hint != "wait until the table is ACTIVE" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "a CREATING table", checks[0])
+	}
+
+	ddb.table.TableStatus = aws.String("ACTIVE")
+	ddb.table.KeySchema = keys.KeySchema[:1]
+
+	checks = driver.Status("credential-store", "alias/credstash", "", nil)
+
+	if checks[0].Detail != "credential-store (ACTIVE, unknown schema)" || checks[0].Err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "an unknown schema", checks[0])
+	}
+
+	ddb.table.KeySchema = keys.KeySchema
+	checks = driver.Status("credential-store", "alias/credstash", "", nil)
+
+	if len(statusErrors(checks)) != 0 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "no errors", statusErrors(checks))
+	}
+}
@@ -1,6 +1,9 @@
 package gcredstash
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	. "gcredstash"
 	"gcredstash/testutils"
 	"github.com/aws/aws-sdk-go/aws"
@@ -8,8 +11,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/golang/mock/gomock"
 	"mockaws"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetMaterialWithoutVersion(t *testing.T) {
@@ -225,7 +231,7 @@ func TestListSecrets(t *testing.T) {
 
 	mddb.EXPECT().Scan(&dynamodb.ScanInput{
 		TableName:                aws.String(table),
-		ProjectionExpression:     aws.String("#name,version"),
+		ProjectionExpression:     aws.String("#name,version,deleted_at"),
 		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
 	}).Return(&dynamodb.ScanOutput{
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
@@ -317,6 +323,7 @@ func TestPutSecret(t *testing.T) {
 
 	item := map[string]string{
 		"contents": "twnH",
+		"digest":   "SHA256",
 		"hmac":     "01cc6772cf2c889c8c0dae1f0ec3d7659e21103d56cd3436039cf29d18759958",
 		"key":      "CiDY1vsR456LEdoL3+0p+PrTCleoqi/sutbDfJZNiUSpphLLAQEBAQB42Nb7EeOeixHaC9/tKfj60wpXqKov7LrWw3yWTYlEqaYAAACiMIGfBgkqhkiG9w0BBwaggZEwgY4CAQAwgYgGCSqGSIb3DQEHATAeBglghkgBZQMEAS4wEQQMeq7h5wZtkuXM8PpxAgEQgFusrxgmwCbvRObKTdbH2yvma5kNrgx3bF3ghmu7pjq6ZhPao8gZJAG2YdwwTvdbjr/wck++u0W8utaP6r07Pe8M8+oUGwWxit9X6UzxfOR6Q4eoW8g2hRUncOgF",
 		"name":     name,
@@ -425,6 +432,7 @@ func TestGetDeleteTargetWithoutVersion(t *testing.T) {
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":name": {S: aws.String(name)},
 		},
+		ProjectionExpression: aws.String("#name,version,deleted_at"),
 	}).Return(&dynamodb.QueryOutput{
 		Count: aws.Int64(1),
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
@@ -512,6 +520,21 @@ func TestDeleteItem(t *testing.T) {
 	name := "test.key"
 	version := "0000000000000000002"
 
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		ConsistentRead:           aws.Bool(true),
+		KeyConditionExpression:   aws.String("#name = :name AND version = :version"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name":    {S: aws.String(name)},
+			":version": {S: aws.String(version)},
+		},
+		ProjectionExpression: aws.String("chunk_count"),
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(1),
+		Items: []map[string]*dynamodb.AttributeValue{{}},
+	}, nil)
+
 	mddb.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
 		TableName: aws.String(table),
 		Key: map[string]*dynamodb.AttributeValue{
@@ -558,11 +581,27 @@ func TestDeleteSecrets(t *testing.T) {
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":name": {S: aws.String(name)},
 		},
+		ProjectionExpression: aws.String("#name,version,deleted_at"),
 	}).Return(&dynamodb.QueryOutput{
 		Count: aws.Int64(1),
 		Items: []map[string]*dynamodb.AttributeValue{testutils.MapToItem(item)},
 	}, nil)
 
+	mddb.EXPECT().Query(&dynamodb.QueryInput{
+		TableName:                aws.String(table),
+		ConsistentRead:           aws.Bool(true),
+		KeyConditionExpression:   aws.String("#name = :name AND version = :version"),
+		ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name":    {S: aws.String(name)},
+			":version": {S: aws.String(version)},
+		},
+		ProjectionExpression: aws.String("chunk_count"),
+	}).Return(&dynamodb.QueryOutput{
+		Count: aws.Int64(1),
+		Items: []map[string]*dynamodb.AttributeValue{{}},
+	}, nil)
+
 	mddb.EXPECT().DeleteItem(&dynamodb.DeleteItemInput{
 		TableName: aws.String(table),
 		Key: map[string]*dynamodb.AttributeValue{
@@ -582,3 +621,226 @@ func TestDeleteSecrets(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
 	}
 }
+
+func TestPutSecretReadOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	driver := &Driver{
+		Ddb:      mddb,
+		Kms:      mkms,
+		ReadOnly: true,
+	}
+
+	err := driver.PutSecret("test.key", "100", "0000000000000000001", "alias/credstash", "credential-store", nil)
+
+	if err != ErrReadOnly {
+		t.Errorf("\nexpected: %v\ngot: %v\n", ErrReadOnly, err)
+	}
+}
+
+func TestDeleteSecretsReadOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	driver := &Driver{
+		Ddb:      mddb,
+		Kms:      mkms,
+		ReadOnly: true,
+	}
+
+	err := driver.DeleteSecrets("test.key", "", "credential-store")
+
+	if err != ErrReadOnly {
+		t.Errorf("\nexpected: %v\ngot: %v\n", ErrReadOnly, err)
+	}
+}
+
+func TestPutSecretWithGcmScheme(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	table := "credential-store"
+	name := "test.key"
+	version := "0000000000000000001"
+	kmsKey := "alias/credstash"
+	plaintext := []byte{145, 99, 240, 141, 84, 162, 135, 185, 20, 181, 81, 249, 15, 215, 56, 150, 222, 94, 65, 27, 27, 196, 165, 220, 49, 90, 199, 244, 14, 165, 188, 116, 135, 60, 104, 13, 136, 145, 109, 232, 87, 153, 237, 234, 174, 87, 7, 124, 131, 121, 67, 68, 239, 184, 174, 16, 197, 129, 97, 139, 146, 144, 89, 5}
+
+	mkms.EXPECT().GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:         aws.String(kmsKey),
+		NumberOfBytes: aws.Int64(64),
+	}).Return(&kms.GenerateDataKeyOutput{
+		CiphertextBlob: []byte("blobData"),
+		Plaintext:      append([]byte{}, plaintext...),
+	}, nil)
+
+	var stored map[string]*dynamodb.AttributeValue
+
+	mddb.EXPECT().PutItem(gomock.Any()).Do(func(params *dynamodb.PutItemInput) {
+		stored = params.Item
+	}).Return(nil, nil)
+
+	mkms.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("blobData"),
+	}).Return(&kms.DecryptOutput{
+		Plaintext: plaintext,
+	}, nil)
+
+	driver := &Driver{
+		Ddb: mddb,
+		Kms: mkms,
+	}
+
+	err := driver.PutSecretWithOptions(name, "100", version, kmsKey, table, map[string]string{}, &PutOptions{Scheme: "aes-gcm"})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if *stored["scheme"].S != "aes-gcm" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "aes-gcm", *stored["scheme"].S)
+	}
+
+	contents := B64Decode(*stored["contents"].S)
+	hmacKey := plaintext[32:]
+
+	// Readers that ignore the scheme must fail the HMAC check
+	if ValidateHMAC(contents, HexDecode(*stored["hmac"].S), hmacKey) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", false, true)
+	}
+
+	actual, err := driver.DecryptMaterial(name, stored, map[string]string{})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if actual != "100" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "100", actual)
+	}
+}
+
+func TestDecryptMaterialWithUnsupportedDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	name := "test.key"
+
+	item := map[string]string{
+		"contents": "eBtO1lgLxIe6Yw==",
+		"digest":   "WHIRLPOOL",
+		"hmac":     "b23a3efafd4795e50ca87afd7d764f263e9ae456499a8d40eece70a63ed5da27",
+		"key":      "YmxvYkRhdGE=",
+		"name":     name,
+		"version":  "0000000000000000002",
+	}
+
+	mkms.EXPECT().Decrypt(&kms.DecryptInput{
+		CiphertextBlob: []byte("blobData"),
+	}).Return(&kms.DecryptOutput{
+		Plaintext: []byte{188, 163, 172, 238, 203, 68, 210, 84, 58, 152, 145, 235, 42, 23, 204, 164, 62, 139, 115, 220, 63, 85, 98, 228, 48, 229, 82, 62, 72, 86, 255, 162, 53, 75, 177, 91, 204, 232, 206, 127, 200, 23, 43, 148, 246, 221, 240, 247, 94, 72, 147, 211, 60, 139, 50, 150, 18, 100, 28, 24, 240, 2, 199, 121},
+	}, nil)
+
+	driver := &Driver{
+		Ddb: mddb,
+		Kms: mkms,
+	}
+
+	_, err := driver.DecryptMaterial(name, testutils.MapToItem(item), map[string]string{})
+	expected := "test.key: unsupported digest algorithm: WHIRLPOOL"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestGetExpiredSecret(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 12, 3, 0, time.UTC)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		errOut := &bytes.Buffer{}
+		driver.Logger = &StdLogger{Out: &bytes.Buffer{}, Err: errOut}
+		driver.Now = func() time.Time { return now }
+
+		table := "credential-store"
+		driver.PutSecretWithOptions("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{ExpiresAt: now.Add(-time.Hour)})
+		driver.PutSecretWithOptions("foo.baz", "200", "0000000000000000001", "alias/credstash", table, nil, &PutOptions{ExpiresAt: now.Add(time.Hour)})
+
+		value, err := driver.GetSecret("foo.bar", "", table, nil)
+
+		if err != nil || value != "100" || !strings.Contains(errOut.String(), "level=warn foo.bar -- version 1 expired at 2026-10-14T08:12:03Z") {
+			t.Errorf("\nexpected: %v\ngot: %v %v %v\n", "100 with a warning", value, err, errOut.String())
+		}
+
+		driver.OnExpired = EXPIRED_FAIL
+		_, err = driver.GetSecret("foo.bar", "", table, nil)
+
+		if !errors.Is(err, ErrExpired) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrExpired, err)
+		}
+
+		value, err = driver.GetSecret("foo.baz", "", table, nil)
+
+		if err != nil || value != "200" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+	})
+}
+
+func TestPutSecretNextVersionConcurrent(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+		versions := make(chan string, 8)
+		errs := make(chan error, 8)
+
+		for i := 0; i < 8; i++ {
+			go func(i int) {
+				version, err := driver.PutSecretNextVersion("test.key", fmt.Sprint(i), "alias/credstash", table, nil, nil)
+				versions <- version
+				errs <- err
+			}(i)
+		}
+
+		seen := map[string]bool{}
+
+		for i := 0; i < 8; i++ {
+			version := <-versions
+
+			if err := <-errs; err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			}
+
+			seen[version] = true
+		}
+
+		for i := 1; i <= 8; i++ {
+			if !seen[VersionNumToStr(i)] {
+				t.Errorf("\nexpected: %v\ngot: %v\n", VersionNumToStr(i), seen)
+			}
+		}
+
+		err := driver.PutSecret("test.key", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		if !errors.Is(err, ErrVersionExists) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionExists, err)
+		}
+
+		expected := "test.key version 8 is already in the credential store. Use the -v flag to specify a new version"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	})
+}
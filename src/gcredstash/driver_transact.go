@@ -0,0 +1,174 @@
+package gcredstash
+
+import (
+	"fmt"
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbv2 "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"sort"
+	"strings"
+)
+
+// DynamoDB accepts at most this many actions in one transaction.
+const MAX_TRANSACT_ITEMS = 100
+
+// MAX_TRANSACT_SIZE is the largest total size of the items of a
+// transaction.
+const MAX_TRANSACT_SIZE = 4 * 1024 * 1024
+
+// TransactionalBackend is implemented by backends that can store several
+// items all-or-nothing.
+type TransactionalBackend interface {
+	PutItems(table string, items []map[string]*dynamodb.AttributeValue) error
+}
+
+func (backend *DynamoDBBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	params := &dynamodb.TransactWriteItemsInput{}
+
+	for _, item := range items {
+		params.TransactItems = append(params.TransactItems, &dynamodb.TransactWriteItem{
+			Put: &dynamodb.Put{
+				TableName:                aws.String(table),
+				Item:                     item,
+				ConditionExpression:      aws.String("attribute_not_exists(#name)"),
+				ExpressionAttributeNames: map[string]*string{"#name": aws.String("name")},
+			},
+		})
+	}
+
+	_, err := backend.Ddb.TransactWriteItems(params)
+
+	return err
+}
+
+func (backend *DynamoDBV2Backend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	params := &dynamodbv2.TransactWriteItemsInput{}
+
+	for _, item := range items {
+		params.TransactItems = append(params.TransactItems, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:                awsv2.String(table),
+				Item:                     itemToV2(item),
+				ConditionExpression:      awsv2.String("attribute_not_exists(#name)"),
+				ExpressionAttributeNames: map[string]string{"#name": "name"},
+			},
+		})
+	}
+
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	_, err := backend.Ddb.TransactWriteItems(ctx, params)
+
+	return backend.requestError(ctx, "TransactWriteItems", table, err)
+}
+
+func (backend *FileBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		m := itemToFileItem(item)
+
+		for _, stored := range tables[table] {
+			if stored["name"] == m["name"] && stored["version"] == m["version"] {
+				return fmt.Errorf("TransactionCanceledException: %s version %s already exists in %s", m["name"], m["version"], backend.Path)
+			}
+		}
+
+		tables[table] = append(tables[table], m)
+	}
+
+	return backend.save(tables)
+}
+
+// PutSecrets stores every secret at the same version in one transaction.
+// When version is empty, the version after the highest one of any of the
+// names is used. It returns the version that was written.
+func (driver *Driver) PutSecrets(secrets map[string]string, version string, kmsKey string, table string, context map[string]string, opts *PutOptions) (string, error) {
+	if err := driver.checkWritable(); err != nil {
+		return "", err
+	}
+
+	backend, ok := driver.backend().(TransactionalBackend)
+
+	if !ok {
+		return "", fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	if len(secrets) == 0 {
+		return "", fmt.Errorf("no credentials to put")
+	}
+
+	if len(secrets) > MAX_TRANSACT_ITEMS {
+		return "", fmt.Errorf("too many credentials for one transaction: %d (max %d)", len(secrets), MAX_TRANSACT_ITEMS)
+	}
+
+	names := []string{}
+
+	for name, _ := range secrets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	if version == "" {
+		max := 0
+
+		for _, name := range names {
+			latest, err := driver.GetHighestVersion(name, table)
+
+			if err != nil {
+				return "", err
+			}
+
+			if latest > max {
+				max = latest
+			}
+		}
+
+		version = VersionNumToStr(max + 1)
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+
+	for _, name := range names {
+		plaintext := []byte(secrets[name])
+		item, err := driver.encryptItem(name, plaintext, version, kmsKey, context, opts)
+		Wipe(plaintext)
+
+		if err != nil {
+			return "", fmt.Errorf("%s: %s", name, err.Error())
+		}
+
+		items = append(items, item)
+	}
+
+	driver.logger().Verbosef("put names=%s version=%s table=%s kms_key=%s", strings.Join(names, ","), version, table, kmsKey)
+
+	err := backend.PutItems(table, items)
+
+	if err != nil && strings.Contains(err.Error(), "TransactionCanceledException") {
+		err = fmt.Errorf("version %d of at least one of %s is already in the credential store; nothing was stored", Atoi(version), strings.Join(names, ", "))
+	}
+
+	for _, name := range names {
+		if logErr := driver.logAccess("put", name, version, table, err); err == nil && logErr != nil {
+			err = logErr
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
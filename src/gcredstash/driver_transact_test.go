@@ -0,0 +1,85 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestPutSecretsWithTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+
+	var input *dynamodb.TransactWriteItemsInput
+
+	mddb.EXPECT().TransactWriteItems(gomock.Any()).Do(func(params *dynamodb.TransactWriteItemsInput) {
+		input = params
+	}).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+	driver := &Driver{Ddb: mddb, Kms: localKms}
+	secrets := map[string]string{"db.user": "app", "db.password": "s3cr3t"}
+
+	version, err := driver.PutSecrets(secrets, "0000000000000000003", "alias/credstash", "credential-store", nil, nil)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if version != "0000000000000000003" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "0000000000000000003", version)
+	}
+
+	names := []string{}
+
+	for _, item := range input.TransactItems {
+		names = append(names, *item.Put.Item["name"].S)
+
+		if *item.Put.Item["version"].S != version || *item.Put.ConditionExpression != "attribute_not_exists(#name)" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", version, item.Put)
+		}
+	}
+
+	expected := []string{"db.password", "db.user"}
+
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, names)
+	}
+}
+
+func TestPutSecretsIsAllOrNothing(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+
+		driver.PutSecret("db.user", "app", "0000000000000000002", "alias/credstash", table, nil)
+
+		version, err := driver.PutSecrets(map[string]string{"db.user": "app2", "db.password": "s3cr3t"}, "", "alias/credstash", table, nil, nil)
+
+		if err != nil || version != "0000000000000000003" {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "0000000000000000003", version, err)
+		}
+
+		_, err = driver.PutSecrets(map[string]string{"db.user": "app3", "db.host": "db"}, "0000000000000000003", "alias/credstash", table, nil, nil)
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+
+		items, _ := driver.ListSecrets(table)
+		expected := map[string][]string{
+			"db.password": {"0000000000000000003"},
+			"db.user":     {"0000000000000000002", "0000000000000000003"},
+		}
+
+		if !reflect.DeepEqual(GroupVersions(items), expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+		}
+	})
+}
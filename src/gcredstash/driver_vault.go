@@ -0,0 +1,115 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/ryanuber/go-glob"
+	"sort"
+)
+
+func (driver *Driver) MigrateToVault(pattern string, vault *VaultClient, mapping *VaultMapping, table string, context map[string]string) ([]string, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for name, _ := range GroupVersions(items) {
+		if glob.Glob(pattern, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	migrated := []string{}
+
+	for _, name := range names {
+		value, err := driver.GetSecret(name, "", table, context)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		path := mapping.Path(name)
+		current, exists, err := vault.Read(path)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		if exists && current[mapping.key()] == value {
+			continue
+		}
+
+		if current == nil {
+			current = map[string]string{}
+		}
+
+		current[mapping.key()] = value
+		err = vault.Write(path, current)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated = append(migrated, fmt.Sprintf("%s -> %s", name, path))
+	}
+
+	return migrated, nil
+}
+
+func (driver *Driver) MigrateFromVault(pattern string, vault *VaultClient, mapping *VaultMapping, kmsKey string, table string, context map[string]string) ([]string, error) {
+	paths, err := vault.List(mapping.Prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	migrated := []string{}
+
+	for _, path := range paths {
+		name := mapping.CredentialName(path)
+
+		if !glob.Glob(pattern, name) {
+			continue
+		}
+
+		secret, exists, err := vault.Read(path)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		value, ok := secret[mapping.key()]
+
+		if !exists || !ok {
+			continue
+		}
+
+		latestVersion, err := driver.GetHighestVersion(name, table)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		if latestVersion > 0 {
+			current, err := driver.GetSecret(name, "", table, context)
+
+			if err == nil && current == value {
+				continue
+			}
+		}
+
+		version, err := driver.PutSecretNextVersion(name, value, kmsKey, table, context, nil)
+
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated = append(migrated, fmt.Sprintf("%s -> %s -- version %d", path, name, Atoi(version)))
+	}
+
+	return migrated, nil
+}
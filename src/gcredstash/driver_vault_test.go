@@ -0,0 +1,169 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fakeVault serves a KV mount at /v1/<mount>/ from memory.
+func fakeVault(mount string, kvVersion int, secrets map[string]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/"+mount+"/")
+
+		if kvVersion == 2 {
+			path = path[strings.Index(path, "/")+1:]
+		}
+
+		switch r.Method {
+		case "LIST":
+			keys := []string{}
+			seen := map[string]bool{}
+
+			for p, _ := range secrets {
+				if !strings.HasPrefix(p, path) {
+					continue
+				}
+
+				key := strings.TrimPrefix(p, path)
+
+				if i := strings.Index(key, "/"); i >= 0 {
+					key = key[:i+1]
+				}
+
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+			}
+
+			sort.Strings(keys)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+		case "GET":
+			secret, ok := secrets[path]
+
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			var data interface{} = secret
+
+			if kvVersion == 2 {
+				data = map[string]interface{}{"data": secret}
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		case "POST":
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			if kvVersion == 2 {
+				body = body["data"].(map[string]interface{})
+			}
+
+			secret := map[string]string{}
+
+			for k, v := range body {
+				secret[k] = v.(string)
+			}
+
+			secrets[path] = secret
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestVaultMapping(t *testing.T) {
+	mapping := &VaultMapping{Prefix: "/app/"}
+
+	if actual := mapping.Path("db.pass"); actual != "app/db/pass" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "app/db/pass", actual)
+	}
+
+	if actual := mapping.CredentialName("app/db/pass"); actual != "db.pass" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "db.pass", actual)
+	}
+}
+
+func TestNewVaultClient(t *testing.T) {
+	os.Setenv("VAULT_TOKEN", "")
+
+	if _, err := NewVaultClient("", "", 2); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+
+	os.Setenv("VAULT_TOKEN", "token")
+	defer os.Unsetenv("VAULT_TOKEN")
+
+	if _, err := NewVaultClient("", "", 3); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+
+	client, err := NewVaultClient("https://vault.example.com/", "", 2)
+
+	if err != nil || client.Address != "https://vault.example.com" || client.Mount != "secret" {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "https://vault.example.com secret", client, err)
+	}
+}
+
+func TestMigrateVault(t *testing.T) {
+	for _, kvVersion := range []int{1, 2} {
+		secrets := map[string]map[string]string{
+			"app/api/token": {"value": "t0ken"},
+		}
+
+		server := fakeVault("secret", kvVersion, secrets)
+		vault := &VaultClient{Address: server.URL, Token: "token", Mount: "secret", KVVersion: kvVersion, Client: http.DefaultClient}
+		mapping := &VaultMapping{Prefix: "app"}
+
+		testutils.TempDriver(func(driver *Driver, f *os.File) {
+			table := "credential-store"
+			driver.PutSecret("db.pass", "s3cr3t", "0000000000000000001", "alias/credstash", table, nil)
+
+			migrated, err := driver.MigrateToVault("*", vault, mapping, table, nil)
+			expected := []string{"db.pass -> app/db/pass"}
+
+			if !reflect.DeepEqual(migrated, expected) || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, migrated, err)
+			}
+
+			if secrets["app/db/pass"]["value"] != "s3cr3t" {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "s3cr3t", secrets["app/db/pass"])
+			}
+
+			migrated, err = driver.MigrateFromVault("api.*", vault, mapping, "alias/credstash", table, nil)
+			expected = []string{"app/api/token -> api.token -- version 1"}
+
+			if !reflect.DeepEqual(migrated, expected) || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, migrated, err)
+			}
+
+			value, _ := driver.GetSecret("api.token", "", table, nil)
+
+			if value != "t0ken" {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "t0ken", value)
+			}
+
+			migrated, _ = driver.MigrateFromVault("*", vault, mapping, "alias/credstash", table, nil)
+
+			if len(migrated) != 0 {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "[]", migrated)
+			}
+		})
+
+		server.Close()
+	}
+}
@@ -0,0 +1,208 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
+	"strings"
+	"time"
+)
+
+const (
+	CHANGE_CREATE = "create"
+	CHANGE_UPDATE = "update"
+	CHANGE_DELETE = "delete"
+)
+
+// ErrStopWatch can be returned by a WatchChanges handler to stop watching
+// without an error.
+var ErrStopWatch = errors.New("stop watching")
+
+// ChangeEvent is a change to one credential version. Storing version 1 of a
+// credential is a create, any later version an update.
+type ChangeEvent struct {
+	Type    string
+	Name    string
+	Version string
+	Time    time.Time
+}
+
+func (driver *Driver) latestStreamArn(table string) (string, error) {
+	resp, err := driver.Ddb.DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	spec := resp.Table.StreamSpecification
+
+	if resp.Table.LatestStreamArn == nil || spec == nil || !aws.BoolValue(spec.StreamEnabled) {
+		return "", fmt.Errorf("%s has no DynamoDB stream; run `gcredstash setup --stream` or enable one on the table", table)
+	}
+
+	return *resp.Table.LatestStreamArn, nil
+}
+
+func (driver *Driver) streamShards(streamArn string) ([]*dynamodbstreams.Shard, error) {
+	shards := []*dynamodbstreams.Shard{}
+	params := &dynamodbstreams.DescribeStreamInput{StreamArn: aws.String(streamArn)}
+
+	for {
+		resp, err := driver.Streams.DescribeStream(params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		shards = append(shards, resp.StreamDescription.Shards...)
+
+		if resp.StreamDescription.LastEvaluatedShardId == nil {
+			break
+		}
+
+		params.ExclusiveStartShardId = resp.StreamDescription.LastEvaluatedShardId
+	}
+
+	return shards, nil
+}
+
+func recordToChangeEvent(record *dynamodbstreams.Record) *ChangeEvent {
+	if record.Dynamodb == nil {
+		return nil
+	}
+
+	event := &ChangeEvent{
+		Name:    stringAttr(record.Dynamodb.Keys, "name"),
+		Version: stringAttr(record.Dynamodb.Keys, "version"),
+		Time:    aws.TimeValue(record.Dynamodb.ApproximateCreationDateTime),
+	}
+
+	switch aws.StringValue(record.EventName) {
+	case dynamodbstreams.OperationTypeInsert:
+		if Atoi(event.Version) == 1 {
+			event.Type = CHANGE_CREATE
+		} else {
+			event.Type = CHANGE_UPDATE
+		}
+	case dynamodbstreams.OperationTypeModify:
+		event.Type = CHANGE_UPDATE
+	case dynamodbstreams.OperationTypeRemove:
+		event.Type = CHANGE_DELETE
+	default:
+		return nil
+	}
+
+	return event
+}
+
+// WatchChanges tails the table's DynamoDB stream and calls handler for every
+// change to a credential whose name starts with prefix, starting with the
+// changes made after it was called. The stream is polled every interval. It
+// returns the handler's error, or nil if that error is ErrStopWatch.
+func (driver *Driver) WatchChanges(table string, prefix string, interval time.Duration, handler func(*ChangeEvent) error) error {
+	if _, ok := driver.baseBackend().(*DynamoDBBackend); !ok || driver.Streams == nil {
+		return fmt.Errorf("watch requires a DynamoDB table with a stream")
+	}
+
+	streamArn, err := driver.latestStreamArn(table)
+
+	if err != nil {
+		return err
+	}
+
+	iterators := map[string]*string{}
+	seen := map[string]bool{}
+	first := true
+	describe := true
+
+	for {
+		if describe {
+			shards, err := driver.streamShards(streamArn)
+
+			if err != nil {
+				return err
+			}
+
+			for _, shard := range shards {
+				shardId := aws.StringValue(shard.ShardId)
+
+				if seen[shardId] {
+					continue
+				}
+
+				seen[shardId] = true
+
+				// Skip the history of the shards that are already open or
+				// closed, but read every shard created while watching.
+				iteratorType := dynamodbstreams.ShardIteratorTypeTrimHorizon
+
+				if first {
+					if shard.SequenceNumberRange != nil && shard.SequenceNumberRange.EndingSequenceNumber != nil {
+						continue
+					}
+
+					iteratorType = dynamodbstreams.ShardIteratorTypeLatest
+				}
+
+				resp, err := driver.Streams.GetShardIterator(&dynamodbstreams.GetShardIteratorInput{
+					StreamArn:         aws.String(streamArn),
+					ShardId:           shard.ShardId,
+					ShardIteratorType: aws.String(iteratorType),
+				})
+
+				if err != nil {
+					return err
+				}
+
+				iterators[shardId] = resp.ShardIterator
+			}
+
+			first = false
+			describe = false
+		}
+
+		for shardId, iterator := range iterators {
+			resp, err := driver.Streams.GetRecords(&dynamodbstreams.GetRecordsInput{ShardIterator: iterator})
+
+			if err != nil {
+				return err
+			}
+
+			for _, record := range resp.Records {
+				event := recordToChangeEvent(record)
+
+				if event == nil || !strings.HasPrefix(event.Name, driver.Namespace+prefix) {
+					continue
+				}
+
+				event.Name = strings.TrimPrefix(event.Name, driver.Namespace)
+
+				driver.logger().Verbosef("stream event=%s name=%s version=%s", event.Type, event.Name, event.Version)
+				err = handler(event)
+
+				if err == ErrStopWatch {
+					return nil
+				}
+
+				if err != nil {
+					return err
+				}
+			}
+
+			if resp.NextShardIterator == nil {
+				// The shard was closed; its children are picked up on the
+				// next pass.
+				delete(iterators, shardId)
+				describe = true
+			} else {
+				iterators[shardId] = resp.NextShardIterator
+			}
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,172 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams/dynamodbstreamsiface"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"reflect"
+	"testing"
+)
+
+type fakeStreams struct {
+	dynamodbstreamsiface.DynamoDBStreamsAPI
+	iteratorTypes map[string]string
+	records       map[string][]*dynamodbstreams.Record
+}
+
+func (streams *fakeStreams) DescribeStream(params *dynamodbstreams.DescribeStreamInput) (*dynamodbstreams.DescribeStreamOutput, error) {
+	return &dynamodbstreams.DescribeStreamOutput{
+		StreamDescription: &dynamodbstreams.StreamDescription{
+			Shards: []*dynamodbstreams.Shard{
+				{
+					ShardId: aws.String("shard-0"),
+					SequenceNumberRange: &dynamodbstreams.SequenceNumberRange{
+						StartingSequenceNumber: aws.String("100"),
+						EndingSequenceNumber:   aws.String("200"),
+					},
+				},
+				{
+					ShardId: aws.String("shard-1"),
+					SequenceNumberRange: &dynamodbstreams.SequenceNumberRange{
+						StartingSequenceNumber: aws.String("201"),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (streams *fakeStreams) GetShardIterator(params *dynamodbstreams.GetShardIteratorInput) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	streams.iteratorTypes[*params.ShardId] = *params.ShardIteratorType
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(*params.ShardId + "/0")}, nil
+}
+
+func (streams *fakeStreams) GetRecords(params *dynamodbstreams.GetRecordsInput) (*dynamodbstreams.GetRecordsOutput, error) {
+	return &dynamodbstreams.GetRecordsOutput{
+		Records:           streams.records[*params.ShardIterator],
+		NextShardIterator: aws.String(*params.ShardIterator + "+"),
+	}, nil
+}
+
+func streamRecord(eventName string, name string, version string) *dynamodbstreams.Record {
+	return &dynamodbstreams.Record{
+		EventName: aws.String(eventName),
+		Dynamodb: &dynamodbstreams.StreamRecord{
+			Keys: map[string]*dynamodb.AttributeValue{
+				"name":    {S: aws.String(name)},
+				"version": {S: aws.String(version)},
+			},
+		},
+	}
+}
+
+func TestWatchChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	table := "credential-store"
+	streamArn := "arn:aws:dynamodb:us-east-1:123456789012:table/credential-store/stream/2026-10-14T00:00:00.000"
+
+	mddb.EXPECT().DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	}).Return(&dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{
+			LatestStreamArn: aws.String(streamArn),
+			StreamSpecification: &dynamodb.StreamSpecification{
+				StreamEnabled:  aws.Bool(true),
+				StreamViewType: aws.String(dynamodb.StreamViewTypeKeysOnly),
+			},
+		},
+	}, nil)
+
+	streams := &fakeStreams{
+		iteratorTypes: map[string]string{},
+		records: map[string][]*dynamodbstreams.Record{
+			"shard-1/0": {
+				streamRecord("INSERT", "app.db.password", "0000000000000000001"),
+				streamRecord("INSERT", "other.token", "0000000000000000002"),
+			},
+			"shard-1/0+": {
+				streamRecord("INSERT", "app.db.password", "0000000000000000002"),
+				streamRecord("REMOVE", "app.db.password", "0000000000000000001"),
+			},
+		},
+	}
+
+	driver := &Driver{Ddb: mddb, Streams: streams, Logger: &NopLogger{}}
+	events := []ChangeEvent{}
+
+	err := driver.WatchChanges(table, "app.", 0, func(event *ChangeEvent) error {
+		events = append(events, *event)
+
+		if len(events) == 3 {
+			return ErrStopWatch
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := []ChangeEvent{
+		{Type: "create", Name: "app.db.password", Version: "0000000000000000001"},
+		{Type: "update", Name: "app.db.password", Version: "0000000000000000002"},
+		{Type: "delete", Name: "app.db.password", Version: "0000000000000000001"},
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, events)
+	}
+
+	expectedTypes := map[string]string{"shard-1": "LATEST"}
+
+	if !reflect.DeepEqual(streams.iteratorTypes, expectedTypes) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expectedTypes, streams.iteratorTypes)
+	}
+}
+
+func TestWatchChangesWithoutStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mddb := mockaws.NewMockDynamoDBAPI(ctrl)
+	table := "credential-store"
+
+	mddb.EXPECT().DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	}).Return(&dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{},
+	}, nil)
+
+	driver := &Driver{Ddb: mddb, Streams: &fakeStreams{}, Logger: &NopLogger{}}
+
+	err := driver.WatchChanges(table, "", 0, func(event *ChangeEvent) error {
+		return nil
+	})
+
+	expected := "credential-store has no DynamoDB stream; run `gcredstash setup --stream` or enable one on the table"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestNewCreateTableInputWithStream(t *testing.T) {
+	params := NewCreateTableInput("credential-store", &TableOptions{StreamEnabled: true})
+
+	expected := &dynamodb.StreamSpecification{
+		StreamEnabled:  aws.Bool(true),
+		StreamViewType: aws.String("KEYS_ONLY"),
+	}
+
+	if !reflect.DeepEqual(params.StreamSpecification, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, params.StreamSpecification)
+	}
+}
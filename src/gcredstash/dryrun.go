@@ -0,0 +1,44 @@
+package gcredstash
+
+import (
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// DryRunBackend reads from Backend but only reports the items it would put
+// or delete to Logger.
+type DryRunBackend struct {
+	Backend Backend
+	Logger  Logger
+}
+
+func (backend *DryRunBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	return backend.Backend.GetItem(table, name, version)
+}
+
+func (backend *DryRunBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	backend.Logger.Infof("dry-run: put %s -- version %d in %s", stringAttr(item, "name"), Atoi(stringAttr(item, "version")), table)
+
+	return nil
+}
+
+func (backend *DryRunBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	return backend.Backend.Query(table, name, opts)
+}
+
+func (backend *DryRunBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	return backend.Backend.Scan(table, opts)
+}
+
+func (backend *DryRunBackend) Delete(table string, name string, version string) error {
+	backend.Logger.Infof("dry-run: delete %s -- version %d from %s", name, Atoi(version), table)
+
+	return nil
+}
+
+func (backend *DryRunBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	for _, item := range items {
+		backend.PutItem(table, item)
+	}
+
+	return nil
+}
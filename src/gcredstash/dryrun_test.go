@@ -0,0 +1,45 @@
+package gcredstash
+
+import (
+	"bytes"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDriverDryRun(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		out := &bytes.Buffer{}
+		driver.Logger = &StdLogger{Out: out, Err: &bytes.Buffer{}}
+		driver.Events = &NopEventHandler{}
+
+		driver.PutSecret("test.key", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.DryRun = true
+
+		if _, err := driver.PutSecretNextVersion("test.key", "200", "alias/credstash", table, nil, nil); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if err := driver.DeleteSecrets("test.key", "", table); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		expected := "dry-run: put test.key -- version 2 in credential-store\n" +
+			"dry-run: delete test.key -- version 1 from credential-store\n"
+
+		if out.String() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, out.String())
+		}
+
+		items, _ := driver.ListSecrets(table)
+		expectedVersions := map[string][]string{"test.key": {"0000000000000000001"}}
+
+		if !reflect.DeepEqual(GroupVersions(items), expectedVersions) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expectedVersions, GroupVersions(items))
+		}
+	})
+}
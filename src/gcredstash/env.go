@@ -0,0 +1,155 @@
+package gcredstash
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type EnvNameOptions struct {
+	Prefix     string
+	KeepPrefix bool
+	KeepCase   bool
+	KeepDots   bool
+}
+
+var dotenvBareValue = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+func EnvName(name string, opts *EnvNameOptions) string {
+	if opts == nil {
+		opts = &EnvNameOptions{}
+	}
+
+	if !opts.KeepPrefix {
+		name = strings.TrimPrefix(name, opts.Prefix)
+	}
+
+	if !opts.KeepDots {
+		name = strings.NewReplacer(".", "_", "-", "_").Replace(name)
+	}
+
+	if !opts.KeepCase {
+		name = strings.ToUpper(name)
+	}
+
+	return name
+}
+
+func EnvNames(creds map[string]string, opts *EnvNameOptions) (map[string]string, error) {
+	env := map[string]string{}
+	sources := map[string]string{}
+
+	for name, value := range creds {
+		key := EnvName(name, opts)
+
+		if key == "" {
+			return nil, fmt.Errorf("%s: empty variable name", name)
+		}
+
+		if other, ok := sources[key]; ok {
+			return nil, fmt.Errorf("%s and %s both map to %s", other, name, key)
+		}
+
+		sources[key] = name
+		env[key] = value
+	}
+
+	return env, nil
+}
+
+func DotenvQuote(value string) string {
+	if dotenvBareValue.MatchString(value) {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func sortedEnvKeys(env map[string]string) []string {
+	keys := []string{}
+
+	for key, _ := range env {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func FormatDotenv(env map[string]string) string {
+	keys := sortedEnvKeys(env)
+	lines := []string{}
+
+	for _, key := range keys {
+		lines = append(lines, key+"="+DotenvQuote(env[key]))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func ShellQuote(str string) string {
+	return "'" + strings.Replace(str, "'", `'\''`, -1) + "'"
+}
+
+func FormatDockerEnvArgs(env map[string]string) string {
+	args := []string{}
+
+	for _, key := range sortedEnvKeys(env) {
+		args = append(args, "--env", ShellQuote(key+"="+env[key]))
+	}
+
+	return strings.Join(args, " ")
+}
+
+// FormatDockerEnvFile renders the `docker run --env-file` format, which takes
+// values literally and has no way to express a newline.
+func FormatDockerEnvFile(env map[string]string) (string, error) {
+	lines := []string{}
+
+	for _, key := range sortedEnvKeys(env) {
+		if strings.Contains(env[key], "\n") {
+			return "", fmt.Errorf("%s: multi-line values cannot be written to an env-file", key)
+		}
+
+		lines = append(lines, key+"="+env[key])
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func WriteSecretFiles(dir string, env map[string]string) error {
+	err := os.MkdirAll(dir, 0700)
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedEnvKeys(env) {
+		if strings.ContainsAny(key, "/\\") || key == "." || key == ".." {
+			return fmt.Errorf("%s: invalid file name", key)
+		}
+
+		err := ioutil.WriteFile(filepath.Join(dir, key), []byte(env[key]), 0600)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
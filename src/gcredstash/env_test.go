@@ -0,0 +1,70 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"testing"
+)
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *EnvNameOptions
+		expected string
+	}{
+		{"app.database-url", &EnvNameOptions{Prefix: "app."}, "DATABASE_URL"},
+		{"app.database-url", &EnvNameOptions{Prefix: "app.", KeepPrefix: true}, "APP_DATABASE_URL"},
+		{"app.database.url", &EnvNameOptions{Prefix: "app.", KeepCase: true}, "database_url"},
+		{"app.database.url", &EnvNameOptions{Prefix: "app.", KeepDots: true}, "DATABASE.URL"},
+		{"foo.bar", nil, "FOO_BAR"},
+	}
+
+	for _, test := range tests {
+		actual := EnvName(test.name, test.opts)
+
+		if actual != test.expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", test.expected, actual)
+		}
+	}
+}
+
+func TestEnvNamesWithCollision(t *testing.T) {
+	_, err := EnvNames(map[string]string{"foo.bar": "1", "foo-bar": "2"}, nil)
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestFormatDotenv(t *testing.T) {
+	env := map[string]string{
+		"URL":    "postgres://db.example.com/app",
+		"SECRET": "a \"quoted\" $value\nline",
+	}
+
+	expected := `SECRET="a \"quoted\" \$value\nline"
+URL=postgres://db.example.com/app
+`
+	actual := FormatDotenv(env)
+
+	if actual != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestFormatDockerEnvArgs(t *testing.T) {
+	env := map[string]string{"A": "it's", "B": "x y"}
+	expected := `--env 'A=it'\''s' --env 'B=x y'`
+	actual := FormatDockerEnvArgs(env)
+
+	if actual != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestFormatDockerEnvFileWithNewline(t *testing.T) {
+	_, err := FormatDockerEnvFile(map[string]string{"A": "line1\nline2"})
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
@@ -0,0 +1,224 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSecretNotFound  = errors.New("secret not found")
+	ErrVersionNotFound = errors.New("version not found")
+	ErrIntegrity       = errors.New("integrity check failed")
+	ErrExpired         = errors.New("credential has expired")
+	ErrVersionExists   = errors.New("version already exists")
+	ErrVersionConflict = errors.New("version conflict")
+	ErrContextMismatch = errors.New("encryption context mismatch")
+	ErrItemTooLarge    = errors.New("item too large")
+)
+
+// NotFoundError is returned when a credential, or the requested version of
+// it, does not exist. Use errors.Is with ErrSecretNotFound or
+// ErrVersionNotFound to check for it.
+type NotFoundError struct {
+	Name    string
+	Version string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Version == "" {
+		return fmt.Sprintf("Item {'name': '%s'} couldn't be found.", e.Name)
+	}
+
+	return fmt.Sprintf("Item {'name': '%s', 'version': %d} couldn't be found.", e.Name, Atoi(e.Version))
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	if e.Version == "" {
+		return target == ErrSecretNotFound
+	}
+
+	return target == ErrVersionNotFound
+}
+
+// IntegrityError is returned when a stored credential fails HMAC
+// validation or authenticated decryption. errors.Is(err, ErrIntegrity)
+// reports it.
+type IntegrityError struct {
+	Name    string
+	Message string
+}
+
+func (e *IntegrityError) Error() string {
+	return e.Message
+}
+
+func (e *IntegrityError) Is(target error) bool {
+	return target == ErrIntegrity
+}
+
+// ContextMismatchError is returned when KMS cannot decrypt a data key,
+// usually because the encryption context is missing or differs from the
+// one the credential was stored with. errors.Is(err, ErrContextMismatch)
+// reports it.
+type ContextMismatchError struct {
+	Name    string
+	Message string
+}
+
+func (e *ContextMismatchError) Error() string {
+	return e.Message
+}
+
+func (e *ContextMismatchError) Is(target error) bool {
+	return target == ErrContextMismatch
+}
+
+// ExpiredError is returned when a credential past its expires_at is read
+// and Driver.OnExpired is EXPIRED_FAIL. errors.Is(err, ErrExpired) reports
+// it.
+type ExpiredError struct {
+	Name      string
+	Version   string
+	ExpiresAt time.Time
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("%s -- version %d expired at %s", e.Name, Atoi(e.Version), e.ExpiresAt.UTC().Format(time.RFC3339))
+}
+
+func (e *ExpiredError) Is(target error) bool {
+	return target == ErrExpired
+}
+
+// VersionExistsError is returned when a put targets a version that is
+// already stored. Latest is the highest version at the time of the
+// failure. errors.Is(err, ErrVersionExists) reports it.
+type VersionExistsError struct {
+	Name    string
+	Version string
+	Latest  int
+}
+
+func (e *VersionExistsError) Error() string {
+	return fmt.Sprintf(
+		"%s version %d is already in the credential store. Use the -v flag to specify a new version",
+		e.Name,
+		e.Latest)
+}
+
+func (e *VersionExistsError) Is(target error) bool {
+	return target == ErrVersionExists
+}
+
+// VersionConflictError is returned by a conditional put when the highest
+// stored version is not the expected one. errors.Is(err,
+// ErrVersionConflict) reports it.
+type VersionConflictError struct {
+	Name     string
+	Expected int
+	Latest   int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s has been changed: expected version %d, but the latest is version %d", e.Name, e.Expected, e.Latest)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// ItemTooLargeError is returned when a version does not fit in a DynamoDB
+// item. CanChunk is set when ChunkingBackend.Split would store it.
+// errors.Is(err, ErrItemTooLarge) reports it.
+type ItemTooLargeError struct {
+	Name     string
+	Size     int
+	CanChunk bool
+}
+
+func (e *ItemTooLargeError) Error() string {
+	msg := fmt.Sprintf("%s is too large to store: %d KB, over the DynamoDB item limit of %d KB", e.Name, (e.Size+1023)/1024, MAX_ITEM_SIZE/1024)
+
+	if e.CanChunk {
+		msg += " (set chunked_storage: true to split it across items)"
+	}
+
+	return msg
+}
+
+func (e *ItemTooLargeError) Is(target error) bool {
+	return target == ErrItemTooLarge
+}
+
+var accessDeniedCodes = []string{
+	"AccessDeniedException",
+	"AccessDenied",
+	"UnauthorizedOperation",
+}
+
+var conditionalCheckCodes = []string{
+	"ConditionalCheckFailedException",
+}
+
+var keyUnavailableCodes = []string{
+	"DisabledException",
+	"KMSInvalidStateException",
+	"NotFoundException",
+	"KeyUnavailableException",
+}
+
+var throttleCodes = []string{
+	"ProvisionedThroughputExceededException",
+	"RequestLimitExceeded",
+	"ThrottlingException",
+	"Throttling",
+	"TooManyRequestsException",
+}
+
+// hasErrorCode reports whether err is an AWS error with one of codes. Some
+// errors are re-wrapped as plain messages, so the message is checked too.
+func hasErrorCode(err error, codes []string) bool {
+	var awsErr awserr.Error
+
+	if errors.As(err, &awsErr) {
+		for _, code := range codes {
+			if awsErr.Code() == code {
+				return true
+			}
+		}
+	}
+
+	for _, code := range codes {
+		if strings.Contains(err.Error(), code+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAccessDenied reports whether AWS refused a request for lack of permission.
+func IsAccessDenied(err error) bool {
+	return err != nil && hasErrorCode(err, accessDeniedCodes)
+}
+
+// IsKmsKeyUnavailable reports whether KMS refused a request because the key
+// is disabled, pending deletion, deleted or otherwise unusable.
+func IsKmsKeyUnavailable(err error) bool {
+	return err != nil && hasErrorCode(err, keyUnavailableCodes)
+}
+
+// IsThrottle reports whether AWS throttled a request.
+func IsThrottle(err error) bool {
+	return err != nil && (request.IsErrorThrottle(err) || hasErrorCode(err, throttleCodes))
+}
+
+// IsConditionalCheckFailed reports whether a conditional write was
+// rejected, e.g. because the version already exists.
+func IsConditionalCheckFailed(err error) bool {
+	return err != nil && hasErrorCode(err, conditionalCheckCodes)
+}
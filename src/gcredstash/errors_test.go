@@ -0,0 +1,57 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"os"
+	"testing"
+)
+
+func TestNotFoundError(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+
+		_, err := driver.GetSecret("test.key", "", table, nil)
+
+		if !errors.Is(err, ErrSecretNotFound) || errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+
+		expected := "Item {'name': 'test.key'} couldn't be found."
+
+		if err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err.Error())
+		}
+
+		driver.PutSecret("test.key", "value", "0000000000000000001", "alias/credstash", table, nil)
+
+		_, err = driver.GetSecret("test.key", "0000000000000000002", table, nil)
+
+		if !errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionNotFound, err)
+		}
+
+		err = driver.DeleteSecrets("test.key", "0000000000000000002", table)
+		expected = "Item {'name': 'test.key', 'version': 2} couldn't be found."
+
+		if !errors.Is(err, ErrVersionNotFound) || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		var notFound *NotFoundError
+
+		if !errors.As(err, &notFound) || notFound.Name != "test.key" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "test.key", notFound)
+		}
+
+		material, _ := driver.GetMaterialWithVersion("test.key", "0000000000000000001", table)
+		material["hmac"].S = aws.String("00")
+		_, err = driver.DecryptMaterial("test.key", material, nil)
+
+		if !errors.Is(err, ErrIntegrity) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrIntegrity, err)
+		}
+	})
+}
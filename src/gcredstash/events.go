@@ -0,0 +1,77 @@
+package gcredstash
+
+import (
+	"time"
+)
+
+// EventHandler receives progress events of driver operations. Without one,
+// the driver reports them as info messages to its Logger; embedding
+// applications can set Driver.Events to control that output. Embed
+// NopEventHandler to handle only some events.
+type EventHandler interface {
+	OnDelete(name string, version string)
+	OnSetupSkipped(table string)
+	OnTableCreating(table string)
+	OnTableWaiting(table string)
+	OnPointInTimeRecoveryEnabled(table string)
+	OnTableCreated(table string)
+	OnTimeToLiveEnabled(table string)
+	OnExpired(name string, version string, expiresAt time.Time)
+}
+
+type NopEventHandler struct{}
+
+func (handler *NopEventHandler) OnDelete(name string, version string)                       {}
+func (handler *NopEventHandler) OnSetupSkipped(table string)                                {}
+func (handler *NopEventHandler) OnTableCreating(table string)                               {}
+func (handler *NopEventHandler) OnTableWaiting(table string)                                {}
+func (handler *NopEventHandler) OnPointInTimeRecoveryEnabled(table string)                  {}
+func (handler *NopEventHandler) OnTableCreated(table string)                                {}
+func (handler *NopEventHandler) OnTimeToLiveEnabled(table string)                           {}
+func (handler *NopEventHandler) OnExpired(name string, version string, expiresAt time.Time) {}
+
+// LoggerEventHandler reports events as the CLI's info messages, and
+// expired reads as warnings.
+type LoggerEventHandler struct {
+	Logger Logger
+}
+
+func (handler *LoggerEventHandler) OnDelete(name string, version string) {
+	handler.Logger.Infof("Deleting %s -- version %d", name, Atoi(version))
+}
+
+func (handler *LoggerEventHandler) OnSetupSkipped(table string) {
+	handler.Logger.Infof("The configured backend does not use a DynamoDB table; nothing to set up.")
+}
+
+func (handler *LoggerEventHandler) OnTableCreating(table string) {
+	handler.Logger.Infof("Creating table...")
+}
+
+func (handler *LoggerEventHandler) OnTableWaiting(table string) {
+	handler.Logger.Infof("Waiting for table to be created...")
+}
+
+func (handler *LoggerEventHandler) OnPointInTimeRecoveryEnabled(table string) {
+	handler.Logger.Infof("Point-in-time recovery has been enabled.")
+}
+
+func (handler *LoggerEventHandler) OnTableCreated(table string) {
+	handler.Logger.Infof("Table has been created. Go read the README about how to create your KMS key")
+}
+
+func (handler *LoggerEventHandler) OnTimeToLiveEnabled(table string) {
+	handler.Logger.Infof("Time to live has been enabled on the ttl attribute.")
+}
+
+func (handler *LoggerEventHandler) OnExpired(name string, version string, expiresAt time.Time) {
+	handler.Logger.Warnf("%s -- version %d expired at %s", name, Atoi(version), expiresAt.UTC().Format(time.RFC3339))
+}
+
+func (driver *Driver) events() EventHandler {
+	if driver.Events == nil {
+		return &LoggerEventHandler{Logger: driver.logger()}
+	}
+
+	return driver.Events
+}
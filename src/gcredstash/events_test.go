@@ -0,0 +1,48 @@
+package gcredstash
+
+import (
+	"bytes"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+type recordingEventHandler struct {
+	NopEventHandler
+	events []string
+}
+
+func (handler *recordingEventHandler) OnDelete(name string, version string) {
+	handler.events = append(handler.events, "delete "+name+" "+version)
+}
+
+func (handler *recordingEventHandler) OnSetupSkipped(table string) {
+	handler.events = append(handler.events, "skip "+table)
+}
+
+func TestEventHandler(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		handler := &recordingEventHandler{}
+		out := &bytes.Buffer{}
+		logger := &StdLogger{Out: out, Err: out}
+		driver.Logger = logger
+		driver.Events = handler
+		table := "credential-store"
+
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.DeleteSecrets("foo.bar", "", table)
+		driver.CreateDdbTable(table)
+
+		expected := []string{"delete foo.bar 0000000000000000001", "skip credential-store"}
+
+		if !reflect.DeepEqual(handler.events, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, handler.events)
+		}
+
+		if out.Len() != 0 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "no output", out.String())
+		}
+	})
+}
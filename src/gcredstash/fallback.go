@@ -0,0 +1,49 @@
+package gcredstash
+
+import (
+	"errors"
+	"strings"
+)
+
+// FallbackStore is a store a Driver reads a credential from when it is not
+// in its own table; see Driver.Fallbacks. Name identifies it in logs.
+type FallbackStore struct {
+	Name   string
+	Driver *Driver
+	Table  string
+}
+
+// FallbackStoreNames returns the stores of the fallback_stores setting, in
+// order.
+func (profile *ConfigProfile) FallbackStoreNames() []string {
+	names := []string{}
+
+	for _, name := range strings.Split(profile.FallbackStores, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// fromFallbacks reads name from the Fallbacks in order while err says it
+// was not found, and logs the store it was found in.
+func (driver *Driver) fromFallbacks(name string, version string, table string, context map[string]string, value []byte, storedVersion string, err error) ([]byte, string, error) {
+	var notFound *NotFoundError
+
+	for _, fallback := range driver.Fallbacks {
+		if !errors.As(err, &notFound) {
+			break
+		}
+
+		driver.logger().Verbosef("get name=%s is not in table=%s; trying store=%s", name, table, fallback.Name)
+		value, storedVersion, err = fallback.Driver.GetSecretBytesWithVersion(name, version, fallback.Table, context)
+
+		if err == nil {
+			driver.logger().Verbosef("get name=%s version=%s from store=%s table=%s", name, storedVersion, fallback.Name, fallback.Table)
+		}
+	}
+
+	return value, storedVersion, err
+}
@@ -0,0 +1,57 @@
+package gcredstash
+
+import (
+	"bytes"
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDriverFallbacks(t *testing.T) {
+	testutils.TempDriver(func(team *Driver, f *os.File) {
+		log := &bytes.Buffer{}
+		logger := &StdLogger{Out: log, Err: log, Level: LOG_LEVEL_VERBOSE}
+		team.Logger = logger
+		shared := &Driver{Backend: &FileBackend{Path: f.Name()}, Kms: team.Kms, Logger: logger}
+		team.Fallbacks = []*FallbackStore{{Name: "shared", Driver: shared, Table: "credential-store-shared"}}
+
+		shared.PutSecret("smtp.password", "shared", VersionNumToStr(1), "alias/credstash", "credential-store-shared", nil)
+		shared.PutSecret("db.password", "shared", VersionNumToStr(1), "alias/credstash", "credential-store-shared", nil)
+		team.PutSecret("db.password", "team", VersionNumToStr(1), "alias/credstash", "credential-store-team", nil)
+
+		values := map[string]string{}
+
+		for _, name := range []string{"db.password", "smtp.password"} {
+			values[name], _ = team.GetSecret(name, "", "credential-store-team", nil)
+		}
+
+		expected := map[string]string{"db.password": "team", "smtp.password": "shared"}
+
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, values)
+		}
+
+		provenance := "get name=smtp.password version=0000000000000000001 from store=shared table=credential-store-shared"
+
+		if !strings.Contains(log.String(), provenance) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", provenance, log.String())
+		}
+
+		if _, err := team.GetSecret("api.key", "", "credential-store-team", nil); !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+	})
+}
+
+func TestFallbackStoreNames(t *testing.T) {
+	profile := &ConfigProfile{FallbackStores: "team, shared,"}
+	expected := []string{"team", "shared"}
+
+	if names := profile.FallbackStoreNames(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, names)
+	}
+}
@@ -0,0 +1,61 @@
+package gcredstash
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+const (
+	CHARSET_ALNUM          = "alnum"
+	CHARSET_HEX            = "hex"
+	CHARSET_BASE64         = "base64"
+	DEFAULT_GENERATE_LEN   = 32
+	DEFAULT_GENERATE_CHARS = CHARSET_ALNUM
+)
+
+var generateCharsets = map[string]string{
+	CHARSET_ALNUM:  "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+	CHARSET_HEX:    "0123456789abcdef",
+	CHARSET_BASE64: "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/",
+}
+
+// GenerateSecret returns length characters drawn uniformly from charset
+// (alnum, hex or base64) using crypto/rand.
+func GenerateSecret(length int, charset string) ([]byte, error) {
+	if charset == "" {
+		charset = DEFAULT_GENERATE_CHARS
+	}
+
+	chars, ok := generateCharsets[charset]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown charset: %s (use %s, %s or %s)", charset, CHARSET_ALNUM, CHARSET_HEX, CHARSET_BASE64)
+	}
+
+	if length < 1 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+
+	// Bytes at or above limit are rejected so that every character is
+	// equally likely.
+	limit := 256 - 256%len(chars)
+	secret := make([]byte, 0, length)
+	buf := make([]byte, length)
+
+	for len(secret) < length {
+		if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+			return nil, err
+		}
+
+		for _, b := range buf {
+			if int(b) < limit && len(secret) < length {
+				secret = append(secret, chars[int(b)%len(chars)])
+			}
+		}
+	}
+
+	Wipe(buf)
+
+	return secret, nil
+}
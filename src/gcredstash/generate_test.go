@@ -0,0 +1,48 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"regexp"
+	"testing"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	for _, tc := range []struct {
+		charset string
+		pattern string
+	}{
+		{"", `^[A-Za-z0-9]{40}$`},
+		{"alnum", `^[A-Za-z0-9]{40}$`},
+		{"hex", `^[0-9a-f]{40}$`},
+		{"base64", `^[A-Za-z0-9+/]{40}$`},
+	} {
+		secret, err := GenerateSecret(40, tc.charset)
+
+		if err != nil || !regexp.MustCompile(tc.pattern).Match(secret) {
+			t.Errorf("\nexpected: %v\ngot: %s %v\n", tc.pattern, secret, err)
+		}
+	}
+
+	a, _ := GenerateSecret(32, "alnum")
+	b, _ := GenerateSecret(32, "alnum")
+
+	if string(a) == string(b) {
+		t.Errorf("\nexpected different values\ngot: %s %s\n", a, b)
+	}
+}
+
+func TestErrGenerateSecret(t *testing.T) {
+	_, err := GenerateSecret(32, "emoji")
+	expected := "unknown charset: emoji (use alnum, hex or base64)"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+
+	_, err = GenerateSecret(0, "hex")
+	expected = "invalid length: 0"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
@@ -0,0 +1,93 @@
+package gcredstash
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The library must stay usable without the command-line tool, so that
+// other programs can vendor it alone.
+var cliImports = []string{
+	"gcredstash/command",
+	"github.com/mitchellh/cli",
+	"github.com/mattn/go-shellwords",
+}
+
+// The third-party modules the library may import, as listed in the
+// README.
+var libraryDependencies = []string{
+	"github.com/aws/aws-sdk-go/",
+	"github.com/aws/aws-sdk-go-v2/",
+	"github.com/aws/aws-dax-go/",
+	"github.com/ryanuber/go-glob",
+	"golang.org/x/crypto/scrypt",
+	"github.com/klauspost/compress/zstd",
+}
+
+// libraryImports returns the imports of each non-test file of the package.
+func libraryImports(t *testing.T) map[string][]string {
+	files, err := filepath.Glob("*.go")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imports := map[string][]string{}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			imports[file] = append(imports[file], path)
+		}
+	}
+
+	return imports
+}
+
+func TestLibraryDoesNotImportCli(t *testing.T) {
+	for file, paths := range libraryImports(t) {
+		for _, path := range paths {
+			for _, cliImport := range cliImports {
+				if path == cliImport {
+					t.Errorf("%s imports %s", file, path)
+				}
+			}
+		}
+	}
+}
+
+func TestLibraryDependencies(t *testing.T) {
+	for file, paths := range libraryImports(t) {
+		for _, path := range paths {
+			if !strings.Contains(strings.Split(path, "/")[0], ".") {
+				continue
+			}
+
+			allowed := false
+
+			for _, dependency := range libraryDependencies {
+				if path == strings.TrimSuffix(dependency, "/") || strings.HasPrefix(path, dependency) {
+					allowed = true
+				}
+			}
+
+			if !allowed {
+				t.Errorf("%s imports %s, which is not a documented dependency", file, path)
+			}
+		}
+	}
+}
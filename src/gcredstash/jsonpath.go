@@ -0,0 +1,218 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type jsonPathStep struct {
+	key   string
+	index int
+	isIdx bool
+}
+
+// parseJSONPath parses a path such as .db.password, $.hosts[0] or
+// servers[1].name. A leading $ and the first dot are optional.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	rest := strings.TrimPrefix(path, "$")
+
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	steps := []jsonPathStep{}
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path: %s", path)
+			}
+
+			steps = append(steps, jsonPathStep{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path: %s", path)
+			}
+
+			inner := rest[1:end]
+			rest = rest[end+1:]
+
+			if key, err := strconv.Unquote(inner); err == nil {
+				steps = append(steps, jsonPathStep{key: key})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path: %s", path)
+			}
+
+			steps = append(steps, jsonPathStep{index: index, isIdx: true})
+		default:
+			return nil, fmt.Errorf("invalid path: %s", path)
+		}
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("invalid path: %s", path)
+	}
+
+	return steps, nil
+}
+
+// ParseJSONValue parses str as JSON, or returns it as a string when it is
+// not valid JSON, so that port=5432 sets a number and user=app a string.
+func ParseJSONValue(str string) interface{} {
+	var value interface{}
+
+	if err := json.Unmarshal([]byte(str), &value); err != nil {
+		return str
+	}
+
+	return value
+}
+
+// SetJSONPath sets the value at path in a document decoded with
+// encoding/json and returns the updated document. Missing objects along
+// the path are created; an array index can be at most the array's length,
+// which appends.
+func SetJSONPath(doc interface{}, path string, value interface{}) (interface{}, error) {
+	steps, err := parseJSONPath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return setJSONPath(doc, steps, value, path)
+}
+
+func setJSONPath(node interface{}, steps []jsonPathStep, value interface{}, path string) (interface{}, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+
+	step := steps[0]
+
+	if step.isIdx {
+		array, ok := node.([]interface{})
+
+		if !ok || step.index > len(array) {
+			return nil, fmt.Errorf("%s: no index %d", path, step.index)
+		}
+
+		if step.index == len(array) {
+			array = append(array, nil)
+		}
+
+		child, err := setJSONPath(array[step.index], steps[1:], value, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		array[step.index] = child
+
+		return array, nil
+	}
+
+	if node == nil {
+		node = map[string]interface{}{}
+	}
+
+	object, ok := node.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %s is not in an object", path, step.key)
+	}
+
+	child, err := setJSONPath(object[step.key], steps[1:], value, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	object[step.key] = child
+
+	return object, nil
+}
+
+// GetJSONPath returns the value at path in a document decoded with
+// encoding/json.
+func GetJSONPath(doc interface{}, path string) (interface{}, error) {
+	steps, err := parseJSONPath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	node := doc
+
+	for _, step := range steps {
+		if step.isIdx {
+			array, ok := node.([]interface{})
+
+			if !ok || step.index >= len(array) {
+				return nil, fmt.Errorf("%s: no index %d", path, step.index)
+			}
+
+			node = array[step.index]
+			continue
+		}
+
+		object, ok := node.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("%s: no field %s", path, step.key)
+		}
+
+		node, ok = object[step.key]
+
+		if !ok {
+			return nil, fmt.Errorf("%s: no field %s", path, step.key)
+		}
+	}
+
+	return node, nil
+}
+
+// JSONField returns the field at path of a JSON-valued secret. Strings are
+// returned as they are and other values as JSON.
+func JSONField(value string, path string) (string, error) {
+	var doc interface{}
+
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return "", fmt.Errorf("the value is not JSON: %s", err.Error())
+	}
+
+	field, err := GetJSONPath(doc, path)
+
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := field.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(field)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,79 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	. "gcredstash"
+	"testing"
+)
+
+func TestSetJSONPath(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		value    string
+		expected string
+	}{
+		{".password", "s3cr3t", `{"hosts":["a","b"],"password":"s3cr3t","port":5432}`},
+		{"port", "6432", `{"hosts":["a","b"],"password":"old","port":6432}`},
+		{"$.hosts[1]", "c", `{"hosts":["a","c"],"password":"old","port":5432}`},
+		{"hosts[2]", "c", `{"hosts":["a","b","c"],"password":"old","port":5432}`},
+		{"replica.host", "r", `{"hosts":["a","b"],"password":"old","port":5432,"replica":{"host":"r"}}`},
+		{`["password"]`, `"123"`, `{"hosts":["a","b"],"password":"123","port":5432}`},
+	} {
+		var doc interface{}
+		json.Unmarshal([]byte(`{"password":"old","port":5432,"hosts":["a","b"]}`), &doc)
+
+		doc, err := SetJSONPath(doc, tc.path, ParseJSONValue(tc.value))
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		actual, _ := json.Marshal(doc)
+
+		if string(actual) != tc.expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, string(actual))
+		}
+	}
+}
+
+func TestSetJSONPathErrors(t *testing.T) {
+	for _, path := range []string{"", "$", "a..b", "hosts[5]", "port.x", "hosts[x]", "hosts[1"} {
+		var doc interface{}
+		json.Unmarshal([]byte(`{"port":5432,"hosts":["a","b"]}`), &doc)
+
+		_, err := SetJSONPath(doc, path, "x")
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", path)
+		}
+	}
+}
+
+func TestJSONField(t *testing.T) {
+	value := `{"password":"s3cr3t","port":5432,"hosts":["a","b"],"replica":{"host":"r"}}`
+
+	for path, expected := range map[string]string{
+		".password":     "s3cr3t",
+		"port":          "5432",
+		"hosts":         `["a","b"]`,
+		"$.hosts[1]":    "b",
+		".replica.host": "r",
+		".replica":      `{"host":"r"}`,
+	} {
+		actual, err := JSONField(value, path)
+
+		if actual != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, actual, err)
+		}
+	}
+
+	for _, path := range []string{".user", "hosts[2]", "port.x"} {
+		if _, err := JSONField(value, path); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", path)
+		}
+	}
+
+	if _, err := JSONField("not json", ".password"); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
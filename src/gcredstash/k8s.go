@@ -0,0 +1,57 @@
+package gcredstash
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const K8S_HASH_ANNOTATION = "gcredstash/checksum"
+
+var k8sSecretKey = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+func K8sSecretManifest(name string, namespace string, data map[string]string, hashAnnotation bool) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("secret name is required")
+	}
+
+	keys := sortedEnvKeys(data)
+	entries := []string{}
+
+	for _, key := range keys {
+		if !k8sSecretKey.MatchString(key) {
+			return "", fmt.Errorf("%s: invalid Secret key", key)
+		}
+
+		entries = append(entries, fmt.Sprintf("  %q: %s", key, B64EncodeStr(data[key])))
+	}
+
+	lines := []string{
+		"apiVersion: v1",
+		"kind: Secret",
+		"metadata:",
+		fmt.Sprintf("  name: %q", name),
+	}
+
+	if namespace != "" {
+		lines = append(lines, fmt.Sprintf("  namespace: %q", namespace))
+	}
+
+	if hashAnnotation {
+		checksum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+		lines = append(lines, "  annotations:")
+		lines = append(lines, fmt.Sprintf("    %s: %q", K8S_HASH_ANNOTATION, HexEncode(checksum[:])))
+	}
+
+	lines = append(lines, "type: Opaque")
+
+	if len(entries) == 0 {
+		lines = append(lines, "data: {}")
+	} else {
+		lines = append(lines, "data:")
+		lines = append(lines, entries...)
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
@@ -0,0 +1,52 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"strings"
+	"testing"
+)
+
+func TestK8sSecretManifest(t *testing.T) {
+	data := map[string]string{"app.b": "2", "app.a": "1"}
+
+	expected := `apiVersion: v1
+kind: Secret
+metadata:
+  name: "mysecret"
+  namespace: "prod"
+type: Opaque
+data:
+  "app.a": MQ==
+  "app.b": Mg==
+`
+	actual, err := K8sSecretManifest("mysecret", "prod", data, false)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if actual != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestK8sSecretManifestWithHashAnnotation(t *testing.T) {
+	before, _ := K8sSecretManifest("mysecret", "", map[string]string{"a": "1"}, true)
+	after, _ := K8sSecretManifest("mysecret", "", map[string]string{"a": "2"}, true)
+
+	if !strings.Contains(before, "gcredstash/checksum: ") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "checksum annotation", before)
+	}
+
+	if before == after {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "different checksums", after)
+	}
+}
+
+func TestK8sSecretManifestWithInvalidKey(t *testing.T) {
+	_, err := K8sSecretManifest("mysecret", "", map[string]string{"a/b": "1"}, false)
+
+	if err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
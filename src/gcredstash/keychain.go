@@ -0,0 +1,248 @@
+package gcredstash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// KEYCHAIN_SERVICE is the service the items of gcredstash are stored under
+// in the OS keychain.
+const KEYCHAIN_SERVICE = "gcredstash"
+
+var ErrKeychainItemNotFound = errors.New("keychain item not found")
+
+var errUseDpapiKeychain = errors.New("CommandKeychain does not support windows: use DpapiKeychain")
+
+// Keychain keeps small secrets of the user, such as local encryption keys,
+// in the OS keychain. Get returns ErrKeychainItemNotFound for a missing
+// item; deleting one is not an error.
+type Keychain interface {
+	Get(service string, account string) ([]byte, error)
+	Set(service string, account string, secret []byte) error
+	Delete(service string, account string) error
+}
+
+// CommandKeychain is a Keychain on the command-line tool of the OS
+// keychain: security(1) on macOS, and secret-tool(1) of the Secret Service
+// (GNOME Keyring, KWallet) on Linux and the BSDs. Secrets are stored
+// base64-encoded, and are written to the stdin of the tool rather than
+// passed as an argument that other users can read in the process list.
+type CommandKeychain struct {
+	// GOOS selects the tool; runtime.GOOS when empty.
+	GOOS string
+	// Command builds the commands run; exec.Command when nil.
+	Command func(name string, args ...string) *exec.Cmd
+}
+
+// NewOSKeychain returns the Keychain of the current OS: a DpapiKeychain on
+// Windows, and a CommandKeychain elsewhere.
+func NewOSKeychain() Keychain {
+	if runtime.GOOS == "windows" {
+		return &DpapiKeychain{Dir: DefaultDpapiKeychainDir()}
+	}
+
+	return &CommandKeychain{}
+}
+
+func (keychain *CommandKeychain) goos() string {
+	if keychain.GOOS == "" {
+		return runtime.GOOS
+	}
+
+	return keychain.GOOS
+}
+
+func (keychain *CommandKeychain) run(stdin string, name string, args ...string) (string, int, error) {
+	out, _, code, err := keychain.runWithStderr(stdin, name, args...)
+	return out, code, err
+}
+
+func (keychain *CommandKeychain) runWithStderr(stdin string, name string, args ...string) (string, string, int, error) {
+	command := exec.Command
+
+	if keychain.Command != nil {
+		command = keychain.Command
+	}
+
+	cmd := command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		return string(out), stderr.String(), exitErr.ExitCode(), fmt.Errorf("%s: %s", name, strings.TrimSpace(stderr.String()))
+	}
+
+	if err != nil {
+		return "", "", -1, fmt.Errorf("%s: %s (is the OS keychain available?)", name, err.Error())
+	}
+
+	return string(out), stderr.String(), 0, nil
+}
+
+// runSecurity runs one security(1) command given on the stdin of
+// `security -i`, as security only takes a secret as an argument. In that
+// mode security can exit 0 after a failed command, so anything it writes to
+// stderr is an error.
+func (keychain *CommandKeychain) runSecurity(args ...string) error {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	quoted := []string{}
+
+	for _, arg := range args {
+		quoted = append(quoted, `"`+quote.Replace(arg)+`"`)
+	}
+
+	_, stderr, _, err := keychain.runWithStderr(strings.Join(quoted, " ")+"\n", "security", "-i")
+
+	if err == nil && strings.TrimSpace(stderr) != "" {
+		err = fmt.Errorf("security: %s", strings.TrimSpace(stderr))
+	}
+
+	return err
+}
+
+func (keychain *CommandKeychain) Get(service string, account string) ([]byte, error) {
+	var out string
+	var code int
+	var err error
+	var notFound bool
+
+	switch keychain.goos() {
+	case "windows":
+		return nil, errUseDpapiKeychain
+	case "darwin":
+		out, code, err = keychain.run("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+		// errSecItemNotFound
+		notFound = code == 44
+	default:
+		out, code, err = keychain.run("", "secret-tool", "lookup", "service", service, "account", account)
+		notFound = code == 1 && out == ""
+	}
+
+	if notFound {
+		return nil, ErrKeychainItemNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(out))
+
+	if err != nil {
+		return nil, fmt.Errorf("keychain item %s/%s is not base64", service, account)
+	}
+
+	return secret, nil
+}
+
+func (keychain *CommandKeychain) Set(service string, account string, secret []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(secret)
+	var err error
+
+	switch keychain.goos() {
+	case "windows":
+		return errUseDpapiKeychain
+	case "darwin":
+		err = keychain.runSecurity("add-generic-password", "-U", "-s", service, "-a", account, "-w", encoded)
+	default:
+		_, _, err = keychain.run(encoded, "secret-tool", "store", "--label="+service+" "+account, "service", service, "account", account)
+	}
+
+	return err
+}
+
+func (keychain *CommandKeychain) Delete(service string, account string) error {
+	var code int
+	var err error
+
+	switch keychain.goos() {
+	case "windows":
+		return errUseDpapiKeychain
+	case "darwin":
+		_, code, err = keychain.run("", "security", "delete-generic-password", "-s", service, "-a", account)
+
+		if code == 44 {
+			return nil
+		}
+	default:
+		// secret-tool clear succeeds when nothing matches.
+		_, _, err = keychain.run("", "secret-tool", "clear", "service", service, "account", account)
+	}
+
+	return err
+}
+
+// DpapiKeychain is a Keychain for Windows, which has no keychain command:
+// each secret is encrypted with DPAPI for the current user and stored as a
+// file in Dir.
+type DpapiKeychain struct {
+	Dir string
+}
+
+// DefaultDpapiKeychainDir returns gcredstash\keychain in the user's local
+// application data.
+func DefaultDpapiKeychainDir() string {
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "gcredstash", "keychain")
+}
+
+func (keychain *DpapiKeychain) path(service string, account string) string {
+	hash := sha256.Sum256([]byte(service + "\x00" + account))
+	return filepath.Join(keychain.Dir, HexEncode(hash[:]))
+}
+
+func (keychain *DpapiKeychain) Get(service string, account string) ([]byte, error) {
+	protected, err := ioutil.ReadFile(keychain.path(service, account))
+
+	if os.IsNotExist(err) {
+		return nil, ErrKeychainItemNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dpapiUnprotect(protected)
+}
+
+func (keychain *DpapiKeychain) Set(service string, account string, secret []byte) error {
+	protected, err := dpapiProtect(secret)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(keychain.Dir, 0700); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(keychain.path(service, account), protected, 0600)
+}
+
+func (keychain *DpapiKeychain) Delete(service string, account string) error {
+	err := os.Remove(keychain.path(service, account))
+
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,249 @@
+package gcredstash
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"sort"
+	"time"
+)
+
+type keychainCacheEntry struct {
+	Value     string `json:"value"`
+	Version   string `json:"version"`
+	Context   string `json:"context"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// KeychainCache keeps decrypted credentials in the OS keychain of the user
+// for TTL, so that repeated reads on a developer machine make no AWS calls.
+// There is one entry per table, name and version ("" for the latest);
+// entries for another encryption context are misses. Expired entries are
+// deleted when they are read. See Driver.KeychainCache.
+type KeychainCache struct {
+	Keychain Keychain
+	TTL      time.Duration
+	Now      func() time.Time
+}
+
+// ParseKeychainCacheTTL parses the keychain_cache_ttl setting, a duration
+// such as 1h. Empty or 0 disables the cache.
+func ParseKeychainCacheTTL(str string) (time.Duration, error) {
+	if str == "" || str == "0" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(str)
+
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid keychain_cache_ttl: %s (must be a duration such as 1h, or 0)", str)
+	}
+
+	return ttl, nil
+}
+
+func NewKeychainCache(keychain Keychain, ttl time.Duration) *KeychainCache {
+	return &KeychainCache{Keychain: keychain, TTL: ttl}
+}
+
+func (cache *KeychainCache) now() time.Time {
+	if cache.Now == nil {
+		return time.Now()
+	}
+
+	return cache.Now()
+}
+
+func keychainCacheAccount(table string, name string, version string) string {
+	if version == "" {
+		return "secret:" + table + "/" + name
+	}
+
+	return "secret:" + table + "/" + name + "@" + version
+}
+
+func keychainCacheContext(context map[string]string) string {
+	keys := []string{}
+
+	for k := range context {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	hash := sha256.New()
+
+	for _, k := range keys {
+		hash.Write([]byte(k + "\x00" + context[k] + "\x00"))
+	}
+
+	return HexEncode(hash.Sum(nil))
+}
+
+// Get returns the cached value and version of name, if it has not expired.
+func (cache *KeychainCache) Get(table string, name string, version string, context map[string]string) ([]byte, string, bool) {
+	account := keychainCacheAccount(table, name, version)
+	content, err := cache.Keychain.Get(KEYCHAIN_SERVICE, account)
+
+	if err != nil {
+		return nil, "", false
+	}
+
+	defer Wipe(content)
+
+	entry := &keychainCacheEntry{}
+
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, "", false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, entry.ExpiresAt)
+
+	if err != nil || !cache.now().Before(expiresAt) {
+		cache.Keychain.Delete(KEYCHAIN_SERVICE, account)
+		return nil, "", false
+	}
+
+	if entry.Context != keychainCacheContext(context) {
+		return nil, "", false
+	}
+
+	return B64Decode(entry.Value), entry.Version, true
+}
+
+// Put caches the value of name for TTL, but not past expiresAt unless it
+// is zero.
+func (cache *KeychainCache) Put(table string, name string, version string, context map[string]string, value []byte, storedVersion string, expiresAt time.Time) error {
+	expires := cache.now().Add(cache.TTL)
+
+	if !expiresAt.IsZero() && expiresAt.Before(expires) {
+		expires = expiresAt
+	}
+
+	content, err := json.Marshal(&keychainCacheEntry{
+		Value:     B64Encode(value),
+		Version:   storedVersion,
+		Context:   keychainCacheContext(context),
+		ExpiresAt: expires.UTC().Format(time.RFC3339),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	defer Wipe(content)
+
+	return cache.Keychain.Set(KEYCHAIN_SERVICE, keychainCacheAccount(table, name, version), content)
+}
+
+// Invalidate drops the cached latest value of name, and version unless it
+// is "".
+func (cache *KeychainCache) Invalidate(table string, name string, version string) error {
+	if err := cache.Keychain.Delete(KEYCHAIN_SERVICE, keychainCacheAccount(table, name, "")); err != nil || version == "" {
+		return err
+	}
+
+	return cache.Keychain.Delete(KEYCHAIN_SERVICE, keychainCacheAccount(table, name, version))
+}
+
+// keychainCacheBackend drops the cached values of the credentials written
+// through it from the KeychainCache.
+type keychainCacheBackend struct {
+	Backend
+	Cache  *KeychainCache
+	Logger Logger
+}
+
+func (backend *keychainCacheBackend) invalidate(table string, name string, version string) {
+	if err := backend.Cache.Invalidate(table, name, version); err != nil {
+		backend.Logger.Warnf("%s: could not drop the cached value: %s", name, err.Error())
+	}
+}
+
+func (backend *keychainCacheBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	err := backend.Backend.PutItem(table, item)
+
+	if err == nil {
+		backend.invalidate(table, stringAttr(item, "name"), "")
+	}
+
+	return err
+}
+
+func (backend *keychainCacheBackend) Delete(table string, name string, version string) error {
+	err := backend.Backend.Delete(table, name, version)
+
+	if err == nil {
+		backend.invalidate(table, name, version)
+	}
+
+	return err
+}
+
+func (backend *keychainCacheBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	transactional, ok := backend.Backend.(TransactionalBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	err := transactional.PutItems(table, items)
+
+	if err == nil {
+		for _, item := range items {
+			backend.invalidate(table, stringAttr(item, "name"), "")
+		}
+	}
+
+	return err
+}
+
+// ReplaceItem drops the version as well, as soft deletes and restores
+// replace an existing version.
+func (backend *keychainCacheBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	replacing, ok := backend.Backend.(ReplacingBackend)
+
+	if !ok {
+		return errSoftDeleteUnsupported
+	}
+
+	err := replacing.ReplaceItem(table, item)
+
+	if err == nil {
+		backend.invalidate(table, stringAttr(item, "name"), stringAttr(item, "version"))
+	}
+
+	return err
+}
+
+// keychainCached returns the value of name from KeychainCache, if it is
+// there. Names are namespaced, as keychainCacheBackend sees them.
+func (driver *Driver) keychainCached(name string, version string, table string, context map[string]string) ([]byte, string, bool) {
+	if driver.KeychainCache == nil {
+		return nil, "", false
+	}
+
+	value, cachedVersion, ok := driver.KeychainCache.Get(table, driver.Namespace+name, version, context)
+
+	if ok {
+		driver.logger().Verbosef("get name=%s version=%s from the keychain cache", name, cachedVersion)
+	}
+
+	return value, cachedVersion, ok
+}
+
+// keepInKeychain caches a value read from material in KeychainCache,
+// unless it is passphrase protected. Failures only warn, as the read itself
+// succeeded.
+func (driver *Driver) keepInKeychain(name string, version string, table string, context map[string]string, material map[string]*dynamodb.AttributeValue, value []byte) {
+	if driver.KeychainCache == nil || IsPassphraseProtected(material) {
+		return
+	}
+
+	expiresAt, _ := time.Parse(time.RFC3339, stringAttr(material, "expires_at"))
+	err := driver.KeychainCache.Put(table, driver.Namespace+name, version, context, value, stringAttr(material, "version"), expiresAt)
+
+	if err != nil {
+		driver.logger().Warnf("%s: could not cache the value in the keychain: %s", name, err.Error())
+	}
+}
@@ -0,0 +1,145 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestKeychainCache(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	keychain := memoryKeychain{}
+	cache := NewKeychainCache(keychain, time.Hour)
+	cache.Now = func() time.Time { return now }
+	context := map[string]string{"env": "prod"}
+
+	if _, _, ok := cache.Get("credential-store", "db.pass", "", context); ok {
+		t.Errorf("\nexpected: %v\ngot: %v\n", false, ok)
+	}
+
+	cache.Put("credential-store", "db.pass", "", context, []byte("100"), VersionNumToStr(3), time.Time{})
+	value, version, ok := cache.Get("credential-store", "db.pass", "", context)
+
+	if string(value) != "100" || version != VersionNumToStr(3) || !ok {
+		t.Errorf("\nexpected: %v\ngot: %v %v %v\n", "100", string(value), version, ok)
+	}
+
+	if _, _, ok := cache.Get("credential-store", "db.pass", "", map[string]string{"env": "dev"}); ok {
+		t.Errorf("\nexpected: %v\ngot: %v\n", false, ok)
+	}
+
+	if _, _, ok := cache.Get("credential-store", "db.pass", VersionNumToStr(3), context); ok {
+		t.Errorf("\nexpected: %v\ngot: %v\n", false, ok)
+	}
+
+	now = now.Add(time.Hour)
+
+	if _, _, ok := cache.Get("credential-store", "db.pass", "", context); ok || len(keychain) != 0 {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "an expired entry deleted", ok, keychain)
+	}
+
+	cache.Put("credential-store", "db.pass", "", context, []byte("100"), VersionNumToStr(3), now.Add(time.Minute))
+	now = now.Add(2 * time.Minute)
+
+	if _, _, ok := cache.Get("credential-store", "db.pass", "", context); ok {
+		t.Errorf("\nexpected: %v\ngot: %v\n", false, ok)
+	}
+}
+
+func TestParseKeychainCacheTTL(t *testing.T) {
+	for str, expected := range map[string]time.Duration{"": 0, "0": 0, "90m": 90 * time.Minute} {
+		if ttl, err := ParseKeychainCacheTTL(str); ttl != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, ttl, err)
+		}
+	}
+
+	_, err := ParseKeychainCacheTTL("-1h")
+	expected := "invalid keychain_cache_ttl: -1h (must be a duration such as 1h, or 0)"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestDriverKeychainCache(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		svc := &unavailableKms{LocalKms: driver.Kms.(*LocalKms)}
+		keychain := memoryKeychain{}
+		driver.Kms = svc
+		driver.KeychainCache = NewKeychainCache(keychain, time.Hour)
+
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		if value, err := driver.GetSecret("db.pass", "", table, nil); value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+
+		svc.down = true
+
+		if value, err := driver.GetSecret("db.pass", "", table, nil); value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+
+		driver.PutSecret("db.pass", "200", VersionNumToStr(2), "alias/credstash", table, nil)
+
+		if _, err := driver.GetSecret("db.pass", "", table, nil); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "a KMS error after the put", err)
+		}
+
+		svc.down = false
+
+		if value, err := driver.GetSecret("db.pass", "", table, nil); value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+
+		opts := &PutOptions{Passphrase: []byte("correct horse")}
+		driver.PutSecretWithOptions("root.key", "s3cr3t", VersionNumToStr(1), "alias/credstash", table, nil, opts)
+		driver.Passphrase = func(name string) ([]byte, error) { return []byte("correct horse"), nil }
+
+		if value, err := driver.GetSecret("root.key", "", table, nil); value != "s3cr3t" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "s3cr3t", value, err)
+		}
+
+		if _, err := keychain.Get(KEYCHAIN_SERVICE, "secret:credential-store/root.key"); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrKeychainItemNotFound, err)
+		}
+	})
+}
+
+func TestDriverKeychainCacheWrites(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		keychain := memoryKeychain{}
+		driver.KeychainCache = NewKeychainCache(keychain, time.Hour)
+
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.GetSecret("db.pass", "", table, nil)
+		driver.GetSecret("db.pass", VersionNumToStr(1), table, nil)
+
+		_, err := driver.PutSecrets(map[string]string{"db.pass": "200", "db.user": "app"}, VersionNumToStr(2), "alias/credstash", table, nil, nil)
+
+		if value, _ := driver.GetSecret("db.pass", "", table, nil); value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+
+		driver.SoftDelete = true
+		err = driver.DeleteItem("db.pass", VersionNumToStr(1), table)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		if _, err := keychain.Get(KEYCHAIN_SERVICE, "secret:credential-store/db.pass@"+VersionNumToStr(1)); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrKeychainItemNotFound, err)
+		}
+
+		if _, err := driver.GetSecret("db.pass", VersionNumToStr(1), table, nil); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "a deleted version", err)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// scriptKeychain is a CommandKeychain that runs script instead of the
+// keychain tool, and records the commands.
+func scriptKeychain(goos string, script string, commands *[]string) *CommandKeychain {
+	return &CommandKeychain{
+		GOOS: goos,
+		Command: func(name string, args ...string) *exec.Cmd {
+			*commands = append(*commands, name+" "+strings.Join(args, " "))
+			return exec.Command("sh", "-c", script)
+		},
+	}
+}
+
+func TestCommandKeychain(t *testing.T) {
+	commands := []string{}
+	keychain := scriptKeychain("darwin", "echo c2VjcmV0", &commands)
+	secret, err := keychain.Get("gcredstash", "data-key-cache")
+
+	if string(secret) != "secret" || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "secret", string(secret), err)
+	}
+
+	keychain = scriptKeychain("darwin", `test "$(cat)" = '"add-generic-password" "-U" "-s" "gcredstash" "-a" "data-key-cache" "-w" "c2VjcmV0"'`, &commands)
+
+	if err := keychain.Set("gcredstash", "data-key-cache", []byte("secret")); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := []string{
+		"security find-generic-password -s gcredstash -a data-key-cache -w",
+		"security -i",
+	}
+
+	if strings.Join(commands, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, commands)
+	}
+
+	commands = []string{}
+	keychain = scriptKeychain("linux", `test "$(cat)" = c2VjcmV0`, &commands)
+
+	if err := keychain.Set("gcredstash", "data-key-cache", []byte("secret")); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected = []string{"secret-tool store --label=gcredstash data-key-cache service gcredstash account data-key-cache"}
+
+	if strings.Join(commands, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, commands)
+	}
+
+	for goos, script := range map[string]string{"darwin": "exit 44", "linux": "exit 1"} {
+		_, err := scriptKeychain(goos, script, &commands).Get("gcredstash", "data-key-cache")
+
+		if !errors.Is(err, ErrKeychainItemNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrKeychainItemNotFound, err)
+		}
+	}
+
+	err = scriptKeychain("darwin", "cat >/dev/null; echo 'SecKeychainItemCreateFromContent: User interaction is not allowed.' >&2", &commands).Set("gcredstash", "data-key-cache", []byte("secret"))
+
+	if err == nil || err.Error() != "security: SecKeychainItemCreateFromContent: User interaction is not allowed." {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "security: SecKeychainItemCreateFromContent: User interaction is not allowed.", err)
+	}
+
+	_, err = scriptKeychain("linux", "echo locked >&2; exit 2", &commands).Get("gcredstash", "data-key-cache")
+
+	if err == nil || err.Error() != "secret-tool: locked" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "secret-tool: locked", err)
+	}
+}
+
+func TestCommandKeychainDelete(t *testing.T) {
+	commands := []string{}
+
+	if err := scriptKeychain("darwin", "exit 44", &commands).Delete("gcredstash", "secret:credential-store/db.password"); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if err := scriptKeychain("linux", "true", &commands).Delete("gcredstash", "secret:credential-store/db.password"); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	expected := []string{
+		"security delete-generic-password -s gcredstash -a secret:credential-store/db.password",
+		"secret-tool clear service gcredstash account secret:credential-store/db.password",
+	}
+
+	if strings.Join(commands, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, commands)
+	}
+}
@@ -7,10 +7,28 @@ import (
 )
 
 func KmsDecrypt(svc kmsiface.KMSAPI, blob []byte, context map[string]string) ([]byte, []byte, error) {
+	dataKey, hmacKey, _, err := KmsDecryptWithKeyId(svc, blob, context)
+
+	return dataKey, hmacKey, err
+}
+
+// KmsDecryptWithKeyId is KmsDecrypt that also returns the ARN of the KMS key
+// that protected the data key, or "" when KMS does not report it.
+func KmsDecryptWithKeyId(svc kmsiface.KMSAPI, blob []byte, context map[string]string) ([]byte, []byte, string, error) {
+	return KmsDecryptWithKey(svc, "", blob, context)
+}
+
+// KmsDecryptWithKey is KmsDecryptWithKeyId that, unless keyId is empty,
+// makes KMS refuse a data key protected by any other key.
+func KmsDecryptWithKey(svc kmsiface.KMSAPI, keyId string, blob []byte, context map[string]string) ([]byte, []byte, string, error) {
 	params := &kms.DecryptInput{
 		CiphertextBlob: blob,
 	}
 
+	if keyId != "" {
+		params.KeyId = aws.String(keyId)
+	}
+
 	if len(context) > 0 {
 		ctx := map[string]*string{}
 
@@ -24,13 +42,13 @@ func KmsDecrypt(svc kmsiface.KMSAPI, blob []byte, context map[string]string) ([]
 	resp, err := svc.Decrypt(params)
 
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	dataKey := resp.Plaintext[:32]
 	hmacKey := resp.Plaintext[32:]
 
-	return dataKey, hmacKey, nil
+	return dataKey, hmacKey, aws.StringValue(resp.KeyId), nil
 }
 
 func KmsGenerateDataKey(svc kmsiface.KMSAPI, keyId string, context map[string]string) ([]byte, []byte, []byte, error) {
@@ -61,3 +79,108 @@ func KmsGenerateDataKey(svc kmsiface.KMSAPI, keyId string, context map[string]st
 
 	return dataKey, hmacKey, wrappedKey, nil
 }
+
+// KmsEncrypt wraps plaintext, such as a data key, with another KMS key.
+func KmsEncrypt(svc kmsiface.KMSAPI, keyId string, plaintext []byte, context map[string]string) ([]byte, error) {
+	params := &kms.EncryptInput{
+		KeyId:     aws.String(keyId),
+		Plaintext: plaintext,
+	}
+
+	if len(context) > 0 {
+		ctx := map[string]*string{}
+
+		for key, value := range context {
+			ctx[key] = aws.String(value)
+		}
+
+		params.EncryptionContext = ctx
+	}
+
+	resp, err := svc.Encrypt(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CiphertextBlob, nil
+}
+
+func KmsDescribeKey(svc kmsiface.KMSAPI, keyId string) (*kms.KeyMetadata, error) {
+	params := &kms.DescribeKeyInput{
+		KeyId: aws.String(keyId),
+	}
+
+	resp, err := svc.DescribeKey(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.KeyMetadata, nil
+}
+
+func KmsDescribeKeyArn(svc kmsiface.KMSAPI, keyId string) (string, error) {
+	metadata, err := KmsDescribeKey(svc, keyId)
+
+	if err != nil {
+		return "", err
+	}
+
+	return *metadata.Arn, nil
+}
+
+func KmsGetKeyRotationStatus(svc kmsiface.KMSAPI, keyId string) (bool, error) {
+	params := &kms.GetKeyRotationStatusInput{
+		KeyId: aws.String(keyId),
+	}
+
+	resp, err := svc.GetKeyRotationStatus(params)
+
+	if err != nil {
+		return false, err
+	}
+
+	return *resp.KeyRotationEnabled, nil
+}
+
+func KmsListGrants(svc kmsiface.KMSAPI, keyId string) ([]*kms.GrantListEntry, error) {
+	grants := []*kms.GrantListEntry{}
+
+	params := &kms.ListGrantsInput{
+		KeyId: aws.String(keyId),
+	}
+
+	err := svc.ListGrantsPages(params, func(page *kms.ListGrantsResponse, lastPage bool) bool {
+		grants = append(grants, page.Grants...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+func KmsCreateGrant(svc kmsiface.KMSAPI, keyId string, grantee string, operations []string) (string, error) {
+	ops := []*string{}
+
+	for _, op := range operations {
+		ops = append(ops, aws.String(op))
+	}
+
+	params := &kms.CreateGrantInput{
+		KeyId:            aws.String(keyId),
+		GranteePrincipal: aws.String(grantee),
+		Operations:       ops,
+	}
+
+	resp, err := svc.CreateGrant(params)
+
+	if err != nil {
+		return "", err
+	}
+
+	return *resp.GrantId, nil
+}
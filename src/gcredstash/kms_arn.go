@@ -0,0 +1,148 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"regexp"
+	"strings"
+)
+
+// KmsKeyArn is a KMS key given by its full ARN, which is the only way to
+// use a key of another account.
+type KmsKeyArn struct {
+	Partition string
+	Region    string
+	Account   string
+	// Resource is key/ID or alias/NAME.
+	Resource string
+}
+
+var kmsAccountRegexp = regexp.MustCompile(`^[0-9]{12}$`)
+
+// ParseKmsKeyArn parses a KMS key ARN. Key IDs and alias names, which
+// refer to the caller's own account, are not ARNs and return nil.
+func ParseKmsKeyArn(kmsKey string) (*KmsKeyArn, error) {
+	if !strings.HasPrefix(kmsKey, "arn:") {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(kmsKey, ":", 6)
+
+	if len(parts) != 6 || parts[1] == "" || parts[2] != "kms" || parts[3] == "" ||
+		!kmsAccountRegexp.MatchString(parts[4]) ||
+		!(strings.HasPrefix(parts[5], "key/") || strings.HasPrefix(parts[5], "alias/")) ||
+		strings.HasSuffix(parts[5], "/") {
+		return nil, fmt.Errorf("invalid KMS key ARN: %s (expected arn:PARTITION:kms:REGION:ACCOUNT:key/ID or alias/NAME)", kmsKey)
+	}
+
+	return &KmsKeyArn{Partition: parts[1], Region: parts[3], Account: parts[4], Resource: parts[5]}, nil
+}
+
+// ValidateKmsKey checks the syntax of a KMS key given as an ARN.
+func ValidateKmsKey(kmsKey string) error {
+	_, err := ParseKmsKeyArn(kmsKey)
+	return err
+}
+
+// KmsAccessDeniedError is returned when KMS refuses GenerateDataKey (on
+// put) or Decrypt (on get) for lack of permission. Arn is the key's ARN
+// when AWS reported it. Account is set when the key belongs to another
+// account than the caller's, in which case the key policy there has to
+// allow the operation too. IsAccessDenied reports it.
+type KmsAccessDeniedError struct {
+	Name      string
+	Operation string
+	KmsKey    string
+	Arn       string
+	Account   string
+	Err       error
+}
+
+func (e *KmsAccessDeniedError) Error() string {
+	msg := e.Operation + " was denied"
+
+	if e.Name != "" {
+		msg = e.Name + ": " + msg
+	}
+
+	switch {
+	case e.KmsKey != "" && e.Arn != "" && e.Arn != e.KmsKey:
+		msg += fmt.Sprintf(" on KMS key(%s) (%s)", e.KmsKey, e.Arn)
+	case e.KmsKey != "":
+		msg += fmt.Sprintf(" on KMS key(%s)", e.KmsKey)
+	case e.Arn != "":
+		msg += fmt.Sprintf(" on KMS key(%s)", e.Arn)
+	}
+
+	if e.Account != "" {
+		return msg + fmt.Sprintf(", which is in account %s: its key policy must allow %s for the caller, not only the caller's IAM policy", e.Account, e.Operation)
+	}
+
+	return msg + ": the key policy, or the caller's IAM policy where the key policy defers to IAM, must allow it"
+}
+
+func (e *KmsAccessDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// callerAccount returns the account of the AWS identity in use, or "" when
+// it is not known.
+func (driver *Driver) callerAccount() string {
+	parts := strings.SplitN(driver.caller(), ":", 6)
+
+	if len(parts) != 6 || !strings.HasPrefix(parts[0], "arn") {
+		return ""
+	}
+
+	return parts[4]
+}
+
+// kmsRegion returns the region KMS requests are sent to, or "" when it is
+// not known, e.g. for LocalKms.
+func (driver *Driver) kmsRegion() string {
+	svc, ok := driver.Kms.(*kms.KMS)
+
+	if !ok || svc.Client == nil {
+		return ""
+	}
+
+	return aws.StringValue(svc.Client.Config.Region)
+}
+
+// preflightKmsKey checks a KMS key before it is used, without calling AWS:
+// an ARN must be well-formed and in the region of the KMS client, as KMS
+// keys cannot be used from another region.
+func (driver *Driver) preflightKmsKey(kmsKey string) error {
+	arn, err := ParseKmsKeyArn(kmsKey)
+
+	if err != nil || arn == nil {
+		return err
+	}
+
+	if region := driver.kmsRegion(); region != "" && region != arn.Region {
+		return fmt.Errorf("KMS key(%s) is in %s, but KMS requests go to %s: a KMS key can only be used in its own region", kmsKey, arn.Region, region)
+	}
+
+	return nil
+}
+
+// kmsAccessError turns an access denial of operation on kmsKey into a
+// *KmsAccessDeniedError and returns other errors as they are.
+func (driver *Driver) kmsAccessError(name string, operation string, kmsKey string, err error) error {
+	if !IsAccessDenied(err) {
+		return err
+	}
+
+	denied := &KmsAccessDeniedError{Name: name, Operation: operation, KmsKey: kmsKey, Err: err}
+
+	if typed, ok := NewAccessDeniedError(operation, kmsKey, err).(*AccessDeniedError); ok && strings.HasPrefix(typed.Resource, "arn:") {
+		denied.Arn = typed.Resource
+	}
+
+	if arn, _ := ParseKmsKeyArn(kmsKey); arn != nil && arn.Account != driver.callerAccount() {
+		denied.Account = arn.Account
+	}
+
+	return denied
+}
@@ -0,0 +1,163 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseKmsKeyArn(t *testing.T) {
+	cases := map[string]*KmsKeyArn{
+		"alias/credstash":                      nil,
+		"1234abcd-12ab-34cd-56ef-1234567890ab": nil,
+		"arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab": {
+			Partition: "aws", Region: "us-east-1", Account: "111122223333", Resource: "key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		"arn:aws-cn:kms:cn-north-1:111122223333:alias/credstash": {
+			Partition: "aws-cn", Region: "cn-north-1", Account: "111122223333", Resource: "alias/credstash",
+		},
+	}
+
+	for key, expected := range cases {
+		arn, err := ParseKmsKeyArn(key)
+
+		if !reflect.DeepEqual(arn, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, arn, err)
+		}
+	}
+
+	for _, key := range []string{
+		"arn:aws:kms:us-east-1:111122223333",
+		"arn:aws:s3:us-east-1:111122223333:key/1234",
+		"arn:aws:kms::111122223333:key/1234",
+		"arn:aws:kms:us-east-1:1111:key/1234",
+		"arn:aws:kms:us-east-1:111122223333:credstash",
+		"arn:aws:kms:us-east-1:111122223333:alias/",
+	} {
+		err := ValidateKmsKey(key)
+		expected := fmt.Sprintf("invalid KMS key ARN: %s (expected arn:PARTITION:kms:REGION:ACCOUNT:key/ID or alias/NAME)", key)
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	}
+}
+
+type denyingKms struct {
+	*LocalKms
+	denyGenerate bool
+	readKey      string
+}
+
+func (svc *denyingKms) GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	if svc.denyGenerate {
+		return nil, awserr.New("AccessDeniedException", "not authorized to perform kms:GenerateDataKey", nil)
+	}
+
+	return svc.LocalKms.GenerateDataKey(input)
+}
+
+func (svc *denyingKms) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	if svc.readKey == "" {
+		return nil, awserr.New("AccessDeniedException", "not authorized to perform kms:Decrypt", nil)
+	}
+
+	if aws.StringValue(input.KeyId) != svc.readKey {
+		return nil, awserr.New("IncorrectKeyException", "the key ID in the request does not match", nil)
+	}
+
+	return svc.LocalKms.Decrypt(input)
+}
+
+func TestKmsAccessDenied(t *testing.T) {
+	writeKey := "arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	readKey := "arn:aws:kms:us-east-1:111122223333:key/0987dcba-09fe-87dc-65ba-ab0987654321"
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		svc := &denyingKms{LocalKms: driver.Kms.(*LocalKms), denyGenerate: true}
+		driver.Kms = svc
+
+		err := driver.PutSecret("db.pass", "100", VersionNumToStr(1), writeKey, table, nil)
+		denied := &KmsAccessDeniedError{}
+
+		if !errors.As(err, &denied) || denied.Operation != "kms:GenerateDataKey" || denied.Account != "111122223333" || !IsAccessDenied(err) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "kms:GenerateDataKey was denied", err)
+		}
+
+		expected := "db.pass: kms:GenerateDataKey was denied on KMS key(" + writeKey + "), which is in account 111122223333: " +
+			"its key policy must allow kms:GenerateDataKey for the caller, not only the caller's IAM policy"
+
+		if err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		svc.denyGenerate = false
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), writeKey, table, nil)
+
+		_, err = driver.GetSecret("db.pass", "", table, nil)
+
+		if !errors.As(err, &denied) || denied.Operation != "kms:Decrypt" || denied.Account != "" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "kms:Decrypt was denied", err)
+		}
+
+		svc.readKey = readKey
+		driver.ReadKmsKey = writeKey
+		_, err = driver.GetSecret("db.pass", "", table, nil)
+		expected = "db.pass: was not encrypted with the read KMS key(" + writeKey + ")"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+
+		driver.ReadKmsKey = readKey
+		value, err := driver.GetSecret("db.pass", "", table, nil)
+
+		if value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+	})
+}
+
+func TestKmsKeyRegionPreflight(t *testing.T) {
+	awsSession := session.Must(session.NewSession(&aws.Config{Region: aws.String("us-east-1")}))
+
+	testutils.TempFile("", func(f *os.File) {
+		driver := &Driver{Backend: &FileBackend{Path: f.Name()}, Kms: kms.New(awsSession), Logger: &NopLogger{}}
+		key := "arn:aws:kms:eu-west-1:111122223333:alias/credstash"
+
+		err := driver.PutSecret("db.pass", "100", VersionNumToStr(1), key, "credential-store", nil)
+
+		if err == nil || !strings.Contains(err.Error(), "is in eu-west-1, but KMS requests go to us-east-1") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "a region mismatch", err)
+		}
+	})
+}
+
+func TestKmsAccessDeniedErrorArn(t *testing.T) {
+	arn := "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	err := &KmsAccessDeniedError{Name: "db.pass", Operation: "kms:Decrypt", KmsKey: "alias/credstash", Arn: arn}
+	expected := "db.pass: kms:Decrypt was denied on KMS key(alias/credstash) (" + arn + "): " +
+		"the key policy, or the caller's IAM policy where the key policy defers to IAM, must allow it"
+
+	if err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+
+	err.KmsKey = ""
+	expected = "db.pass: kms:Decrypt was denied on KMS key(" + arn + "): " +
+		"the key policy, or the caller's IAM policy where the key policy defers to IAM, must allow it"
+
+	if err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
@@ -0,0 +1,114 @@
+package gcredstash
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"io"
+)
+
+// LocalKms stands in for KMS with a locally held 256-bit master key. Data
+// keys are wrapped with AES-GCM under a key derived from the master key and
+// the encryption context, so a mismatched context fails to decrypt.
+// Only GenerateDataKey, Encrypt and Decrypt are implemented; the key and
+// grant calls made by keys, grant and policy return an error.
+type LocalKms struct {
+	kmsiface.KMSAPI
+	MasterKey []byte
+}
+
+func NewLocalKms(encodedKey string) (*LocalKms, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key: %s", err.Error())
+	}
+
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid master key: expected 32 bytes, got %d", len(key))
+	}
+
+	return &LocalKms{MasterKey: key}, nil
+}
+
+func (svc *LocalKms) wrappingKey(context map[string]*string) []byte {
+	ctx := map[string]string{}
+
+	for key, value := range context {
+		ctx[key] = aws.StringValue(value)
+	}
+
+	return Digest([]byte(MapToJson(ctx)), svc.MasterKey)
+}
+
+func (svc *LocalKms) GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	plaintext := make([]byte, aws.Int64Value(input.NumberOfBytes))
+
+	if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+		return nil, err
+	}
+
+	wrappingKey := svc.wrappingKey(input.EncryptionContext)
+	defer Wipe(wrappingKey)
+
+	blob, err := GcmEncrypt(plaintext, wrappingKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &kms.GenerateDataKeyOutput{
+		CiphertextBlob: blob,
+		KeyId:          input.KeyId,
+		Plaintext:      plaintext,
+	}, nil
+}
+
+func (svc *LocalKms) Encrypt(input *kms.EncryptInput) (*kms.EncryptOutput, error) {
+	wrappingKey := svc.wrappingKey(input.EncryptionContext)
+	defer Wipe(wrappingKey)
+
+	blob, err := GcmEncrypt(input.Plaintext, wrappingKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &kms.EncryptOutput{CiphertextBlob: blob, KeyId: input.KeyId}, nil
+}
+
+func (svc *LocalKms) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	wrappingKey := svc.wrappingKey(input.EncryptionContext)
+	defer Wipe(wrappingKey)
+
+	plaintext, err := GcmDecrypt(input.CiphertextBlob, wrappingKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("InvalidCiphertextException: %s", err.Error())
+	}
+
+	return &kms.DecryptOutput{Plaintext: plaintext}, nil
+}
+
+func localKmsUnsupported(operation string) error {
+	return fmt.Errorf("kms:%s is not supported with the file backend's local master key", operation)
+}
+
+func (svc *LocalKms) DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	return nil, localKmsUnsupported("DescribeKey")
+}
+
+func (svc *LocalKms) GetKeyRotationStatus(input *kms.GetKeyRotationStatusInput) (*kms.GetKeyRotationStatusOutput, error) {
+	return nil, localKmsUnsupported("GetKeyRotationStatus")
+}
+
+func (svc *LocalKms) ListGrantsPages(input *kms.ListGrantsInput, fn func(*kms.ListGrantsResponse, bool) bool) error {
+	return localKmsUnsupported("ListGrants")
+}
+
+func (svc *LocalKms) CreateGrant(input *kms.CreateGrantInput) (*kms.CreateGrantOutput, error) {
+	return nil, localKmsUnsupported("CreateGrant")
+}
@@ -83,3 +83,58 @@ func TestKmsGenerateDataKey(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", expectedWrappedKey, wrappedKey)
 	}
 }
+
+func TestKmsDescribeKeyArn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyId := "alias/credstash"
+	expected := "arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4"
+
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	mkms.EXPECT().DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyId),
+	}).Return(&kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{Arn: aws.String(expected)},
+	}, nil)
+
+	actual, err := KmsDescribeKeyArn(mkms, keyId)
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != actual {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
+
+func TestKmsCreateGrant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyId := "arn:aws:kms:us-east-1:123456789012:key/f6ab0c5d-8dc0-4bb6-a5d0-bb0176840bd4"
+	grantee := "arn:aws:iam::123456789012:role/app"
+	expected := "grant-id"
+
+	mkms := mockaws.NewMockKMSAPI(ctrl)
+
+	mkms.EXPECT().CreateGrant(&kms.CreateGrantInput{
+		KeyId:            aws.String(keyId),
+		GranteePrincipal: aws.String(grantee),
+		Operations:       []*string{aws.String("Decrypt"), aws.String("GenerateDataKey")},
+	}).Return(&kms.CreateGrantOutput{
+		GrantId: aws.String(expected),
+	}, nil)
+
+	actual, err := KmsCreateGrant(mkms, keyId, grantee, []string{"Decrypt", "GenerateDataKey"})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if expected != actual {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
@@ -0,0 +1,74 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ryanuber/go-glob"
+	"io/ioutil"
+)
+
+const DEFAULT_LOCK_FILE = "gcredstash.lock"
+
+// LockVersions returns the latest version of every credential matching
+// pattern, to be written with WriteLockFile.
+func (driver *Driver) LockVersions(pattern string, table string) (map[string]string, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+
+	for name, vers := range GroupVersions(items) {
+		if glob.Glob(pattern, name) {
+			versions[name] = vers[len(vers)-1]
+		}
+	}
+
+	return versions, nil
+}
+
+// ReadLockFile reads a lock file, a JSON object mapping credential names to
+// version numbers.
+func ReadLockFile(path string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nums := map[string]int{}
+
+	if err := json.Unmarshal(content, &nums); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	versions := map[string]string{}
+
+	for name, num := range nums {
+		if num < 1 {
+			return nil, fmt.Errorf("%s: invalid version of %s: %d", path, name, num)
+		}
+
+		versions[name] = VersionNumToStr(num)
+	}
+
+	return versions, nil
+}
+
+func WriteLockFile(path string, versions map[string]string) error {
+	nums := map[string]int{}
+
+	for name, version := range versions {
+		nums[name] = Atoi(version)
+	}
+
+	content, err := json.MarshalIndent(nums, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, append(content, '\n'), 0644)
+}
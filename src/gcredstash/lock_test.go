@@ -0,0 +1,59 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLockFile(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		table := "credential-store"
+		driver.PutSecret("foo.bar", "100", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("foo.bar", "200", "0000000000000000002", "alias/credstash", table, nil)
+		driver.PutSecret("foo.baz", "300", "0000000000000000001", "alias/credstash", table, nil)
+		driver.PutSecret("other.key", "400", "0000000000000000001", "alias/credstash", table, nil)
+
+		versions, err := driver.LockVersions("foo.*", table)
+		expected := map[string]string{"foo.bar": "0000000000000000002", "foo.baz": "0000000000000000001"}
+
+		if err != nil || !reflect.DeepEqual(versions, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, versions, err)
+		}
+
+		testutils.TempFile("", func(lock *os.File) {
+			WriteLockFile(lock.Name(), versions)
+			content, _ := ioutil.ReadFile(lock.Name())
+			expectedContent := "{\n  \"foo.bar\": 2,\n  \"foo.baz\": 1\n}\n"
+
+			if string(content) != expectedContent {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expectedContent, string(content))
+			}
+
+			read, err := ReadLockFile(lock.Name())
+
+			if err != nil || !reflect.DeepEqual(read, expected) {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, read, err)
+			}
+
+			driver.PutSecret("foo.bar", "500", "0000000000000000003", "alias/credstash", table, nil)
+			creds, err := driver.GetSecretsWithVersions(read, table, nil)
+			expectedCreds := map[string]string{"foo.bar": "200", "foo.baz": "300"}
+
+			if err != nil || !reflect.DeepEqual(creds, expectedCreds) {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expectedCreds, creds, err)
+			}
+
+			ioutil.WriteFile(lock.Name(), []byte(`{"foo.bar": 0}`), 0644)
+			_, err = ReadLockFile(lock.Name())
+			expectedErr := lock.Name() + ": invalid version of foo.bar: 0"
+
+			if err == nil || err.Error() != expectedErr {
+				t.Errorf("\nexpected: %v\ngot: %v\n", expectedErr, err)
+			}
+		})
+	})
+}
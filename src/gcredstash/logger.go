@@ -0,0 +1,95 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type LogLevel int
+
+const (
+	LOG_LEVEL_INFO LogLevel = iota
+	LOG_LEVEL_VERBOSE
+	LOG_LEVEL_DEBUG
+)
+
+type Logger interface {
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Verbosef(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+}
+
+// StdLogger writes info messages to Out and verbose/debug messages to Err.
+type StdLogger struct {
+	Out   io.Writer
+	Err   io.Writer
+	Level LogLevel
+}
+
+func NewStdLogger(level LogLevel) *StdLogger {
+	return &StdLogger{
+		Out:   os.Stdout,
+		Err:   os.Stderr,
+		Level: level,
+	}
+}
+
+func (logger *StdLogger) Infof(format string, v ...interface{}) {
+	fmt.Fprintln(logger.Out, fmt.Sprintf(format, v...))
+}
+
+// Warnf writes to Err at every level, so that warnings do not mix with
+// command output.
+func (logger *StdLogger) Warnf(format string, v ...interface{}) {
+	logger.write("warn", format, v...)
+}
+
+func (logger *StdLogger) Verbosef(format string, v ...interface{}) {
+	if logger.Level >= LOG_LEVEL_VERBOSE {
+		logger.write("verbose", format, v...)
+	}
+}
+
+func (logger *StdLogger) Debugf(format string, v ...interface{}) {
+	if logger.Level >= LOG_LEVEL_DEBUG {
+		logger.write("debug", format, v...)
+	}
+}
+
+func (logger *StdLogger) write(level string, format string, v ...interface{}) {
+	now := time.Now().Format(time.RFC3339)
+	msg := strings.TrimRight(fmt.Sprintf(format, v...), "\n")
+	fmt.Fprintf(logger.Err, "time=%s level=%s %s\n", now, level, msg)
+}
+
+type NopLogger struct{}
+
+func (logger *NopLogger) Infof(format string, v ...interface{})    {}
+func (logger *NopLogger) Warnf(format string, v ...interface{})    {}
+func (logger *NopLogger) Verbosef(format string, v ...interface{}) {}
+func (logger *NopLogger) Debugf(format string, v ...interface{})   {}
+
+func AddLoggingHandlers(handlers *request.Handlers, logger Logger) {
+	handlers.Retry.PushBack(func(r *request.Request) {
+		if r.WillRetry() {
+			logger.Verbosef("service=%s operation=%s request_id=%s retry=%d error=%q",
+				r.ClientInfo.ServiceName, r.Operation.Name, r.RequestID, r.RetryCount+1, fmt.Sprint(r.Error))
+		}
+	})
+
+	handlers.Complete.PushBack(func(r *request.Request) {
+		status := 0
+
+		if r.HTTPResponse != nil {
+			status = r.HTTPResponse.StatusCode
+		}
+
+		logger.Debugf("service=%s operation=%s request_id=%s status=%d retries=%d duration=%s",
+			r.ClientInfo.ServiceName, r.Operation.Name, r.RequestID, status, r.RetryCount, time.Since(r.Time))
+	})
+}
@@ -0,0 +1,45 @@
+package gcredstash
+
+import (
+	"bytes"
+	. "gcredstash"
+	"regexp"
+	"testing"
+)
+
+func TestStdLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+
+	logger := &StdLogger{Out: out, Err: errOut, Level: LOG_LEVEL_VERBOSE}
+	logger.Infof("Deleting %s -- version %d", "test.key", 1)
+	logger.Verbosef("get name=%s", "test.key")
+	logger.Debugf("service=%s", "dynamodb")
+	logger.Warnf("%s has expired", "test.key")
+
+	expected := "Deleting test.key -- version 1\n"
+
+	if expected != out.String() {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, out.String())
+	}
+
+	re := regexp.MustCompile(`\Atime=\S+ level=verbose get name=test.key\ntime=\S+ level=warn test.key has expired\n\z`)
+
+	if !re.MatchString(errOut.String()) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", re, errOut.String())
+	}
+}
+
+func TestStdLoggerWithDebug(t *testing.T) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+
+	logger := &StdLogger{Out: out, Err: errOut, Level: LOG_LEVEL_DEBUG}
+	logger.Debugf("service=%s", "dynamodb")
+
+	re := regexp.MustCompile(`\Atime=\S+ level=debug service=dynamodb\n\z`)
+
+	if !re.MatchString(errOut.String()) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", re, errOut.String())
+	}
+}
@@ -0,0 +1,27 @@
+package gcredstash
+
+import (
+	"fmt"
+	"os"
+)
+
+// MaskSecret returns value with all but its first and last characters
+// hidden, followed by its length: one character of each end from 8
+// characters, up to four from 32. Shorter values are hidden entirely.
+func MaskSecret(value string) string {
+	runes := []rune(value)
+	keep := len(runes) / 8
+
+	if keep > 4 {
+		keep = 4
+	}
+
+	return fmt.Sprintf("%s...%s (%d chars)", string(runes[:keep]), string(runes[len(runes)-keep:]), len(runes))
+}
+
+// IsTerminal reports whether f is an interactive terminal rather than a
+// pipe or a file.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
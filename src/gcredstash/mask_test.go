@@ -0,0 +1,21 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"testing"
+)
+
+func TestMaskSecret(t *testing.T) {
+	for value, expected := range map[string]string{
+		"":                      "... (0 chars)",
+		"s3cr3t":                "... (6 chars)",
+		"s3cr3t!!":              "s...! (8 chars)",
+		"correct horse battery": "co...ry (21 chars)",
+		"AKIAIOSFODNN7EXAMPLEwJalrXUtnFEMIK7MDENG": "AKIA...DENG (40 chars)",
+		"pässwörter-sind-gut":                      "pä...ut (19 chars)",
+	} {
+		if got := MaskSecret(value); got != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, got)
+		}
+	}
+}
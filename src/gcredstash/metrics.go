@@ -0,0 +1,154 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var decryptBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+type metricKey struct {
+	a string
+	b string
+}
+
+// Metrics collects counters for the server and writes them in the
+// Prometheus text format. It is safe for concurrent use.
+type Metrics struct {
+	mutex          sync.Mutex
+	decryptBuckets []uint64
+	decryptCount   uint64
+	decryptSeconds float64
+	awsErrors      map[metricKey]uint64
+	fetches        map[metricKey]uint64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		decryptBuckets: make([]uint64, len(decryptBuckets)),
+		awsErrors:      map[metricKey]uint64{},
+		fetches:        map[metricKey]uint64{},
+	}
+}
+
+// AddHandlers records the latency of KMS Decrypt calls and the errors of
+// every AWS call made with handlers.
+func (metrics *Metrics) AddHandlers(handlers *request.Handlers) {
+	handlers.Complete.PushBack(func(r *request.Request) {
+		if r.ClientInfo.ServiceName == "kms" && r.Operation.Name == "Decrypt" {
+			metrics.ObserveDecrypt(time.Since(r.Time))
+		}
+
+		if r.Error != nil {
+			code := "Unknown"
+
+			var awsErr awserr.Error
+
+			if errors.As(r.Error, &awsErr) {
+				code = awsErr.Code()
+			}
+
+			metrics.mutex.Lock()
+			metrics.awsErrors[metricKey{r.ClientInfo.ServiceName, code}]++
+			metrics.mutex.Unlock()
+		}
+	})
+}
+
+func (metrics *Metrics) ObserveDecrypt(duration time.Duration) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	seconds := duration.Seconds()
+	metrics.decryptCount++
+	metrics.decryptSeconds += seconds
+
+	for i, bound := range decryptBuckets {
+		if seconds <= bound {
+			metrics.decryptBuckets[i]++
+		}
+	}
+}
+
+// ObserveFetch counts a read of a credential by its result, e.g. "ok" or
+// "not_found". Only successful reads are counted by name: the names of the
+// others may not exist, and would let any client add series.
+func (metrics *Metrics) ObserveFetch(name string, result string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if result != ACCESS_RESULT_OK {
+		name = ""
+	}
+
+	metrics.fetches[metricKey{name, result}]++
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func writeCounters(w io.Writer, name string, labels [2]string, counters map[metricKey]uint64) {
+	keys := []metricKey{}
+
+	for key := range counters {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].a != keys[j].a {
+			return keys[i].a < keys[j].a
+		}
+
+		return keys[i].b < keys[j].b
+	})
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{%s=\"%s\",%s=\"%s\"} %d\n", name, labels[0], escapeLabel(key.a), labels[1], escapeLabel(key.b), counters[key])
+	}
+}
+
+// WriteTo writes every metric. cache may be nil.
+func (metrics *Metrics) WriteTo(w io.Writer, cache *SecretCache) {
+	var hits, misses uint64
+
+	if cache != nil {
+		hits, misses = cache.Stats()
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	fmt.Fprintf(w, "# HELP gcredstash_cache_hits_total Reads served from the cache.\n")
+	fmt.Fprintf(w, "# TYPE gcredstash_cache_hits_total counter\n")
+	fmt.Fprintf(w, "gcredstash_cache_hits_total %d\n", hits)
+	fmt.Fprintf(w, "# HELP gcredstash_cache_misses_total Reads that were not in the cache.\n")
+	fmt.Fprintf(w, "# TYPE gcredstash_cache_misses_total counter\n")
+	fmt.Fprintf(w, "gcredstash_cache_misses_total %d\n", misses)
+
+	fmt.Fprintf(w, "# HELP gcredstash_kms_decrypt_duration_seconds Latency of KMS Decrypt calls.\n")
+	fmt.Fprintf(w, "# TYPE gcredstash_kms_decrypt_duration_seconds histogram\n")
+
+	for i, bound := range decryptBuckets {
+		fmt.Fprintf(w, "gcredstash_kms_decrypt_duration_seconds_bucket{le=\"%g\"} %d\n", bound, metrics.decryptBuckets[i])
+	}
+
+	fmt.Fprintf(w, "gcredstash_kms_decrypt_duration_seconds_bucket{le=\"+Inf\"} %d\n", metrics.decryptCount)
+	fmt.Fprintf(w, "gcredstash_kms_decrypt_duration_seconds_sum %g\n", metrics.decryptSeconds)
+	fmt.Fprintf(w, "gcredstash_kms_decrypt_duration_seconds_count %d\n", metrics.decryptCount)
+
+	fmt.Fprintf(w, "# HELP gcredstash_aws_errors_total Failed AWS calls by service and error code.\n")
+	fmt.Fprintf(w, "# TYPE gcredstash_aws_errors_total counter\n")
+	writeCounters(w, "gcredstash_aws_errors_total", [2]string{"service", "code"}, metrics.awsErrors)
+
+	fmt.Fprintf(w, "# HELP gcredstash_secret_fetches_total Reads by result, and by credential name when successful.\n")
+	fmt.Fprintf(w, "# TYPE gcredstash_secret_fetches_total counter\n")
+	writeCounters(w, "gcredstash_secret_fetches_total", [2]string{"name", "result"}, metrics.fetches)
+}
@@ -0,0 +1,52 @@
+package gcredstash
+
+import (
+	"bytes"
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	handlers := &request.Handlers{}
+	metrics.AddHandlers(handlers)
+
+	handlers.Complete.Run(&request.Request{
+		ClientInfo: metadata.ClientInfo{ServiceName: "dynamodb"},
+		Operation:  &request.Operation{Name: "Query"},
+		Error:      awserr.New("ProvisionedThroughputExceededException", "slow down", nil),
+		Time:       time.Now(),
+	})
+
+	metrics.ObserveDecrypt(30 * time.Millisecond)
+	metrics.ObserveFetch("foo.bar", "ok")
+	metrics.ObserveFetch("foo.bar", "ok")
+	metrics.ObserveFetch(`foo"baz`, "ok")
+	metrics.ObserveFetch("foo.qux", "not_found")
+	metrics.ObserveFetch("foo.quux", "not_found")
+
+	buf := &bytes.Buffer{}
+	metrics.WriteTo(buf, nil)
+	out := buf.String()
+
+	for _, line := range []string{
+		"gcredstash_cache_hits_total 0",
+		`gcredstash_kms_decrypt_duration_seconds_bucket{le="0.025"} 0`,
+		`gcredstash_kms_decrypt_duration_seconds_bucket{le="0.05"} 1`,
+		`gcredstash_kms_decrypt_duration_seconds_bucket{le="+Inf"} 1`,
+		"gcredstash_kms_decrypt_duration_seconds_count 1",
+		`gcredstash_aws_errors_total{service="dynamodb",code="ProvisionedThroughputExceededException"} 1`,
+		`gcredstash_secret_fetches_total{name="foo.bar",result="ok"} 2`,
+		`gcredstash_secret_fetches_total{name="foo\"baz",result="ok"} 1`,
+		`gcredstash_secret_fetches_total{name="",result="not_found"} 2`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", line, out)
+		}
+	}
+}
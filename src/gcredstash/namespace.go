@@ -0,0 +1,131 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"strings"
+)
+
+// NamespaceBackend prefixes every name with Namespace, e.g. "team1/", so
+// that several teams can share one table. Names are given and returned
+// without the prefix, and credentials outside the namespace are invisible.
+type NamespaceBackend struct {
+	Backend   Backend
+	Namespace string
+}
+
+func (backend *NamespaceBackend) withName(item map[string]*dynamodb.AttributeValue, name string) map[string]*dynamodb.AttributeValue {
+	copied := map[string]*dynamodb.AttributeValue{}
+
+	for key, value := range item {
+		copied[key] = value
+	}
+
+	copied["name"] = &dynamodb.AttributeValue{S: aws.String(name)}
+
+	return copied
+}
+
+func (backend *NamespaceBackend) addPrefix(item map[string]*dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+	return backend.withName(item, backend.Namespace+stringAttr(item, "name"))
+}
+
+func (backend *NamespaceBackend) stripPrefix(item map[string]*dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+	if _, ok := item["name"]; !ok {
+		return item
+	}
+
+	return backend.withName(item, strings.TrimPrefix(stringAttr(item, "name"), backend.Namespace))
+}
+
+func (backend *NamespaceBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	item, err := backend.Backend.GetItem(table, backend.Namespace+name, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.stripPrefix(item), nil
+}
+
+func (backend *NamespaceBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	return backend.Backend.PutItem(table, backend.addPrefix(item))
+}
+
+func (backend *NamespaceBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, err := backend.Backend.Query(table, backend.Namespace+name, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stripped := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		stripped = append(stripped, backend.stripPrefix(item))
+	}
+
+	return stripped, nil
+}
+
+func (backend *NamespaceBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	scanOpts := &ScanOptions{}
+
+	if opts != nil {
+		*scanOpts = *opts
+	}
+
+	scanOpts.Prefix = backend.Namespace + scanOpts.Prefix
+	items, err := backend.Backend.Scan(table, scanOpts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stripped := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		if strings.HasPrefix(stringAttr(item, "name"), backend.Namespace) {
+			stripped = append(stripped, backend.stripPrefix(item))
+		}
+	}
+
+	return stripped, nil
+}
+
+func (backend *NamespaceBackend) Delete(table string, name string, version string) error {
+	return backend.Backend.Delete(table, backend.Namespace+name, version)
+}
+
+func (backend *NamespaceBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	transactional, ok := backend.Backend.(TransactionalBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	prefixed := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		prefixed = append(prefixed, backend.addPrefix(item))
+	}
+
+	return transactional.PutItems(table, prefixed)
+}
+
+// KmsKeyFor returns the KMS key that NamespaceKeys maps the longest
+// matching prefix of the namespaced name to, or kmsKey when none matches.
+func (driver *Driver) KmsKeyFor(name string, kmsKey string) string {
+	fullName := driver.Namespace + name
+	longest := -1
+
+	for prefix, key := range driver.NamespaceKeys {
+		if strings.HasPrefix(fullName, prefix) && len(prefix) > longest {
+			kmsKey = key
+			longest = len(prefix)
+		}
+	}
+
+	return kmsKey
+}
@@ -0,0 +1,133 @@
+package gcredstash
+
+import (
+	"bytes"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDriverWithNamespace(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(shared *Driver, f *os.File) {
+		team1 := &Driver{Backend: shared.Backend, Kms: shared.Kms, Logger: shared.Logger, Namespace: "team1/"}
+		team2 := &Driver{Backend: shared.Backend, Kms: shared.Kms, Logger: shared.Logger, Namespace: "team2/"}
+
+		team1.PutSecret("db.password", "100", "0000000000000000001", "alias/credstash", table, nil)
+		team2.PutSecret("db.password", "200", "0000000000000000001", "alias/credstash", table, nil)
+
+		if _, err := team1.PutSecretNextVersion("db.password", "101", "alias/credstash", table, nil, nil); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		for driver, expected := range map[*Driver]string{team1: "101", team2: "200"} {
+			actual, err := driver.GetSecret("db.password", "", table, nil)
+
+			if actual != expected || err != nil {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, actual, err)
+			}
+		}
+
+		items, _ := team1.ListSecrets(table)
+		expected := map[string][]string{"db.password": {"0000000000000000001", "0000000000000000002"}}
+
+		if !reflect.DeepEqual(GroupVersions(items), expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+		}
+
+		if err := team2.DeleteSecrets("db.password", "", table); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		items, _ = shared.ListSecrets(table)
+		expected = map[string][]string{"team1/db.password": {"0000000000000000001", "0000000000000000002"}}
+
+		if !reflect.DeepEqual(GroupVersions(items), expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+		}
+	})
+}
+
+func TestKmsKeyFor(t *testing.T) {
+	driver := &Driver{
+		Namespace: "team1/",
+		NamespaceKeys: map[string]string{
+			"team1/":      "alias/team1",
+			"team1/prod.": "alias/team1-prod",
+			"team2/":      "alias/team2",
+		},
+	}
+
+	for name, expected := range map[string]string{
+		"db.password":      "alias/team1",
+		"prod.db.password": "alias/team1-prod",
+	} {
+		if actual := driver.KmsKeyFor(name, "alias/credstash"); actual != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+		}
+	}
+
+	driver.Namespace = ""
+
+	if actual := driver.KmsKeyFor("db.password", "alias/credstash"); actual != "alias/credstash" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "alias/credstash", actual)
+	}
+}
+
+type keyRecordingKms struct {
+	*LocalKms
+	keyIds []string
+}
+
+func (svc *keyRecordingKms) GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	svc.keyIds = append(svc.keyIds, *input.KeyId)
+	return svc.LocalKms.GenerateDataKey(input)
+}
+
+func (svc *keyRecordingKms) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	resp, err := svc.LocalKms.Decrypt(input)
+
+	if err == nil {
+		resp.KeyId = &svc.keyIds[len(svc.keyIds)-1]
+	}
+
+	return resp, err
+}
+
+func TestDriverWithNamespaceKeys(t *testing.T) {
+	localKms, _ := NewLocalKms(testutils.LOCAL_MASTER_KEY)
+	recordingKms := &keyRecordingKms{LocalKms: localKms}
+	table := "credential-store"
+
+	testutils.TempFile("", func(f *os.File) {
+		logger := &StdLogger{Out: &bytes.Buffer{}, Err: &bytes.Buffer{}, Level: LOG_LEVEL_VERBOSE}
+
+		driver := &Driver{
+			Backend:       &FileBackend{Path: f.Name()},
+			Kms:           recordingKms,
+			Logger:        logger,
+			Namespace:     "team1/",
+			NamespaceKeys: map[string]string{"team1/": "alias/team1"},
+		}
+
+		driver.PutSecret("db.password", "100", "0000000000000000001", "alias/credstash", table, nil)
+
+		expected := []string{"alias/team1"}
+
+		if !reflect.DeepEqual(recordingKms.keyIds, expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, recordingKms.keyIds)
+		}
+
+		driver.GetSecret("db.password", "", table, nil)
+		log := logger.Err.(*bytes.Buffer).String()
+
+		if !strings.Contains(log, "decrypt name=db.password kms_key=alias/team1") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "decrypt name=db.password kms_key=alias/team1", log)
+		}
+	})
+}
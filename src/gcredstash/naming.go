@@ -0,0 +1,142 @@
+package gcredstash
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NamingRules restrict the credential names that can be stored, so that
+// typos such as "DB.pass" next to "db.pass" do not become separate
+// credentials. Empty fields do not restrict anything.
+type NamingRules struct {
+	// Charset is the content of a regexp character class, e.g. "a-z0-9._-",
+	// that every character of a name must match.
+	Charset   string
+	MaxLength int
+	// Lowercase makes Normalize lowercase names.
+	Lowercase        bool
+	ReservedPrefixes []string
+}
+
+// NewNamingRules returns NamingRules after checking that charset is a
+// valid character class.
+func NewNamingRules(charset string, maxLength int, lowercase bool, reservedPrefixes []string) (*NamingRules, error) {
+	rules := &NamingRules{
+		Charset:          charset,
+		MaxLength:        maxLength,
+		Lowercase:        lowercase,
+		ReservedPrefixes: reservedPrefixes,
+	}
+
+	if _, err := rules.charsetPattern(); err != nil {
+		return nil, err
+	}
+
+	if maxLength < 0 {
+		return nil, fmt.Errorf("invalid name max length: %d", maxLength)
+	}
+
+	return rules, nil
+}
+
+func (rules *NamingRules) charsetPattern() (*regexp.Regexp, error) {
+	if rules.Charset == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile("^[" + rules.Charset + "]*$")
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid name charset: %s", rules.Charset)
+	}
+
+	return re, nil
+}
+
+// Normalize returns name as it should be stored. It is a no-op on nil
+// rules.
+func (rules *NamingRules) Normalize(name string) string {
+	if rules == nil {
+		return name
+	}
+
+	if rules.Lowercase {
+		name = strings.ToLower(name)
+	}
+
+	return name
+}
+
+// Validate checks name against the rules. Normalization is not applied,
+// so that an un-normalized name can still be stored on purpose.
+func (rules *NamingRules) Validate(name string) error {
+	if rules == nil {
+		return nil
+	}
+
+	charset, err := rules.charsetPattern()
+
+	if err != nil {
+		return err
+	}
+
+	if charset != nil && !charset.MatchString(name) {
+		return fmt.Errorf("invalid name: %s (allowed characters: %s)", name, rules.Charset)
+	}
+
+	if rules.MaxLength > 0 && len(name) > rules.MaxLength {
+		return fmt.Errorf("invalid name: %s (longer than %d characters)", name, rules.MaxLength)
+	}
+
+	for _, prefix := range rules.ReservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("invalid name: %s (prefix %s is reserved)", name, prefix)
+		}
+	}
+
+	return nil
+}
+
+// NamingRules returns the naming rules of a config profile, or nil when it
+// sets none.
+func (profile *ConfigProfile) NamingRules() (*NamingRules, error) {
+	if profile.NameCharset == "" && profile.NameMaxLength == "" && profile.NameLowercase == "" && profile.NameReservedPrefixes == "" {
+		return nil, nil
+	}
+
+	maxLength := 0
+
+	if profile.NameMaxLength != "" {
+		num, err := strconv.Atoi(profile.NameMaxLength)
+
+		if err != nil || num < 1 {
+			return nil, fmt.Errorf("invalid name_max_length: %s", profile.NameMaxLength)
+		}
+
+		maxLength = num
+	}
+
+	lowercase := false
+
+	if profile.NameLowercase != "" {
+		b, err := strconv.ParseBool(profile.NameLowercase)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid name_lowercase: %s (must be true or false)", profile.NameLowercase)
+		}
+
+		lowercase = b
+	}
+
+	prefixes := []string{}
+
+	for _, prefix := range strings.Split(profile.NameReservedPrefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return NewNamingRules(profile.NameCharset, maxLength, lowercase, prefixes)
+}
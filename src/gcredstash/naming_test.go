@@ -0,0 +1,86 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestNamingRules(t *testing.T) {
+	rules, err := NewNamingRules("a-z0-9._-", 10, true, []string{"aws."})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if actual := rules.Normalize("DB.Pass"); actual != "db.pass" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "db.pass", actual)
+	}
+
+	if err := rules.Validate("db.pass"); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	for _, name := range []string{"DB.Pass", "db.password1", "aws.key", "db/pass"} {
+		if err := rules.Validate(name); err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+		}
+	}
+
+	var none *NamingRules
+
+	if none.Normalize("DB.Pass") != "DB.Pass" || none.Validate("DB Pass") != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "no rules", none)
+	}
+
+	if _, err := NewNamingRules("a-", 0, false, nil); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	if _, err := NewNamingRules("z-a", 0, false, nil); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
+
+func TestConfigProfileNamingRules(t *testing.T) {
+	testutils.TempFile(`
+name_charset: a-z.
+name_max_length: 32
+name_lowercase: true
+name_reserved_prefixes: aws., internal.
+`, func(f *os.File) {
+		cfg, err := LoadConfigFile(f.Name())
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		profile, _ := cfg.Resolve("", "")
+		rules, err := profile.NamingRules()
+
+		expected := &NamingRules{
+			Charset:          "a-z.",
+			MaxLength:        32,
+			Lowercase:        true,
+			ReservedPrefixes: []string{"aws.", "internal."},
+		}
+
+		if !reflect.DeepEqual(expected, rules) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, rules, err)
+		}
+	})
+
+	for _, content := range []string{
+		"name_max_length: 0\n",
+		"name_lowercase: maybe\n",
+		"name_charset: z-a\n",
+	} {
+		testutils.TempFile(content, func(f *os.File) {
+			if _, err := LoadConfigFile(f.Name()); err == nil {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+			}
+		})
+	}
+}
@@ -4,55 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
-func ParseOptionWithValue(args []string, key string) ([]string, string, error) {
-	newArgs := []string{}
-	val := ""
-	nextOpt := false
-
-	for _, arg := range args {
-		if nextOpt {
-			if strings.HasPrefix(arg, "-") {
-				return nil, "", fmt.Errorf("option requires an argument: %s", key)
-			}
-
-			val = arg
-			nextOpt = false
-		} else if arg == key {
-			nextOpt = true
-		} else {
-			newArgs = append(newArgs, arg)
-		}
-	}
-
-	if nextOpt {
-		return nil, "", fmt.Errorf("option requires an argument: %s", key)
-	}
-
-	return newArgs, val, nil
-}
-
-func ParseVersion(args []string) ([]string, string, error) {
-	newArgs, version, err := ParseOptionWithValue(args, "-v")
-
-	if err != nil {
-		return nil, "", err
-	}
-
-	if version != "" {
-		ver, err := strconv.Atoi(version)
-
-		if err != nil {
-			return nil, "", err
-		}
-
-		version = fmt.Sprintf("%019d", ver)
-	}
-
-	return newArgs, version, nil
-}
-
 func ParseContext(strs []string) (map[string]string, error) {
 	context := map[string]string{}
 
@@ -69,17 +23,23 @@ func ParseContext(strs []string) (map[string]string, error) {
 	return context, nil
 }
 
-func HasOption(args []string, opt string) ([]string, bool) {
-	newArgs := []string{}
-	hasOpt := false
+// ParseAge parses a duration that may also be given in days, e.g. "90d".
+func ParseAge(str string) (time.Duration, error) {
+	if strings.HasSuffix(str, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(str, "d"))
 
-	for _, arg := range args {
-		if arg == opt {
-			hasOpt = true
-		} else {
-			newArgs = append(newArgs, arg)
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid age: %s", str)
 		}
+
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(str)
+
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age: %s", str)
 	}
 
-	return newArgs, hasOpt
+	return age, nil
 }
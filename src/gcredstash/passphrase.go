@@ -0,0 +1,202 @@
+package gcredstash
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"golang.org/x/crypto/scrypt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The scrypt parameters of new items. Items record the parameters they were
+// stored with, so that these can be raised later.
+const (
+	PASSPHRASE_SCRYPT_N   = 32768
+	PASSPHRASE_SCRYPT_R   = 8
+	PASSPHRASE_SCRYPT_P   = 1
+	PASSPHRASE_SALT_BYTES = 16
+)
+
+// The largest scrypt parameters an item may name. passphrase_kdf is not
+// covered by the HMAC, so it is bounded before scrypt allocates memory and
+// time for it.
+const (
+	PASSPHRASE_SCRYPT_MAX_N = 1 << 20
+	PASSPHRASE_SCRYPT_MAX_R = 32
+	PASSPHRASE_SCRYPT_MAX_P = 16
+)
+
+var ErrPassphraseRequired = errors.New("a passphrase is required")
+
+// PassphraseRequiredError is returned when a credential protected with a
+// passphrase is read without Driver.Passphrase. errors.Is(err,
+// ErrPassphraseRequired) reports it.
+type PassphraseRequiredError struct {
+	Name string
+}
+
+func (e *PassphraseRequiredError) Error() string {
+	return fmt.Sprintf("%s is protected with a passphrase, but none was given", e.Name)
+}
+
+func (e *PassphraseRequiredError) Is(target error) bool {
+	return target == ErrPassphraseRequired
+}
+
+// IsPassphraseProtected reports whether an item has the passphrase layer.
+func IsPassphraseProtected(material map[string]*dynamodb.AttributeValue) bool {
+	return stringAttr(material, "passphrase_kdf") != ""
+}
+
+// EnvPassphrase returns a Driver.Passphrase that reads the passphrase from
+// the file GCREDSTASH_PASSPHRASE_FILE names (without its trailing newline),
+// or from GCREDSTASH_PASSPHRASE. It returns nil when neither is set.
+func EnvPassphrase() func(name string) ([]byte, error) {
+	if path := os.Getenv("GCREDSTASH_PASSPHRASE_FILE"); path != "" {
+		return func(name string) ([]byte, error) {
+			content, err := ioutil.ReadFile(path)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []byte(StripNewline(string(content))), nil
+		}
+	}
+
+	if passphrase := os.Getenv("GCREDSTASH_PASSPHRASE"); passphrase != "" {
+		return func(name string) ([]byte, error) {
+			return []byte(passphrase), nil
+		}
+	}
+
+	return nil
+}
+
+// passphrase returns the passphrase for name from Driver.Passphrase.
+func (driver *Driver) passphrase(name string) ([]byte, error) {
+	if driver.Passphrase == nil {
+		return nil, &PassphraseRequiredError{Name: name}
+	}
+
+	passphrase, err := driver.Passphrase(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(passphrase) == 0 {
+		return nil, &PassphraseRequiredError{Name: name}
+	}
+
+	return passphrase, nil
+}
+
+func passphraseKey(passphrase []byte, salt []byte, kdf string) ([]byte, error) {
+	params := strings.Split(kdf, ":")
+
+	if len(params) != 4 || params[0] != "scrypt" {
+		return nil, fmt.Errorf("unsupported passphrase kdf: %s", kdf)
+	}
+
+	nums := []int{}
+
+	for _, param := range params[1:] {
+		num, err := strconv.Atoi(param)
+
+		if err != nil {
+			return nil, fmt.Errorf("unsupported passphrase kdf: %s", kdf)
+		}
+
+		nums = append(nums, num)
+	}
+
+	if nums[0] > PASSPHRASE_SCRYPT_MAX_N || nums[1] > PASSPHRASE_SCRYPT_MAX_R || nums[2] > PASSPHRASE_SCRYPT_MAX_P {
+		return nil, fmt.Errorf("passphrase kdf parameters too large: %s", kdf)
+	}
+
+	return scrypt.Key(passphrase, salt, nums[0], nums[1], nums[2], 32)
+}
+
+// lockWithPassphrase encrypts plaintext with AES-GCM under a key derived
+// from passphrase with scrypt, before it goes through KMS envelope
+// encryption. It returns the ciphertext and the attributes to store it
+// with.
+func lockWithPassphrase(plaintext []byte, passphrase []byte) ([]byte, map[string]*dynamodb.AttributeValue, error) {
+	salt := make([]byte, PASSPHRASE_SALT_BYTES)
+
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, nil, err
+	}
+
+	kdf := fmt.Sprintf("scrypt:%d:%d:%d", PASSPHRASE_SCRYPT_N, PASSPHRASE_SCRYPT_R, PASSPHRASE_SCRYPT_P)
+	key, err := passphraseKey(passphrase, salt, kdf)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer Wipe(key)
+
+	locked, err := GcmEncrypt(plaintext, key)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return locked, map[string]*dynamodb.AttributeValue{
+		"passphrase_kdf":  {S: aws.String(kdf)},
+		"passphrase_salt": {S: aws.String(B64Encode(salt))},
+	}, nil
+}
+
+// unlockWithPassphrase reverses lockWithPassphrase for a decrypted item.
+func (driver *Driver) unlockWithPassphrase(name string, material map[string]*dynamodb.AttributeValue, locked []byte) ([]byte, error) {
+	passphrase, err := driver.passphrase(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer Wipe(passphrase)
+
+	key, err := passphraseKey(passphrase, B64Decode(stringAttr(material, "passphrase_salt")), stringAttr(material, "passphrase_kdf"))
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
+	}
+
+	defer Wipe(key)
+
+	plaintext, err := GcmDecrypt(locked, key)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: wrong passphrase", name)
+	}
+
+	return plaintext, nil
+}
+
+// keepPassphrase makes a put of the plaintext of material, such as a copy
+// or a rollback, keep its passphrase layer.
+func (driver *Driver) keepPassphrase(name string, material map[string]*dynamodb.AttributeValue, opts *PutOptions) error {
+	if !IsPassphraseProtected(material) {
+		return nil
+	}
+
+	passphrase, err := driver.passphrase(name)
+
+	if err != nil {
+		return err
+	}
+
+	opts.Passphrase = passphrase
+
+	return nil
+}
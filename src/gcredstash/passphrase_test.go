@@ -0,0 +1,95 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPassphrase(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		opts := &PutOptions{Passphrase: []byte("correct horse")}
+
+		err := driver.PutSecretWithOptions("root.key", "s3cr3t", VersionNumToStr(1), "alias/credstash", table, nil, opts)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		material, _ := driver.GetMaterialWithVersion("root.key", VersionNumToStr(1), table)
+
+		if !IsPassphraseProtected(material) || *material["passphrase_kdf"].S != "scrypt:32768:8:1" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "scrypt:32768:8:1", material)
+		}
+
+		_, err = driver.GetSecret("root.key", "", table, nil)
+
+		if !errors.Is(err, ErrPassphraseRequired) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrPassphraseRequired, err)
+		}
+
+		results, _ := driver.AuditSecrets(table, "", nil)
+
+		if len(results) != 1 || results[0].Err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "audit without a passphrase", results[0].Err)
+		}
+
+		driver.Passphrase = func(name string) ([]byte, error) { return []byte("wrong horse"), nil }
+		_, err = driver.GetSecret("root.key", "", table, nil)
+
+		if err == nil || err.Error() != "root.key: wrong passphrase" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "root.key: wrong passphrase", err)
+		}
+
+		driver.Passphrase = func(name string) ([]byte, error) { return []byte("correct horse"), nil }
+		value, err := driver.GetSecret("root.key", "", table, nil)
+
+		if value != "s3cr3t" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "s3cr3t", value, err)
+		}
+
+		for _, kdf := range []string{"scrypt:1073741824:8:1", "scrypt:32768:1024:1", "scrypt:32768:8:1024"} {
+			tampered := map[string]*dynamodb.AttributeValue{}
+
+			for key, value := range material {
+				tampered[key] = value
+			}
+
+			tampered["version"] = &dynamodb.AttributeValue{S: aws.String(VersionNumToStr(99))}
+			tampered["passphrase_kdf"] = &dynamodb.AttributeValue{S: aws.String(kdf)}
+			driver.Backend.PutItem(table, tampered)
+			_, err = driver.GetSecret("root.key", VersionNumToStr(99), table, nil)
+			driver.Backend.Delete(table, "root.key", VersionNumToStr(99))
+
+			if err == nil || !strings.HasSuffix(err.Error(), "passphrase kdf parameters too large: "+kdf) {
+				t.Errorf("\nexpected: %v\ngot: %v\n", "passphrase kdf parameters too large: "+kdf, err)
+			}
+		}
+
+		version, stored, err := driver.PutSecretIdempotent("root.key", "s3cr3t", "alias/credstash", table, nil, opts)
+
+		if version != VersionNumToStr(1) || stored || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v %v\n", "unchanged", version, stored, err)
+		}
+
+		version, stored, _ = driver.PutSecretIdempotent("root.key", "s3cr3t", "alias/credstash", table, nil, nil)
+
+		if version != VersionNumToStr(2) || !stored {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "stored without a passphrase", version, stored)
+		}
+
+		version, err = driver.Rollback("root.key", VersionNumToStr(1), "alias/credstash", table, nil)
+		material, _ = driver.GetMaterialWithVersion("root.key", version, table)
+
+		if !IsPassphraseProtected(material) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "a rollback that keeps the passphrase", material, err)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"strings"
+)
+
+// POLICY_CONTEXT_KEY is the conventional encryption context key that IAM
+// policies scope access on, e.g. role=webserver.
+const POLICY_CONTEXT_KEY = "role"
+
+// PolicyContext is an encryption context meant to be matched by IAM policy
+// conditions (see NewPolicyDocument). KMS enforces it: a credential stored
+// with a context can only be decrypted with the same context.
+type PolicyContext map[string]string
+
+// RoleContext returns a PolicyContext with the conventional role key.
+func RoleContext(role string) PolicyContext {
+	return PolicyContext{POLICY_CONTEXT_KEY: role}
+}
+
+// Validate checks that the context is not empty and has no empty keys or
+// values, which a policy condition could not match.
+func (ctx PolicyContext) Validate() error {
+	if len(ctx) == 0 {
+		return ErrContextRequired
+	}
+
+	for key, value := range ctx {
+		if key == "" || value == "" {
+			return fmt.Errorf("invalid encryption context: %s=%s", key, value)
+		}
+	}
+
+	return nil
+}
+
+type PolicyStatement struct {
+	Sid       string
+	Effect    string
+	Action    []string
+	Resource  string
+	Condition map[string]map[string]interface{} `json:",omitempty"`
+}
+
+type PolicyDocument struct {
+	Version   string
+	Statement []*PolicyStatement
+}
+
+func leadingKeysCondition(patterns []string) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"ForAllValues:StringLike": {"dynamodb:LeadingKeys": patterns},
+	}
+}
+
+func encryptionContextCondition(context map[string]string) map[string]map[string]interface{} {
+	if len(context) == 0 {
+		return nil
+	}
+
+	values := map[string]interface{}{}
+
+	for key, value := range context {
+		values["kms:EncryptionContext:"+key] = value
+	}
+
+	return map[string]map[string]interface{}{"StringEquals": values}
+}
+
+// NewPolicyDocument returns a least-privilege IAM policy for reading and
+// writing credentials whose names match the given patterns. Credential names
+// are the table's partition key, so access is scoped with
+// dynamodb:LeadingKeys; `*` in a pattern matches like it does in IAM.
+func NewPolicyDocument(tableArn string, keyArn string, readers []string, writers []string, context map[string]string) *PolicyDocument {
+	doc := &PolicyDocument{Version: "2012-10-17", Statement: []*PolicyStatement{}}
+
+	if len(readers) > 0 {
+		doc.Statement = append(doc.Statement, &PolicyStatement{
+			Sid:       "ReadCredentials",
+			Effect:    "Allow",
+			Action:    []string{"dynamodb:GetItem", "dynamodb:Query"},
+			Resource:  tableArn,
+			Condition: leadingKeysCondition(readers),
+		}, &PolicyStatement{
+			Sid:       "DecryptCredentials",
+			Effect:    "Allow",
+			Action:    []string{"kms:Decrypt"},
+			Resource:  keyArn,
+			Condition: encryptionContextCondition(context),
+		})
+	}
+
+	if len(writers) > 0 {
+		doc.Statement = append(doc.Statement, &PolicyStatement{
+			Sid:       "WriteCredentials",
+			Effect:    "Allow",
+			Action:    []string{"dynamodb:PutItem", "dynamodb:Query"},
+			Resource:  tableArn,
+			Condition: leadingKeysCondition(writers),
+		}, &PolicyStatement{
+			Sid:       "EncryptCredentials",
+			Effect:    "Allow",
+			Action:    []string{"kms:GenerateDataKey"},
+			Resource:  keyArn,
+			Condition: encryptionContextCondition(context),
+		})
+	}
+
+	return doc
+}
+
+func (doc *PolicyDocument) String() string {
+	content, err := json.MarshalIndent(doc, "", "  ")
+
+	if err != nil {
+		panic(err)
+	}
+
+	return string(content)
+}
+
+// GeneratePolicy resolves the table and KMS key ARNs and returns the policy
+// from NewPolicyDocument.
+func (driver *Driver) GeneratePolicy(table string, kmsKey string, readers []string, writers []string, context map[string]string) (*PolicyDocument, error) {
+	if len(readers) == 0 && len(writers) == 0 {
+		return nil, fmt.Errorf("no credential patterns specified")
+	}
+
+	for _, pattern := range append(append([]string{}, readers...), writers...) {
+		if strings.TrimSpace(pattern) == "" {
+			return nil, fmt.Errorf("empty credential pattern")
+		}
+	}
+
+	resp, err := driver.Ddb.DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not describe table(%s): %s", table, err.Error())
+	}
+
+	keyArn, err := KmsDescribeKeyArn(driver.Kms, kmsKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not resolve KMS key(%s): %s", kmsKey, err.Error())
+	}
+
+	return NewPolicyDocument(aws.StringValue(resp.Table.TableArn), keyArn, readers, writers, driver.encryptionContext(context)), nil
+}
@@ -0,0 +1,112 @@
+package gcredstash
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that allows Rate requests per second on
+// average and bursts of up to Burst requests.
+type RateLimiter struct {
+	Rate  float64
+	Burst float64
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(rate float64) *RateLimiter {
+	burst := rate
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{Rate: rate, Burst: burst, tokens: burst}
+}
+
+// reserve takes a token and returns how long to wait before it can be used.
+func (limiter *RateLimiter) reserve(now time.Time) time.Duration {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if !limiter.last.IsZero() {
+		limiter.tokens += now.Sub(limiter.last).Seconds() * limiter.Rate
+
+		if limiter.tokens > limiter.Burst {
+			limiter.tokens = limiter.Burst
+		}
+	}
+
+	limiter.last = now
+	limiter.tokens--
+
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-limiter.tokens / limiter.Rate * float64(time.Second))
+}
+
+// Wait blocks until a request is allowed or ctx is done.
+func (limiter *RateLimiter) Wait(ctx context.Context) error {
+	delay := limiter.reserve(time.Now())
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ParseRateLimit parses a number of requests per second.
+func ParseRateLimit(str string) (float64, error) {
+	rate, err := strconv.ParseFloat(str, 64)
+
+	if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+		return 0, fmt.Errorf("invalid rate limit: %s", str)
+	}
+
+	return rate, nil
+}
+
+// AddRateLimitHandlers limits the AWS requests made with handlers to rate
+// per second, with a separate bucket for each service so that KMS and
+// DynamoDB quotas are throttled independently. Retries are limited too.
+func AddRateLimitHandlers(handlers *request.Handlers, rate float64) {
+	var mutex sync.Mutex
+	limiters := map[string]*RateLimiter{}
+
+	// Sign runs before every attempt, and an error there stops the request.
+	handlers.Sign.PushFront(func(r *request.Request) {
+		service := r.ClientInfo.ServiceName
+
+		mutex.Lock()
+		limiter, ok := limiters[service]
+
+		if !ok {
+			limiter = NewRateLimiter(rate)
+			limiters[service] = limiter
+		}
+
+		mutex.Unlock()
+
+		if err := limiter.Wait(r.Context()); err != nil {
+			r.Error = err
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package gcredstash
+
+import (
+	"context"
+	. "gcredstash"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(20)
+	start := time.Now()
+
+	for i := 0; i < 22; i++ {
+		limiter.Wait(context.Background())
+	}
+
+	// The first 20 requests are a burst; the next two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond || elapsed > time.Second {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "about 100ms", elapsed)
+	}
+
+	limiter = NewRateLimiter(0.001)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := limiter.Wait(ctx)
+
+	if err != context.Canceled {
+		t.Errorf("\nexpected: %v\ngot: %v\n", context.Canceled, err)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	rate, err := ParseRateLimit("2.5")
+
+	if rate != 2.5 || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", 2.5, rate, err)
+	}
+
+	for _, str := range []string{"0", "-1", "x", "Inf"} {
+		_, err := ParseRateLimit(str)
+
+		if err == nil || err.Error() != "invalid rate limit: "+str {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "invalid rate limit: "+str, err)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package gcredstash
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// DEFAULT_S3_THRESHOLD is the size over which values go to the S3 bucket
+// when s3_threshold is not set.
+const DEFAULT_S3_THRESHOLD = 100 * 1024
+
+// ParseS3Threshold parses the s3_threshold setting: a number of bytes,
+// optionally with a KB or MB suffix. Empty is DEFAULT_S3_THRESHOLD.
+func ParseS3Threshold(str string) (int, error) {
+	if str == "" {
+		return DEFAULT_S3_THRESHOLD, nil
+	}
+
+	unit := 1
+	num := str
+
+	switch {
+	case strings.HasSuffix(str, "KB"):
+		unit, num = 1024, strings.TrimSuffix(str, "KB")
+	case strings.HasSuffix(str, "MB"):
+		unit, num = 1024*1024, strings.TrimSuffix(str, "MB")
+	}
+
+	n, err := strconv.Atoi(num)
+
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid s3_threshold: %s (must be a size such as 100KB)", str)
+	}
+
+	return n * unit, nil
+}
+
+// S3OverflowBackend stores the encrypted value of a version over Threshold
+// bytes as an object in Bucket, and only a pointer to it in contents_s3;
+// the key and the HMAC stay in the item, so the object is checked like
+// contents on every read. Objects are encrypted with SSE-KMS on top of the
+// envelope encryption of the value. Reads fetch the objects of the items
+// they return, whatever Bucket is; objects are only deleted with Bucket
+// set, though.
+type S3OverflowBackend struct {
+	Backend   Backend
+	S3        s3iface.S3API
+	Bucket    string
+	Threshold int
+}
+
+func parseS3Pointer(pointer string) (string, string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(pointer, "s3://"), "/", 2)
+
+	if !strings.HasPrefix(pointer, "s3://") || len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid contents_s3: %s", pointer)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// s3ObjectKey returns a new key for the value of name. It is random, so
+// that a put that fails on an existing version never overwrites the object
+// of that version.
+func s3ObjectKey(table string, name string, version string) (string, error) {
+	suffix := make([]byte, 8)
+
+	if _, err := io.ReadFull(rand.Reader, suffix); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s/%s-%s", table, name, version, HexEncode(suffix)), nil
+}
+
+// offload returns item with its contents moved to a new object, when they
+// are over Threshold.
+func (backend *S3OverflowBackend) offload(table string, item map[string]*dynamodb.AttributeValue) (map[string]*dynamodb.AttributeValue, error) {
+	offloaded := map[string]*dynamodb.AttributeValue{}
+
+	for attr, value := range item {
+		offloaded[attr] = value
+	}
+
+	delete(offloaded, "contents_s3")
+
+	if backend.Bucket == "" || len(stringAttr(offloaded, "contents"))*3/4 <= backend.Threshold {
+		return offloaded, nil
+	}
+
+	name := stringAttr(item, "name")
+	key, err := s3ObjectKey(table, name, stringAttr(item, "version"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = backend.S3.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String(backend.Bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(B64Decode(stringAttr(offloaded, "contents"))),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not store the value in s3://%s/%s: %s", name, backend.Bucket, key, err.Error())
+	}
+
+	delete(offloaded, "contents")
+	offloaded["contents_s3"] = &dynamodb.AttributeValue{S: aws.String("s3://" + backend.Bucket + "/" + key)}
+
+	return offloaded, nil
+}
+
+func (backend *S3OverflowBackend) deleteObject(item map[string]*dynamodb.AttributeValue) error {
+	pointer := stringAttr(item, "contents_s3")
+
+	if pointer == "" {
+		return nil
+	}
+
+	bucket, key, err := parseS3Pointer(pointer)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = backend.S3.DeleteObject(&s3.DeleteObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+
+	return err
+}
+
+// fetch returns item with the contents of its object, if it has one.
+func (backend *S3OverflowBackend) fetch(item map[string]*dynamodb.AttributeValue) (map[string]*dynamodb.AttributeValue, error) {
+	pointer := stringAttr(item, "contents_s3")
+
+	if pointer == "" {
+		return item, nil
+	}
+
+	bucket, key, err := parseS3Pointer(pointer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := backend.S3.GetObject(&s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not read the value from %s: %s", stringAttr(item, "name"), pointer, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := map[string]*dynamodb.AttributeValue{}
+
+	for attr, value := range item {
+		fetched[attr] = value
+	}
+
+	fetched["contents"] = &dynamodb.AttributeValue{S: aws.String(B64Encode(contents))}
+
+	return fetched, nil
+}
+
+func (backend *S3OverflowBackend) fetchAll(items []map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, error) {
+	fetched := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		item, err := backend.fetch(item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fetched = append(fetched, item)
+	}
+
+	return fetched, nil
+}
+
+func (backend *S3OverflowBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	item, err := backend.Backend.GetItem(table, name, version)
+
+	if err != nil || item == nil {
+		return item, err
+	}
+
+	return backend.fetch(item)
+}
+
+func (backend *S3OverflowBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	offloaded, err := backend.offload(table, item)
+
+	if err != nil {
+		return err
+	}
+
+	err = backend.Backend.PutItem(table, offloaded)
+
+	if err != nil {
+		backend.deleteObject(offloaded)
+	}
+
+	return err
+}
+
+func (backend *S3OverflowBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, err := backend.Backend.Query(table, name, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.fetchAll(items)
+}
+
+func (backend *S3OverflowBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, err := backend.Backend.Scan(table, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.fetchAll(items)
+}
+
+func (backend *S3OverflowBackend) Delete(table string, name string, version string) error {
+	if backend.Bucket == "" {
+		return backend.Backend.Delete(table, name, version)
+	}
+
+	item, err := backend.Backend.GetItem(table, name, version)
+
+	if err != nil {
+		return err
+	}
+
+	if err := backend.Backend.Delete(table, name, version); err != nil || item == nil {
+		return err
+	}
+
+	return backend.deleteObject(item)
+}
+
+func (backend *S3OverflowBackend) offloadAll(table string, items []map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, error) {
+	offloaded := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		item, err := backend.offload(table, item)
+
+		if err != nil {
+			for _, item := range offloaded {
+				backend.deleteObject(item)
+			}
+
+			return nil, err
+		}
+
+		offloaded = append(offloaded, item)
+	}
+
+	return offloaded, nil
+}
+
+func (backend *S3OverflowBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	transactional, ok := backend.Backend.(TransactionalBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	offloaded, err := backend.offloadAll(table, items)
+
+	if err != nil {
+		return err
+	}
+
+	err = transactional.PutItems(table, offloaded)
+
+	if err != nil {
+		for _, item := range offloaded {
+			backend.deleteObject(item)
+		}
+	}
+
+	return err
+}
+
+// ReplaceItem keeps the object of an item that has one, as it replaces the
+// same version.
+func (backend *S3OverflowBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	replacing, ok := backend.Backend.(ReplacingBackend)
+
+	if !ok {
+		return errSoftDeleteUnsupported
+	}
+
+	if stringAttr(item, "contents_s3") == "" {
+		offloaded, err := backend.offload(table, item)
+
+		if err != nil {
+			return err
+		}
+
+		return replacing.ReplaceItem(table, offloaded)
+	}
+
+	kept := map[string]*dynamodb.AttributeValue{}
+
+	for attr, value := range item {
+		kept[attr] = value
+	}
+
+	delete(kept, "contents")
+
+	return replacing.ReplaceItem(table, kept)
+}
+
+func (backend *S3OverflowBackend) MoveItems(table string, items []map[string]*dynamodb.AttributeValue, from []map[string]*dynamodb.AttributeValue) error {
+	moving, ok := backend.Backend.(MovingBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	offloaded, err := backend.offloadAll(table, items)
+
+	if err != nil {
+		return err
+	}
+
+	err = moving.MoveItems(table, offloaded, from)
+
+	if err != nil {
+		for _, item := range offloaded {
+			backend.deleteObject(item)
+		}
+
+		return err
+	}
+
+	for _, item := range from {
+		backend.deleteObject(item)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package gcredstash
+
+import (
+	"bytes"
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+type memoryS3 struct {
+	s3iface.S3API
+	objects map[string][]byte
+}
+
+func (svc *memoryS3) PutObject(params *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	if *params.ServerSideEncryption != s3.ServerSideEncryptionAwsKms {
+		return nil, errors.New("AccessDenied: objects must be encrypted with SSE-KMS")
+	}
+
+	body, _ := ioutil.ReadAll(params.Body)
+	svc.objects[*params.Bucket+"/"+*params.Key] = body
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (svc *memoryS3) GetObject(params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	body, ok := svc.objects[*params.Bucket+"/"+*params.Key]
+
+	if !ok {
+		return nil, errors.New("NoSuchKey: The specified key does not exist.")
+	}
+
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func (svc *memoryS3) DeleteObject(params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	delete(svc.objects, *params.Bucket+"/"+*params.Key)
+
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestParseS3Threshold(t *testing.T) {
+	for str, expected := range map[string]int{"": DEFAULT_S3_THRESHOLD, "4096": 4096, "64KB": 64 * 1024, "1MB": 1024 * 1024} {
+		if threshold, err := ParseS3Threshold(str); threshold != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, threshold, err)
+		}
+	}
+
+	_, err := ParseS3Threshold("0")
+	expected := "invalid s3_threshold: 0 (must be a size such as 100KB)"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
+
+func TestDriverS3Overflow(t *testing.T) {
+	table := "credential-store"
+	value := strings.Repeat("0123456789abcdef", 4*1024)
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		svc := &memoryS3{objects: map[string][]byte{}}
+		driver.S3 = svc
+		driver.S3Bucket = "credstash-large"
+		driver.S3Threshold = 32 * 1024
+		driver.Events = &NopEventHandler{}
+
+		driver.PutSecret("app.keystore", value, VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.PutSecret("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		if len(svc.objects) != 1 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 1, len(svc.objects))
+		}
+
+		content, _ := ioutil.ReadFile(f.Name())
+
+		if !strings.Contains(string(content), "s3://credstash-large/credential-store/app.keystore/0000000000000000001-") {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "a contents_s3 pointer", string(content))
+		}
+
+		if got, err := driver.GetSecret("app.keystore", "", table, nil); got != value || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", len(value), len(got), err)
+		}
+
+		// The HMAC in the item covers the object.
+		for key, body := range svc.objects {
+			body[0] ^= 0xff
+			svc.objects[key] = body
+		}
+
+		if _, err := driver.GetSecret("app.keystore", "", table, nil); !errors.Is(err, ErrIntegrity) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrIntegrity, err)
+		}
+
+		if err := driver.DeleteSecrets("app.keystore", "", table); err != nil || len(svc.objects) != 0 {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "the object deleted", err, svc.objects)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/ryanuber/go-glob"
+	"regexp"
+	"strings"
+)
+
+const (
+	SEARCH_SUBSTRING = "substring"
+	SEARCH_GLOB      = "glob"
+	SEARCH_REGEX     = "regex"
+	SEARCH_FUZZY     = "fuzzy"
+)
+
+// NameMatcher reports whether a credential name matches a search term.
+type NameMatcher func(name string) bool
+
+// NewNameMatcher returns a case-insensitive matcher for term. With
+// SEARCH_FUZZY, the characters of term must appear in the name in order,
+// e.g. "dbpw" matches "app.db.password".
+func NewNameMatcher(term string, mode string) (NameMatcher, error) {
+	lower := strings.ToLower(term)
+
+	switch mode {
+	case "", SEARCH_SUBSTRING:
+		return func(name string) bool {
+			return strings.Contains(strings.ToLower(name), lower)
+		}, nil
+	case SEARCH_GLOB:
+		return func(name string) bool {
+			return glob.Glob(lower, strings.ToLower(name))
+		}, nil
+	case SEARCH_REGEX:
+		re, err := regexp.Compile("(?i)" + term)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %s", term)
+		}
+
+		return re.MatchString, nil
+	case SEARCH_FUZZY:
+		return func(name string) bool {
+			return fuzzyMatch(lower, strings.ToLower(name))
+		}, nil
+	}
+
+	return nil, fmt.Errorf("invalid search mode: %s", mode)
+}
+
+func fuzzyMatch(term string, name string) bool {
+	for _, r := range term {
+		i := strings.IndexRune(name, r)
+
+		if i < 0 {
+			return false
+		}
+
+		name = name[i+len(string(r)):]
+	}
+
+	return true
+}
+
+// SearchSecrets returns the latest version of every credential whose name
+// matches.
+func (driver *Driver) SearchSecrets(table string, match NameMatcher) (map[string]int, error) {
+	items, err := driver.ListSecrets(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]int{}
+
+	for name, versions := range GroupVersions(items) {
+		if !match(name) {
+			continue
+		}
+
+		found[name] = Atoi(versions[len(versions)-1])
+	}
+
+	return found, nil
+}
@@ -0,0 +1,43 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"testing"
+)
+
+func TestNewNameMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		term     string
+		mode     string
+		name     string
+		expected bool
+	}{
+		{"DB", SEARCH_SUBSTRING, "app.db.password", true},
+		{"db.pass", "", "app.db.user", false},
+		{"app.*.PASSWORD", SEARCH_GLOB, "app.db.password", true},
+		{"app.*", SEARCH_GLOB, "web.app.key", false},
+		{"^APP\\.db", SEARCH_REGEX, "app.db.password", true},
+		{"^db", SEARCH_REGEX, "app.db.password", false},
+		{"adp", SEARCH_FUZZY, "app.db.password", true},
+		{"sdb", SEARCH_FUZZY, "app.db.password", false},
+	} {
+		match, err := NewNameMatcher(tc.term, tc.mode)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+			continue
+		}
+
+		if actual := match(tc.name); actual != tc.expected {
+			t.Errorf("\nexpected: %v\ngot: %v (%s %s %s)\n", tc.expected, actual, tc.mode, tc.term, tc.name)
+		}
+	}
+
+	if _, err := NewNameMatcher("(", SEARCH_REGEX); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+
+	if _, err := NewNameMatcher("a", "soundex"); err == nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "error", err)
+	}
+}
@@ -0,0 +1,47 @@
+package gcredstash
+
+import (
+	"io"
+	"runtime"
+)
+
+// SecureBytes holds plaintext secret material. It never prints its contents
+// through fmt, and its buffer is zeroed by Wipe or, as a fallback, when it is
+// garbage collected. Bytes returns the underlying buffer; callers must not
+// keep references to it after calling Wipe.
+type SecureBytes struct {
+	b []byte
+}
+
+// NewSecureBytes takes ownership of b.
+func NewSecureBytes(b []byte) *SecureBytes {
+	secure := &SecureBytes{b: b}
+	runtime.SetFinalizer(secure, (*SecureBytes).Wipe)
+	return secure
+}
+
+func (secure *SecureBytes) Bytes() []byte {
+	return secure.b
+}
+
+func (secure *SecureBytes) Len() int {
+	return len(secure.b)
+}
+
+func (secure *SecureBytes) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(secure.b)
+	return int64(n), err
+}
+
+func (secure *SecureBytes) Wipe() {
+	Wipe(secure.b)
+	secure.b = nil
+}
+
+func (secure *SecureBytes) String() string {
+	return "[REDACTED]"
+}
+
+func (secure *SecureBytes) GoString() string {
+	return "[REDACTED]"
+}
@@ -0,0 +1,33 @@
+package gcredstash
+
+import (
+	"bytes"
+	"fmt"
+	. "gcredstash"
+	"testing"
+)
+
+func TestSecureBytes(t *testing.T) {
+	b := []byte("secret")
+	secure := NewSecureBytes(b)
+
+	formatted := fmt.Sprintf("%v %s %#v", secure, secure, secure)
+	expected := "[REDACTED] [REDACTED] [REDACTED]"
+
+	if formatted != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, formatted)
+	}
+
+	buf := &bytes.Buffer{}
+	secure.WriteTo(buf)
+
+	if buf.String() != "secret" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "secret", buf.String())
+	}
+
+	secure.Wipe()
+
+	if !bytes.Equal(b, make([]byte, 6)) || secure.Len() != 0 {
+		t.Errorf("\nexpected: %v\ngot: %v\n", make([]byte, 6), b)
+	}
+}
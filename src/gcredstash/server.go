@@ -0,0 +1,304 @@
+package gcredstash
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	SERVER_SECRETS_PATH = "/v1/secrets"
+	SERVER_METRICS_PATH = "/metrics"
+)
+
+// SecretResponse is the body of a successful get or put.
+type SecretResponse struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	Value   string `json:"value,omitempty"`
+}
+
+// PutRequest is the body of a put. Without a version, the next version
+// after the highest stored one is used.
+type PutRequest struct {
+	Value   string            `json:"value"`
+	Version int               `json:"version,omitempty"`
+	Context map[string]string `json:"context,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server exposes a credential store over HTTP:
+//
+//	GET    /v1/secrets                  list names and versions
+//	GET    /v1/secrets/NAME[?version=N] get a credential
+//	PUT    /v1/secrets/NAME             put a credential (PutRequest)
+//	DELETE /v1/secrets/NAME[?version=N] delete a credential
+//
+// The encryption context of get is given as context=KEY=VALUE query
+// parameters. Reads of the latest version without a context are served
+// from Cache when it is set. When Metrics is set, GET /metrics returns them
+// in the Prometheus text format.
+type Server struct {
+	Client  *Client
+	Cache   *SecretCache
+	Metrics *Metrics
+	Logger  Logger
+}
+
+func (server *Server) logger() Logger {
+	if server.Logger == nil {
+		return &NopLogger{}
+	}
+
+	return server.Logger
+}
+
+// httpStatus maps an error to the status code a client can act on.
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrSecretNotFound), errors.Is(err, ErrVersionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrReadOnly), IsAccessDenied(err):
+		return http.StatusForbidden
+	case errors.Is(err, ErrContextRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrExpired):
+		return http.StatusGone
+	case IsThrottle(err):
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJson(w, status, &errorResponse{Error: err.Error()})
+}
+
+func parseVersionParam(r *http.Request) ([]CallOption, error) {
+	versionStr := r.URL.Query().Get("version")
+
+	if versionStr == "" {
+		return nil, nil
+	}
+
+	version, err := strconv.Atoi(versionStr)
+
+	if err != nil || version < 1 {
+		return nil, fmt.Errorf("invalid version: %s", versionStr)
+	}
+
+	return []CallOption{WithVersion(version)}, nil
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		server.logger().Verbosef("%s %s client=%s", r.Method, r.URL.Path, r.TLS.PeerCertificates[0].Subject.CommonName)
+	} else {
+		server.logger().Verbosef("%s %s", r.Method, r.URL.Path)
+	}
+
+	if r.URL.Path == SERVER_METRICS_PATH && server.Metrics != nil {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		server.Metrics.WriteTo(w, server.Cache)
+		return
+	}
+
+	if r.URL.Path == SERVER_SECRETS_PATH {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+			return
+		}
+
+		server.list(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, SERVER_SECRETS_PATH+"/") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("not found: %s", r.URL.Path))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, SERVER_SECRETS_PATH+"/")
+
+	if name == "" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("not found: %s", r.URL.Path))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		server.get(w, r, name)
+	case http.MethodPut:
+		server.put(w, r, name)
+	case http.MethodDelete:
+		server.delete(w, r, name)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+	}
+}
+
+func (server *Server) list(w http.ResponseWriter, r *http.Request) {
+	names, err := server.Client.List(r.Context())
+
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+
+	versions := map[string][]int{}
+
+	for name, strs := range names {
+		for _, str := range strs {
+			versions[name] = append(versions[name], Atoi(str))
+		}
+	}
+
+	writeJson(w, http.StatusOK, versions)
+}
+
+func (server *Server) get(w http.ResponseWriter, r *http.Request, name string) {
+	opts, err := parseVersionParam(r)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	context, err := ParseContext(r.URL.Query()["context"])
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var value []byte
+	var version string
+
+	if server.Cache != nil && len(opts) == 0 && len(context) == 0 {
+		value, version, err = server.Cache.GetWithVersion(name)
+	} else {
+		value, version, err = server.Client.GetBytesWithVersion(r.Context(), name, append(opts, WithEncryptionContext(context))...)
+	}
+
+	if server.Metrics != nil {
+		server.Metrics.ObserveFetch(name, accessResult(err))
+	}
+
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+
+	defer Wipe(value)
+
+	writeJson(w, http.StatusOK, &SecretResponse{Name: name, Version: Atoi(version), Value: string(value)})
+}
+
+func (server *Server) put(w http.ResponseWriter, r *http.Request, name string) {
+	body, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req := &PutRequest{}
+	err = json.Unmarshal(body, req)
+	Wipe(body)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	opts := []CallOption{WithEncryptionContext(req.Context)}
+
+	if req.Version > 0 {
+		opts = append(opts, WithVersion(req.Version))
+	}
+
+	version, err := server.Client.Put(r.Context(), name, req.Value, opts...)
+
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+
+	if server.Cache != nil {
+		server.Cache.Invalidate(name)
+	}
+
+	writeJson(w, http.StatusCreated, &SecretResponse{Name: name, Version: version})
+}
+
+func (server *Server) delete(w http.ResponseWriter, r *http.Request, name string) {
+	opts, err := parseVersionParam(r)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = server.Client.Delete(r.Context(), name, opts...)
+
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+
+	if server.Cache != nil {
+		server.Cache.Invalidate(name)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NewServerTLSConfig returns a TLS config that serves certFile and only
+// accepts clients with a certificate signed by a CA in clientCAFile.
+func NewServerTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pem, err := ioutil.ReadFile(clientCAFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+
+	if !clientCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s: no certificates found", clientCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func serve(handler http.Handler, method string, path string, body string) (int, string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func TestServer(t *testing.T) {
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		client := &Client{Driver: driver, Table: "credential-store", KmsKey: "alias/credstash"}
+		server := &Server{Client: client, Cache: NewSecretCache(driver, "credential-store", nil, time.Minute)}
+
+		for _, tc := range []struct {
+			method string
+			path   string
+			body   string
+			code   int
+			out    string
+		}{
+			{"PUT", "/v1/secrets/foo.bar", `{"value":"100"}`, 201, `{"name":"foo.bar","version":1}`},
+			{"GET", "/v1/secrets/foo.bar", "", 200, `{"name":"foo.bar","version":1,"value":"100"}`},
+			{"PUT", "/v1/secrets/foo.bar", `{"value":"200"}`, 201, `{"name":"foo.bar","version":2}`},
+			{"GET", "/v1/secrets/foo.bar", "", 200, `{"name":"foo.bar","version":2,"value":"200"}`},
+			{"GET", "/v1/secrets/foo.bar?version=1", "", 200, `{"name":"foo.bar","version":1,"value":"100"}`},
+			{"PUT", "/v1/secrets/foo.baz", `{"value":"300","context":{"app":"web"}}`, 201, `{"name":"foo.baz","version":1}`},
+			{"GET", "/v1/secrets/foo.baz?context=app=web", "", 200, `{"name":"foo.baz","version":1,"value":"300"}`},
+			{"GET", "/v1/secrets", "", 200, `{"foo.bar":[1,2],"foo.baz":[1]}`},
+			{"DELETE", "/v1/secrets/foo.bar?version=1", "", 204, ""},
+			{"GET", "/v1/secrets/foo.bar?version=1", "", 404, `{"error":"Item {'name': 'foo.bar', 'version': 1} couldn't be found."}`},
+			{"GET", "/v1/secrets/foo.qux", "", 404, `{"error":"Item {'name': 'foo.qux'} couldn't be found."}`},
+			{"GET", "/v1/secrets/foo.bar?version=x", "", 400, `{"error":"invalid version: x"}`},
+			{"POST", "/v1/secrets/foo.bar", "", 405, `{"error":"method not allowed: POST"}`},
+			{"GET", "/v2/secrets", "", 404, `{"error":"not found: /v2/secrets"}`},
+		} {
+			code, out := serve(server, tc.method, tc.path, tc.body)
+
+			if code != tc.code || out != tc.out {
+				t.Errorf("\n%s %s\nexpected: %v %v\ngot: %v %v\n", tc.method, tc.path, tc.code, tc.out, code, out)
+			}
+		}
+
+		server = &Server{Client: client, Cache: NewSecretCache(driver, "credential-store", nil, time.Minute), Metrics: NewMetrics()}
+		serve(server, "GET", "/v1/secrets/foo.bar", "")
+		serve(server, "GET", "/v1/secrets/foo.bar", "")
+		serve(server, "GET", "/v1/secrets/foo.qux", "")
+		code, out := serve(server, "GET", "/metrics", "")
+
+		for _, line := range []string{
+			"gcredstash_cache_hits_total 1",
+			"gcredstash_cache_misses_total 2",
+			`gcredstash_secret_fetches_total{name="foo.bar",result="ok"} 2`,
+			`gcredstash_secret_fetches_total{name="",result="not_found"} 1`,
+		} {
+			if code != 200 || !strings.Contains(out+"\n", line+"\n") {
+				t.Errorf("\nexpected: %v\ngot: %v %v\n", line, code, out)
+			}
+		}
+
+		driver.ReadOnly = true
+		code, _ = serve(server, "PUT", "/v1/secrets/foo.bar", `{"value":"400"}`)
+
+		if code != 403 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 403, code)
+		}
+	})
+}
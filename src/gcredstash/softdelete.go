@@ -0,0 +1,212 @@
+package gcredstash
+
+import (
+	"errors"
+	"fmt"
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbv2 "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// ReplacingBackend is implemented by backends that can overwrite a stored
+// item, which soft delete and restore need.
+type ReplacingBackend interface {
+	ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error
+}
+
+func (backend *DynamoDBBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	_, err := backend.Ddb.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      item,
+	})
+
+	return err
+}
+
+func (backend *DynamoDBV2Backend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	_, err := backend.Ddb.PutItem(ctx, &dynamodbv2.PutItemInput{
+		TableName: awsv2.String(table),
+		Item:      itemToV2(item),
+	})
+
+	return backend.requestError(ctx, "PutItem", table, err)
+}
+
+func (backend *FileBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	tables, err := backend.load()
+
+	if err != nil {
+		return err
+	}
+
+	m := itemToFileItem(item)
+	kept := []map[string]string{}
+
+	for _, stored := range tables[table] {
+		if stored["name"] != m["name"] || stored["version"] != m["version"] {
+			kept = append(kept, stored)
+		}
+	}
+
+	tables[table] = append(kept, m)
+
+	return backend.save(tables)
+}
+
+func (backend *DryRunBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	backend.Logger.Infof("dry-run: update %s -- version %d in %s", stringAttr(item, "name"), Atoi(stringAttr(item, "version")), table)
+
+	return nil
+}
+
+func (backend *NamespaceBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	replacing, ok := backend.Backend.(ReplacingBackend)
+
+	if !ok {
+		return errSoftDeleteUnsupported
+	}
+
+	return replacing.ReplaceItem(table, backend.addPrefix(item))
+}
+
+var errSoftDeleteUnsupported = errors.New("the configured backend does not support soft delete")
+
+// TombstoneBackend hides soft-deleted versions, i.e. items with a
+// deleted_at attribute, unless a query or scan asks for them with
+// IncludeDeleted. It is always in place, so a deleted version does not come
+// back when soft delete is turned off.
+type TombstoneBackend struct {
+	Backend Backend
+}
+
+func isTombstone(item map[string]*dynamodb.AttributeValue) bool {
+	return stringAttr(item, "deleted_at") != ""
+}
+
+func withoutTombstones(items []map[string]*dynamodb.AttributeValue) []map[string]*dynamodb.AttributeValue {
+	live := []map[string]*dynamodb.AttributeValue{}
+
+	for _, item := range items {
+		if !isTombstone(item) {
+			live = append(live, item)
+		}
+	}
+
+	return live
+}
+
+// withDeletedAt adds deleted_at to a projection, so that tombstones can be
+// told apart. An empty projection already returns every attribute.
+func withDeletedAt(attrs []string) []string {
+	if len(attrs) == 0 {
+		return attrs
+	}
+
+	return append(append([]string{}, attrs...), "deleted_at")
+}
+
+func (backend *TombstoneBackend) GetItem(table string, name string, version string) (map[string]*dynamodb.AttributeValue, error) {
+	item, err := backend.Backend.GetItem(table, name, version)
+
+	if err != nil || isTombstone(item) {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (backend *TombstoneBackend) PutItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	return backend.Backend.PutItem(table, item)
+}
+
+func (backend *TombstoneBackend) Query(table string, name string, opts *QueryOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	if opts != nil && opts.IncludeDeleted {
+		return backend.Backend.Query(table, name, opts)
+	}
+
+	queryOpts := &QueryOptions{}
+
+	if opts != nil {
+		*queryOpts = *opts
+	}
+
+	queryOpts.Attributes = withDeletedAt(queryOpts.Attributes)
+	items, err := backend.Backend.Query(table, name, queryOpts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	live := withoutTombstones(items)
+
+	// Tombstones took up part of the limit, so there may be more live
+	// versions after them.
+	if queryOpts.Limit > 0 && len(live) < len(items) && int64(len(items)) == queryOpts.Limit {
+		queryOpts.Limit = 0
+		items, err = backend.Backend.Query(table, name, queryOpts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		live = withoutTombstones(items)
+
+		if int64(len(live)) > opts.Limit {
+			live = live[:opts.Limit]
+		}
+	}
+
+	return live, nil
+}
+
+func (backend *TombstoneBackend) Scan(table string, opts *ScanOptions) ([]map[string]*dynamodb.AttributeValue, error) {
+	if opts != nil && opts.IncludeDeleted {
+		return backend.Backend.Scan(table, opts)
+	}
+
+	scanOpts := &ScanOptions{}
+
+	if opts != nil {
+		*scanOpts = *opts
+	}
+
+	scanOpts.Attributes = withDeletedAt(scanOpts.Attributes)
+	items, err := backend.Backend.Scan(table, scanOpts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutTombstones(items), nil
+}
+
+func (backend *TombstoneBackend) Delete(table string, name string, version string) error {
+	return backend.Backend.Delete(table, name, version)
+}
+
+func (backend *TombstoneBackend) PutItems(table string, items []map[string]*dynamodb.AttributeValue) error {
+	transactional, ok := backend.Backend.(TransactionalBackend)
+
+	if !ok {
+		return fmt.Errorf("the configured backend does not support transactions")
+	}
+
+	return transactional.PutItems(table, items)
+}
+
+func (backend *TombstoneBackend) ReplaceItem(table string, item map[string]*dynamodb.AttributeValue) error {
+	replacing, ok := backend.Backend.(ReplacingBackend)
+
+	if !ok {
+		return errSoftDeleteUnsupported
+	}
+
+	return replacing.ReplaceItem(table, item)
+}
@@ -0,0 +1,147 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSoftDelete(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Events = &NopEventHandler{}
+		driver.SoftDelete = true
+		driver.Author = "alice"
+		driver.Now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+		for i, value := range []string{"100", "200", "300"} {
+			driver.PutSecret("test.key", value, VersionNumToStr(i+1), "alias/credstash", table, nil)
+		}
+
+		if err := driver.DeleteSecrets("test.key", VersionNumToStr(3), table); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		value, err := driver.GetSecret("test.key", "", table, nil)
+
+		if value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+
+		_, err = driver.GetSecret("test.key", VersionNumToStr(3), table, nil)
+
+		if !errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionNotFound, err)
+		}
+
+		version, err := driver.PutSecretNextVersion("test.key", "400", "alias/credstash", table, nil, nil)
+
+		if version != VersionNumToStr(4) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", VersionNumToStr(4), version, err)
+		}
+
+		if err := driver.DeleteSecrets("test.key", "", table); err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		_, err = driver.GetSecret("test.key", "", table, nil)
+
+		if !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+
+		items, _ := driver.ListSecrets(table)
+
+		if len(items) != 0 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", 0, GroupVersions(items))
+		}
+
+		items, _ = driver.DeletedSecrets(table, "")
+		expected := []string{VersionNumToStr(1), VersionNumToStr(2), VersionNumToStr(3), VersionNumToStr(4)}
+
+		if !reflect.DeepEqual(GroupVersions(items)["test.key"], expected) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, GroupVersions(items))
+		}
+
+		raw, _ := (&FileBackend{Path: f.Name()}).GetItem(table, "test.key", VersionNumToStr(1))
+
+		if *raw["deleted_at"].S != "2026-01-02T03:04:05Z" || *raw["deleted_by"].S != "alice" {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "deleted_at and deleted_by", raw)
+		}
+
+		restored, err := driver.RestoreSecrets("test.key", VersionNumToStr(2), table)
+
+		if !reflect.DeepEqual(restored, []string{VersionNumToStr(2)}) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", []string{VersionNumToStr(2)}, restored, err)
+		}
+
+		value, err = driver.GetSecret("test.key", "", table, nil)
+
+		if value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+
+		_, err = driver.RestoreSecrets("test.key", VersionNumToStr(2), table)
+		expectedErr := "test.key version 2 is not deleted"
+
+		if err == nil || err.Error() != expectedErr {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expectedErr, err)
+		}
+
+		purged, err := driver.PurgeSecrets("test.key", "", table)
+		expected = []string{VersionNumToStr(1), VersionNumToStr(3), VersionNumToStr(4)}
+
+		if !reflect.DeepEqual(purged, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, purged, err)
+		}
+
+		_, err = driver.RestoreSecrets("test.key", VersionNumToStr(1), table)
+
+		if !errors.Is(err, ErrVersionNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrVersionNotFound, err)
+		}
+
+		value, err = driver.GetSecret("test.key", "", table, nil)
+
+		if value != "200" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "200", value, err)
+		}
+	})
+}
+
+func TestSoftDeleteOff(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		driver.Events = &NopEventHandler{}
+		driver.SoftDelete = true
+
+		driver.PutSecret("test.key", "100", VersionNumToStr(1), "alias/credstash", table, nil)
+		driver.DeleteSecrets("test.key", "", table)
+		driver.SoftDelete = false
+
+		_, err := driver.GetSecret("test.key", "", table, nil)
+
+		if !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+
+		_, err = driver.RestoreSecrets("test.key", "", table)
+
+		if err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		driver.DeleteSecrets("test.key", "", table)
+		_, err = driver.RestoreSecrets("test.key", "", table)
+
+		if !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", ErrSecretNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package gcredstash
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// NormalizeSSHPrivateKey checks that key is a PEM encoded private key, as
+// written by ssh-keygen, and ends it with the newline ssh requires.
+func NormalizeSSHPrivateKey(key []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(key)
+
+	if !bytes.HasPrefix(trimmed, []byte("-----BEGIN ")) || !bytes.HasSuffix(trimmed, []byte(" PRIVATE KEY-----")) {
+		return nil, fmt.Errorf("not a PEM encoded private key")
+	}
+
+	return append(trimmed, '\n'), nil
+}
+
+// WriteSSHKeyFile writes key to path with 0600 permissions. The file is
+// replaced rather than rewritten, so an existing file with wider
+// permissions never holds the key.
+func WriteSSHKeyFile(path string, key []byte) error {
+	tmpfile, err := ioutil.TempFile(filepath.Dir(path), ".gcredstash")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmpfile.Name())
+
+	err = tmpfile.Chmod(0600)
+
+	if err != nil {
+		tmpfile.Close()
+		return err
+	}
+
+	_, err = tmpfile.Write(key)
+
+	if err != nil {
+		tmpfile.Close()
+		return err
+	}
+
+	err = tmpfile.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpfile.Name(), path)
+}
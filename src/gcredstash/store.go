@@ -0,0 +1,226 @@
+package gcredstash
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"strconv"
+)
+
+// Store is a credential store: a driver and the table and KMS key it uses.
+type Store struct {
+	Driver *Driver
+	Table  string
+	KmsKey string
+}
+
+// NewSession returns a session for the region, AWS profile and credential
+// options of a config profile. Without any, the standard AWS environment is
+// used as is.
+func NewSession(profile *ConfigProfile) (*session.Session, error) {
+	creds, err := profile.CredentialOptions()
+
+	if err != nil {
+		return nil, err
+	}
+
+	options := session.Options{SharedConfigState: session.SharedConfigEnable}
+
+	if profile.Region != "" {
+		options.Config.Region = aws.String(profile.Region)
+	}
+
+	options.Profile = profile.Profile
+
+	if options.Config.Region == nil && options.Profile == "" && creds == (CredentialOptions{}) {
+		return session.New(), nil
+	}
+
+	return newSessionWithCredentials(options, creds)
+}
+
+// NewStore returns a Store for a resolved config profile, with the default
+// table and KMS key when the profile does not set them. Placeholders in the
+// table and KMS keys are expanded with Expand, and KMS key ARNs are
+// validated with ValidateKmsKey. The AWS calls of the store are limited to
+// the profile's RateLimit per second and its Timeout each, and fail with an
+// *AccessDeniedError when they are denied. With AwsSdk v2, credential items are read and
+// written with DynamoDBV2Backend.
+func NewStore(profile *ConfigProfile, logger Logger) (*Store, error) {
+	awsSession, err := NewSession(profile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	AddLoggingHandlers(&awsSession.Handlers, logger)
+	AddAccessDeniedHandlers(&awsSession.Handlers)
+
+	if profile.RateLimit != "" {
+		rate, err := ParseRateLimit(profile.RateLimit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		AddRateLimitHandlers(&awsSession.Handlers, rate)
+	}
+
+	timeout := DEFAULT_TIMEOUT
+
+	if profile.Timeout != "" {
+		timeout, err = ParseTimeout(profile.Timeout)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeout > 0 {
+		AddTimeoutHandlers(&awsSession.Handlers, timeout)
+	}
+
+	table, err := profile.Expand(profile.Table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	kmsKey, err := profile.Expand(profile.KmsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	readKmsKey, err := profile.Expand(profile.ReadKmsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryKmsKey, err := profile.Expand(profile.RecoveryKmsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{kmsKey, readKmsKey, recoveryKmsKey} {
+		if err := ValidateKmsKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range profile.NamespaceKeys {
+		if err := ValidateKmsKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	store := &Store{
+		Driver: NewDriver(awsSession, logger),
+		Table:  table,
+		KmsKey: kmsKey,
+	}
+
+	store.Driver.Context = profile.Context
+	store.Driver.Namespace = profile.Namespace
+	store.Driver.NamespaceKeys = profile.NamespaceKeys
+	store.Driver.ReadKmsKey = readKmsKey
+	store.Driver.RecoveryKmsKey = recoveryKmsKey
+
+	if err := ValidateAwsSdk(profile.AwsSdk); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateReadConsistency(profile.ReadConsistency); err != nil {
+		return nil, err
+	}
+
+	store.Driver.ReadConsistency = profile.ReadConsistency
+
+	store.Driver.Naming, err = profile.NamingRules()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.SoftDelete != "" {
+		store.Driver.SoftDelete, err = strconv.ParseBool(profile.SoftDelete)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid soft_delete: %s (must be true or false)", profile.SoftDelete)
+		}
+	}
+
+	if profile.ChunkedStorage != "" {
+		store.Driver.ChunkedStorage, err = strconv.ParseBool(profile.ChunkedStorage)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunked_storage: %s (must be true or false)", profile.ChunkedStorage)
+		}
+	}
+
+	store.Driver.S3Bucket = profile.S3Bucket
+	store.Driver.S3Threshold, err = ParseS3Threshold(profile.S3Threshold)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.OfflineCache != "" {
+		offlineCache, err := strconv.ParseBool(profile.OfflineCache)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid offline_cache: %s (must be true or false)", profile.OfflineCache)
+		}
+
+		if offlineCache {
+			store.Driver.DataKeyCache = NewDataKeyCache(DefaultDataKeyCachePath(), NewOSKeychain())
+		}
+	}
+
+	keychainCacheTTL, err := ParseKeychainCacheTTL(profile.KeychainCacheTTL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if keychainCacheTTL > 0 {
+		store.Driver.KeychainCache = NewKeychainCache(NewOSKeychain(), keychainCacheTTL)
+	}
+
+	if profile.DaxEndpoint != "" {
+		if profile.AwsSdk == AWS_SDK_V2 {
+			return nil, fmt.Errorf("dax_endpoint cannot be used with aws_sdk: v2")
+		}
+
+		store.Driver.Dax, err = NewDaxClient(awsSession, profile.DaxEndpoint)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if profile.AwsSdk == AWS_SDK_V2 {
+		cfg, err := LoadAwsV2Config(context.Background(), profile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		backend := NewDynamoDBV2Backend(cfg)
+		backend.Timeout = timeout
+		store.Driver.Backend = backend
+	}
+
+	if store.Table == "" {
+		store.Table = DEFAULT_TABLE
+	}
+
+	if store.KmsKey == "" {
+		store.KmsKey = DEFAULT_KMS_KEY
+	}
+
+	return store, nil
+}
@@ -0,0 +1,111 @@
+package gcredstash
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"strings"
+)
+
+// ParseTags parses key=value tags, as given with --tag.
+func ParseTags(strs []string) (map[string]string, error) {
+	tags := map[string]string{}
+
+	for _, str := range strs {
+		kv := strings.SplitN(str, "=", 2)
+
+		if len(kv) < 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid tag: %s (must be key=value)", str)
+		}
+
+		tags[kv[0]] = kv[1]
+	}
+
+	return tags, nil
+}
+
+// MatchTags reports whether tags has every key of filter with the same
+// value.
+func MatchTags(tags map[string]string, filter map[string]string) bool {
+	for key, value := range filter {
+		if tag, ok := tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func tagsAttr(tags map[string]string) *dynamodb.AttributeValue {
+	m := map[string]*dynamodb.AttributeValue{}
+
+	for key, value := range tags {
+		m[key] = &dynamodb.AttributeValue{S: aws.String(value)}
+	}
+
+	return &dynamodb.AttributeValue{M: m}
+}
+
+func mapAttr(item map[string]*dynamodb.AttributeValue, attr string) map[string]string {
+	value, ok := item[attr]
+
+	if !ok || value.M == nil {
+		return nil
+	}
+
+	m := map[string]string{}
+
+	for key, v := range value.M {
+		if v != nil && v.S != nil {
+			m[key] = *v.S
+		}
+	}
+
+	return m
+}
+
+// CredentialTags returns the tags of every credential under prefix. They
+// are taken from the newest version that has any, so they need not be
+// repeated on every put.
+func (driver *Driver) CredentialTags(table string, prefix string) (map[string]map[string]string, error) {
+	infos, err := driver.ListSecretsWithMetadata(table, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]map[string]string{}
+	versions := map[string]string{}
+
+	for _, info := range infos {
+		if _, ok := tags[info.Name]; !ok {
+			tags[info.Name] = map[string]string{}
+		}
+
+		if len(info.Tags) > 0 && info.Version > versions[info.Name] {
+			tags[info.Name] = info.Tags
+			versions[info.Name] = info.Version
+		}
+	}
+
+	return tags, nil
+}
+
+// TaggedNames returns the credentials under prefix whose tags match filter.
+func (driver *Driver) TaggedNames(table string, prefix string, filter map[string]string) (map[string]bool, error) {
+	tags, err := driver.CredentialTags(table, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+
+	for name, credentialTags := range tags {
+		if MatchTags(credentialTags, filter) {
+			names[name] = true
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,62 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"gcredstash/testutils"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tags, err := ParseTags([]string{"team=payments", "env=prod=eu"})
+	expected := map[string]string{"team": "payments", "env": "prod=eu"}
+
+	if !reflect.DeepEqual(tags, expected) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, tags, err)
+	}
+
+	for _, str := range []string{"team", "=payments"} {
+		_, err := ParseTags([]string{str})
+		expected := "invalid tag: " + str + " (must be key=value)"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	}
+}
+
+func TestDriverCredentialTags(t *testing.T) {
+	table := "credential-store"
+
+	testutils.TempDriver(func(driver *Driver, f *os.File) {
+		tags := map[string]string{"team": "payments", "env": "prod"}
+
+		driver.PutSecretWithOptions("db.pass", "100", VersionNumToStr(1), "alias/credstash", table, nil, &PutOptions{Tags: tags})
+		driver.PutSecret("db.pass", "200", VersionNumToStr(2), "alias/credstash", table, nil)
+		driver.PutSecret("db.user", "admin", VersionNumToStr(1), "alias/credstash", table, nil)
+
+		credentialTags, err := driver.CredentialTags(table, "")
+		expected := map[string]map[string]string{"db.pass": tags, "db.user": {}}
+
+		if !reflect.DeepEqual(credentialTags, expected) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, credentialTags, err)
+		}
+
+		names, err := driver.TaggedNames(table, "", map[string]string{"team": "payments"})
+
+		if !reflect.DeepEqual(names, map[string]bool{"db.pass": true}) || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", map[string]bool{"db.pass": true}, names, err)
+		}
+
+		names, err = driver.TaggedNames(table, "", map[string]string{"team": "payments", "env": "dev"})
+
+		if len(names) != 0 || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", map[string]bool{}, names, err)
+		}
+
+		if value, err := driver.GetSecret("db.pass", VersionNumToStr(1), table, nil); value != "100" || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", "100", value, err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package gcredstash
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TerraformQuery is the query a Terraform external data source sends on
+// stdin. Either Name or Prefix is set. Keys other than name, version and
+// prefix are the encryption context.
+type TerraformQuery struct {
+	Name    string
+	Version string
+	Prefix  string
+	Context map[string]string
+}
+
+// ParseTerraformQuery parses a query such as {"name": "db.password"}.
+// Terraform only sends string values.
+func ParseTerraformQuery(input string) (*TerraformQuery, error) {
+	fields := map[string]string{}
+
+	if err := json.Unmarshal([]byte(input), &fields); err != nil {
+		return nil, fmt.Errorf("invalid query: %s", err.Error())
+	}
+
+	query := &TerraformQuery{Context: map[string]string{}}
+
+	for key, value := range fields {
+		switch key {
+		case "name":
+			query.Name = value
+		case "version":
+			query.Version = value
+		case "prefix":
+			query.Prefix = value
+		default:
+			query.Context[key] = value
+		}
+	}
+
+	if (query.Name == "") == (query.Prefix == "") {
+		return nil, fmt.Errorf("invalid query: one of name or prefix is required")
+	}
+
+	if query.Prefix != "" && query.Version != "" {
+		return nil, fmt.Errorf("invalid query: version cannot be used with prefix")
+	}
+
+	if query.Version != "" {
+		ver, err := strconv.Atoi(query.Version)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: version: %s", err.Error())
+		}
+
+		query.Version = VersionNumToStr(ver)
+	}
+
+	return query, nil
+}
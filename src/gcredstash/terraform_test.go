@@ -0,0 +1,44 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"reflect"
+	"testing"
+)
+
+func TestParseTerraformQuery(t *testing.T) {
+	query, err := ParseTerraformQuery(`{"name": "db.password", "version": "2", "app": "web"}`)
+
+	expected := &TerraformQuery{
+		Name:    "db.password",
+		Version: "0000000000000000002",
+		Context: map[string]string{"app": "web"},
+	}
+
+	if !reflect.DeepEqual(query, expected) || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, query, err)
+	}
+
+	query, err = ParseTerraformQuery(`{"prefix": "app."}`)
+
+	if query.Prefix != "app." || err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v %v\n", "app.", query, err)
+	}
+}
+
+func TestParseTerraformQueryErrors(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`{"name": 1}`,
+		`{}`,
+		`{"name": "db.password", "prefix": "app."}`,
+		`{"prefix": "app.", "version": "1"}`,
+		`{"name": "db.password", "version": "latest"}`,
+	} {
+		_, err := ParseTerraformQuery(input)
+
+		if err == nil {
+			t.Errorf("\nexpected: %v\ngot: %v\n", "error", input)
+		}
+	}
+}
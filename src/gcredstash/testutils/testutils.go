@@ -1,10 +1,19 @@
 package testutils
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"gcredstash"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"io/ioutil"
+	"math/big"
 	"os"
+	"time"
 )
 
 func MapToItem(m map[string]string) map[string]*dynamodb.AttributeValue {
@@ -57,6 +66,23 @@ func TempFile(content string, f func(*os.File)) {
 	}
 }
 
+// LOCAL_MASTER_KEY is the master key of the LocalKms of TempDriver.
+const LOCAL_MASTER_KEY = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+// TempDriver calls f with a Driver on a FileBackend in a temporary file,
+// encrypting with a LocalKms, and with a NopLogger.
+func TempDriver(f func(*gcredstash.Driver, *os.File)) {
+	localKms, err := gcredstash.NewLocalKms(LOCAL_MASTER_KEY)
+
+	if err != nil {
+		panic(err)
+	}
+
+	TempFile("", func(tmpfile *os.File) {
+		f(&gcredstash.Driver{Backend: &gcredstash.FileBackend{Path: tmpfile.Name()}, Kms: localKms, Logger: &gcredstash.NopLogger{}}, tmpfile)
+	})
+}
+
 func Setenv(key, value string) {
 	err := os.Setenv(key, value)
 
@@ -64,3 +90,38 @@ func Setenv(key, value string) {
 		panic(err)
 	}
 }
+
+// SelfSignedCert returns a PEM certificate for commonName that expires at
+// notAfter, and its PEM private key.
+func SelfSignedCert(commonName string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	return string(cert), string(keyPem)
+}
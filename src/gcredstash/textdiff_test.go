@@ -0,0 +1,16 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	actual := DiffLines("a\nb\nc", "a\nc\nd")
+	expected := []string{"  a", "- b", "  c", "+ d"}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
@@ -0,0 +1,97 @@
+package gcredstash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"time"
+)
+
+// DEFAULT_TIMEOUT is how long an AWS operation, retries included, may take
+// when the config does not set timeout.
+const DEFAULT_TIMEOUT = 10 * time.Second
+
+var ErrTimeout = errors.New("timed out")
+
+// TimeoutError is returned when an AWS operation does not complete within
+// its timeout, e.g. because the endpoint does not answer. errors.Is(err,
+// ErrTimeout) reports it.
+type TimeoutError struct {
+	Service   string
+	Region    string
+	Operation string
+	Timeout   time.Duration
+	Err       error
+}
+
+func (e *TimeoutError) Error() string {
+	msg := "timed out talking to " + e.Service
+
+	if e.Region != "" {
+		msg += " in region " + e.Region
+	}
+
+	return msg + fmt.Sprintf(": %s did not complete within %s", e.Operation, e.Timeout)
+}
+
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// ParseTimeout parses the timeout setting, a duration such as 10s. 0
+// disables the timeout.
+func ParseTimeout(str string) (time.Duration, error) {
+	if str == "0" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(str)
+
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout: %s (must be a duration such as 10s, or 0)", str)
+	}
+
+	return timeout, nil
+}
+
+// AddTimeoutHandlers makes AWS requests made with handlers fail with a
+// *TimeoutError when they, retries included, take longer than timeout.
+func AddTimeoutHandlers(handlers *request.Handlers, timeout time.Duration) {
+	// Validate runs once, before the first attempt.
+	handlers.Validate.PushFront(func(r *request.Request) {
+		parent := r.Context()
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		r.SetContext(ctx)
+
+		r.Handlers.AfterRetry.PushBack(func(r *request.Request) {
+			// A deadline of the caller's context is not our timeout.
+			if r.Error != nil && ctx.Err() == context.DeadlineExceeded && parent.Err() == nil {
+				r.Error = &TimeoutError{
+					Service:   serviceDisplayName(r),
+					Region:    aws.StringValue(r.Config.Region),
+					Operation: r.Operation.Name,
+					Timeout:   timeout,
+					Err:       r.Error,
+				}
+			}
+		})
+
+		r.Handlers.Complete.PushBack(func(r *request.Request) {
+			cancel()
+		})
+	})
+}
+
+func serviceDisplayName(r *request.Request) string {
+	if r.ClientInfo.ServiceID != "" {
+		return r.ClientInfo.ServiceID
+	}
+
+	return r.ClientInfo.ServiceName
+}
@@ -0,0 +1,64 @@
+package gcredstash
+
+import (
+	"errors"
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseTimeout(t *testing.T) {
+	for str, expected := range map[string]time.Duration{"10s": 10 * time.Second, "1m30s": 90 * time.Second, "0": 0} {
+		timeout, err := ParseTimeout(str)
+
+		if timeout != expected || err != nil {
+			t.Errorf("\nexpected: %v\ngot: %v %v\n", expected, timeout, err)
+		}
+	}
+
+	for _, str := range []string{"10", "-1s", "x"} {
+		_, err := ParseTimeout(str)
+		expected := "invalid timeout: " + str + " (must be a duration such as 10s, or 0)"
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	}
+}
+
+func TestAddTimeoutHandlers(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+
+	defer server.Close()
+	defer close(done)
+
+	awsSession := session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	}))
+
+	AddTimeoutHandlers(&awsSession.Handlers, 50*time.Millisecond)
+
+	_, err := dynamodb.New(awsSession).GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("credential-store"),
+		Key:       map[string]*dynamodb.AttributeValue{"name": {S: aws.String("db.pass")}},
+	})
+	expected := "timed out talking to DynamoDB in region us-east-1: GetItem did not complete within 50ms"
+
+	if err == nil || err.Error() != expected || !errors.Is(err, ErrTimeout) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
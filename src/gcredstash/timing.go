@@ -0,0 +1,257 @@
+package gcredstash
+
+import (
+	"context"
+	"fmt"
+	dynamodbv2 "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+type timingStat struct {
+	calls    int
+	retries  int
+	duration time.Duration
+	sent     int64
+	received int64
+	// wrapped is set for calls timed around a client without request
+	// handlers, whose retries and bytes are not known.
+	wrapped bool
+}
+
+// Timing adds up how long the AWS calls of one command took, how often
+// they were retried and how many bytes they sent and received, for
+// --timing. It is safe for concurrent use.
+type Timing struct {
+	mutex sync.Mutex
+	start time.Time
+	stats map[metricKey]*timingStat
+}
+
+func NewTiming() *Timing {
+	return &Timing{start: time.Now(), stats: map[metricKey]*timingStat{}}
+}
+
+func (timing *Timing) stat(r *request.Request) *timingStat {
+	return timing.statFor(serviceDisplayName(r), r.Operation.Name)
+}
+
+func (timing *Timing) statFor(service string, operation string) *timingStat {
+	key := metricKey{service, operation}
+	stat, ok := timing.stats[key]
+
+	if !ok {
+		stat = &timingStat{}
+		timing.stats[key] = stat
+	}
+
+	return stat
+}
+
+// countingReader counts the bytes of a response body as it is read.
+type countingReader struct {
+	io.ReadCloser
+	timing *Timing
+	stat   *timingStat
+}
+
+func (reader *countingReader) Read(p []byte) (int, error) {
+	n, err := reader.ReadCloser.Read(p)
+
+	reader.timing.mutex.Lock()
+	reader.stat.received += int64(n)
+	reader.timing.mutex.Unlock()
+
+	return n, err
+}
+
+// AddHandlers times every AWS call made with handlers.
+func (timing *Timing) AddHandlers(handlers *request.Handlers) {
+	// Send runs once per attempt.
+	handlers.Send.PushBack(func(r *request.Request) {
+		timing.mutex.Lock()
+		defer timing.mutex.Unlock()
+
+		stat := timing.stat(r)
+
+		if r.HTTPRequest != nil && r.HTTPRequest.ContentLength > 0 {
+			stat.sent += r.HTTPRequest.ContentLength
+		}
+
+		if r.HTTPResponse != nil && r.HTTPResponse.Body != nil {
+			r.HTTPResponse.Body = &countingReader{ReadCloser: r.HTTPResponse.Body, timing: timing, stat: stat}
+		}
+	})
+
+	handlers.Complete.PushBack(func(r *request.Request) {
+		timing.mutex.Lock()
+		defer timing.mutex.Unlock()
+
+		stat := timing.stat(r)
+		stat.calls++
+		stat.retries += r.RetryCount
+		stat.duration += time.Since(r.Time)
+	})
+}
+
+// since adds a call of operation that started at start, for the wrappers
+// of clients without request handlers.
+func (timing *Timing) since(service string, operation string, start time.Time) {
+	timing.mutex.Lock()
+	defer timing.mutex.Unlock()
+
+	stat := timing.statFor(service, operation)
+	stat.calls++
+	stat.duration += time.Since(start)
+	stat.wrapped = true
+}
+
+// Instrument times the DynamoDB and KMS calls of driver, when its clients
+// are the SDK's own, and the calls to the aws-sdk-go-v2 backend and Dax.
+func (timing *Timing) Instrument(driver *Driver) {
+	if svc, ok := driver.Ddb.(*dynamodb.DynamoDB); ok {
+		timing.AddHandlers(&svc.Handlers)
+	}
+
+	if svc, ok := driver.Kms.(*kms.KMS); ok {
+		timing.AddHandlers(&svc.Handlers)
+	}
+
+	if backend, ok := driver.Backend.(*DynamoDBV2Backend); ok {
+		backend.Ddb = &timedDynamoDBV2{DynamoDBV2API: backend.Ddb, timing: timing}
+	}
+
+	if driver.Dax != nil {
+		driver.Dax = &timedDax{DynamoDBAPI: driver.Dax, timing: timing}
+	}
+}
+
+// timedDynamoDBV2 times the calls of the aws-sdk-go-v2 backend.
+type timedDynamoDBV2 struct {
+	DynamoDBV2API
+	timing *Timing
+}
+
+func (svc *timedDynamoDBV2) GetItem(ctx context.Context, params *dynamodbv2.GetItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.GetItemOutput, error) {
+	defer svc.timing.since("DynamoDB", "GetItem", time.Now())
+	return svc.DynamoDBV2API.GetItem(ctx, params, optFns...)
+}
+
+func (svc *timedDynamoDBV2) PutItem(ctx context.Context, params *dynamodbv2.PutItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.PutItemOutput, error) {
+	defer svc.timing.since("DynamoDB", "PutItem", time.Now())
+	return svc.DynamoDBV2API.PutItem(ctx, params, optFns...)
+}
+
+func (svc *timedDynamoDBV2) Query(ctx context.Context, params *dynamodbv2.QueryInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.QueryOutput, error) {
+	defer svc.timing.since("DynamoDB", "Query", time.Now())
+	return svc.DynamoDBV2API.Query(ctx, params, optFns...)
+}
+
+func (svc *timedDynamoDBV2) Scan(ctx context.Context, params *dynamodbv2.ScanInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.ScanOutput, error) {
+	defer svc.timing.since("DynamoDB", "Scan", time.Now())
+	return svc.DynamoDBV2API.Scan(ctx, params, optFns...)
+}
+
+func (svc *timedDynamoDBV2) DeleteItem(ctx context.Context, params *dynamodbv2.DeleteItemInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.DeleteItemOutput, error) {
+	defer svc.timing.since("DynamoDB", "DeleteItem", time.Now())
+	return svc.DynamoDBV2API.DeleteItem(ctx, params, optFns...)
+}
+
+func (svc *timedDynamoDBV2) TransactWriteItems(ctx context.Context, params *dynamodbv2.TransactWriteItemsInput, optFns ...func(*dynamodbv2.Options)) (*dynamodbv2.TransactWriteItemsOutput, error) {
+	defer svc.timing.since("DynamoDB", "TransactWriteItems", time.Now())
+	return svc.DynamoDBV2API.TransactWriteItems(ctx, params, optFns...)
+}
+
+// timedDax times the reads of a DAX client, the only calls Driver makes on
+// Dax.
+type timedDax struct {
+	dynamodbiface.DynamoDBAPI
+	timing *Timing
+}
+
+func (svc *timedDax) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	defer svc.timing.since("DAX", "GetItem", time.Now())
+	return svc.DynamoDBAPI.GetItem(input)
+}
+
+func (svc *timedDax) GetItemWithContext(ctx aws.Context, input *dynamodb.GetItemInput, opts ...request.Option) (*dynamodb.GetItemOutput, error) {
+	defer svc.timing.since("DAX", "GetItem", time.Now())
+	return svc.DynamoDBAPI.GetItemWithContext(ctx, input, opts...)
+}
+
+func (svc *timedDax) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	defer svc.timing.since("DAX", "Query", time.Now())
+	return svc.DynamoDBAPI.Query(input)
+}
+
+func (svc *timedDax) QueryWithContext(ctx aws.Context, input *dynamodb.QueryInput, opts ...request.Option) (*dynamodb.QueryOutput, error) {
+	defer svc.timing.since("DAX", "Query", time.Now())
+	return svc.DynamoDBAPI.QueryWithContext(ctx, input, opts...)
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	}
+
+	return fmt.Sprintf("%d B", n)
+}
+
+func plural(n int, one string, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// Print writes one line per service and operation, and a total.
+func (timing *Timing) Print(w io.Writer) {
+	timing.mutex.Lock()
+	defer timing.mutex.Unlock()
+
+	keys := []metricKey{}
+	total := &timingStat{}
+
+	for key, stat := range timing.stats {
+		keys = append(keys, key)
+		total.calls += stat.calls
+		total.retries += stat.retries
+		total.duration += stat.duration
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].a != keys[j].a {
+			return keys[i].a < keys[j].a
+		}
+
+		return keys[i].b < keys[j].b
+	})
+
+	for _, key := range keys {
+		stat := timing.stats[key]
+
+		if stat.wrapped {
+			fmt.Fprintf(w, "timing: %s %s: %s, %s\n", key.a, key.b, plural(stat.calls, "call", "calls"), stat.duration.Round(time.Millisecond))
+			continue
+		}
+
+		fmt.Fprintf(w, "timing: %s %s: %s, %s, %s, %s sent, %s received\n",
+			key.a, key.b, plural(stat.calls, "call", "calls"), plural(stat.retries, "retry", "retries"), stat.duration.Round(time.Millisecond),
+			formatBytes(stat.sent), formatBytes(stat.received))
+	}
+
+	fmt.Fprintf(w, "timing: total: %s, %s, %s in AWS calls, %s elapsed\n",
+		plural(total.calls, "call", "calls"), plural(total.retries, "retry", "retries"), total.duration.Round(time.Millisecond),
+		time.Since(timing.start).Round(time.Millisecond))
+}
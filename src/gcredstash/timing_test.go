@@ -0,0 +1,89 @@
+package gcredstash
+
+import (
+	"bytes"
+	. "gcredstash"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+	"mockaws"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestTiming(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"__type":"InternalServerError","message":"try again"}`))
+			return
+		}
+
+		w.Write([]byte(`{"Item":{"name":{"S":"db.pass"}}}`))
+	}))
+
+	defer server.Close()
+
+	awsSession := session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	}))
+
+	svc := dynamodb.New(awsSession)
+	timing := NewTiming()
+	timing.Instrument(&Driver{Ddb: svc})
+
+	_, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("credential-store"),
+		Key:       map[string]*dynamodb.AttributeValue{"name": {S: aws.String("db.pass")}, "version": {S: aws.String("0000000000000000001")}},
+	})
+
+	if err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	buf := &bytes.Buffer{}
+	timing.Print(buf)
+	// Both attempts count towards the bytes.
+	expected := regexp.MustCompile(`^timing: DynamoDB GetItem: 1 call, 1 retry, \d+ms, 202 B sent, 87 B received\n` +
+		`timing: total: 1 call, 1 retry, \d+ms in AWS calls, \d+ms elapsed\n$`)
+
+	if !expected.MatchString(buf.String()) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, buf.String())
+	}
+}
+
+func TestTimingV2AndDax(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mdax := mockaws.NewMockDynamoDBAPI(ctrl)
+	mdax.EXPECT().Query(gomock.Any()).Return(&dynamodb.QueryOutput{}, nil)
+
+	backend := &DynamoDBV2Backend{Ddb: &memoryDynamoDBV2{items: map[string]map[string]types.AttributeValue{}}}
+	driver := &Driver{Backend: backend, Dax: mdax}
+	timing := NewTiming()
+	timing.Instrument(driver)
+
+	backend.GetItem("credential-store", "db.pass", "0000000000000000001")
+	driver.Dax.Query(&dynamodb.QueryInput{})
+
+	buf := &bytes.Buffer{}
+	timing.Print(buf)
+	expected := regexp.MustCompile(`^timing: DAX Query: 1 call, \S+\n` +
+		`timing: DynamoDB GetItem: 1 call, \S+\n` +
+		`timing: total: 2 calls, 0 retries, \S+ in AWS calls, \S+ elapsed\n$`)
+
+	if !expected.MatchString(buf.String()) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, buf.String())
+	}
+}
@@ -0,0 +1,108 @@
+package gcredstash
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TOTPKey holds the parameters of a RFC 6238 time-based one-time password.
+type TOTPKey struct {
+	Secret    []byte
+	Digits    int
+	Period    int
+	Algorithm string
+}
+
+var totpAlgorithms = map[string]func() hash.Hash{
+	"SHA1":   sha1.New,
+	"SHA256": sha256.New,
+	"SHA512": sha512.New,
+}
+
+// ParseTOTPKey parses a base32 seed or an otpauth://totp/ URI. Seeds use 6
+// digits, a 30 second period and SHA1, as most authenticator apps do.
+func ParseTOTPKey(seed string) (*TOTPKey, error) {
+	key := &TOTPKey{Digits: 6, Period: 30, Algorithm: "SHA1"}
+	seed = strings.TrimSpace(seed)
+
+	if strings.HasPrefix(seed, "otpauth://") {
+		uri, err := url.Parse(seed)
+
+		if err != nil || uri.Host != "totp" {
+			return nil, fmt.Errorf("invalid TOTP URI")
+		}
+
+		query := uri.Query()
+		seed = query.Get("secret")
+
+		if digits := query.Get("digits"); digits != "" {
+			key.Digits, err = strconv.Atoi(digits)
+
+			if err != nil || key.Digits < 6 || key.Digits > 8 {
+				return nil, fmt.Errorf("invalid TOTP digits: %s", digits)
+			}
+		}
+
+		if period := query.Get("period"); period != "" {
+			key.Period, err = strconv.Atoi(period)
+
+			if err != nil || key.Period < 1 {
+				return nil, fmt.Errorf("invalid TOTP period: %s", period)
+			}
+		}
+
+		if algorithm := query.Get("algorithm"); algorithm != "" {
+			key.Algorithm = strings.ToUpper(algorithm)
+
+			if _, ok := totpAlgorithms[key.Algorithm]; !ok {
+				return nil, fmt.Errorf("invalid TOTP algorithm: %s", algorithm)
+			}
+		}
+	}
+
+	seed = strings.ToUpper(strings.Replace(seed, " ", "", -1))
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(seed, "="))
+
+	if err != nil || len(secret) == 0 {
+		return nil, fmt.Errorf("invalid TOTP seed: must be base32")
+	}
+
+	key.Secret = secret
+
+	return key, nil
+}
+
+// Code returns the one-time password for t and the time it expires.
+func (key *TOTPKey) Code(t time.Time) (string, time.Time) {
+	counter := uint64(t.Unix()) / uint64(key.Period)
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(totpAlgorithms[key.Algorithm], key.Secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < key.Digits; i++ {
+		mod *= 10
+	}
+
+	code := fmt.Sprintf("%0*d", key.Digits, bin%mod)
+	expires := time.Unix(int64(counter+1)*int64(key.Period), 0)
+
+	return code, expires
+}
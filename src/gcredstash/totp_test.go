@@ -0,0 +1,54 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// Test vectors from RFC 6238 Appendix B.
+	for _, tc := range []struct {
+		seed     string
+		time     int64
+		expected string
+	}{
+		{"otpauth://totp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&digits=8", 59, "94287082"},
+		{"otpauth://totp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&digits=8", 1111111109, "07081804"},
+		{"otpauth://totp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA&digits=8&algorithm=SHA256", 59, "46119246"},
+		{"otpauth://totp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA&digits=8&algorithm=sha512", 59, "90693936"},
+		{"gezd gnbv gy3t qojq gezd gnbv gy3t qojq", 59, "287082"},
+	} {
+		key, err := ParseTOTPKey(tc.seed)
+
+		if err != nil {
+			t.Fatalf("\nexpected: %v\ngot: %v\n", nil, err)
+		}
+
+		code, expires := key.Code(time.Unix(tc.time, 0))
+
+		if code != tc.expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, code)
+		}
+
+		if expires.Unix() != (tc.time/30+1)*30 {
+			t.Errorf("\nexpected: %v\ngot: %v\n", (tc.time/30+1)*30, expires.Unix())
+		}
+	}
+}
+
+func TestErrParseTOTPKey(t *testing.T) {
+	for seed, expected := range map[string]string{
+		"not base32!":                      "invalid TOTP seed: must be base32",
+		"":                                 "invalid TOTP seed: must be base32",
+		"otpauth://hotp/x?secret=GEZDGNBV": "invalid TOTP URI",
+		"otpauth://totp/x?secret=GEZDGNBV&digits=4":      "invalid TOTP digits: 4",
+		"otpauth://totp/x?secret=GEZDGNBV&algorithm=MD5": "invalid TOTP algorithm: MD5",
+	} {
+		_, err := ParseTOTPKey(seed)
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+		}
+	}
+}
@@ -1,12 +1,11 @@
 package gcredstash
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -29,15 +28,9 @@ func VersionNumToStr(version int) string {
 	return fmt.Sprintf(VERSION_FORMAT, version)
 }
 
-func ReadStdin() string {
-	reader := bufio.NewReader(os.Stdin)
-	input, err := ioutil.ReadAll(reader)
-
-	if err != nil {
-		panic(err)
-	}
-
-	return strings.TrimRight(string(input), "\n")
+// StripNewline removes trailing "\n" and "\r\n" line endings.
+func StripNewline(value string) string {
+	return strings.TrimRight(value, "\r\n")
 }
 
 func ReadFile(filename string) (string, error) {
@@ -51,7 +44,12 @@ func ReadFile(filename string) (string, error) {
 }
 
 func MapToJson(m map[string]string) string {
-	jsonString, err := json.MarshalIndent(m, "", "  ")
+	return ToJson(m)
+}
+
+// ToJson returns v as indented JSON without escaping <, > and &.
+func ToJson(v interface{}) string {
+	jsonString, err := json.MarshalIndent(v, "", "  ")
 
 	if err != nil {
 		panic(err)
@@ -77,3 +75,17 @@ func MaxKeyLen(items map[*string]*string) int {
 
 	return max
 }
+
+func GroupVersions(items map[*string]*string) map[string][]string {
+	versions := map[string][]string{}
+
+	for name, version := range items {
+		versions[*name] = append(versions[*name], *version)
+	}
+
+	for _, vers := range versions {
+		sort.Strings(vers)
+	}
+
+	return versions
+}
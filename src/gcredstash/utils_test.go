@@ -2,6 +2,7 @@ package gcredstash
 
 import (
 	. "gcredstash"
+	"reflect"
 	"testing"
 )
 
@@ -23,6 +24,23 @@ func TestVersionNumToStr(t *testing.T) {
 	}
 }
 
+func TestStripNewline(t *testing.T) {
+	for value, expected := range map[string]string{
+		"100":     "100",
+		"100\n":   "100",
+		"100\r\n": "100",
+		"100\n\n": "100",
+		"1\n00\n": "1\n00",
+		"":        "",
+	} {
+		actual := StripNewline(value)
+
+		if expected != actual {
+			t.Errorf("\nexpected: %q\ngot: %q\n", expected, actual)
+		}
+	}
+}
+
 func TestMapToJson(t *testing.T) {
 	m := map[string]string{"foo": "bar", "bar": "zoo"}
 
@@ -67,3 +85,23 @@ func TestMaxKeyLen(t *testing.T) {
 		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
 	}
 }
+
+func TestGroupVersions(t *testing.T) {
+	name1 := "foo"
+	ver1 := "0000000000000000002"
+	name2 := "foo"
+	ver2 := "0000000000000000001"
+	name3 := "bar"
+	ver3 := "0000000000000000001"
+
+	m := map[*string]*string{&name1: &ver1, &name2: &ver2, &name3: &ver3}
+	expected := map[string][]string{
+		"foo": {"0000000000000000001", "0000000000000000002"},
+		"bar": {"0000000000000000001"},
+	}
+	actual := GroupVersions(m)
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, actual)
+	}
+}
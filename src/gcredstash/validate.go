@@ -0,0 +1,50 @@
+package gcredstash
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Validator checks a plaintext before it is encrypted and stored. Set
+// PutOptions.Validators to reject values such as empty strings or values
+// with a trailing newline.
+type Validator interface {
+	Validate(name string, secret []byte) error
+}
+
+// PatternValidator requires the value to match Pattern. Anchor the pattern
+// to check the whole value.
+type PatternValidator struct {
+	Pattern *regexp.Regexp
+}
+
+func NewPatternValidator(pattern string) (*PatternValidator, error) {
+	re, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %s", pattern)
+	}
+
+	return &PatternValidator{Pattern: re}, nil
+}
+
+func (validator *PatternValidator) Validate(name string, secret []byte) error {
+	if !validator.Pattern.Match(secret) {
+		return fmt.Errorf("%s: value does not match %s", name, validator.Pattern.String())
+	}
+
+	return nil
+}
+
+// MaxLengthValidator rejects values longer than MaxLength bytes.
+type MaxLengthValidator struct {
+	MaxLength int
+}
+
+func (validator *MaxLengthValidator) Validate(name string, secret []byte) error {
+	if len(secret) > validator.MaxLength {
+		return fmt.Errorf("%s: value is longer than %d bytes", name, validator.MaxLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package gcredstash
+
+import (
+	. "gcredstash"
+	"testing"
+)
+
+func TestPatternValidator(t *testing.T) {
+	validator, err := NewPatternValidator(`^\S+$`)
+
+	if err != nil {
+		t.Fatalf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	for _, tc := range []struct {
+		secret   string
+		expected string
+	}{
+		{"abc", ""},
+		{"", `foo.bar: value does not match ^\S+$`},
+		{"abc\n", `foo.bar: value does not match ^\S+$`},
+	} {
+		err := validator.Validate("foo.bar", []byte(tc.secret))
+
+		if (tc.expected == "" && err != nil) || (tc.expected != "" && (err == nil || err.Error() != tc.expected)) {
+			t.Errorf("\nexpected: %v\ngot: %v\n", tc.expected, err)
+		}
+	}
+
+	_, err = NewPatternValidator("(")
+
+	if err == nil || err.Error() != "invalid pattern: (" {
+		t.Errorf("\nexpected: %v\ngot: %v\n", "invalid pattern: (", err)
+	}
+}
+
+func TestMaxLengthValidator(t *testing.T) {
+	validator := &MaxLengthValidator{MaxLength: 3}
+
+	if err := validator.Validate("foo.bar", []byte("abc")); err != nil {
+		t.Errorf("\nexpected: %v\ngot: %v\n", nil, err)
+	}
+
+	err := validator.Validate("foo.bar", []byte("abcd"))
+	expected := "foo.bar: value is longer than 3 bytes"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nexpected: %v\ngot: %v\n", expected, err)
+	}
+}
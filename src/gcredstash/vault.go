@@ -0,0 +1,255 @@
+package gcredstash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	DEFAULT_VAULT_ADDR  = "http://127.0.0.1:8200"
+	DEFAULT_VAULT_MOUNT = "secret"
+	DEFAULT_VAULT_KEY   = "value"
+)
+
+// VaultClient reads and writes secrets in a KV secrets engine mount of a
+// HashiCorp Vault server over its HTTP API. KVVersion is 1 or 2.
+type VaultClient struct {
+	Address   string
+	Token     string
+	Namespace string
+	Mount     string
+	KVVersion int
+	Client    *http.Client
+}
+
+// NewVaultClient returns a client for a mount using VAULT_ADDR, VAULT_TOKEN
+// and VAULT_NAMESPACE. An empty address falls back to VAULT_ADDR.
+func NewVaultClient(address string, mount string, kvVersion int) (*VaultClient, error) {
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+
+	if address == "" {
+		address = DEFAULT_VAULT_ADDR
+	}
+
+	if mount == "" {
+		mount = DEFAULT_VAULT_MOUNT
+	}
+
+	if kvVersion != 1 && kvVersion != 2 {
+		return nil, fmt.Errorf("KV version must be 1 or 2: %d", kvVersion)
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+
+	if token == "" {
+		return nil, fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	client := &VaultClient{
+		Address:   strings.TrimRight(address, "/"),
+		Token:     token,
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Mount:     strings.Trim(mount, "/"),
+		KVVersion: kvVersion,
+		Client:    http.DefaultClient,
+	}
+
+	return client, nil
+}
+
+func (c *VaultClient) url(kind string, path string) string {
+	url := c.Address + "/v1/" + c.Mount + "/"
+
+	if c.KVVersion == 2 {
+		url += kind + "/"
+	}
+
+	return url + strings.TrimLeft(path, "/")
+}
+
+func (c *VaultClient) do(method string, url string, body interface{}) (map[string]interface{}, int, error) {
+	var reqBody []byte
+
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(reqBody))
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("X-Vault-Token", c.Token)
+
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Client.Do(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, resp.StatusCode, nil
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, resp.StatusCode, fmt.Errorf("vault: %s %s: %s %s", method, url, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	result := map[string]interface{}{}
+
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("vault: %s %s: %s", method, url, err.Error())
+		}
+	}
+
+	return result, resp.StatusCode, nil
+}
+
+// Read returns the data of the secret at path, and false if it does not exist.
+func (c *VaultClient) Read(path string) (map[string]string, bool, error) {
+	result, _, err := c.do("GET", c.url("data", path), nil)
+
+	if err != nil || result == nil {
+		return nil, false, err
+	}
+
+	data, _ := result["data"].(map[string]interface{})
+
+	if c.KVVersion == 2 {
+		data, _ = data["data"].(map[string]interface{})
+	}
+
+	if data == nil {
+		return nil, false, nil
+	}
+
+	secret := map[string]string{}
+
+	for k, v := range data {
+		str, ok := v.(string)
+
+		if !ok {
+			return nil, true, fmt.Errorf("vault: %s: %s is not a string", path, k)
+		}
+
+		secret[k] = str
+	}
+
+	return secret, true, nil
+}
+
+// Write replaces the data of the secret at path.
+func (c *VaultClient) Write(path string, secret map[string]string) error {
+	var body interface{} = secret
+
+	if c.KVVersion == 2 {
+		body = map[string]interface{}{"data": secret}
+	}
+
+	_, _, err := c.do("POST", c.url("data", path), body)
+
+	return err
+}
+
+// List returns the paths of all secrets under path, recursively.
+func (c *VaultClient) List(path string) ([]string, error) {
+	path = strings.Trim(path, "/")
+
+	if path != "" {
+		path += "/"
+	}
+
+	result, _, err := c.do("LIST", c.url("metadata", path), nil)
+
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	data, _ := result["data"].(map[string]interface{})
+	keys, _ := data["keys"].([]interface{})
+	paths := []string{}
+
+	for _, k := range keys {
+		key, ok := k.(string)
+
+		if !ok {
+			continue
+		}
+
+		if strings.HasSuffix(key, "/") {
+			children, err := c.List(path + key)
+
+			if err != nil {
+				return nil, err
+			}
+
+			paths = append(paths, children...)
+		} else {
+			paths = append(paths, path+key)
+		}
+	}
+
+	return paths, nil
+}
+
+// VaultMapping maps credential names to Vault paths: "app.db.pass" is
+// stored under Key in the secret at "<Prefix>app/db/pass".
+type VaultMapping struct {
+	Prefix string
+	Key    string
+}
+
+func (m *VaultMapping) prefix() string {
+	prefix := strings.Trim(m.Prefix, "/")
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return prefix
+}
+
+func (m *VaultMapping) key() string {
+	if m.Key == "" {
+		return DEFAULT_VAULT_KEY
+	}
+
+	return m.Key
+}
+
+func (m *VaultMapping) Path(name string) string {
+	return m.prefix() + strings.Replace(name, ".", "/", -1)
+}
+
+func (m *VaultMapping) CredentialName(path string) string {
+	return strings.Replace(strings.TrimPrefix(path, m.prefix()), "/", ".", -1)
+}